@@ -7,22 +7,38 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/go-jose/go-jose/v3"
 	"github.com/go-jose/go-jose/v3/jwt"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// Installation access tokens are valid for one hour. This is only used when
+	// GitHub does not report an explicit expiry for a minted token.
+	appInstallationTokenLifetime = time.Hour
+	// Installation access tokens are re-minted this long before they expire so
+	// that requests in flight are not rejected with an expired token.
+	appInstallationTokenRefreshWindow = 5 * time.Minute
 )
 
 // GenerateOAuthTokenFromApp generates a GitHub OAuth access token from a set of valid GitHub App credentials.
 // The returned token can be used to interact with both GitHub's REST and GraphQL APIs.
 func GenerateOAuthTokenFromApp(baseURL, appID, appInstallationID, pemData string) (string, error) {
+	return generateOAuthTokenFromApp(http.DefaultClient, baseURL, appID, appInstallationID, pemData)
+}
+
+func generateOAuthTokenFromApp(client *http.Client, baseURL, appID, appInstallationID, pemData string) (string, error) {
 	appJWT, err := generateAppJWT(appID, time.Now(), []byte(pemData))
 	if err != nil {
 		return "", err
 	}
 
-	token, err := getInstallationAccessToken(baseURL, appJWT, appInstallationID)
+	token, err := getInstallationAccessToken(client, baseURL, appJWT, appInstallationID)
 	if err != nil {
 		return "", err
 	}
@@ -30,7 +46,89 @@ func GenerateOAuthTokenFromApp(baseURL, appID, appInstallationID, pemData string
 	return token, nil
 }
 
-func getInstallationAccessToken(baseURL string, jwt string, installationID string) (string, error) {
+// AppInstallationTokenSource is an oauth2.TokenSource that mints GitHub App
+// installation access tokens and re-mints them shortly before they expire, so
+// that long running applies are not interrupted by the one hour token lifetime.
+type AppInstallationTokenSource struct {
+	client         *http.Client
+	baseURL        string
+	appID          string
+	installationID string
+	pemData        []byte
+
+	m     sync.Mutex
+	token *oauth2.Token
+}
+
+// NewAppInstallationTokenSource returns a token source for the given set of
+// GitHub App credentials, minting tokens with the given HTTP client. If a nil
+// client is provided, http.DefaultClient will be used. No token is minted
+// until Token is first called.
+func NewAppInstallationTokenSource(client *http.Client, baseURL, appID, appInstallationID, pemData string) *AppInstallationTokenSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &AppInstallationTokenSource{
+		client:         client,
+		baseURL:        baseURL,
+		appID:          appID,
+		installationID: appInstallationID,
+		pemData:        []byte(pemData),
+	}
+}
+
+// Token returns the cached installation access token, minting a new one when
+// none is cached or the cached one is about to expire.
+func (ts *AppInstallationTokenSource) Token() (*oauth2.Token, error) {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	if ts.token != nil && time.Now().Add(appInstallationTokenRefreshWindow).Before(ts.token.Expiry) {
+		return ts.token, nil
+	}
+
+	appJWT, err := generateAppJWT(ts.appID, time.Now(), ts.pemData)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := createInstallationAccessToken(ts.client, ts.baseURL, appJWT, ts.installationID)
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("[DEBUG] Minted GitHub App installation token expiring at %s", token.Expiry.Format(time.RFC3339))
+
+	ts.token = token
+	return ts.token, nil
+}
+
+// invalidate drops the cached token if it is still the one given, forcing the
+// next call to Token to mint a new one. Comparing against the rejected token
+// avoids re-minting several times when concurrent requests fail together.
+func (ts *AppInstallationTokenSource) invalidate(accessToken string) {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	if ts.token != nil && ts.token.AccessToken == accessToken {
+		ts.token = nil
+	}
+}
+
+func (ts *AppInstallationTokenSource) identity() []string {
+	return []string{"app", ts.appID, ts.installationID}
+}
+
+func getInstallationAccessToken(client *http.Client, baseURL string, jwt string, installationID string) (string, error) {
+	token, err := createInstallationAccessToken(client, baseURL, jwt, installationID)
+	if err != nil {
+		return "", err
+	}
+
+	return token.AccessToken, nil
+}
+
+func createInstallationAccessToken(client *http.Client, baseURL string, jwt string, installationID string) (*oauth2.Token, error) {
 	if baseURL != "https://api.github.com/" {
 		baseURL += "api/v3/"
 	}
@@ -39,37 +137,47 @@ func getInstallationAccessToken(baseURL string, jwt string, installationID strin
 
 	req, err := http.NewRequest(http.MethodPost, url, nil)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	req.Header.Add("Accept", "application/vnd.github.v3+json")
 	req.Header.Add("Authorization", fmt.Sprintf("Bearer %s", jwt))
 
-	res, err := http.DefaultClient.Do(req)
+	res, err := client.Do(req)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 	defer func() { _ = res.Body.Close() }()
 
 	resBytes, err := io.ReadAll(res.Body)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if res.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("failed to create OAuth token from GitHub App: %s", string(resBytes))
+		return nil, fmt.Errorf("failed to create OAuth token from GitHub App: %s", string(resBytes))
 	}
 
 	resData := struct {
-		Token string `json:"token"`
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
 	}{}
 
 	err = json.Unmarshal(resBytes, &resData)
 	if err != nil {
-		return "", err
+		return nil, err
+	}
+
+	expiry := resData.ExpiresAt
+	if expiry.IsZero() {
+		expiry = time.Now().Add(appInstallationTokenLifetime)
 	}
 
-	return resData.Token, nil
+	return &oauth2.Token{
+		AccessToken: resData.Token,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}, nil
 }
 
 func generateAppJWT(appID string, now time.Time, pemData []byte) (string, error) {
@@ -5,6 +5,7 @@ import (
 	"crypto/x509"
 	"encoding/pem"
 	"fmt"
+	"net/http"
 	"os"
 	"strings"
 	"testing"
@@ -157,7 +158,7 @@ func TestGetInstallationAccessToken(t *testing.T) {
 	})
 	defer ts.Close()
 
-	accessToken, err := getInstallationAccessToken(ts.URL+"/", fakeJWT, testGitHubAppInstallationID)
+	accessToken, err := getInstallationAccessToken(http.DefaultClient, ts.URL+"/", fakeJWT, testGitHubAppInstallationID)
 
 	if err != nil {
 		t.Logf("Unexpected error: %s", err)
@@ -169,3 +170,98 @@ func TestGetInstallationAccessToken(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestAppInstallationTokenSource(t *testing.T) {
+	accessTokenURI := fmt.Sprintf("/api/v3/app/installations/%s/access_tokens", testGitHubAppInstallationID)
+
+	t.Run("reuses a token until it is about to expire", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri:    accessTokenURI,
+				ExpectedMethod: "POST",
+				ResponseBody: fmt.Sprintf(`{"token": "first", "expires_at": "%s"}`,
+					time.Now().Add(time.Hour).Format(time.RFC3339)),
+				StatusCode: 201,
+			},
+		})
+		defer ts.Close()
+
+		source := NewAppInstallationTokenSource(nil, ts.URL+"/", testGitHubAppID, testGitHubAppInstallationID, string(testGitHubAppPrivateKeyPemData))
+
+		for i := 0; i < 2; i++ {
+			token, err := source.Token()
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if token.AccessToken != "first" {
+				t.Fatalf("Unexpected access token - Found: %s - Expected: first", token.AccessToken)
+			}
+		}
+	})
+
+	t.Run("re-mints a token that is about to expire", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri:    accessTokenURI,
+				ExpectedMethod: "POST",
+				ResponseBody: fmt.Sprintf(`{"token": "first", "expires_at": "%s"}`,
+					time.Now().Add(time.Minute).Format(time.RFC3339)),
+				StatusCode: 201,
+			},
+			{
+				ExpectedUri:    accessTokenURI,
+				ExpectedMethod: "POST",
+				ResponseBody: fmt.Sprintf(`{"token": "second", "expires_at": "%s"}`,
+					time.Now().Add(time.Hour).Format(time.RFC3339)),
+				StatusCode: 201,
+			},
+		})
+		defer ts.Close()
+
+		source := NewAppInstallationTokenSource(nil, ts.URL+"/", testGitHubAppID, testGitHubAppInstallationID, string(testGitHubAppPrivateKeyPemData))
+
+		for _, expected := range []string{"first", "second"} {
+			token, err := source.Token()
+			if err != nil {
+				t.Fatalf("Unexpected error: %s", err)
+			}
+			if token.AccessToken != expected {
+				t.Fatalf("Unexpected access token - Found: %s - Expected: %s", token.AccessToken, expected)
+			}
+		}
+	})
+
+	t.Run("re-mints a token after it is invalidated", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{
+			{
+				ExpectedUri:  accessTokenURI,
+				ResponseBody: `{"token": "first"}`,
+				StatusCode:   201,
+			},
+			{
+				ExpectedUri:  accessTokenURI,
+				ResponseBody: `{"token": "second"}`,
+				StatusCode:   201,
+			},
+		})
+		defer ts.Close()
+
+		source := NewAppInstallationTokenSource(nil, ts.URL+"/", testGitHubAppID, testGitHubAppInstallationID, string(testGitHubAppPrivateKeyPemData))
+
+		if _, err := source.Token(); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+
+		// Invalidating a token which is no longer cached is a no-op.
+		source.invalidate("unknown")
+		source.invalidate("first")
+
+		token, err := source.Token()
+		if err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+		if token.AccessToken != "second" {
+			t.Fatalf("Unexpected access token - Found: %s - Expected: second", token.AccessToken)
+		}
+	})
+}
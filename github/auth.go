@@ -0,0 +1,290 @@
+package github
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+const (
+	// Token files are re-read at least this often, so that tokens rotated on
+	// disk are picked up by long running applies.
+	tokenFileRereadInterval = time.Minute
+	// Tokens exchanged with a token broker are assumed to be valid this long
+	// when the broker does not report an explicit expiry.
+	brokerTokenLifetime = time.Hour
+)
+
+// refreshingTokenSource is an oauth2.TokenSource whose tokens can be replaced
+// while the provider is running. It is used with a tokenRefreshTransport,
+// which invalidates a token GitHub rejected and retries with a fresh one.
+type refreshingTokenSource interface {
+	oauth2.TokenSource
+
+	// invalidate drops the cached token if it is still the one given, forcing
+	// the next call to Token to fetch a new one.
+	invalidate(accessToken string)
+
+	// identity describes the credentials the tokens are issued for, without
+	// containing any secret. It is used to key cached responses.
+	identity() []string
+}
+
+// fileTokenSource reads a token from a file, such as a token mounted by a
+// secrets manager, and re-reads it periodically and whenever GitHub rejects
+// it so that rotated tokens are picked up without restarting Terraform.
+type fileTokenSource struct {
+	path string
+
+	m      sync.Mutex
+	token  *oauth2.Token
+	readAt time.Time
+}
+
+func newFileTokenSource(path string) *fileTokenSource {
+	return &fileTokenSource{path: path}
+}
+
+func (ts *fileTokenSource) Token() (*oauth2.Token, error) {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	if ts.token != nil && time.Since(ts.readAt) < tokenFileRereadInterval {
+		return ts.token, nil
+	}
+
+	data, err := os.ReadFile(ts.path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading token_file: %w", err)
+	}
+	token := strings.TrimSpace(string(data))
+	if token == "" {
+		return nil, fmt.Errorf("token_file %s is empty", ts.path)
+	}
+
+	if ts.token != nil && ts.token.AccessToken != token {
+		log.Printf("[DEBUG] Token in %s was rotated", ts.path)
+	}
+
+	ts.token = &oauth2.Token{AccessToken: token, TokenType: "Bearer"}
+	ts.readAt = time.Now()
+	return ts.token, nil
+}
+
+func (ts *fileTokenSource) invalidate(accessToken string) {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	if ts.token != nil && ts.token.AccessToken == accessToken {
+		ts.token = nil
+	}
+}
+
+func (ts *fileTokenSource) identity() []string {
+	return []string{"token_file", ts.path}
+}
+
+// brokerTokenSource exchanges an OIDC ID token, such as a Kubernetes service
+// account token or a GitHub Actions ID token, for a GitHub token by calling a
+// token broker. The broker is sent the ID token as a bearer token in a POST
+// request and must answer with a JSON object containing the GitHub token in
+// "token" and, optionally, its expiry in "expires_at", like GitHub does for
+// App installation tokens. The ID token is read again for every exchange, as
+// workload identity tokens are usually short lived and rotated on disk.
+type brokerTokenSource struct {
+	client      *http.Client
+	brokerURL   string
+	idToken     string
+	idTokenFile string
+	audience    string
+
+	m     sync.Mutex
+	token *oauth2.Token
+}
+
+func newBrokerTokenSource(client *http.Client, brokerURL, idToken, idTokenFile, audience string) *brokerTokenSource {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	return &brokerTokenSource{
+		client:      client,
+		brokerURL:   brokerURL,
+		idToken:     idToken,
+		idTokenFile: idTokenFile,
+		audience:    audience,
+	}
+}
+
+func (ts *brokerTokenSource) Token() (*oauth2.Token, error) {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	if ts.token != nil && time.Now().Add(appInstallationTokenRefreshWindow).Before(ts.token.Expiry) {
+		return ts.token, nil
+	}
+
+	idToken, err := ts.readIDToken()
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, ts.brokerURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Authorization", "Bearer "+idToken)
+
+	res, err := ts.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("error calling token broker: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	resBytes, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.StatusCode != http.StatusOK && res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("token broker refused to exchange the ID token (%s): %s", res.Status, string(resBytes))
+	}
+
+	resData := struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}{}
+	if err := json.Unmarshal(resBytes, &resData); err != nil {
+		return nil, fmt.Errorf("error decoding token broker response: %w", err)
+	}
+	if resData.Token == "" {
+		return nil, fmt.Errorf("token broker response does not contain a token")
+	}
+
+	expiry := resData.ExpiresAt
+	if expiry.IsZero() {
+		expiry = time.Now().Add(brokerTokenLifetime)
+	}
+	log.Printf("[DEBUG] Exchanged ID token for a GitHub token expiring at %s", expiry.Format(time.RFC3339))
+
+	ts.token = &oauth2.Token{
+		AccessToken: resData.Token,
+		TokenType:   "Bearer",
+		Expiry:      expiry,
+	}
+	return ts.token, nil
+}
+
+// readIDToken returns the configured ID token, reading it from its file, or
+// requesting one from GitHub Actions when neither is configured.
+func (ts *brokerTokenSource) readIDToken() (string, error) {
+	if ts.idToken != "" {
+		return ts.idToken, nil
+	}
+
+	if ts.idTokenFile != "" {
+		data, err := os.ReadFile(ts.idTokenFile)
+		if err != nil {
+			return "", fmt.Errorf("error reading oidc_auth.id_token_file: %w", err)
+		}
+		return strings.TrimSpace(string(data)), nil
+	}
+
+	requestURL := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_URL")
+	requestToken := os.Getenv("ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	if requestURL == "" || requestToken == "" {
+		return "", fmt.Errorf("oidc_auth requires id_token or id_token_file to be set when not running in a GitHub Actions workflow with the id-token: write permission")
+	}
+
+	u, err := url.Parse(requestURL)
+	if err != nil {
+		return "", err
+	}
+	if ts.audience != "" {
+		q := u.Query()
+		q.Set("audience", ts.audience)
+		u.RawQuery = q.Encode()
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+requestToken)
+
+	// The ID token is requested from the Actions runtime rather than from
+	// GitHub, so the TLS settings configured for GitHub do not apply.
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("error requesting GitHub Actions ID token: %w", err)
+	}
+	defer func() { _ = res.Body.Close() }()
+
+	resData := struct {
+		Value string `json:"value"`
+	}{}
+	if err := json.NewDecoder(res.Body).Decode(&resData); err != nil || res.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("error requesting GitHub Actions ID token: %s", res.Status)
+	}
+
+	return resData.Value, nil
+}
+
+func (ts *brokerTokenSource) invalidate(accessToken string) {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+
+	if ts.token != nil && ts.token.AccessToken == accessToken {
+		ts.token = nil
+	}
+}
+
+// identity includes the subject of the ID token, as workloads with different
+// ID tokens may share a broker and an ID token file path. The ID token cannot
+// be read yet when it is requested from GitHub Actions at a later time, it is
+// then left out as no token can be exchanged either.
+func (ts *brokerTokenSource) identity() []string {
+	identity := []string{"oidc", ts.brokerURL, ts.idTokenFile, ts.audience}
+
+	idToken, err := ts.readIDToken()
+	if err != nil {
+		log.Printf("[DEBUG] Cannot read the ID token to identify cached responses: %s", err)
+		return identity
+	}
+	return append(identity, idTokenSubject(idToken))
+}
+
+// idTokenSubject returns the issuer and subject of an OIDC ID token, which
+// stay the same when the token is rotated. The claims are not verified, that
+// is up to the broker. Tokens which are not JWTs are identified by their hash.
+func idTokenSubject(idToken string) string {
+	parts := strings.Split(idToken, ".")
+	if len(parts) == 3 {
+		payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+		if err == nil {
+			claims := struct {
+				Issuer  string `json:"iss"`
+				Subject string `json:"sub"`
+			}{}
+			if json.Unmarshal(payload, &claims) == nil && claims.Subject != "" {
+				return claims.Issuer + " " + claims.Subject
+			}
+		}
+	}
+
+	h := sha256.Sum256([]byte(idToken))
+	return "sha256:" + hex.EncodeToString(h[:])
+}
@@ -0,0 +1,228 @@
+package github
+
+import (
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestFileTokenSource(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(path, []byte("first\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	source := newFileTokenSource(path)
+	token, err := source.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "first" {
+		t.Fatalf("Expected the token to be read from the file, got: %q", token.AccessToken)
+	}
+
+	if err := os.WriteFile(path, []byte("second\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	token, _ = source.Token()
+	if token.AccessToken != "first" {
+		t.Fatalf("Expected the token to be reused until it is refreshed, got: %q", token.AccessToken)
+	}
+
+	source.invalidate("first")
+	token, _ = source.Token()
+	if token.AccessToken != "second" {
+		t.Fatalf("Expected the rotated token to be read, got: %q", token.AccessToken)
+	}
+
+	source.readAt = time.Now().Add(-tokenFileRereadInterval)
+	if err := os.WriteFile(path, []byte(""), 0600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := source.Token(); err == nil {
+		t.Fatal("Expected an error for an empty token file")
+	}
+}
+
+func TestBrokerTokenSource(t *testing.T) {
+
+	var exchanged []string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		idToken := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		exchanged = append(exchanged, idToken)
+		if r.Method != http.MethodPost || idToken == "invalid" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"token": "ghs_` + idToken + `", "expires_at": "` + time.Now().Add(time.Hour).Format(time.RFC3339) + `"}`))
+	}))
+	defer ts.Close()
+
+	path := filepath.Join(t.TempDir(), "id-token")
+	if err := os.WriteFile(path, []byte("workload-1\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	source := newBrokerTokenSource(nil, ts.URL, "", path, "")
+	for i := 0; i < 2; i++ {
+		token, err := source.Token()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if token.AccessToken != "ghs_workload-1" {
+			t.Fatalf("Unexpected token: %q", token.AccessToken)
+		}
+	}
+	if len(exchanged) != 1 {
+		t.Fatalf("Expected the token to be exchanged once until it expires, got %d exchanges", len(exchanged))
+	}
+
+	// The ID token is read again for every exchange
+	if err := os.WriteFile(path, []byte("workload-2\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+	source.invalidate("ghs_workload-1")
+	token, err := source.Token()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token.AccessToken != "ghs_workload-2" {
+		t.Fatalf("Expected the rotated ID token to be exchanged, got: %q", token.AccessToken)
+	}
+
+	if _, err := newBrokerTokenSource(nil, ts.URL, "invalid", "", "").Token(); err == nil {
+		t.Fatal("Expected an error when the broker refuses the ID token")
+	}
+}
+
+func TestBrokerTokenSourceIdentity(t *testing.T) {
+
+	jwt := func(subject, signature string) string {
+		payload := base64.RawURLEncoding.EncodeToString([]byte(`{"iss": "https://issuer.example.com", "sub": "` + subject + `"}`))
+		return "eyJhbGciOiJSUzI1NiJ9." + payload + "." + signature
+	}
+
+	identity := func(idToken string) string {
+		return strings.Join(newBrokerTokenSource(nil, "https://broker.example.com", idToken, "", "").identity(), "\n")
+	}
+
+	if identity(jwt("workload-1", "a")) == identity(jwt("workload-2", "a")) {
+		t.Fatal("Expected ID tokens of different workloads to have different identities")
+	}
+	if identity(jwt("workload-1", "a")) != identity(jwt("workload-1", "b")) {
+		t.Fatal("Expected rotated ID tokens of a workload to keep their identity")
+	}
+	if identity("opaque-1") == identity("opaque-2") {
+		t.Fatal("Expected different opaque ID tokens to have different identities")
+	}
+	if strings.Contains(identity("opaque-1"), "opaque-1") {
+		t.Fatal("Expected the identity not to contain the ID token")
+	}
+}
+
+func TestConfigureAuthentication(t *testing.T) {
+
+	for _, env := range []string{"GITHUB_TOKEN", "GITHUB_TOKEN_FILE", "GITHUB_APP_ID", "GITHUB_APP_INSTALLATION_ID",
+		"GITHUB_APP_PEM_FILE", "GITHUB_APP_PEM_FILE_PATH", "GITHUB_OIDC_TOKEN_BROKER_URL", "GITHUB_OIDC_ID_TOKEN",
+		"GITHUB_OIDC_ID_TOKEN_FILE", "GITHUB_OIDC_AUDIENCE"} {
+		t.Setenv(env, "")
+	}
+	t.Setenv("GH_PATH", filepath.Join(t.TempDir(), "gh"))
+
+	tokenFile := filepath.Join(t.TempDir(), "token")
+	if err := os.WriteFile(tokenFile, []byte("from-file"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	configure := func(raw map[string]interface{}) (string, refreshingTokenSource, diag.Diagnostics) {
+		d := schema.TestResourceDataRaw(t, Provider().Schema, raw)
+		return configureAuthentication(d, "https://api.github.com/", true, http.DefaultClient)
+	}
+
+	t.Run("uses the token", func(t *testing.T) {
+		token, source, diags := configure(map[string]interface{}{"token": "secret"})
+		if token != "secret" || source != nil || len(diags) != 0 {
+			t.Fatalf("Unexpected authentication: %q, %v, %v", token, source, diags)
+		}
+	})
+
+	t.Run("reads the token from a file", func(t *testing.T) {
+		token, source, diags := configure(map[string]interface{}{"token_file": tokenFile})
+		if token != "" || len(diags) != 0 {
+			t.Fatalf("Unexpected authentication: %q, %v", token, diags)
+		}
+		if _, ok := source.(*fileTokenSource); !ok {
+			t.Fatalf("Expected a file token source, got: %T", source)
+		}
+	})
+
+	t.Run("warns about methods which are ignored", func(t *testing.T) {
+		token, _, diags := configure(map[string]interface{}{"token": "secret", "token_file": tokenFile})
+		if token != "secret" {
+			t.Fatalf("Expected token to take precedence, got: %q", token)
+		}
+		if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Detail, "precedence over token_file") {
+			t.Fatalf("Expected a warning about token_file being ignored, got: %v", diags)
+		}
+	})
+
+	t.Run("reads the App private key from a file", func(t *testing.T) {
+		_, _, diags := configure(map[string]interface{}{
+			"app_auth": []interface{}{map[string]interface{}{
+				"id":              testGitHubAppID,
+				"installation_id": testGitHubAppInstallationID,
+				"pem_file_path":   filepath.Join(t.TempDir(), "missing.pem"),
+			}},
+		})
+		if !diags.HasError() || !strings.Contains(diags[0].Detail, "app_auth.pem_file_path") {
+			t.Fatalf("Expected an error reading the private key, got: %v", diags)
+		}
+	})
+
+	t.Run("warns when accessing GitHub anonymously", func(t *testing.T) {
+		token, source, diags := configure(map[string]interface{}{})
+		if token != "" || source != nil {
+			t.Fatalf("Expected anonymous access, got: %q, %v", token, source)
+		}
+		if len(diags) != 1 || diags[0].Severity != diag.Warning {
+			t.Fatalf("Expected a warning about anonymous access, got: %v", diags)
+		}
+	})
+
+	t.Run("warns when reading the token file set by the environment", func(t *testing.T) {
+		t.Setenv("GITHUB_TOKEN_FILE", tokenFile)
+		_, source, diags := configure(map[string]interface{}{})
+		if _, ok := source.(*fileTokenSource); !ok {
+			t.Fatalf("Expected a file token source, got: %T", source)
+		}
+		if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "GITHUB_TOKEN_FILE") {
+			t.Fatalf("Expected a warning about the token file, got: %v", diags)
+		}
+	})
+
+	t.Run("warns when falling back to GitHub CLI", func(t *testing.T) {
+		gh := filepath.Join(t.TempDir(), "gh")
+		if err := os.WriteFile(gh, []byte("#!/bin/sh\necho from-gh\n"), 0700); err != nil {
+			t.Fatal(err)
+		}
+		t.Setenv("GH_PATH", gh)
+		token, _, diags := configure(map[string]interface{}{})
+		if token != "from-gh" {
+			t.Fatalf("Expected the token from GitHub CLI, got: %q", token)
+		}
+		if len(diags) != 1 || diags[0].Severity != diag.Warning || !strings.Contains(diags[0].Summary, "GitHub CLI") {
+			t.Fatalf("Expected a warning about GitHub CLI, got: %v", diags)
+		}
+	})
+}
@@ -2,8 +2,13 @@ package github
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"log"
 	"net/http"
 	"net/url"
+	"os"
 	"path"
 	"strings"
 	"time"
@@ -15,16 +20,23 @@ import (
 )
 
 type Config struct {
-	Token            string
-	Owner            string
-	BaseURL          string
-	Insecure         bool
-	WriteDelay       time.Duration
-	ReadDelay        time.Duration
-	RetryDelay       time.Duration
-	RetryableErrors  map[int]bool
-	MaxRetries       int
-	ParallelRequests bool
+	Token                    string
+	TokenSource              refreshingTokenSource
+	Owner                    string
+	BaseURL                  string
+	Insecure                 bool
+	Transport                *http.Transport
+	WriteDelay               time.Duration
+	ReadDelay                time.Duration
+	RetryDelay               time.Duration
+	RetryableErrors          map[int]bool
+	MaxRetries               int
+	ParallelRequests         bool
+	RateLimitPacing          bool
+	RateLimitPacingThreshold int
+	EtagCacheDir             string
+	CassetteMode             string
+	CassettePath             string
 }
 
 type Owner struct {
@@ -32,14 +44,20 @@ type Owner struct {
 	id             int64
 	v3client       *github.Client
 	v4client       *githubv4.Client
+	httpClient     *http.Client
 	StopContext    context.Context
 	IsOrganization bool
+	cache          *lookupCache
 }
 
-func RateLimitedHTTPClient(client *http.Client, writeDelay time.Duration, readDelay time.Duration, retryDelay time.Duration, parallelRequests bool, retryableErrors map[int]bool, maxRetries int) *http.Client {
+func RateLimitedHTTPClient(client *http.Client, writeDelay time.Duration, readDelay time.Duration, retryDelay time.Duration, parallelRequests bool, retryableErrors map[int]bool, maxRetries int, pacing bool, pacingThreshold int, etagCache *etagCache, cassette *cassette) *http.Client {
 
-	client.Transport = NewEtagTransport(client.Transport)
-	client.Transport = NewRateLimitTransport(client.Transport, WithWriteDelay(writeDelay), WithReadDelay(readDelay), WithParallelRequests(parallelRequests))
+	if cassette != nil {
+		client.Transport = NewRecorderTransport(client.Transport, cassette)
+	}
+
+	client.Transport = NewEtagTransport(client.Transport, WithEtagCache(etagCache))
+	client.Transport = NewRateLimitTransport(client.Transport, WithWriteDelay(writeDelay), WithReadDelay(readDelay), WithParallelRequests(parallelRequests), WithRateLimitPacing(pacing, pacingThreshold))
 	client.Transport = logging.NewSubsystemLoggingHTTPTransport("GitHub", client.Transport)
 	client.Transport = newPreviewHeaderInjectorTransport(map[string]string{
 		// TODO: remove when Stone Crop preview is moved to general availability in the GraphQL API
@@ -50,27 +68,155 @@ func RateLimitedHTTPClient(client *http.Client, writeDelay time.Duration, readDe
 		client.Transport = NewRetryTransport(client.Transport, WithRetryDelay(retryDelay), WithRetryableErrors(retryableErrors), WithMaxRetries(maxRetries))
 	}
 
+	// Failed responses are recorded once retries are exhausted
+	client.Transport = NewAPIErrorTransport(client.Transport)
+
 	return client
 }
 
-func (c *Config) AuthenticatedHTTPClient() *http.Client {
+func (c *Config) AuthenticatedHTTPClient(etagCache *etagCache, cassette *cassette) *http.Client {
+
+	if c.TokenSource != nil {
+		// The token source is used directly rather than through oauth2.NewClient
+		// which would cache tokens itself and defeat the early refresh.
+		client := &http.Client{
+			Transport: NewTokenRefreshTransport(&oauth2.Transport{Source: c.TokenSource, Base: c.baseTransport()}, c.TokenSource),
+		}
+		return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries, c.RateLimitPacing, c.RateLimitPacingThreshold, etagCache, cassette)
+	}
 
-	ctx := context.Background()
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: c.baseTransport()})
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: c.Token},
 	)
 	client := oauth2.NewClient(ctx, ts)
 
-	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries)
+	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries, c.RateLimitPacing, c.RateLimitPacingThreshold, etagCache, cassette)
 }
 
 func (c *Config) Anonymous() bool {
-	return c.Token == ""
+	return c.Token == "" && c.TokenSource == nil
+}
+
+func (c *Config) AnonymousHTTPClient(etagCache *etagCache, cassette *cassette) *http.Client {
+	client := &http.Client{Transport: c.baseTransport()}
+	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries, c.RateLimitPacing, c.RateLimitPacingThreshold, etagCache, cassette)
+}
+
+// baseTransport returns the transport all requests to GitHub are sent with,
+// carrying the proxy and TLS settings of the provider.
+func (c *Config) baseTransport() *http.Transport {
+	if c.Transport != nil {
+		return c.Transport
+	}
+	return http.DefaultTransport.(*http.Transport).Clone()
+}
+
+// newHTTPTransport returns a transport connecting through the given proxy and
+// trusting the given CA bundle in addition to the system's certificate pool.
+// The CA bundle, client certificate and client key may each be given either as
+// PEM encoded contents or as the path of a file containing them. When no proxy
+// URL is given, the standard HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment
+// variables are honoured.
+func newHTTPTransport(insecure bool, proxyURL, caBundle, clientCertificate, clientKey string) (*http.Transport, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy_url: %w", err)
+		}
+		if u.Scheme == "" || u.Host == "" {
+			return nil, fmt.Errorf("invalid proxy_url %q: must be an absolute URL such as http://proxy.example.com:8080", proxyURL)
+		}
+		transport.Proxy = http.ProxyURL(u)
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		// The insecure argument is intended for testing against instances
+		// with self-signed certificates.
+		InsecureSkipVerify: insecure, // #nosec G402
+	}
+
+	if caBundle != "" {
+		pemData, err := readPEM(caBundle)
+		if err != nil {
+			return nil, fmt.Errorf("error reading ca_bundle: %w", err)
+		}
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			log.Printf("[WARN] Unable to load the system certificate pool, only trusting ca_bundle: %s", err)
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(pemData) {
+			return nil, fmt.Errorf("ca_bundle does not contain any PEM encoded certificates")
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if (clientCertificate == "") != (clientKey == "") {
+		return nil, fmt.Errorf("client_certificate and client_key must be set together")
+	}
+	if clientCertificate != "" {
+		certPEM, err := readPEM(clientCertificate)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client_certificate: %w", err)
+		}
+		keyPEM, err := readPEM(clientKey)
+		if err != nil {
+			return nil, fmt.Errorf("error reading client_key: %w", err)
+		}
+		cert, err := tls.X509KeyPair(certPEM, keyPEM)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	transport.TLSClientConfig = tlsConfig
+
+	return transport, nil
 }
 
-func (c *Config) AnonymousHTTPClient() *http.Client {
-	client := &http.Client{Transport: &http.Transport{}}
-	return RateLimitedHTTPClient(client, c.WriteDelay, c.ReadDelay, c.RetryDelay, c.ParallelRequests, c.RetryableErrors, c.MaxRetries)
+// readPEM returns the given PEM encoded contents, or the contents of the file
+// at the given path. As for app_auth.pem_file, any occurrence of \n in inline
+// contents is replaced with an actual new line.
+func readPEM(value string) ([]byte, error) {
+	if strings.Contains(value, "-----BEGIN") {
+		return []byte(strings.Replace(value, `\n`, "\n", -1)), nil
+	}
+	return os.ReadFile(value)
+}
+
+// EtagCache returns the on-disk response cache shared by the provider's HTTP
+// clients, or nil when no cache directory is configured.
+func (c *Config) EtagCache() (*etagCache, error) {
+	if c.EtagCacheDir == "" {
+		return nil, nil
+	}
+
+	var identity string
+	switch {
+	case c.TokenSource != nil:
+		identity = cacheIdentity(append([]string{c.BaseURL}, c.TokenSource.identity()...)...)
+	case c.Token != "":
+		identity = cacheIdentity(c.BaseURL, "token", c.Token)
+	default:
+		identity = cacheIdentity(c.BaseURL, "anonymous")
+	}
+
+	return newEtagCache(c.EtagCacheDir, identity)
+}
+
+// Cassette returns the cassette requests are recorded into or replayed from,
+// or nil when requests are sent to GitHub as usual.
+func (c *Config) Cassette() (*cassette, error) {
+	if c.CassetteMode == "" || c.CassettePath == "" {
+		return nil, nil
+	}
+
+	return openCassette(c.CassettePath, c.CassetteMode)
 }
 
 func (c *Config) NewGraphQLClient(client *http.Client) (*githubv4.Client, error) {
@@ -110,8 +256,10 @@ func (c *Config) NewRESTClient(client *http.Client) (*github.Client, error) {
 	return v3client, nil
 }
 
-func (c *Config) ConfigureOwner(owner *Owner) (*Owner, error) {
-	ctx := context.Background()
+// ConfigureOwner discovers the owner the provider manages, looking up the
+// authenticated user when none is configured. The lookups are cancelled along
+// with ctx.
+func (c *Config) ConfigureOwner(ctx context.Context, owner *Owner) (*Owner, error) {
 	owner.name = c.Owner
 	if owner.name == "" {
 		if c.Anonymous() {
@@ -139,12 +287,28 @@ func (c *Config) ConfigureOwner(owner *Owner) (*Owner, error) {
 // Meta returns the meta parameter that is passed into subsequent resources
 // https://godoc.org/github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema#ConfigureFunc
 func (c *Config) Meta() (interface{}, error) {
+	return c.MetaContext(context.Background())
+}
+
+// MetaContext is like Meta, cancelling the requests made to configure the
+// owner along with ctx, e.g. when Terraform is interrupted during configure.
+func (c *Config) MetaContext(ctx context.Context) (interface{}, error) {
+
+	etagCache, err := c.EtagCache()
+	if err != nil {
+		return nil, err
+	}
+
+	cassette, err := c.Cassette()
+	if err != nil {
+		return nil, err
+	}
 
 	var client *http.Client
 	if c.Anonymous() {
-		client = c.AnonymousHTTPClient()
+		client = c.AnonymousHTTPClient(etagCache, cassette)
 	} else {
-		client = c.AuthenticatedHTTPClient()
+		client = c.AuthenticatedHTTPClient(etagCache, cassette)
 	}
 
 	v3client, err := c.NewRESTClient(client)
@@ -160,9 +324,11 @@ func (c *Config) Meta() (interface{}, error) {
 	var owner Owner
 	owner.v4client = v4client
 	owner.v3client = v3client
+	owner.httpClient = &http.Client{Transport: c.baseTransport()}
 	owner.StopContext = context.Background()
+	owner.cache = newLookupCache()
 
-	_, err = c.ConfigureOwner(&owner)
+	_, err = c.ConfigureOwner(ctx, &owner)
 	if err != nil {
 		return &owner, err
 	}
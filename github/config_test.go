@@ -2,7 +2,21 @@ package github
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/shurcooL/githubv4"
 )
@@ -162,3 +176,148 @@ func TestAccConfigMeta(t *testing.T) {
 	})
 
 }
+
+func TestNewHTTPTransport(t *testing.T) {
+
+	ts := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if len(r.TLS.PeerCertificates) > 0 {
+			w.Header().Set("X-Client-Certificate", r.TLS.PeerCertificates[0].Subject.CommonName)
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	ts.TLS = &tls.Config{ClientAuth: tls.RequestClientCert}
+	ts.StartTLS()
+	defer ts.Close()
+
+	serverCA := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ts.Certificate().Raw}))
+
+	get := func(transport *http.Transport) (*http.Response, error) {
+		return (&http.Client{Transport: transport}).Get(ts.URL)
+	}
+
+	t.Run("rejects unknown certificates by default", func(t *testing.T) {
+		transport, err := newHTTPTransport(false, "", "", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := get(transport); err == nil {
+			t.Fatal("Expected the server certificate to be rejected")
+		}
+	})
+
+	t.Run("skips verification when insecure", func(t *testing.T) {
+		transport, err := newHTTPTransport(true, "", "", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := get(transport); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("trusts a CA bundle given as PEM or as a file", func(t *testing.T) {
+		file := filepath.Join(t.TempDir(), "ca.pem")
+		if err := os.WriteFile(file, []byte(serverCA), 0600); err != nil {
+			t.Fatal(err)
+		}
+
+		for _, caBundle := range []string{serverCA, strings.ReplaceAll(serverCA, "\n", `\n`), file} {
+			transport, err := newHTTPTransport(false, "", caBundle, "", "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := get(transport); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+
+	t.Run("presents a client certificate", func(t *testing.T) {
+		key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "terraform"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+			ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		keyDer, err := x509.MarshalECPrivateKey(key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		certPEM := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}))
+		keyPEM := string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}))
+
+		transport, err := newHTTPTransport(false, "", serverCA, certPEM, keyPEM)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := get(transport)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if cn := resp.Header.Get("X-Client-Certificate"); cn != "terraform" {
+			t.Fatalf("Expected the client certificate to be presented, got: %q", cn)
+		}
+
+		if _, err := newHTTPTransport(false, "", "", certPEM, ""); err == nil {
+			t.Fatal("Expected an error for a client certificate without a key")
+		}
+	})
+
+	t.Run("sends requests through a proxy", func(t *testing.T) {
+		transport, err := newHTTPTransport(false, "http://proxy.example.com:8080", "", "", "")
+		if err != nil {
+			t.Fatal(err)
+		}
+		req, _ := http.NewRequest(http.MethodGet, "https://api.github.com/", nil)
+		proxy, err := transport.Proxy(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if proxy.String() != "http://proxy.example.com:8080" {
+			t.Fatalf("Expected requests to be sent through the proxy, got: %v", proxy)
+		}
+
+		if _, err := newHTTPTransport(false, "proxy.example.com", "", "", ""); err == nil {
+			t.Fatal("Expected an error for a relative proxy URL")
+		}
+	})
+}
+
+func TestConfigMetaContext(t *testing.T) {
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"login": "octocat"}`))
+	}))
+	defer ts.Close()
+
+	config := Config{BaseURL: ts.URL + "/", Token: "fake"}
+
+	t.Run("discovers the authenticated user", func(t *testing.T) {
+		meta, err := config.MetaContext(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name := meta.(*Owner).name; name != "octocat" {
+			t.Fatalf("Unexpected owner %q", name)
+		}
+	})
+
+	t.Run("stops discovering the user once the context is cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		if _, err := config.MetaContext(ctx); err == nil {
+			t.Fatal("Expected an error configuring the owner with a cancelled context")
+		}
+	})
+}
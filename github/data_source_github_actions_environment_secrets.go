@@ -7,12 +7,13 @@ import (
 
 	"github.com/google/go-github/v66/github"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubActionsEnvironmentSecrets() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubActionsEnvironmentSecretsRead,
+		ReadContext: dataSourceGithubActionsEnvironmentSecretsRead,
 
 		Schema: map[string]*schema.Schema{
 			"full_name": {
@@ -55,7 +56,7 @@ func dataSourceGithubActionsEnvironmentSecrets() *schema.Resource {
 	}
 }
 
-func dataSourceGithubActionsEnvironmentSecretsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubActionsEnvironmentSecretsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	var repoName string
@@ -67,7 +68,7 @@ func dataSourceGithubActionsEnvironmentSecretsRead(d *schema.ResourceData, meta
 		var err error
 		owner, repoName, err = splitRepoFullName(fullName.(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -76,12 +77,12 @@ func dataSourceGithubActionsEnvironmentSecretsRead(d *schema.ResourceData, meta
 	}
 
 	if repoName == "" {
-		return fmt.Errorf("one of %q or %q has to be provided", "full_name", "name")
+		return diag.FromErr(fmt.Errorf("one of %q or %q has to be provided", "full_name", "name"))
 	}
 
-	repo, _, err := client.Repositories.Get(context.TODO(), owner, repoName)
+	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	options := github.ListOptions{
@@ -90,9 +91,9 @@ func dataSourceGithubActionsEnvironmentSecretsRead(d *schema.ResourceData, meta
 
 	var all_secrets []map[string]string
 	for {
-		secrets, resp, err := client.Actions.ListEnvSecrets(context.TODO(), int(repo.GetID()), escapedEnvName, &options)
+		secrets, resp, err := client.Actions.ListEnvSecrets(ctx, int(repo.GetID()), escapedEnvName, &options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		for _, secret := range secrets.Secrets {
 			new_secret := map[string]string{
@@ -1,12 +1,14 @@
 package github
 
 import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubActionsOrganizationOIDCSubjectClaimCustomizationTemplate() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubActionsOrganizationOIDCSubjectClaimCustomizationTemplateRead,
+		ReadContext: dataSourceGithubActionsOrganizationOIDCSubjectClaimCustomizationTemplateRead,
 
 		Schema: map[string]*schema.Schema{
 			"include_claim_keys": {
@@ -20,27 +22,26 @@ func dataSourceGithubActionsOrganizationOIDCSubjectClaimCustomizationTemplate()
 	}
 }
 
-func dataSourceGithubActionsOrganizationOIDCSubjectClaimCustomizationTemplateRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubActionsOrganizationOIDCSubjectClaimCustomizationTemplateRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := meta.(*Owner).StopContext
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	template, _, err := client.Actions.GetOrgOIDCSubjectClaimCustomTemplate(ctx, orgName)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(orgName)
 	err = d.Set("include_claim_keys", template.IncludeClaimKeys)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -3,12 +3,13 @@ package github
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubActionsOrganizationPublicKey() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubActionsOrganizationPublicKeyRead,
+		ReadContext: dataSourceGithubActionsOrganizationPublicKeyRead,
 
 		Schema: map[string]*schema.Schema{
 			"key_id": {
@@ -23,30 +24,28 @@ func dataSourceGithubActionsOrganizationPublicKey() *schema.Resource {
 	}
 }
 
-func dataSourceGithubActionsOrganizationPublicKeyRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubActionsOrganizationPublicKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 
-	ctx := context.Background()
-
 	publicKey, _, err := client.Actions.GetOrgPublicKey(ctx, owner)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(publicKey.GetKeyID())
 	err = d.Set("key_id", publicKey.GetKeyID())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("key", publicKey.GetKey())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
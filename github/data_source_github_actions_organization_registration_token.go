@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubActionsOrganizationRegistrationToken() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubActionsOrganizationRegistrationTokenRead,
+		ReadContext: dataSourceGithubActionsOrganizationRegistrationTokenRead,
 
 		Schema: map[string]*schema.Schema{
 			"token": {
@@ -25,24 +26,24 @@ func dataSourceGithubActionsOrganizationRegistrationToken() *schema.Resource {
 	}
 }
 
-func dataSourceGithubActionsOrganizationRegistrationTokenRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubActionsOrganizationRegistrationTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 
 	log.Printf("[DEBUG] Creating a GitHub Actions organization registration token for %s", owner)
-	token, _, err := client.Actions.CreateOrganizationRegistrationToken(context.TODO(), owner)
+	token, _, err := client.Actions.CreateOrganizationRegistrationToken(ctx, owner)
 	if err != nil {
-		return fmt.Errorf("error creating a GitHub Actions organization registration token for %s: %s", owner, err)
+		return diag.FromErr(fmt.Errorf("error creating a GitHub Actions organization registration token for %s: %s", owner, err))
 	}
 
 	d.SetId(owner)
 	err = d.Set("token", token.Token)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("expires_at", token.ExpiresAt.Unix())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
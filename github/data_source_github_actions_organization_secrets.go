@@ -5,12 +5,13 @@ import (
 
 	"github.com/google/go-github/v66/github"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubActionsOrganizationSecrets() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubActionsOrganizationSecretsRead,
+		ReadContext: dataSourceGithubActionsOrganizationSecretsRead,
 
 		Schema: map[string]*schema.Schema{
 			"secrets": {
@@ -41,7 +42,7 @@ func dataSourceGithubActionsOrganizationSecrets() *schema.Resource {
 	}
 }
 
-func dataSourceGithubActionsOrganizationSecretsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubActionsOrganizationSecretsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 
@@ -51,9 +52,9 @@ func dataSourceGithubActionsOrganizationSecretsRead(d *schema.ResourceData, meta
 
 	var all_secrets []map[string]string
 	for {
-		secrets, resp, err := client.Actions.ListOrgSecrets(context.TODO(), owner, &options)
+		secrets, resp, err := client.Actions.ListOrgSecrets(ctx, owner, &options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		for _, secret := range secrets.Secrets {
 			new_secret := map[string]string{
@@ -74,7 +75,7 @@ func dataSourceGithubActionsOrganizationSecretsRead(d *schema.ResourceData, meta
 	d.SetId(owner)
 	err := d.Set("secrets", all_secrets)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
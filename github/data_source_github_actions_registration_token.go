@@ -5,12 +5,13 @@ import (
 	"fmt"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubActionsRegistrationToken() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubActionsRegistrationTokenRead,
+		ReadContext: dataSourceGithubActionsRegistrationTokenRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -30,25 +31,25 @@ func dataSourceGithubActionsRegistrationToken() *schema.Resource {
 	}
 }
 
-func dataSourceGithubActionsRegistrationTokenRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubActionsRegistrationTokenRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 
 	log.Printf("[DEBUG] Creating a GitHub Actions repository registration token for %s/%s", owner, repoName)
-	token, _, err := client.Actions.CreateRegistrationToken(context.TODO(), owner, repoName)
+	token, _, err := client.Actions.CreateRegistrationToken(ctx, owner, repoName)
 	if err != nil {
-		return fmt.Errorf("error creating a GitHub Actions repository registration token for %s/%s: %s", owner, repoName, err)
+		return diag.FromErr(fmt.Errorf("error creating a GitHub Actions repository registration token for %s/%s: %s", owner, repoName, err))
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s", owner, repoName))
 	err = d.Set("token", token.Token)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("expires_at", token.ExpiresAt.Unix())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
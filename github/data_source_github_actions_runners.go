@@ -0,0 +1,123 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubActionsRunners() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubActionsRunnersRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The repository to list the runners of. The runners of the organization are listed if not set.",
+			},
+			"runners": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The self-hosted runners.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the runner.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the runner.",
+						},
+						"os": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The operating system of the runner.",
+						},
+						"status": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The status of the runner, 'online' or 'offline'.",
+						},
+						"busy": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the runner is running a job.",
+						},
+						"ephemeral": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether the runner is removed once it has run a job.",
+						},
+						"runner_group_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the runner group the runner belongs to.",
+						},
+						"labels": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The labels of the runner, including the ones GitHub gives it from its platform.",
+						},
+						"custom_labels": {
+							Type:        schema.TypeList,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The labels added to the runner.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubActionsRunnersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+
+	path, err := actionsRunnersPath(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	list, err := listActionsRunners(ctx, client, path)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing runners of %s: %w", path, err))
+	}
+
+	runners := make([]interface{}, 0, len(list))
+	for _, runner := range list {
+		labels := []string{}
+		for _, label := range runner.Labels {
+			labels = append(labels, label.GetName())
+		}
+		runners = append(runners, map[string]interface{}{
+			"id":              runner.GetID(),
+			"name":            runner.GetName(),
+			"os":              runner.GetOS(),
+			"status":          runner.GetStatus(),
+			"busy":            runner.GetBusy(),
+			"ephemeral":       runner.GetEphemeral(),
+			"runner_group_id": runner.GetRunnerGroupID(),
+			"labels":          labels,
+			"custom_labels":   customActionsRunnerLabels(runner.Labels),
+		})
+	}
+
+	if repoName, ok := d.GetOk("repository"); ok {
+		d.SetId(buildTwoPartID(meta.(*Owner).name, repoName.(string)))
+	} else {
+		d.SetId(meta.(*Owner).name)
+	}
+	if err = d.Set("runners", runners); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -6,12 +6,13 @@ import (
 
 	"github.com/google/go-github/v66/github"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubActionsSecrets() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubActionsSecretsRead,
+		ReadContext: dataSourceGithubActionsSecretsRead,
 
 		Schema: map[string]*schema.Schema{
 			"full_name": {
@@ -50,7 +51,7 @@ func dataSourceGithubActionsSecrets() *schema.Resource {
 	}
 }
 
-func dataSourceGithubActionsSecretsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubActionsSecretsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	var repoName string
@@ -59,7 +60,7 @@ func dataSourceGithubActionsSecretsRead(d *schema.ResourceData, meta interface{}
 		var err error
 		owner, repoName, err = splitRepoFullName(fullName.(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -68,7 +69,7 @@ func dataSourceGithubActionsSecretsRead(d *schema.ResourceData, meta interface{}
 	}
 
 	if repoName == "" {
-		return fmt.Errorf("one of %q or %q has to be provided", "full_name", "name")
+		return diag.FromErr(fmt.Errorf("one of %q or %q has to be provided", "full_name", "name"))
 	}
 
 	options := github.ListOptions{
@@ -77,9 +78,9 @@ func dataSourceGithubActionsSecretsRead(d *schema.ResourceData, meta interface{}
 
 	var all_secrets []map[string]string
 	for {
-		secrets, resp, err := client.Actions.ListRepoSecrets(context.TODO(), owner, repoName, &options)
+		secrets, resp, err := client.Actions.ListRepoSecrets(ctx, owner, repoName, &options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		for _, secret := range secrets.Secrets {
 			new_secret := map[string]string{
@@ -98,7 +99,7 @@ func dataSourceGithubActionsSecretsRead(d *schema.ResourceData, meta interface{}
 	d.SetId(repoName)
 	err := d.Set("secrets", all_secrets)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -6,12 +6,13 @@ import (
 
 	"github.com/google/go-github/v66/github"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubActionsVariables() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubActionsVariablesRead,
+		ReadContext: dataSourceGithubActionsVariablesRead,
 
 		Schema: map[string]*schema.Schema{
 			"full_name": {
@@ -54,7 +55,7 @@ func dataSourceGithubActionsVariables() *schema.Resource {
 	}
 }
 
-func dataSourceGithubActionsVariablesRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubActionsVariablesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	var repoName string
@@ -63,7 +64,7 @@ func dataSourceGithubActionsVariablesRead(d *schema.ResourceData, meta interface
 		var err error
 		owner, repoName, err = splitRepoFullName(fullName.(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -72,7 +73,7 @@ func dataSourceGithubActionsVariablesRead(d *schema.ResourceData, meta interface
 	}
 
 	if repoName == "" {
-		return fmt.Errorf("one of %q or %q has to be provided", "full_name", "name")
+		return diag.FromErr(fmt.Errorf("one of %q or %q has to be provided", "full_name", "name"))
 	}
 
 	options := github.ListOptions{
@@ -81,9 +82,9 @@ func dataSourceGithubActionsVariablesRead(d *schema.ResourceData, meta interface
 
 	var all_variables []map[string]string
 	for {
-		variables, resp, err := client.Actions.ListRepoVariables(context.TODO(), owner, repoName, &options)
+		variables, resp, err := client.Actions.ListRepoVariables(ctx, owner, repoName, &options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		for _, variable := range variables.Variables {
 			new_variable := map[string]string{
@@ -103,7 +104,7 @@ func dataSourceGithubActionsVariablesRead(d *schema.ResourceData, meta interface
 	d.SetId(repoName)
 	err := d.Set("variables", all_variables)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
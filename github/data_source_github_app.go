@@ -4,12 +4,13 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubApp() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubAppRead,
+		ReadContext: dataSourceGithubAppRead,
 
 		Schema: map[string]*schema.Schema{
 			"slug": {
@@ -32,29 +33,28 @@ func dataSourceGithubApp() *schema.Resource {
 	}
 }
 
-func dataSourceGithubAppRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubAppRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	slug := d.Get("slug").(string)
 
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	app, _, err := client.Apps.Get(ctx, slug)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.FormatInt(app.GetID(), 10))
 	err = d.Set("description", app.GetDescription())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("name", app.GetName())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("node_id", app.GetNodeID())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -41,8 +42,9 @@ func TestAccGithubAppTokenDataSource(t *testing.T) {
 		client.BaseURL = u
 
 		meta := &Owner{
-			name:     owner,
-			v3client: client,
+			name:       owner,
+			v3client:   client,
+			httpClient: http.DefaultClient,
 		}
 
 		testSchema := map[string]*schema.Schema{
@@ -59,8 +61,8 @@ func TestAccGithubAppTokenDataSource(t *testing.T) {
 			"token":           "",
 		})
 
-		err := dataSourceGithubAppTokenRead(schema, meta)
-		assert.Nil(t, err)
+		diags := dataSourceGithubAppTokenRead(context.Background(), schema, meta)
+		assert.Nil(t, diags)
 		assert.Equal(t, expectedAccessToken, schema.Get("token"))
 	})
 }
@@ -6,12 +6,13 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubBranch() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubBranchRead,
+		ReadContext: dataSourceGithubBranchRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -40,14 +41,14 @@ func dataSourceGithubBranch() *schema.Resource {
 	}
 }
 
-func dataSourceGithubBranchRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubBranchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 	branchName := d.Get("branch").(string)
 	branchRefName := "refs/heads/" + branchName
 
-	ref, resp, err := client.Git.GetRef(context.TODO(), orgName, repoName, branchRefName)
+	ref, resp, err := client.Git.GetRef(ctx, orgName, repoName, branchRefName)
 	if err != nil {
 		if err, ok := err.(*github.ErrorResponse); ok {
 			if err.Response.StatusCode == http.StatusNotFound {
@@ -56,21 +57,21 @@ func dataSourceGithubBranchRead(d *schema.ResourceData, meta interface{}) error
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repoName, branchName))
 	err = d.Set("etag", resp.Header.Get("ETag"))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("ref", *ref.Ref)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("sha", *ref.Object.SHA)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -1,13 +1,15 @@
 package github
 
 import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/shurcooL/githubv4"
 )
 
 func dataSourceGithubBranchProtectionRules() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubBranchProtectionRulesRead,
+		ReadContext: dataSourceGithubBranchProtectionRulesRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -30,7 +32,7 @@ func dataSourceGithubBranchProtectionRules() *schema.Resource {
 	}
 }
 
-func dataSourceGithubBranchProtectionRulesRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubBranchProtectionRulesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v4client
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -55,9 +57,9 @@ func dataSourceGithubBranchProtectionRulesRead(d *schema.ResourceData, meta inte
 
 	var rules []interface{}
 	for {
-		err := client.Query(meta.(*Owner).StopContext, &query, variables)
+		err := client.Query(ctx, &query, variables)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		additionalRules := make([]interface{}, len(query.Repository.BranchProtectionRules.Nodes))
@@ -77,7 +79,7 @@ func dataSourceGithubBranchProtectionRulesRead(d *schema.ResourceData, meta inte
 	d.SetId(string(query.Repository.ID))
 	err := d.Set("rules", rules)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
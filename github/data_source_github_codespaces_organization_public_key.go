@@ -3,12 +3,13 @@ package github
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubCodespacesOrganizationPublicKey() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubCodespacesOrganizationPublicKeyRead,
+		ReadContext: dataSourceGithubCodespacesOrganizationPublicKeyRead,
 
 		Schema: map[string]*schema.Schema{
 			"key_id": {
@@ -23,30 +24,28 @@ func dataSourceGithubCodespacesOrganizationPublicKey() *schema.Resource {
 	}
 }
 
-func dataSourceGithubCodespacesOrganizationPublicKeyRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubCodespacesOrganizationPublicKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 
-	ctx := context.Background()
-
 	publicKey, _, err := client.Codespaces.GetOrgPublicKey(ctx, owner)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(publicKey.GetKeyID())
 	err = d.Set("key_id", publicKey.GetKeyID())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("key", publicKey.GetKey())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -4,12 +4,13 @@ import (
 	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubCodespacesPublicKey() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubCodespacesPublicKeyRead,
+		ReadContext: dataSourceGithubCodespacesPublicKeyRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -28,27 +29,26 @@ func dataSourceGithubCodespacesPublicKey() *schema.Resource {
 	}
 }
 
-func dataSourceGithubCodespacesPublicKeyRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubCodespacesPublicKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	repository := d.Get("repository").(string)
 	owner := meta.(*Owner).name
 	log.Printf("[INFO] Refreshing GitHub Codespaces Public Key from: %s/%s", owner, repository)
 
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	publicKey, _, err := client.Codespaces.GetRepoPublicKey(ctx, owner, repository)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(publicKey.GetKeyID())
 	err = d.Set("key_id", publicKey.GetKeyID())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("key", publicKey.GetKey())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
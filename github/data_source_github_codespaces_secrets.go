@@ -6,12 +6,13 @@ import (
 
 	"github.com/google/go-github/v66/github"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubCodespacesSecrets() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubCodespacesSecretsRead,
+		ReadContext: dataSourceGithubCodespacesSecretsRead,
 
 		Schema: map[string]*schema.Schema{
 			"full_name": {
@@ -52,10 +53,9 @@ func dataSourceGithubCodespacesSecrets() *schema.Resource {
 	}
 }
 
-func dataSourceGithubCodespacesSecretsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubCodespacesSecretsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	var repoName string
 
@@ -63,7 +63,7 @@ func dataSourceGithubCodespacesSecretsRead(d *schema.ResourceData, meta interfac
 		var err error
 		owner, repoName, err = splitRepoFullName(fullName.(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -72,7 +72,7 @@ func dataSourceGithubCodespacesSecretsRead(d *schema.ResourceData, meta interfac
 	}
 
 	if repoName == "" {
-		return fmt.Errorf("one of %q or %q has to be provided", "full_name", "name")
+		return diag.FromErr(fmt.Errorf("one of %q or %q has to be provided", "full_name", "name"))
 	}
 
 	options := github.ListOptions{
@@ -83,7 +83,7 @@ func dataSourceGithubCodespacesSecretsRead(d *schema.ResourceData, meta interfac
 	for {
 		secrets, resp, err := client.Codespaces.ListRepoSecrets(ctx, owner, repoName, &options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		for _, secret := range secrets.Secrets {
 			new_secret := map[string]string{
@@ -102,7 +102,7 @@ func dataSourceGithubCodespacesSecretsRead(d *schema.ResourceData, meta interfac
 	d.SetId(repoName)
 	err := d.Set("secrets", all_secrets)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
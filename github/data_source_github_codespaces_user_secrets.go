@@ -5,12 +5,13 @@ import (
 
 	"github.com/google/go-github/v66/github"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubCodespacesUserSecrets() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubCodespacesUserSecretsRead,
+		ReadContext: dataSourceGithubCodespacesUserSecretsRead,
 
 		Schema: map[string]*schema.Schema{
 			"secrets": {
@@ -41,7 +42,7 @@ func dataSourceGithubCodespacesUserSecrets() *schema.Resource {
 	}
 }
 
-func dataSourceGithubCodespacesUserSecretsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubCodespacesUserSecretsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 
@@ -51,9 +52,9 @@ func dataSourceGithubCodespacesUserSecretsRead(d *schema.ResourceData, meta inte
 
 	var all_secrets []map[string]string
 	for {
-		secrets, resp, err := client.Codespaces.ListUserSecrets(context.TODO(), &options)
+		secrets, resp, err := client.Codespaces.ListUserSecrets(ctx, &options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		for _, secret := range secrets.Secrets {
 			new_secret := map[string]string{
@@ -74,7 +75,7 @@ func dataSourceGithubCodespacesUserSecretsRead(d *schema.ResourceData, meta inte
 	d.SetId(owner)
 	err := d.Set("secrets", all_secrets)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
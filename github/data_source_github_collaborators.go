@@ -5,13 +5,14 @@ import (
 	"fmt"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceGithubCollaborators() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubCollaboratorsRead,
+		ReadContext: dataSourceGithubCollaboratorsRead,
 
 		Schema: map[string]*schema.Schema{
 			"owner": {
@@ -120,10 +121,9 @@ func dataSourceGithubCollaborators() *schema.Resource {
 	}
 }
 
-func dataSourceGithubCollaboratorsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubCollaboratorsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	owner := d.Get("owner").(string)
 	repo := d.Get("repository").(string)
@@ -145,31 +145,31 @@ func dataSourceGithubCollaboratorsRead(d *schema.ResourceData, meta interface{})
 	}
 	err := d.Set("owner", owner)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("repository", repo)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("affiliation", affiliation)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("permission", permission)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	totalCollaborators := make([]interface{}, 0)
 	for {
 		collaborators, resp, err := client.Repositories.ListCollaborators(ctx, owner, repo, options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		result, err := flattenGitHubCollaborators(collaborators)
 		if err != nil {
-			return fmt.Errorf("unable to flatten GitHub Collaborators (Owner: %q/Repository: %q) : %+v", owner, repo, err)
+			return diag.FromErr(fmt.Errorf("unable to flatten GitHub Collaborators (Owner: %q/Repository: %q) : %+v", owner, repo, err))
 		}
 
 		totalCollaborators = append(totalCollaborators, result...)
@@ -182,7 +182,7 @@ func dataSourceGithubCollaboratorsRead(d *schema.ResourceData, meta interface{})
 
 	err = d.Set("collaborator", totalCollaborators)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -0,0 +1,125 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubCopilotOrganizationSeats() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubCopilotOrganizationSeatsRead,
+
+		Schema: map[string]*schema.Schema{
+			"total_seats": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The total number of Copilot seats of the organization.",
+			},
+			"seats": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The Copilot seats of the organization.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"login": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The login of the user assigned the seat.",
+						},
+						"assigning_team_slug": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The slug of the team through which the seat is assigned, if any.",
+						},
+						"plan_type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The Copilot plan of the seat.",
+						},
+						"pending_cancellation_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The date the seat will be cancelled, if it is pending cancellation.",
+						},
+						"last_activity_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The last time the user used Copilot.",
+						},
+						"last_activity_editor": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The editor in which the user last used Copilot.",
+						},
+						"created_at": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The time the seat was assigned.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubCopilotOrganizationSeatsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	var totalSeats int64
+	seats := []interface{}{}
+	options := &github.ListOptions{PerPage: maxPerPage}
+	for {
+		page, resp, err := client.Copilot.ListCopilotSeats(ctx, orgName, options)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error querying Copilot seats of %s: %w", orgName, err))
+		}
+		totalSeats = page.TotalSeats
+
+		for _, seat := range page.Seats {
+			user, ok := seat.GetUser()
+			if !ok {
+				continue
+			}
+			// Seats never used have no last activity.
+			lastActivityAt := ""
+			if seat.LastActivityAt != nil {
+				lastActivityAt = seat.LastActivityAt.String()
+			}
+			seats = append(seats, map[string]interface{}{
+				"login":                     user.GetLogin(),
+				"assigning_team_slug":       seat.GetAssigningTeam().GetSlug(),
+				"plan_type":                 seat.GetPlanType(),
+				"pending_cancellation_date": seat.GetPendingCancellationDate(),
+				"last_activity_at":          lastActivityAt,
+				"last_activity_editor":      seat.GetLastActivityEditor(),
+				"created_at":                seat.GetCreatedAt().String(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	d.SetId(orgName)
+	if err = d.Set("total_seats", totalSeats); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("seats", seats); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 	"log"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubDependabotPublicKey() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubDependabotPublicKeyRead,
+		ReadContext: dataSourceGithubDependabotPublicKeyRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -28,27 +29,26 @@ func dataSourceGithubDependabotPublicKey() *schema.Resource {
 	}
 }
 
-func dataSourceGithubDependabotPublicKeyRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubDependabotPublicKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	repository := d.Get("repository").(string)
 	owner := meta.(*Owner).name
 	log.Printf("[INFO] Refreshing GitHub Dependabot Public Key from: %s/%s", owner, repository)
 
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	publicKey, _, err := client.Dependabot.GetRepoPublicKey(ctx, owner, repository)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(publicKey.GetKeyID())
 	err = d.Set("key_id", publicKey.GetKeyID())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("key", publicKey.GetKey())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
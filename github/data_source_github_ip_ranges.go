@@ -1,15 +1,17 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"net"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubIpRanges() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubIpRangesRead,
+		ReadContext: dataSourceGithubIpRangesRead,
 
 		Schema: map[string]*schema.Schema{
 			"hooks": {
@@ -151,57 +153,57 @@ func dataSourceGithubIpRanges() *schema.Resource {
 	}
 }
 
-func dataSourceGithubIpRangesRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubIpRangesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	owner := meta.(*Owner)
 
-	api, _, err := owner.v3client.Meta.Get(owner.StopContext)
+	api, _, err := owner.v3client.Meta.Get(ctx)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cidrHooksIpv4, cidrHooksIpv6, err := splitIpv4Ipv6Cidrs(&api.Hooks)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cidrGitIpv4, cidrGitIpv6, err := splitIpv4Ipv6Cidrs(&api.Git)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cidrPackagesIpv4, cidrPackagesIpv6, err := splitIpv4Ipv6Cidrs(&api.Packages)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cidrPagesIpv4, cidrPagesIpv6, err := splitIpv4Ipv6Cidrs(&api.Pages)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cidrImporterIpv4, cidrImporterIpv6, err := splitIpv4Ipv6Cidrs(&api.Importer)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cidrActionsIpv4, cidrActionsIpv6, err := splitIpv4Ipv6Cidrs(&api.Actions)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cidrDependabotIpv4, cidrDependabotIpv6, err := splitIpv4Ipv6Cidrs(&api.Dependabot)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cidrWebIpv4, cidrWebIpv6, err := splitIpv4Ipv6Cidrs(&api.Web)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	cidrApiIpv4, cidrApiIpv6, err := splitIpv4Ipv6Cidrs(&api.API)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(api.Hooks)+len(api.Git)+len(api.Pages)+len(api.Importer)+len(api.Actions)+len(api.Dependabot) > 0 {
@@ -210,29 +212,29 @@ func dataSourceGithubIpRangesRead(d *schema.ResourceData, meta interface{}) erro
 	if len(api.Hooks) > 0 {
 		err = d.Set("hooks", api.Hooks)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("hooks_ipv4", cidrHooksIpv4)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("hooks_ipv6", cidrHooksIpv6)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if len(api.Git) > 0 {
 		err = d.Set("git", api.Git)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("git_ipv4", cidrGitIpv4)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("git_ipv6", cidrGitIpv6)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if len(api.Packages) > 0 {
@@ -243,85 +245,85 @@ func dataSourceGithubIpRangesRead(d *schema.ResourceData, meta interface{}) erro
 	if len(api.Pages) > 0 {
 		err = d.Set("pages", api.Pages)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("pages_ipv4", cidrPagesIpv4)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("pages_ipv6", cidrPagesIpv6)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if len(api.Importer) > 0 {
 		err = d.Set("importer", api.Importer)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("importer_ipv4", cidrImporterIpv4)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("importer_ipv6", cidrImporterIpv6)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if len(api.Actions) > 0 {
 		err = d.Set("actions", api.Actions)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("actions_ipv4", cidrActionsIpv4)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("actions_ipv6", cidrActionsIpv6)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if len(api.Dependabot) > 0 {
 		err = d.Set("dependabot", api.Dependabot)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("dependabot_ipv4", cidrDependabotIpv4)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("dependabot_ipv6", cidrDependabotIpv6)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if len(api.Web) > 0 {
 		err = d.Set("web", api.Web)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("web_ipv4", cidrWebIpv4)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("web_ipv6", cidrWebIpv6)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if len(api.API) > 0 {
 		err = d.Set("api", api.API)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("api_ipv4", cidrApiIpv4)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		err = d.Set("api_ipv6", cidrApiIpv6)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
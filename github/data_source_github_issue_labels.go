@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubIssueLabels() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubIssueLabelsRead,
+		ReadContext: dataSourceGithubIssueLabelsRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -45,12 +46,11 @@ func dataSourceGithubIssueLabels() *schema.Resource {
 	}
 }
 
-func dataSourceGithubIssueLabelsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubIssueLabelsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	repository := d.Get("repository").(string)
 
-	ctx := context.Background()
 	opts := &github.ListOptions{
 		PerPage: maxPerPage,
 	}
@@ -61,12 +61,12 @@ func dataSourceGithubIssueLabelsRead(d *schema.ResourceData, meta interface{}) e
 	for {
 		labels, resp, err := client.Issues.ListLabels(ctx, owner, repository, opts)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		result, err := flattenLabels(labels)
 		if err != nil {
-			return fmt.Errorf("unable to flatten GitHub Labels (Owner: %q/Repository: %q) : %+v", owner, repository, err)
+			return diag.FromErr(fmt.Errorf("unable to flatten GitHub Labels (Owner: %q/Repository: %q) : %+v", owner, repository, err))
 		}
 
 		allLabels = append(allLabels, result...)
@@ -79,7 +79,7 @@ func dataSourceGithubIssueLabelsRead(d *schema.ResourceData, meta interface{}) e
 
 	err := d.Set("labels", allLabels)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
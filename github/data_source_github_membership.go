@@ -3,12 +3,13 @@ package github
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubMembership() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubMembershipRead,
+		ReadContext: dataSourceGithubMembershipRead,
 
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -35,7 +36,7 @@ func dataSourceGithubMembership() *schema.Resource {
 	}
 }
 
-func dataSourceGithubMembershipRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	username := d.Get("username").(string)
 
 	client := meta.(*Owner).v3client
@@ -45,32 +46,30 @@ func dataSourceGithubMembershipRead(d *schema.ResourceData, meta interface{}) er
 		orgName = configuredOrg
 	}
 
-	ctx := context.Background()
-
 	membership, resp, err := client.Organizations.GetOrgMembership(ctx,
 		username, orgName)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(membership.GetOrganization().GetLogin(), membership.GetUser().GetLogin()))
 
 	err = d.Set("username", membership.GetUser().GetLogin())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("role", membership.GetRole())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("etag", resp.Header.Get("ETag"))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("state", membership.GetState())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
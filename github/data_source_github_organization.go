@@ -1,16 +1,18 @@
 package github
 
 import (
+	"context"
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/shurcooL/githubv4"
 )
 
 func dataSourceGithubOrganization() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubOrganizationRead,
+		ReadContext: dataSourceGithubOrganizationRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -148,16 +150,15 @@ func dataSourceGithubOrganization() *schema.Resource {
 	}
 }
 
-func dataSourceGithubOrganizationRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubOrganizationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	name := d.Get("name").(string)
 
 	client4 := meta.(*Owner).v4client
 	client3 := meta.(*Owner).v3client
-	ctx := meta.(*Owner).StopContext
 
 	organization, _, err := client3.Organizations.Get(ctx, name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var planName string
@@ -178,7 +179,7 @@ func dataSourceGithubOrganizationRead(d *schema.ResourceData, meta interface{})
 		for {
 			repos, resp, err := client3.Repositories.ListByOrg(ctx, name, opts)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			allRepos = append(allRepos, repos...)
 
@@ -226,7 +227,7 @@ func dataSourceGithubOrganizationRead(d *schema.ResourceData, meta interface{})
 		for {
 			err := client4.Query(ctx, &query, variables)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			for _, edge := range query.Organization.MembersWithRole.Edges {
 				members = append(members, string(edge.Node.Login))
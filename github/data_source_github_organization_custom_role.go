@@ -7,12 +7,13 @@ import (
 
 	"github.com/google/go-github/v66/github"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubOrganizationCustomRole() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubOrganizationCustomRoleRead,
+		ReadContext: dataSourceGithubOrganizationCustomRoleRead,
 
 		Schema: map[string]*schema.Schema{
 			"name": {
@@ -36,14 +37,13 @@ func dataSourceGithubOrganizationCustomRole() *schema.Resource {
 	}
 }
 
-func dataSourceGithubOrganizationCustomRoleRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubOrganizationCustomRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 	orgName := meta.(*Owner).name
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// ListCustomRepoRoles returns a list of all custom repository roles for an organization.
@@ -51,7 +51,7 @@ func dataSourceGithubOrganizationCustomRoleRead(d *schema.ResourceData, meta int
 	// implemented in the go-github library.
 	roleList, _, err := client.Organizations.ListCustomRepoRoles(ctx, orgName)
 	if err != nil {
-		return fmt.Errorf("error querying GitHub custom repository roles %s: %s", orgName, err)
+		return diag.FromErr(fmt.Errorf("error querying GitHub custom repository roles %s: %s", orgName, err))
 	}
 
 	var role *github.CustomRepoRoles
@@ -71,19 +71,19 @@ func dataSourceGithubOrganizationCustomRoleRead(d *schema.ResourceData, meta int
 	d.SetId(fmt.Sprint(*role.ID))
 	err = d.Set("name", role.Name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("description", role.Description)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("base_role", role.BaseRole)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("permissions", role.Permissions)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubOrganizationOutsideCollaborators() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubOrganizationOutsideCollaboratorsRead,
+
+		Schema: map[string]*schema.Schema{
+			"filter": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "all",
+				ValidateDiagFunc: validateValueFunc([]string{"all", "2fa_disabled"}),
+				Description:      "Filter the outside collaborators. Must be one of 'all' or '2fa_disabled'.",
+			},
+			"collaborators": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The outside collaborators of the organization.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the user.",
+						},
+						"login": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The login of the user.",
+						},
+						"node_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The node ID of the user.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubOrganizationOutsideCollaboratorsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	filter := d.Get("filter").(string)
+
+	collaborators := []interface{}{}
+	options := &github.ListOutsideCollaboratorsOptions{
+		Filter:      filter,
+		ListOptions: github.ListOptions{PerPage: maxPerPage},
+	}
+	for {
+		users, resp, err := client.Organizations.ListOutsideCollaborators(ctx, orgName, options)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error listing the outside collaborators of %s: %w", orgName, err))
+		}
+		for _, user := range users {
+			collaborators = append(collaborators, map[string]interface{}{
+				"id":      user.GetID(),
+				"login":   user.GetLogin(),
+				"node_id": user.GetNodeID(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	d.SetId(buildTwoPartID(orgName, filter))
+	if err = d.Set("collaborators", collaborators); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
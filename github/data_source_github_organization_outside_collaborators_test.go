@@ -0,0 +1,52 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationOutsideCollaboratorsDataSource(t *testing.T) {
+
+	t.Run("lists the outside collaborators of an organization without error", func(t *testing.T) {
+
+		config := `
+			data "github_organization_outside_collaborators" "all" {}
+
+			data "github_organization_outside_collaborators" "without_2fa" {
+				filter = "2fa_disabled"
+			}
+		`
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttrSet("data.github_organization_outside_collaborators.all", "collaborators.#"),
+			resource.TestCheckResourceAttr("data.github_organization_outside_collaborators.all", "filter", "all"),
+			resource.TestCheckResourceAttrSet("data.github_organization_outside_collaborators.without_2fa", "collaborators.#"),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
@@ -0,0 +1,92 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubOrganizationRoles() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubOrganizationRolesRead,
+
+		Schema: map[string]*schema.Schema{
+			"roles": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The organization roles, both the ones predefined by GitHub and the custom ones.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"role_id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the organization role.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The name of the organization role.",
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The description of the organization role.",
+						},
+						"source": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The source of the organization role. Can be one of: 'Predefined', 'Organization' or 'Enterprise'.",
+						},
+						"base_role": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The system role from which the role inherits permissions on the repositories of the organization.",
+						},
+						"permissions": {
+							Type:        schema.TypeSet,
+							Computed:    true,
+							Elem:        &schema.Schema{Type: schema.TypeString},
+							Description: "The permissions of the organization role.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubOrganizationRolesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	roles, _, err := client.Organizations.ListRoles(ctx, orgName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error querying GitHub organization roles %s: %w", orgName, err))
+	}
+
+	flattened := make([]interface{}, 0, len(roles.CustomRepoRoles))
+	for _, role := range roles.CustomRepoRoles {
+		flattened = append(flattened, map[string]interface{}{
+			"role_id":     role.GetID(),
+			"name":        role.GetName(),
+			"description": role.GetDescription(),
+			"source":      role.GetSource(),
+			"base_role":   role.GetBaseRole(),
+			"permissions": flattenStringList(role.Permissions),
+		})
+	}
+
+	d.SetId(orgName)
+	if err = d.Set("roles", flattened); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationRolesDataSource(t *testing.T) {
+
+	t.Run("queries the organization roles", func(t *testing.T) {
+		randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+		config := fmt.Sprintf(`
+			resource "github_organization_role" "test" {
+				name        = "tf-acc-test-%s"
+				permissions = [
+					"read_audit_logs",
+				]
+			}
+		`, randomID)
+
+		config2 := config + `
+			data "github_organization_roles" "test" {}
+
+			locals {
+				custom_roles = [
+					for role in data.github_organization_roles.test.roles : role if role.name == github_organization_role.test.name
+				]
+			}
+
+			output "custom_role_source" {
+				value = one(local.custom_roles).source
+			}
+		`
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttrSet(
+				"data.github_organization_roles.test", "roles.#",
+			),
+			resource.TestCheckOutput("custom_role_source", "Organization"),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  resource.ComposeTestCheckFunc(),
+					},
+					{
+						Config: config2,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubOrganizationTeamSyncGroups() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubOrganizationTeamSyncGroupsRead,
+		ReadContext: dataSourceGithubOrganizationTeamSyncGroupsRead,
 
 		Schema: map[string]*schema.Schema{
 			"groups": {
@@ -37,9 +38,8 @@ func dataSourceGithubOrganizationTeamSyncGroups() *schema.Resource {
 	}
 }
 
-func dataSourceGithubOrganizationTeamSyncGroupsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubOrganizationTeamSyncGroupsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	orgName := meta.(*Owner).name
 	options := &github.ListIDPGroupsOptions{
@@ -52,12 +52,12 @@ func dataSourceGithubOrganizationTeamSyncGroupsRead(d *schema.ResourceData, meta
 	for {
 		idpGroupList, resp, err := client.Teams.ListIDPGroupsInOrganization(ctx, orgName, options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		result, err := flattenGithubIDPGroupList(idpGroupList)
 		if err != nil {
-			return fmt.Errorf("unable to flatten IdP Groups in GitHub Organization(Org: %q) : %+v", orgName, err)
+			return diag.FromErr(fmt.Errorf("unable to flatten IdP Groups in GitHub Organization(Org: %q) : %+v", orgName, err))
 		}
 
 		groups = append(groups, result...)
@@ -70,7 +70,7 @@ func dataSourceGithubOrganizationTeamSyncGroupsRead(d *schema.ResourceData, meta
 
 	d.SetId(fmt.Sprintf("%s/github-org-team-sync-groups", orgName))
 	if err := d.Set("groups", groups); err != nil {
-		return fmt.Errorf("error setting groups: %s", err)
+		return diag.FromErr(fmt.Errorf("error setting groups: %s", err))
 	}
 
 	return nil
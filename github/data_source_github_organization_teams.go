@@ -1,6 +1,8 @@
 package github
 
 import (
+	"context"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/shurcooL/githubv4"
@@ -8,7 +10,7 @@ import (
 
 func dataSourceGithubOrganizationTeams() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubOrganizationTeamsRead,
+		ReadContext: dataSourceGithubOrganizationTeamsRead,
 
 		Schema: map[string]*schema.Schema{
 			"root_teams_only": {
@@ -78,10 +80,10 @@ func dataSourceGithubOrganizationTeams() *schema.Resource {
 	}
 }
 
-func dataSourceGithubOrganizationTeamsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubOrganizationTeamsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v4client
@@ -102,9 +104,9 @@ func dataSourceGithubOrganizationTeamsRead(d *schema.ResourceData, meta interfac
 
 	var teams []interface{}
 	for {
-		err = client.Query(meta.(*Owner).StopContext, &query, variables)
+		err = client.Query(ctx, &query, variables)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		additionalTeams := flattenGitHubTeams(query)
@@ -119,7 +121,7 @@ func dataSourceGithubOrganizationTeamsRead(d *schema.ResourceData, meta interfac
 	d.SetId(string(query.Organization.ID))
 	err = d.Set("teams", teams)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -0,0 +1,64 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceGithubOrganizationWebhookDeliveries() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubOrganizationWebhookDeliveriesRead,
+
+		Schema: map[string]*schema.Schema{
+			"webhook_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				Description: "The ID of the webhook.",
+			},
+			"since": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ValidateDiagFunc: toDiagFunc(validation.IsRFC3339Time, "since"),
+				Description:      "Only list the deliveries since this RFC 3339 timestamp.",
+			},
+			"deliveries": hookDeliveriesSchema(),
+		},
+	}
+}
+
+func dataSourceGithubOrganizationWebhookDeliveriesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	hookID := int64(d.Get("webhook_id").(int))
+
+	since, err := hookDeliveriesSince(d)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	deliveries, err := listHookDeliveries(ctx,
+		func(ctx context.Context, opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error) {
+			return client.Organizations.ListHookDeliveries(ctx, orgName, hookID, opts)
+		}, since)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing deliveries of webhook %d of %s: %w", hookID, orgName, err))
+	}
+
+	d.SetId(strconv.FormatInt(hookID, 10))
+	if err = d.Set("deliveries", flattenHookDeliveries(deliveries)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationWebhookDeliveriesDataSource(t *testing.T) {
+
+	t.Run("lists the deliveries of an organization webhook without error", func(t *testing.T) {
+
+		config := `
+			resource "github_organization_webhook" "test" {
+				configuration {
+					url          = "https://google.de/webhook"
+					content_type = "json"
+				}
+
+				events = ["pull_request"]
+			}
+
+			data "github_organization_webhook_deliveries" "test" {
+				webhook_id = github_organization_webhook.test.id
+			}
+		`
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr("data.github_organization_webhook_deliveries.test", "deliveries.0.event", "ping"),
+			resource.TestCheckResourceAttrSet("data.github_organization_webhook_deliveries.test", "deliveries.0.guid"),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
@@ -4,12 +4,13 @@ import (
 	"context"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubOrganizationWebhooks() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubOrganizationWebhooksRead,
+		ReadContext: dataSourceGithubOrganizationWebhooksRead,
 
 		Schema: map[string]*schema.Schema{
 			"webhooks": {
@@ -44,11 +45,10 @@ func dataSourceGithubOrganizationWebhooks() *schema.Resource {
 	}
 }
 
-func dataSourceGithubOrganizationWebhooksRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubOrganizationWebhooksRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	owner := meta.(*Owner).name
 
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	options := &github.ListOptions{
 		PerPage: 100,
@@ -58,7 +58,7 @@ func dataSourceGithubOrganizationWebhooksRead(d *schema.ResourceData, meta inter
 	for {
 		hooks, resp, err := client.Organizations.ListHooks(ctx, owner, options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		results = append(results, flattenGitHubWebhooks(hooks)...)
@@ -72,7 +72,7 @@ func dataSourceGithubOrganizationWebhooksRead(d *schema.ResourceData, meta inter
 	d.SetId(owner)
 	err := d.Set("webhooks", results)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
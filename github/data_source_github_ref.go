@@ -6,12 +6,13 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubRef() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubRefRead,
+		ReadContext: dataSourceGithubRefRead,
 
 		Schema: map[string]*schema.Schema{
 			"ref": {
@@ -40,7 +41,7 @@ func dataSourceGithubRef() *schema.Resource {
 	}
 }
 
-func dataSourceGithubRefRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubRefRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner, ok := d.Get("owner").(string)
 	if !ok {
@@ -49,7 +50,7 @@ func dataSourceGithubRefRead(d *schema.ResourceData, meta interface{}) error {
 	repoName := d.Get("repository").(string)
 	ref := d.Get("ref").(string)
 
-	refData, resp, err := client.Git.GetRef(context.TODO(), owner, repoName, ref)
+	refData, resp, err := client.Git.GetRef(ctx, owner, repoName, ref)
 	if err != nil {
 		if err, ok := err.(*github.ErrorResponse); ok {
 			if err.Response.StatusCode == http.StatusNotFound {
@@ -58,17 +59,17 @@ func dataSourceGithubRefRead(d *schema.ResourceData, meta interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repoName, ref))
 	err = d.Set("etag", resp.Header.Get("ETag"))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("sha", *refData.Object.SHA)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 
 	"github.com/google/go-github/v66/github"
@@ -14,7 +15,7 @@ import (
 
 func dataSourceGithubRelease() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubReleaseRead,
+		ReadContext: dataSourceGithubReleaseRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -151,12 +152,11 @@ func dataSourceGithubRelease() *schema.Resource {
 	}
 }
 
-func dataSourceGithubReleaseRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubReleaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	repository := d.Get("repository").(string)
 	owner := d.Get("owner").(string)
 
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	var err error
 	var release *github.RepositoryRelease
@@ -167,85 +167,85 @@ func dataSourceGithubReleaseRead(d *schema.ResourceData, meta interface{}) error
 	case "id":
 		releaseID := int64(d.Get("release_id").(int))
 		if releaseID == 0 {
-			return fmt.Errorf("`release_id` must be set when `retrieve_by` = `id`")
+			return diag.FromErr(fmt.Errorf("`release_id` must be set when `retrieve_by` = `id`"))
 		}
 
 		release, _, err = client.Repositories.GetRelease(ctx, owner, repository, releaseID)
 	case "tag":
 		tag := d.Get("release_tag").(string)
 		if tag == "" {
-			return fmt.Errorf("`release_tag` must be set when `retrieve_by` = `tag`")
+			return diag.FromErr(fmt.Errorf("`release_tag` must be set when `retrieve_by` = `tag`"))
 		}
 
 		release, _, err = client.Repositories.GetReleaseByTag(ctx, owner, repository, tag)
 	default:
-		return fmt.Errorf("one of: `latest`, `id`, `tag` must be set for `retrieve_by`")
+		return diag.FromErr(fmt.Errorf("one of: `latest`, `id`, `tag` must be set for `retrieve_by`"))
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.FormatInt(release.GetID(), 10))
 	err = d.Set("release_tag", release.GetTagName())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("target_commitish", release.GetTargetCommitish())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("name", release.GetName())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("body", release.GetBody())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("draft", release.GetDraft())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("prerelease", release.GetPrerelease())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("created_at", release.GetCreatedAt().String())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("published_at", release.GetPublishedAt().String())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("url", release.GetURL())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("html_url", release.GetHTMLURL())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("assets_url", release.GetAssetsURL())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("asserts_url", release.GetAssetsURL()) // Deprecated, original version of assets_url
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("upload_url", release.GetUploadURL())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("zipball_url", release.GetZipballURL())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("tarball_url", release.GetTarballURL())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	assets := make([]interface{}, 0, len(release.Assets))
@@ -270,7 +270,7 @@ func dataSourceGithubReleaseRead(d *schema.ResourceData, meta interface{}) error
 
 	err = d.Set("assets", assets)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
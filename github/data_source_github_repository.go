@@ -8,12 +8,13 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubRepository() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubRepositoryRead,
+		ReadContext: dataSourceGithubRepositoryRead,
 
 		Schema: map[string]*schema.Schema{
 			"full_name": {
@@ -330,6 +331,11 @@ func dataSourceGithubRepository() *schema.Resource {
 				Type:     schema.TypeInt,
 				Computed: true,
 			},
+			"custom_properties": {
+				Type:     schema.TypeMap,
+				Elem:     &schema.Schema{Type: schema.TypeString},
+				Computed: true,
+			},
 			"delete_branch_on_merge": {
 				Type:     schema.TypeBool,
 				Computed: true,
@@ -338,7 +344,7 @@ func dataSourceGithubRepository() *schema.Resource {
 	}
 }
 
-func dataSourceGithubRepositoryRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubRepositoryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	var repoName string
@@ -347,7 +353,7 @@ func dataSourceGithubRepositoryRead(d *schema.ResourceData, meta interface{}) er
 		var err error
 		owner, repoName, err = splitRepoFullName(fullName.(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if name, ok := d.GetOk("name"); ok {
@@ -355,10 +361,10 @@ func dataSourceGithubRepositoryRead(d *schema.ResourceData, meta interface{}) er
 	}
 
 	if repoName == "" {
-		return fmt.Errorf("one of %q or %q has to be provided", "full_name", "name")
+		return diag.FromErr(fmt.Errorf("one of %q or %q has to be provided", "full_name", "name"))
 	}
 
-	repo, _, err := client.Repositories.Get(context.TODO(), owner, repoName)
+	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
 	if err != nil {
 		if err, ok := err.(*github.ErrorResponse); ok {
 			if err.Response.StatusCode == http.StatusNotFound {
@@ -367,7 +373,7 @@ func dataSourceGithubRepositoryRead(d *schema.ResourceData, meta interface{}) er
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(repoName)
@@ -402,32 +408,33 @@ func dataSourceGithubRepositoryRead(d *schema.ResourceData, meta interface{}) er
 	d.Set("archived", repo.GetArchived())
 	d.Set("node_id", repo.GetNodeID())
 	d.Set("repo_id", repo.GetID())
+	d.Set("custom_properties", flattenCustomProperties(repo.CustomProperties))
 	d.Set("has_projects", repo.GetHasProjects())
 	d.Set("delete_branch_on_merge", repo.GetDeleteBranchOnMerge())
 	d.Set("allow_update_branch", repo.GetAllowUpdateBranch())
 
 	if repo.GetHasPages() {
-		pages, _, err := client.Repositories.GetPagesInfo(context.TODO(), owner, repoName)
+		pages, _, err := client.Repositories.GetPagesInfo(ctx, owner, repoName)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("pages", flattenPages(pages)); err != nil {
-			return fmt.Errorf("error setting pages: %w", err)
+			return diag.FromErr(fmt.Errorf("error setting pages: %w", err))
 		}
 	} else {
 		err = d.Set("pages", flattenPages(nil))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if repo.License != nil {
-		repository_license, _, err := client.Repositories.License(context.TODO(), owner, repoName)
+		repository_license, _, err := client.Repositories.License(ctx, owner, repoName)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err := d.Set("repository_license", flattenRepositoryLicense(repository_license)); err != nil {
-			return fmt.Errorf("error setting repository_license: %w", err)
+			return diag.FromErr(fmt.Errorf("error setting repository_license: %w", err))
 		}
 	} else {
 		d.Set("repository_license", flattenRepositoryLicense(nil))
@@ -441,18 +448,18 @@ func dataSourceGithubRepositoryRead(d *schema.ResourceData, meta interface{}) er
 			},
 		})
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		err = d.Set("template", []interface{}{})
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	err = d.Set("topics", flattenStringList(repo.Topics))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
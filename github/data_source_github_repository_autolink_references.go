@@ -4,12 +4,13 @@ import (
 	"context"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubRepositoryAutolinkReferences() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubRepositoryAutolinkReferencesRead,
+		ReadContext: dataSourceGithubRepositoryAutolinkReferencesRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -40,7 +41,7 @@ func dataSourceGithubRepositoryAutolinkReferences() *schema.Resource {
 	}
 }
 
-func dataSourceGithubRepositoryAutolinkReferencesRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubRepositoryAutolinkReferencesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -49,9 +50,9 @@ func dataSourceGithubRepositoryAutolinkReferencesRead(d *schema.ResourceData, me
 
 	var listOptions *github.ListOptions
 	for {
-		autoLinks, resp, err := client.Repositories.ListAutolinks(context.Background(), orgName, repoName, listOptions)
+		autoLinks, resp, err := client.Repositories.ListAutolinks(ctx, orgName, repoName, listOptions)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		results = append(results, flattenAutolinkReferences(autoLinks)...)
@@ -66,7 +67,7 @@ func dataSourceGithubRepositoryAutolinkReferencesRead(d *schema.ResourceData, me
 	d.SetId(repoName)
 	err := d.Set("autolink_references", results)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
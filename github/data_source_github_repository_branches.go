@@ -5,12 +5,13 @@ import (
 	"fmt"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubRepositoryBranches() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubRepositoryBranchesRead,
+		ReadContext: dataSourceGithubRepositoryBranchesRead,
 		Schema: map[string]*schema.Schema{
 			"repository": {
 				Type:     schema.TypeString,
@@ -64,7 +65,7 @@ func flattenBranches(branches []*github.Branch) []map[string]interface{} {
 	return results
 }
 
-func dataSourceGithubRepositoryBranchesRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubRepositoryBranchesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -86,9 +87,9 @@ func dataSourceGithubRepositoryBranchesRead(d *schema.ResourceData, meta interfa
 
 	results := make([]map[string]interface{}, 0)
 	for {
-		branches, resp, err := client.Repositories.ListBranches(context.TODO(), orgName, repoName, listBranchOptions)
+		branches, resp, err := client.Repositories.ListBranches(ctx, orgName, repoName, listBranchOptions)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		results = append(results, flattenBranches(branches)...)
 
@@ -102,11 +103,11 @@ func dataSourceGithubRepositoryBranchesRead(d *schema.ResourceData, meta interfa
 	d.SetId(fmt.Sprintf("%s/%s", orgName, repoName))
 	err := d.Set("repository", repoName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("branches", results)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -4,12 +4,13 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubRepositoryDeploymentBranchPolicies() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubRepositoryDeploymentBranchPoliciesRead,
+		ReadContext: dataSourceGithubRepositoryDeploymentBranchPoliciesRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -44,13 +45,13 @@ func dataSourceGithubRepositoryDeploymentBranchPolicies() *schema.Resource {
 	}
 }
 
-func dataSourceGithubRepositoryDeploymentBranchPoliciesRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubRepositoryDeploymentBranchPoliciesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 	environmentName := d.Get("environment_name").(string)
 
-	policies, _, err := client.Repositories.ListDeploymentBranchPolicies(context.Background(), owner, repoName, environmentName)
+	policies, _, err := client.Repositories.ListDeploymentBranchPolicies(ctx, owner, repoName, environmentName)
 	if err != nil {
 		return nil
 	}
@@ -67,7 +68,7 @@ func dataSourceGithubRepositoryDeploymentBranchPoliciesRead(d *schema.ResourceDa
 	d.SetId(repoName + ":" + environmentName)
 	err = d.Set("deployment_branch_policies", results)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
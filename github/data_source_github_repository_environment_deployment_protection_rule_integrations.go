@@ -0,0 +1,88 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubRepositoryEnvironmentDeploymentProtectionRuleIntegrations() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubRepositoryEnvironmentDeploymentProtectionRuleIntegrationsRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository.",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the environment.",
+			},
+			"integrations": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The GitHub Apps which can be enabled as custom deployment protection rules of the environment.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The ID of the GitHub App.",
+						},
+						"slug": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The slug of the GitHub App.",
+						},
+						"integration_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The API URL of the GitHub App.",
+						},
+						"node_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The node ID of the GitHub App.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceGithubRepositoryEnvironmentDeploymentProtectionRuleIntegrationsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+	envName := d.Get("environment").(string)
+
+	response, _, err := client.Repositories.ListCustomDeploymentRuleIntegrations(ctx, owner, repoName, url.PathEscape(envName))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing custom deployment protection rule integrations of environment %s of %s/%s: %w",
+			envName, owner, repoName, err))
+	}
+
+	integrations := make([]interface{}, 0, len(response.AvailableIntegrations))
+	for _, app := range response.AvailableIntegrations {
+		integrations = append(integrations, map[string]interface{}{
+			"id":              app.GetID(),
+			"slug":            app.GetSlug(),
+			"integration_url": app.GetIntegrationURL(),
+			"node_id":         app.GetNodeID(),
+		})
+	}
+
+	d.SetId(buildTwoPartID(repoName, envName))
+	if err = d.Set("integrations", integrations); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
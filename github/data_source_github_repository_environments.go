@@ -4,12 +4,13 @@ import (
 	"context"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubRepositoryEnvironments() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubRepositoryEnvironmentsRead,
+		ReadContext: dataSourceGithubRepositoryEnvironmentsRead,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -36,7 +37,7 @@ func dataSourceGithubRepositoryEnvironments() *schema.Resource {
 	}
 }
 
-func dataSourceGithubRepositoryEnvironmentsRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubRepositoryEnvironmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -45,9 +46,9 @@ func dataSourceGithubRepositoryEnvironmentsRead(d *schema.ResourceData, meta int
 
 	var listOptions *github.EnvironmentListOptions
 	for {
-		environments, resp, err := client.Repositories.ListEnvironments(context.Background(), orgName, repoName, listOptions)
+		environments, resp, err := client.Repositories.ListEnvironments(ctx, orgName, repoName, listOptions)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		results = append(results, flattenEnvironments(environments)...)
@@ -62,7 +63,7 @@ func dataSourceGithubRepositoryEnvironmentsRead(d *schema.ResourceData, meta int
 	d.SetId(repoName)
 	err := d.Set("environments", results)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -144,7 +144,7 @@ func dataSourceGithubRepositoryFileRead(ctx context.Context, d *schema.ResourceD
 	}
 
 	log.Printf("[DEBUG] Data Source fetching commit info for repository file: %s/%s/%s", owner, repo, file)
-	commit, err := getFileCommit(client, owner, repo, file, ref)
+	commit, err := getFileCommit(ctx, client, owner, repo, file, ref)
 	log.Printf("[DEBUG] Found file: %s/%s/%s, in commit SHA: %s ", owner, repo, file, commit.GetSHA())
 	if err != nil {
 		return diag.FromErr(err)
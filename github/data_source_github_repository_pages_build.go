@@ -0,0 +1,102 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceGithubRepositoryPagesBuild() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceGithubRepositoryPagesBuildRead,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the repository.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the latest build, e.g. 'built' or 'errored'.",
+			},
+			"error": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The error message of the latest build, if it failed.",
+			},
+			"commit": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The SHA of the commit built.",
+			},
+			"pusher": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The login of the user who triggered the build.",
+			},
+			"duration": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The duration of the build, in milliseconds.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the build was started.",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the build was last updated.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The API URL of the build.",
+			},
+		},
+	}
+}
+
+func dataSourceGithubRepositoryPagesBuildRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+
+	build, _, err := client.Repositories.GetLatestPagesBuild(ctx, owner, repoName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading the latest GitHub Pages build of %s/%s: %w", owner, repoName, err))
+	}
+
+	d.SetId(repoName)
+	if err = d.Set("status", build.GetStatus()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("error", build.GetError().GetMessage()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("commit", build.GetCommit()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("pusher", build.GetPusher().GetLogin()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("duration", build.GetDuration()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("created_at", build.GetCreatedAt().String()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("updated_at", build.GetUpdatedAt().String()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("url", build.GetURL()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
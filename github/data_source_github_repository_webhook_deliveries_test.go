@@ -0,0 +1,71 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubRepositoryWebhookDeliveriesDataSource(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("lists the deliveries of a repository webhook without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name      = "tf-acc-test-%s"
+				auto_init = true
+			}
+
+			resource "github_repository_webhook" "test" {
+				repository = github_repository.test.name
+
+				configuration {
+					url          = "https://google.de/webhook"
+					content_type = "json"
+				}
+
+				events = ["pull_request"]
+			}
+
+			data "github_repository_webhook_deliveries" "test" {
+				repository = github_repository.test.name
+				webhook_id = github_repository_webhook.test.id
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr("data.github_repository_webhook_deliveries.test", "deliveries.0.event", "ping"),
+			resource.TestCheckResourceAttrSet("data.github_repository_webhook_deliveries.test", "deliveries.0.guid"),
+			resource.TestCheckResourceAttrSet("data.github_repository_webhook_deliveries.test", "deliveries.0.status_code"),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
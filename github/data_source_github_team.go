@@ -8,13 +8,14 @@ import (
 
 	"github.com/shurcooL/githubv4"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func dataSourceGithubTeam() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubTeamRead,
+		ReadContext: dataSourceGithubTeamRead,
 
 		Schema: map[string]*schema.Schema{
 			"slug": {
@@ -88,18 +89,17 @@ func dataSourceGithubTeam() *schema.Resource {
 	}
 }
 
-func dataSourceGithubTeamRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	slug := d.Get("slug").(string)
 
 	client := meta.(*Owner).v3client
 	orgId := meta.(*Owner).id
-	ctx := context.Background()
 	summaryOnly := d.Get("summary_only").(bool)
 	resultsPerPage := d.Get("results_per_page").(int)
 
 	team, _, err := client.Teams.GetTeamBySlug(ctx, meta.(*Owner).name, slug)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var members []string
@@ -117,7 +117,7 @@ func dataSourceGithubTeamRead(d *schema.ResourceData, meta interface{}) error {
 			for {
 				member, resp, err := client.Teams.ListTeamMembersByID(ctx, orgId, team.GetID(), &options)
 				if err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 
 				for _, v := range member {
@@ -155,7 +155,7 @@ func dataSourceGithubTeamRead(d *schema.ResourceData, meta interface{}) error {
 			for {
 				nameErr := client.Query(ctx, &query, variables)
 				if nameErr != nil {
-					return nameErr
+					return diag.FromErr(nameErr)
 				}
 				for _, v := range query.Organization.Team.Members.Nodes {
 					members = append(members, v.Login)
@@ -173,7 +173,7 @@ func dataSourceGithubTeamRead(d *schema.ResourceData, meta interface{}) error {
 		for {
 			repository, resp, err := client.Teams.ListTeamReposByID(ctx, orgId, team.GetID(), &options.ListOptions)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 
 			for _, v := range repository {
@@ -193,28 +193,28 @@ func dataSourceGithubTeamRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(strconv.FormatInt(team.GetID(), 10))
 	if err = d.Set("name", team.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("members", members); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("repositories", repositories); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("repositories_detailed", repositories_detailed); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("description", team.GetDescription()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("privacy", team.GetPrivacy()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("permission", team.GetPermission()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("node_id", team.GetNodeID()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
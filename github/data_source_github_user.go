@@ -4,12 +4,13 @@ import (
 	"context"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func dataSourceGithubUser() *schema.Resource {
 	return &schema.Resource{
-		Read: dataSourceGithubUserRead,
+		ReadContext: dataSourceGithubUserRead,
 
 		Schema: map[string]*schema.Schema{
 			"username": {
@@ -102,24 +103,23 @@ func dataSourceGithubUser() *schema.Resource {
 	}
 }
 
-func dataSourceGithubUserRead(d *schema.ResourceData, meta interface{}) error {
+func dataSourceGithubUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	username := d.Get("username").(string)
 
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	user, _, err := client.Users.Get(ctx, username)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	gpg, _, err := client.Users.ListGPGKeys(ctx, user.GetLogin(), nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	ssh, _, err := client.Users.ListKeys(ctx, user.GetLogin(), nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	gpgKeys := []string{}
@@ -134,64 +134,64 @@ func dataSourceGithubUserRead(d *schema.ResourceData, meta interface{}) error {
 
 	d.SetId(strconv.FormatInt(user.GetID(), 10))
 	if err = d.Set("login", user.GetLogin()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("avatar_url", user.GetAvatarURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("gravatar_id", user.GetGravatarID()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("site_admin", user.GetSiteAdmin()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("company", user.GetCompany()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("blog", user.GetBlog()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("location", user.GetLocation()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", user.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("email", user.GetEmail()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("bio", user.GetBio()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("gpg_keys", gpgKeys); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("ssh_keys", sshKeys); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("public_repos", user.GetPublicRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("public_gists", user.GetPublicGists()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("followers", user.GetFollowers()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("following", user.GetFollowing()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("created_at", user.GetCreatedAt().String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("updated_at", user.GetUpdatedAt().String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("suspended_at", user.GetSuspendedAt().String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("node_id", user.GetNodeID()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	ctyjson "github.com/hashicorp/go-cty/cty/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"github.com/integrations/terraform-provider-github/v6/internal/fakegithub"
+)
+
+// fakeOrganization is the organization the provider is configured with when
+// testing against the fake API.
+const fakeOrganization = "fake-org"
+
+// newFakeAPI starts a fake GitHub API with an organization, and returns it
+// along with the meta of a provider pointed at it through base_url.
+func newFakeAPI(t *testing.T) (*fakegithub.Server, interface{}) {
+	t.Helper()
+
+	srv := fakegithub.New()
+	t.Cleanup(srv.Close)
+	srv.AddOrganization(fakeOrganization)
+
+	config := Config{
+		BaseURL: srv.BaseURL(),
+		Token:   "fake",
+		Owner:   fakeOrganization,
+	}
+	meta, err := config.Meta()
+	if err != nil {
+		t.Fatalf("Unexpected error configuring the provider: %s", err)
+	}
+	return srv, meta
+}
+
+// fakeResource drives a resource the way Terraform does on plan, apply,
+// refresh and import, keeping its state between calls.
+type fakeResource struct {
+	t        *testing.T
+	resource *schema.Resource
+	meta     interface{}
+	state    *terraform.InstanceState
+}
+
+func newFakeResource(t *testing.T, meta interface{}, name string) *fakeResource {
+	resource, ok := Provider().ResourcesMap[name]
+	if !ok {
+		t.Fatalf("Unknown resource %s", name)
+	}
+	return &fakeResource{t: t, resource: resource, meta: meta}
+}
+
+// plan returns the changes needed to converge the resource onto config.
+func (f *fakeResource) plan(config map[string]interface{}) *terraform.InstanceDiff {
+	f.t.Helper()
+
+	// Terraform passes the configuration along with the prior state, it is
+	// the only place write-only attributes can be read from.
+	state := f.state.DeepCopy()
+	if state == nil {
+		state = &terraform.InstanceState{}
+	}
+	state.RawConfig = f.rawConfig(config)
+
+	diff, err := f.resource.Diff(context.Background(), state, terraform.NewResourceConfigRaw(config), f.meta)
+	if err != nil {
+		f.t.Fatalf("Unexpected error planning: %s", err)
+	}
+	return diff
+}
+
+// rawConfig converts config to the value Terraform sends for it.
+func (f *fakeResource) rawConfig(config map[string]interface{}) cty.Value {
+	f.t.Helper()
+
+	b, err := json.Marshal(config)
+	if err != nil {
+		f.t.Fatal(err)
+	}
+	v, err := ctyjson.Unmarshal(b, f.resource.CoreConfigSchema().ImpliedType())
+	if err != nil {
+		f.t.Fatalf("Unexpected error converting the configuration: %s", err)
+	}
+	return v
+}
+
+// apply creates or updates the resource to match config.
+func (f *fakeResource) apply(config map[string]interface{}) {
+	f.t.Helper()
+
+	diff := f.plan(config)
+	if diff == nil {
+		return
+	}
+	state, diags := f.resource.Apply(context.Background(), f.state, diff, f.meta)
+	if diags.HasError() {
+		f.t.Fatalf("Unexpected error applying: %v", diags)
+	}
+	f.state = state
+}
+
+// refresh reads the resource, its state is nil once it no longer exists.
+func (f *fakeResource) refresh() {
+	f.t.Helper()
+
+	state, diags := f.resource.RefreshWithoutUpgrade(context.Background(), f.state, f.meta)
+	if diags.HasError() {
+		f.t.Fatalf("Unexpected error refreshing: %v", diags)
+	}
+	f.state = state
+}
+
+func (f *fakeResource) destroy() {
+	f.t.Helper()
+
+	state, diags := f.resource.Apply(context.Background(), f.state, &terraform.InstanceDiff{Destroy: true}, f.meta)
+	if diags.HasError() {
+		f.t.Fatalf("Unexpected error destroying: %v", diags)
+	}
+	f.state = state
+}
+
+// importState imports the resource with the given ID, then refreshes it.
+func (f *fakeResource) importState(id string) {
+	f.t.Helper()
+
+	d := f.resource.Data(&terraform.InstanceState{ID: id})
+	imported := []*schema.ResourceData{d}
+	if importer := f.resource.Importer; importer != nil && importer.StateContext != nil {
+		var err error
+		if imported, err = importer.StateContext(context.Background(), d, f.meta); err != nil {
+			f.t.Fatalf("Unexpected error importing: %s", err)
+		}
+	}
+	if len(imported) != 1 {
+		f.t.Fatalf("Expected a single imported resource, got %d", len(imported))
+	}
+
+	f.state = imported[0].State()
+	f.refresh()
+}
+
+// get returns the value of an attribute of the state.
+func (f *fakeResource) get(attribute string) string {
+	f.t.Helper()
+
+	if f.state == nil {
+		f.t.Fatalf("Expected the resource to exist when reading %s", attribute)
+	}
+	return f.state.Attributes[attribute]
+}
+
+// expectNoChanges fails the test when the resource does not match config.
+func (f *fakeResource) expectNoChanges(config map[string]interface{}) {
+	f.t.Helper()
+
+	if diff := f.plan(config); diff != nil && !diff.Empty() {
+		f.t.Fatalf("Expected no changes, got: %v", diff.Attributes)
+	}
+}
+
+// expectChanges fails the test when the resource matches config, e.g. as
+// drift was not detected.
+func (f *fakeResource) expectChanges(config map[string]interface{}) {
+	f.t.Helper()
+
+	if diff := f.plan(config); diff == nil || diff.Empty() {
+		f.t.Fatal("Expected changes, got none")
+	}
+}
+
+// newFakeRepository creates an initialized repository with a main branch.
+func newFakeRepository(t *testing.T, meta interface{}, name string) *fakeResource {
+	t.Helper()
+
+	repo := newFakeResource(t, meta, "github_repository")
+	repo.apply(map[string]interface{}{
+		"name":      name,
+		"auto_init": true,
+	})
+	return repo
+}
+
+func mustParseInt64(t *testing.T, s string) int64 {
+	t.Helper()
+
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return i
+}
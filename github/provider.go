@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net/http"
 	"net/url"
 	"os"
 	"os/exec"
@@ -24,6 +25,12 @@ func Provider() *schema.Provider {
 				DefaultFunc: schema.EnvDefaultFunc("GITHUB_TOKEN", nil),
 				Description: descriptions["token"],
 			},
+			"token_file": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_TOKEN_FILE", nil),
+				Description: descriptions["token_file"],
+			},
 			"owner": {
 				Type:        schema.TypeString,
 				Optional:    true,
@@ -69,6 +76,33 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: descriptions["insecure"],
 			},
+			"proxy_url": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_PROXY_URL", nil),
+				Description: descriptions["proxy_url"],
+			},
+			"ca_bundle": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_CA_BUNDLE", nil),
+				Description: descriptions["ca_bundle"],
+			},
+			"client_certificate": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				DefaultFunc:  schema.EnvDefaultFunc("GITHUB_CLIENT_CERTIFICATE", nil),
+				Description:  descriptions["client_certificate"],
+				RequiredWith: []string{"client_key"},
+			},
+			"client_key": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				Sensitive:    true,
+				DefaultFunc:  schema.EnvDefaultFunc("GITHUB_CLIENT_KEY", nil),
+				Description:  descriptions["client_key"],
+				RequiredWith: []string{"client_certificate"},
+			},
 			"write_delay_ms": {
 				Type:        schema.TypeInt,
 				Optional:    true,
@@ -93,6 +127,24 @@ func Provider() *schema.Provider {
 				Default:     false,
 				Description: descriptions["parallel_requests"],
 			},
+			"rate_limit_pacing": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: descriptions["rate_limit_pacing"],
+			},
+			"rate_limit_pacing_threshold": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				Default:     50,
+				Description: descriptions["rate_limit_pacing_threshold"],
+			},
+			"etag_cache_dir": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				DefaultFunc: schema.EnvDefaultFunc("GITHUB_ETAG_CACHE_DIR", nil),
+				Description: descriptions["etag_cache_dir"],
+			},
 			"app_auth": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -114,11 +166,52 @@ func Provider() *schema.Provider {
 						},
 						"pem_file": {
 							Type:        schema.TypeString,
-							Required:    true,
+							Optional:    true,
 							Sensitive:   true,
 							DefaultFunc: schema.EnvDefaultFunc("GITHUB_APP_PEM_FILE", nil),
 							Description: descriptions["app_auth.pem_file"],
 						},
+						"pem_file_path": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc("GITHUB_APP_PEM_FILE_PATH", nil),
+							Description: descriptions["app_auth.pem_file_path"],
+						},
+					},
+				},
+			},
+			"oidc_auth": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				MaxItems:    1,
+				Description: descriptions["oidc_auth"],
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"token_broker_url": {
+							Type:        schema.TypeString,
+							Required:    true,
+							DefaultFunc: schema.EnvDefaultFunc("GITHUB_OIDC_TOKEN_BROKER_URL", nil),
+							Description: descriptions["oidc_auth.token_broker_url"],
+						},
+						"id_token": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Sensitive:   true,
+							DefaultFunc: schema.EnvDefaultFunc("GITHUB_OIDC_ID_TOKEN", nil),
+							Description: descriptions["oidc_auth.id_token"],
+						},
+						"id_token_file": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc("GITHUB_OIDC_ID_TOKEN_FILE", nil),
+							Description: descriptions["oidc_auth.id_token_file"],
+						},
+						"audience": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							DefaultFunc: schema.EnvDefaultFunc("GITHUB_OIDC_AUDIENCE", nil),
+							Description: descriptions["oidc_auth.audience"],
+						},
 					},
 				},
 			},
@@ -137,6 +230,8 @@ func Provider() *schema.Provider {
 			"github_actions_repository_oidc_subject_claim_customization_template":   resourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplate(),
 			"github_actions_repository_permissions":                                 resourceGithubActionsRepositoryPermissions(),
 			"github_actions_runner_group":                                           resourceGithubActionsRunnerGroup(),
+			"github_actions_runner_jit_config":                                      resourceGithubActionsRunnerJITConfig(),
+			"github_actions_runner_labels":                                          resourceGithubActionsRunnerLabels(),
 			"github_actions_secret":                                                 resourceGithubActionsSecret(),
 			"github_actions_variable":                                               resourceGithubActionsVariable(),
 			"github_app_installation_repositories":                                  resourceGithubAppInstallationRepositories(),
@@ -149,6 +244,8 @@ func Provider() *schema.Provider {
 			"github_codespaces_organization_secret_repositories":                    resourceGithubCodespacesOrganizationSecretRepositories(),
 			"github_codespaces_secret":                                              resourceGithubCodespacesSecret(),
 			"github_codespaces_user_secret":                                         resourceGithubCodespacesUserSecret(),
+			"github_copilot_organization_seat_assignment":                           resourceGithubCopilotOrganizationSeatAssignment(),
+			"github_copilot_organization_seat_assignments":                          resourceGithubCopilotOrganizationSeatAssignments(),
 			"github_dependabot_organization_secret":                                 resourceGithubDependabotOrganizationSecret(),
 			"github_dependabot_organization_secret_repositories":                    resourceGithubDependabotOrganizationSecretRepositories(),
 			"github_dependabot_secret":                                              resourceGithubDependabotSecret(),
@@ -158,26 +255,40 @@ func Provider() *schema.Provider {
 			"github_issue_labels":                                                   resourceGithubIssueLabels(),
 			"github_membership":                                                     resourceGithubMembership(),
 			"github_organization_block":                                             resourceOrganizationBlock(),
+			"github_organization_code_security_configuration":                       resourceGithubOrganizationCodeSecurityConfiguration(),
+			"github_organization_code_security_configuration_attachment":            resourceGithubOrganizationCodeSecurityConfigurationAttachment(),
+			"github_organization_custom_property":                                   resourceGithubOrganizationCustomProperty(),
 			"github_organization_custom_role":                                       resourceGithubOrganizationCustomRole(),
+			"github_organization_invitation":                                        resourceGithubOrganizationInvitation(),
 			"github_organization_project":                                           resourceGithubOrganizationProject(),
 			"github_organization_security_manager":                                  resourceGithubOrganizationSecurityManager(),
+			"github_organization_role":                                              resourceGithubOrganizationRole(),
+			"github_organization_role_team":                                         resourceGithubOrganizationRoleTeam(),
+			"github_organization_role_user":                                         resourceGithubOrganizationRoleUser(),
 			"github_organization_ruleset":                                           resourceGithubOrganizationRuleset(),
 			"github_organization_settings":                                          resourceGithubOrganizationSettings(),
 			"github_organization_webhook":                                           resourceGithubOrganizationWebhook(),
 			"github_project_card":                                                   resourceGithubProjectCard(),
 			"github_project_column":                                                 resourceGithubProjectColumn(),
+			"github_project_v2":                                                     resourceGithubProjectV2(),
+			"github_project_v2_field":                                               resourceGithubProjectV2Field(),
+			"github_project_v2_item":                                                resourceGithubProjectV2Item(),
 			"github_release":                                                        resourceGithubRelease(),
 			"github_repository":                                                     resourceGithubRepository(),
 			"github_repository_autolink_reference":                                  resourceGithubRepositoryAutolinkReference(),
 			"github_repository_dependabot_security_updates":                         resourceGithubRepositoryDependabotSecurityUpdates(),
+			"github_repository_code_scanning_default_setup":                         resourceGithubRepositoryCodeScanningDefaultSetup(),
 			"github_repository_collaborator":                                        resourceGithubRepositoryCollaborator(),
 			"github_repository_collaborators":                                       resourceGithubRepositoryCollaborators(),
+			"github_repository_custom_properties":                                   resourceGithubRepositoryCustomProperties(),
 			"github_repository_deploy_key":                                          resourceGithubRepositoryDeployKey(),
 			"github_repository_deployment_branch_policy":                            resourceGithubRepositoryDeploymentBranchPolicy(),
 			"github_repository_environment":                                         resourceGithubRepositoryEnvironment(),
 			"github_repository_environment_deployment_policy":                       resourceGithubRepositoryEnvironmentDeploymentPolicy(),
+			"github_repository_environment_deployment_protection_rule":              resourceGithubRepositoryEnvironmentDeploymentProtectionRule(),
 			"github_repository_file":                                                resourceGithubRepositoryFile(),
 			"github_repository_milestone":                                           resourceGithubRepositoryMilestone(),
+			"github_repository_pages":                                               resourceGithubRepositoryPages(),
 			"github_repository_project":                                             resourceGithubRepositoryProject(),
 			"github_repository_pull_request":                                        resourceGithubRepositoryPullRequest(),
 			"github_repository_ruleset":                                             resourceGithubRepositoryRuleset(),
@@ -207,6 +318,7 @@ func Provider() *schema.Provider {
 			"github_actions_public_key":                                             dataSourceGithubActionsPublicKey(),
 			"github_actions_registration_token":                                     dataSourceGithubActionsRegistrationToken(),
 			"github_actions_repository_oidc_subject_claim_customization_template":   dataSourceGithubActionsRepositoryOIDCSubjectClaimCustomizationTemplate(),
+			"github_actions_runners":                                                dataSourceGithubActionsRunners(),
 			"github_actions_secrets":                                                dataSourceGithubActionsSecrets(),
 			"github_actions_variables":                                              dataSourceGithubActionsVariables(),
 			"github_app":                                                            dataSourceGithubApp(),
@@ -220,6 +332,7 @@ func Provider() *schema.Provider {
 			"github_codespaces_secrets":                                             dataSourceGithubCodespacesSecrets(),
 			"github_codespaces_user_public_key":                                     dataSourceGithubCodespacesUserPublicKey(),
 			"github_codespaces_user_secrets":                                        dataSourceGithubCodespacesUserSecrets(),
+			"github_copilot_organization_seats":                                     dataSourceGithubCopilotOrganizationSeats(),
 			"github_dependabot_organization_public_key":                             dataSourceGithubDependabotOrganizationPublicKey(),
 			"github_dependabot_organization_secrets":                                dataSourceGithubDependabotOrganizationSecrets(),
 			"github_dependabot_public_key":                                          dataSourceGithubDependabotPublicKey(),
@@ -232,8 +345,11 @@ func Provider() *schema.Provider {
 			"github_organization_custom_role":                                       dataSourceGithubOrganizationCustomRole(),
 			"github_organization_external_identities":                               dataSourceGithubOrganizationExternalIdentities(),
 			"github_organization_ip_allow_list":                                     dataSourceGithubOrganizationIpAllowList(),
+			"github_organization_outside_collaborators":                             dataSourceGithubOrganizationOutsideCollaborators(),
+			"github_organization_roles":                                             dataSourceGithubOrganizationRoles(),
 			"github_organization_team_sync_groups":                                  dataSourceGithubOrganizationTeamSyncGroups(),
 			"github_organization_teams":                                             dataSourceGithubOrganizationTeams(),
+			"github_organization_webhook_deliveries":                                dataSourceGithubOrganizationWebhookDeliveries(),
 			"github_organization_webhooks":                                          dataSourceGithubOrganizationWebhooks(),
 			"github_ref":                                                            dataSourceGithubRef(),
 			"github_release":                                                        dataSourceGithubRelease(),
@@ -241,14 +357,17 @@ func Provider() *schema.Provider {
 			"github_repository":                                                     dataSourceGithubRepository(),
 			"github_repository_autolink_references":                                 dataSourceGithubRepositoryAutolinkReferences(),
 			"github_repository_branches":                                            dataSourceGithubRepositoryBranches(),
+			"github_repository_environment_deployment_protection_rule_integrations": dataSourceGithubRepositoryEnvironmentDeploymentProtectionRuleIntegrations(),
 			"github_repository_environments":                                        dataSourceGithubRepositoryEnvironments(),
 			"github_repository_deploy_keys":                                         dataSourceGithubRepositoryDeployKeys(),
 			"github_repository_deployment_branch_policies":                          dataSourceGithubRepositoryDeploymentBranchPolicies(),
 			"github_repository_file":                                                dataSourceGithubRepositoryFile(),
 			"github_repository_milestone":                                           dataSourceGithubRepositoryMilestone(),
+			"github_repository_pages_build":                                         dataSourceGithubRepositoryPagesBuild(),
 			"github_repository_pull_request":                                        dataSourceGithubRepositoryPullRequest(),
 			"github_repository_pull_requests":                                       dataSourceGithubRepositoryPullRequests(),
 			"github_repository_teams":                                               dataSourceGithubRepositoryTeams(),
+			"github_repository_webhook_deliveries":                                  dataSourceGithubRepositoryWebhookDeliveries(),
 			"github_repository_webhooks":                                            dataSourceGithubRepositoryWebhooks(),
 			"github_rest_api":                                                       dataSourceGithubRestApi(),
 			"github_ssh_keys":                                                       dataSourceGithubSshKeys(),
@@ -261,6 +380,15 @@ func Provider() *schema.Provider {
 		},
 	}
 
+	for name, r := range p.ResourcesMap {
+		withAPIErrorDiagnostics(name, r)
+		withResourceType(name, r)
+	}
+	for name, r := range p.DataSourcesMap {
+		withAPIErrorDiagnostics(name, r)
+		withResourceType("data."+name, r)
+	}
+
 	p.ConfigureContextFunc = providerConfigure(p)
 
 	return p
@@ -270,12 +398,22 @@ var descriptions map[string]string
 
 func init() {
 	descriptions = map[string]string{
-		"token": "The OAuth token used to connect to GitHub. Anonymous mode is enabled if both `token` and " +
-			"`app_auth` are not set.",
+		"token": "The OAuth token used to connect to GitHub. Anonymous mode is enabled if none of `token`, " +
+			"`token_file`, `app_auth` and `oidc_auth` are set.",
+		"token_file": "The path of a file containing the OAuth token used to connect to GitHub. The file is " +
+			"read again periodically and when GitHub rejects the token, so that rotated tokens are picked up.",
 
 		"base_url": "The GitHub Base API URL",
 
-		"insecure": "Enable `insecure` mode for testing purposes",
+		"insecure": "Enable `insecure` mode for testing purposes, which skips the verification of " +
+			"the TLS certificate presented by GitHub",
+		"proxy_url": "URL of an HTTP(S) proxy to send requests to GitHub through. " +
+			"Defaults to the HTTPS_PROXY, HTTP_PROXY and NO_PROXY environment variables",
+		"ca_bundle": "PEM encoded CA certificates, or the path of a file containing them, " +
+			"to trust in addition to the system's certificate pool",
+		"client_certificate": "PEM encoded client certificate, or the path of a file containing it, " +
+			"to present to GitHub for mutual TLS authentication. Requires client_key",
+		"client_key": "PEM encoded private key of the client certificate, or the path of a file containing it",
 
 		"owner": "The GitHub owner name to manage. " +
 			"Use this field instead of `organization` when managing individual accounts.",
@@ -283,11 +421,19 @@ func init() {
 		"organization": "The GitHub organization name to manage. " +
 			"Use this field instead of `owner` when managing organization accounts.",
 
-		"app_auth": "The GitHub App credentials used to connect to GitHub. Conflicts with " +
-			"`token`. Anonymous mode is enabled if both `token` and `app_auth` are not set.",
+		"app_auth": "The GitHub App credentials used to connect to GitHub. Takes precedence over " +
+			"`oidc_auth`, `token` and `token_file`.",
 		"app_auth.id":              "The GitHub App ID.",
 		"app_auth.installation_id": "The GitHub App installation instance ID.",
-		"app_auth.pem_file":        "The GitHub App PEM file contents.",
+		"app_auth.pem_file":        "The GitHub App PEM file contents. Conflicts with `pem_file_path`.",
+		"app_auth.pem_file_path":   "The path of the GitHub App PEM file. Conflicts with `pem_file`.",
+		"oidc_auth": "Exchange an OIDC ID token for a GitHub token by calling a token broker. " +
+			"Conflicts with `token`, `token_file` and `app_auth`.",
+		"oidc_auth.token_broker_url": "The URL of the token broker exchanging ID tokens for GitHub tokens.",
+		"oidc_auth.id_token":         "The OIDC ID token to exchange.",
+		"oidc_auth.id_token_file":    "The path of a file containing the OIDC ID token to exchange, read again for every exchange.",
+		"oidc_auth.audience": "The audience of the ID token requested from GitHub Actions when neither " +
+			"`id_token` nor `id_token_file` are set.",
 		"write_delay_ms": "Amount of time in milliseconds to sleep in between writes to GitHub API. " +
 			"Defaults to 1000ms or 1s if not set.",
 		"read_delay_ms": "Amount of time in milliseconds to sleep in between non-write requests to GitHub API. " +
@@ -303,6 +449,14 @@ func init() {
 			"Defaults to [500, 502, 503, 504]",
 		"max_retries": "Number of times to retry a request after receiving an error status code" +
 			"Defaults to 3",
+		"rate_limit_pacing": "Track the remaining GitHub API rate limit budget reported in responses and " +
+			"spread requests evenly over the rest of the rate limit window instead of exhausting it. " +
+			"Defaults to false if not set",
+		"etag_cache_dir": "Directory in which to cache GitHub API responses between runs. " +
+			"Cached responses are revalidated with conditional requests, which do not count against the rate limit " +
+			"when the response did not change. Disabled if not set",
+		"rate_limit_pacing_threshold": "Percentage of the rate limit budget below which requests are paced " +
+			"when rate_limit_pacing is enabled. Defaults to 50",
 	}
 }
 
@@ -310,7 +464,6 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 	return func(ctx context.Context, d *schema.ResourceData) (interface{}, diag.Diagnostics) {
 		owner := d.Get("owner").(string)
 		baseURL := d.Get("base_url").(string)
-		token := d.Get("token").(string)
 		insecure := d.Get("insecure").(bool)
 
 		// BEGIN backwards compatibility
@@ -335,42 +488,17 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			owner = org
 		}
 
-		if appAuth, ok := d.Get("app_auth").([]interface{}); ok && len(appAuth) > 0 && appAuth[0] != nil {
-			appAuthAttr := appAuth[0].(map[string]interface{})
-
-			var appID, appInstallationID, appPemFile string
-
-			if v, ok := appAuthAttr["id"].(string); ok && v != "" {
-				appID = v
-			} else {
-				return nil, wrapErrors([]error{fmt.Errorf("app_auth.id must be set and contain a non-empty value")})
-			}
-
-			if v, ok := appAuthAttr["installation_id"].(string); ok && v != "" {
-				appInstallationID = v
-			} else {
-				return nil, wrapErrors([]error{fmt.Errorf("app_auth.installation_id must be set and contain a non-empty value")})
-			}
-
-			if v, ok := appAuthAttr["pem_file"].(string); ok && v != "" {
-				// The Go encoding/pem package only decodes PEM formatted blocks
-				// that contain new lines. Some platforms, like Terraform Cloud,
-				// do not support new lines within Environment Variables.
-				// Any occurrence of \n in the `pem_file` argument's value
-				// (explicit value, or default value taken from
-				// GITHUB_APP_PEM_FILE Environment Variable) is replaced with an
-				// actual new line character before decoding.
-				appPemFile = strings.Replace(v, `\n`, "\n", -1)
-			} else {
-				return nil, wrapErrors([]error{fmt.Errorf("app_auth.pem_file must be set and contain a non-empty value")})
-			}
-
-			appToken, err := GenerateOAuthTokenFromApp(baseURL, appID, appInstallationID, appPemFile)
-			if err != nil {
-				return nil, wrapErrors([]error{err})
-			}
+		proxyURL := d.Get("proxy_url").(string)
+		caBundle := d.Get("ca_bundle").(string)
+		clientCertificate := d.Get("client_certificate").(string)
+		clientKey := d.Get("client_key").(string)
 
-			token = appToken
+		transport, err := newHTTPTransport(insecure, proxyURL, caBundle, clientCertificate, clientKey)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+		if insecure {
+			log.Printf("[WARN] Skipping the verification of TLS certificates presented by GitHub")
 		}
 
 		isGithubDotCom, err := regexp.MatchString("^"+regexp.QuoteMeta("https://api.github.com"), baseURL)
@@ -378,12 +506,9 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			return nil, diag.FromErr(err)
 		}
 
-		if token == "" {
-			ghAuthToken, err := tokenFromGhCli(baseURL, isGithubDotCom)
-			if err != nil {
-				return nil, diag.FromErr(fmt.Errorf("gh auth token: %w", err))
-			}
-			token = ghAuthToken
+		token, tokenSource, diags := configureAuthentication(d, baseURL, isGithubDotCom, &http.Client{Transport: transport})
+		if diags.HasError() {
+			return nil, diags
 		}
 
 		writeDelay := d.Get("write_delay_ms").(int)
@@ -430,10 +555,34 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 		}
 		log.Printf("[DEBUG] Setting parallel_requests to %t", parallelRequests)
 
+		rateLimitPacing := d.Get("rate_limit_pacing").(bool)
+		log.Printf("[DEBUG] Setting rate_limit_pacing to %t", rateLimitPacing)
+
+		rateLimitPacingThreshold := d.Get("rate_limit_pacing_threshold").(int)
+		if rateLimitPacingThreshold < 1 || rateLimitPacingThreshold > 100 {
+			return nil, wrapErrors([]error{fmt.Errorf("rate_limit_pacing_threshold must be between 1 and 100")})
+		}
+		log.Printf("[DEBUG] Setting rate_limit_pacing_threshold to %d", rateLimitPacingThreshold)
+
+		etagCacheDir := d.Get("etag_cache_dir").(string)
+		if etagCacheDir != "" {
+			log.Printf("[DEBUG] Setting etag_cache_dir to %s", etagCacheDir)
+		}
+
+		// Acceptance tests record their requests to GitHub into a cassette, or
+		// replay them from one, when these are set. See CONTRIBUTING.md.
+		cassetteMode := os.Getenv("GITHUB_CASSETTE_MODE")
+		cassettePath := os.Getenv("GITHUB_CASSETTE")
+		if cassetteMode != "" && cassettePath != "" {
+			log.Printf("[INFO] Using cassette %s in %s mode", cassettePath, cassetteMode)
+		}
+
 		config := Config{
 			Token:            token,
+			TokenSource:      tokenSource,
 			BaseURL:          baseURL,
 			Insecure:         insecure,
+			Transport:        transport,
 			Owner:            owner,
 			WriteDelay:       time.Duration(writeDelay) * time.Millisecond,
 			ReadDelay:        time.Duration(readDelay) * time.Millisecond,
@@ -441,15 +590,178 @@ func providerConfigure(p *schema.Provider) schema.ConfigureContextFunc {
 			RetryableErrors:  retryableErrors,
 			MaxRetries:       maxRetries,
 			ParallelRequests: parallelRequests,
+
+			RateLimitPacing:          rateLimitPacing,
+			RateLimitPacingThreshold: rateLimitPacingThreshold,
+			EtagCacheDir:             etagCacheDir,
+			CassetteMode:             cassetteMode,
+			CassettePath:             cassettePath,
 		}
 
-		meta, err := config.Meta()
+		meta, err := config.MetaContext(ctx)
 		if err != nil {
 			return nil, wrapErrors([]error{err})
 		}
 
-		return meta, nil
+		// Expose a context which is cancelled when Terraform stops the provider.
+		if stopCtx, ok := schema.StopContext(ctx); ok {
+			meta.(*Owner).StopContext = stopCtx
+		}
+
+		return meta, diags
+	}
+}
+
+// configureAuthentication selects how the provider authenticates to GitHub.
+// The first of app_auth, oidc_auth, token and token_file that is configured is
+// used, falling back to the token of the GitHub CLI and then to anonymous
+// access. The selection is logged, and reported with a warning when it is
+// likely not what was intended.
+func configureAuthentication(d *schema.ResourceData, baseURL string, isGithubDotCom bool, client *http.Client) (string, refreshingTokenSource, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	appAuth := d.Get("app_auth").([]interface{})
+	oidcAuth := d.Get("oidc_auth").([]interface{})
+	token := d.Get("token").(string)
+	tokenFile := d.Get("token_file").(string)
+
+	// Authentication methods configured, in order of precedence
+	var configured []string
+	if len(appAuth) > 0 && appAuth[0] != nil {
+		configured = append(configured, "app_auth")
+	}
+	if len(oidcAuth) > 0 && oidcAuth[0] != nil {
+		configured = append(configured, "oidc_auth")
+	}
+	if token != "" {
+		configured = append(configured, "token")
+	}
+	if tokenFile != "" {
+		configured = append(configured, "token_file")
+	}
+
+	if len(configured) == 0 {
+		ghAuthToken, err := tokenFromGhCli(baseURL, isGithubDotCom)
+		if err != nil {
+			return "", nil, diag.FromErr(fmt.Errorf("gh auth token: %w", err))
+		}
+		if ghAuthToken != "" {
+			log.Printf("[INFO] Authenticating with the token from GitHub CLI, as none of app_auth, oidc_auth, token or token_file are set")
+			return ghAuthToken, nil, append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Authenticating with the token from GitHub CLI",
+				Detail: "None of app_auth, oidc_auth, token or token_file are set, neither in the provider " +
+					"configuration nor through their environment variables, so the provider falls back to the " +
+					"token returned by `gh auth token`. Set one of them to choose how the provider authenticates.",
+			})
+		}
+
+		log.Printf("[INFO] Accessing GitHub anonymously, as no credentials were found")
+		return "", nil, append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "No GitHub credentials found, accessing GitHub anonymously",
+			Detail: "None of app_auth, oidc_auth, token or token_file are set, neither in the provider " +
+				"configuration nor through their environment variables, and no token could be read from " +
+				"GitHub CLI with `gh auth token`. Anonymous access only allows reading public data and " +
+				"is subject to a lower rate limit.",
+		})
+	}
+
+	if len(configured) > 1 {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Multiple GitHub authentication methods are set",
+			Detail: fmt.Sprintf("Authenticating with %s, which takes precedence over %s. Remove the unused "+
+				"credentials from the provider configuration or the environment to silence this warning.",
+				configured[0], strings.Join(configured[1:], ", ")),
+		})
+	}
+
+	var tokenSource refreshingTokenSource
+	switch configured[0] {
+	case "app_auth":
+		ts, err := appTokenSourceFromConfig(appAuth[0].(map[string]interface{}), baseURL, client)
+		if err != nil {
+			return "", nil, append(diags, wrapErrors([]error{err})...)
+		}
+		log.Printf("[INFO] Authenticating as installation %s of GitHub App %s", ts.installationID, ts.appID)
+		tokenSource = ts
+	case "oidc_auth":
+		oidcAuthAttr := oidcAuth[0].(map[string]interface{})
+		brokerURL := oidcAuthAttr["token_broker_url"].(string)
+		if brokerURL == "" {
+			return "", nil, append(diags, wrapErrors([]error{fmt.Errorf("oidc_auth.token_broker_url must be set and contain a non-empty value")})...)
+		}
+		log.Printf("[INFO] Authenticating with a token exchanged by the token broker %s", brokerURL)
+		tokenSource = newBrokerTokenSource(client, brokerURL, oidcAuthAttr["id_token"].(string), oidcAuthAttr["id_token_file"].(string), oidcAuthAttr["audience"].(string))
+	case "token":
+		log.Printf("[INFO] Authenticating with token")
+		return token, nil, diags
+	case "token_file":
+		log.Printf("[INFO] Authenticating with the token read from %s", tokenFile)
+		if tokenFile == os.Getenv("GITHUB_TOKEN_FILE") {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  "Authenticating with the token file set by GITHUB_TOKEN_FILE",
+				Detail: fmt.Sprintf("No other authentication method is set, so the provider reads its token from %s, "+
+					"the file the GITHUB_TOKEN_FILE environment variable points to. Set token_file in the provider "+
+					"configuration to choose it explicitly.", tokenFile),
+			})
+		}
+		tokenSource = newFileTokenSource(tokenFile)
+	}
+
+	// Fetch the first token up front so that invalid credentials are reported
+	// when configuring the provider.
+	if _, err := tokenSource.Token(); err != nil {
+		return "", nil, append(diags, wrapErrors([]error{fmt.Errorf("%s: %w", configured[0], err)})...)
 	}
+
+	return "", tokenSource, diags
+}
+
+// appTokenSourceFromConfig returns a token source for the credentials in the
+// app_auth block.
+func appTokenSourceFromConfig(appAuthAttr map[string]interface{}, baseURL string, client *http.Client) (*AppInstallationTokenSource, error) {
+	var appID, appInstallationID, appPemFile string
+
+	if v, ok := appAuthAttr["id"].(string); ok && v != "" {
+		appID = v
+	} else {
+		return nil, fmt.Errorf("app_auth.id must be set and contain a non-empty value")
+	}
+
+	if v, ok := appAuthAttr["installation_id"].(string); ok && v != "" {
+		appInstallationID = v
+	} else {
+		return nil, fmt.Errorf("app_auth.installation_id must be set and contain a non-empty value")
+	}
+
+	pemFile, _ := appAuthAttr["pem_file"].(string)
+	pemFilePath, _ := appAuthAttr["pem_file_path"].(string)
+	switch {
+	case pemFile != "" && pemFilePath != "":
+		return nil, fmt.Errorf("only one of app_auth.pem_file and app_auth.pem_file_path can be set")
+	case pemFile != "":
+		// The Go encoding/pem package only decodes PEM formatted blocks
+		// that contain new lines. Some platforms, like Terraform Cloud,
+		// do not support new lines within Environment Variables.
+		// Any occurrence of \n in the `pem_file` argument's value
+		// (explicit value, or default value taken from
+		// GITHUB_APP_PEM_FILE Environment Variable) is replaced with an
+		// actual new line character before decoding.
+		appPemFile = strings.Replace(pemFile, `\n`, "\n", -1)
+	case pemFilePath != "":
+		data, err := os.ReadFile(pemFilePath)
+		if err != nil {
+			return nil, fmt.Errorf("error reading app_auth.pem_file_path: %w", err)
+		}
+		appPemFile = string(data)
+	default:
+		return nil, fmt.Errorf("app_auth.pem_file or app_auth.pem_file_path must be set and contain a non-empty value")
+	}
+
+	return NewAppInstallationTokenSource(client, baseURL, appID, appInstallationID, appPemFile), nil
 }
 
 // See https://github.com/integrations/terraform-provider-github/issues/1822
@@ -484,6 +796,7 @@ func tokenFromGhCli(baseURL string, isGithubDotCom bool) (string, error) {
 	if err != nil {
 		// GH CLI is either not installed or there was no `gh auth login` command issued,
 		// which is fine. don't return the error to keep the flow going
+		log.Printf("[DEBUG] No token available from GitHub CLI: %s", err)
 		return "", nil
 	}
 
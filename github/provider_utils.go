@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -37,6 +39,12 @@ func testAccPreCheck(t *testing.T) {
 }
 
 func skipUnlessMode(t *testing.T, providerMode string) {
+	defer func() {
+		if !t.Skipped() {
+			useTestCassette(t)
+		}
+	}()
+
 	switch providerMode {
 	case anonymous:
 		if os.Getenv("GITHUB_BASE_URL") != "" &&
@@ -74,6 +82,18 @@ func skipUnlessMode(t *testing.T, providerMode string) {
 	t.Skipf("Skipping %s which requires %s mode", t.Name(), providerMode)
 }
 
+// useTestCassette records the requests the test makes to GitHub into a
+// cassette named after the test, or replays them from it, when the
+// GITHUB_CASSETTE_MODE environment variable is set to record or replay.
+func useTestCassette(t *testing.T) {
+	if os.Getenv("GITHUB_CASSETTE_MODE") == "" {
+		return
+	}
+
+	name := strings.NewReplacer("/", "_", " ", "_").Replace(t.Name())
+	t.Setenv("GITHUB_CASSETTE", filepath.Join("test-fixtures", "cassettes", name+".json"))
+}
+
 func testAccCheckOrganization() error {
 
 	baseURL := os.Getenv("GITHUB_BASE_URL")
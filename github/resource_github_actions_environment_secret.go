@@ -8,15 +8,16 @@ import (
 	"net/url"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubActionsEnvironmentSecret() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubActionsEnvironmentSecretCreateOrUpdate,
-		Read:   resourceGithubActionsEnvironmentSecretRead,
-		Delete: resourceGithubActionsEnvironmentSecretDelete,
+		CreateContext: resourceGithubActionsEnvironmentSecretCreateOrUpdate,
+		ReadContext:   resourceGithubActionsEnvironmentSecretRead,
+		DeleteContext: resourceGithubActionsEnvironmentSecretDelete,
 
 		Schema: map[string]*schema.Schema{
 			"repository": {
@@ -69,10 +70,9 @@ func resourceGithubActionsEnvironmentSecret() *schema.Resource {
 	}
 }
 
-func resourceGithubActionsEnvironmentSecretCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnvironmentSecretCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repoName := d.Get("repository").(string)
 	envName := d.Get("environment").(string)
@@ -81,14 +81,14 @@ func resourceGithubActionsEnvironmentSecretCreateOrUpdate(d *schema.ResourceData
 	plaintextValue := d.Get("plaintext_value").(string)
 	var encryptedValue string
 
-	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
+	repo, err := getRepository(ctx, owner, repoName, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	keyId, publicKey, err := getEnvironmentPublicKeyDetails(repo.GetID(), escapedEnvName, meta)
+	keyId, publicKey, err := getEnvironmentPublicKeyDetails(ctx, repo.GetID(), escapedEnvName, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
@@ -96,7 +96,7 @@ func resourceGithubActionsEnvironmentSecretCreateOrUpdate(d *schema.ResourceData
 	} else {
 		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
 	}
@@ -110,25 +110,24 @@ func resourceGithubActionsEnvironmentSecretCreateOrUpdate(d *schema.ResourceData
 
 	_, err = client.Actions.CreateOrUpdateEnvSecret(ctx, int(repo.GetID()), escapedEnvName, eSecret)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildThreePartID(repoName, envName, secretName))
-	return resourceGithubActionsEnvironmentSecretRead(d, meta)
+	return resourceGithubActionsEnvironmentSecretRead(ctx, d, meta)
 }
 
-func resourceGithubActionsEnvironmentSecretRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnvironmentSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repoName, envName, secretName, err := parseThreePartID(d.Id(), "repository", "environment", "secret_name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	escapedEnvName := url.PathEscape(envName)
 
-	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
+	repo, err := getRepository(ctx, owner, repoName, meta)
 	if err != nil {
 		if ghErr, ok := err.(*github.ErrorResponse); ok {
 			if ghErr.Response.StatusCode == http.StatusNotFound {
@@ -138,7 +137,7 @@ func resourceGithubActionsEnvironmentSecretRead(d *schema.ResourceData, meta int
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	secret, _, err := client.Actions.GetEnvSecret(ctx, int(repo.GetID()), escapedEnvName, secretName)
@@ -151,17 +150,17 @@ func resourceGithubActionsEnvironmentSecretRead(d *schema.ResourceData, meta int
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("encrypted_value", d.Get("encrypted_value")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("plaintext_value", d.Get("plaintext_value")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("created_at", secret.CreatedAt.String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// This is a drift detection mechanism based on timestamps.
@@ -184,36 +183,35 @@ func resourceGithubActionsEnvironmentSecretRead(d *schema.ResourceData, meta int
 		d.SetId("")
 	} else if !ok {
 		if err = d.Set("updated_at", secret.UpdatedAt.String()); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceGithubActionsEnvironmentSecretDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnvironmentSecretDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	repoName, envName, secretName, err := parseThreePartID(d.Id(), "repository", "environment", "secret_name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	escapedEnvName := url.PathEscape(envName)
-	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
+	repo, err := getRepository(ctx, owner, repoName, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	log.Printf("[INFO] Deleting environment secret: %s", d.Id())
 	_, err = client.Actions.DeleteEnvSecret(ctx, int(repo.GetID()), escapedEnvName, secretName)
 
-	return err
+	return diag.FromErr(err)
 }
 
-func getEnvironmentPublicKeyDetails(repoID int64, envName string, meta interface{}) (keyId, pkValue string, err error) {
+func getEnvironmentPublicKeyDetails(ctx context.Context, repoID int64, envName string, meta interface{}) (keyId, pkValue string, err error) {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	publicKey, _, err := client.Actions.GetEnvPublicKey(ctx, int(repoID), envName)
 	if err != nil {
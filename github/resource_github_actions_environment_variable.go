@@ -7,15 +7,16 @@ import (
 	"net/url"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubActionsEnvironmentVariable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubActionsEnvironmentVariableCreate,
-		Read:   resourceGithubActionsEnvironmentVariableRead,
-		Update: resourceGithubActionsEnvironmentVariableUpdate,
-		Delete: resourceGithubActionsEnvironmentVariableDelete,
+		CreateContext: resourceGithubActionsEnvironmentVariableCreate,
+		ReadContext:   resourceGithubActionsEnvironmentVariableRead,
+		UpdateContext: resourceGithubActionsEnvironmentVariableUpdate,
+		DeleteContext: resourceGithubActionsEnvironmentVariableDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -58,10 +59,9 @@ func resourceGithubActionsEnvironmentVariable() *schema.Resource {
 	}
 }
 
-func resourceGithubActionsEnvironmentVariableCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnvironmentVariableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repoName := d.Get("repository").(string)
 	envName := d.Get("environment").(string)
@@ -75,17 +75,16 @@ func resourceGithubActionsEnvironmentVariableCreate(d *schema.ResourceData, meta
 
 	_, err := client.Actions.CreateEnvVariable(ctx, owner, repoName, escapedEnvName, variable)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildThreePartID(repoName, envName, name))
-	return resourceGithubActionsEnvironmentVariableRead(d, meta)
+	return resourceGithubActionsEnvironmentVariableRead(ctx, d, meta)
 }
 
-func resourceGithubActionsEnvironmentVariableUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnvironmentVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repoName := d.Get("repository").(string)
 	envName := d.Get("environment").(string)
@@ -99,21 +98,20 @@ func resourceGithubActionsEnvironmentVariableUpdate(d *schema.ResourceData, meta
 
 	_, err := client.Actions.UpdateEnvVariable(ctx, owner, repoName, escapedEnvName, variable)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildThreePartID(repoName, envName, name))
-	return resourceGithubActionsEnvironmentVariableRead(d, meta)
+	return resourceGithubActionsEnvironmentVariableRead(ctx, d, meta)
 }
 
-func resourceGithubActionsEnvironmentVariableRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnvironmentVariableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repoName, envName, name, err := parseThreePartID(d.Id(), "repository", "environment", "variable_name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	escapedEnvName := url.PathEscape(envName)
 
@@ -127,7 +125,7 @@ func resourceGithubActionsEnvironmentVariableRead(d *schema.ResourceData, meta i
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("repository", repoName)
@@ -140,18 +138,18 @@ func resourceGithubActionsEnvironmentVariableRead(d *schema.ResourceData, meta i
 	return nil
 }
 
-func resourceGithubActionsEnvironmentVariableDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnvironmentVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	repoName, envName, name, err := parseThreePartID(d.Id(), "repository", "environment", "variable_name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	escapedEnvName := url.PathEscape(envName)
 
 	_, err = client.Actions.DeleteEnvVariable(ctx, owner, repoName, escapedEnvName, name)
 
-	return err
+	return diag.FromErr(err)
 }
@@ -7,15 +7,16 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubActionsOrganizationVariable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubActionsOrganizationVariableCreate,
-		Read:   resourceGithubActionsOrganizationVariableRead,
-		Update: resourceGithubActionsOrganizationVariableUpdate,
-		Delete: resourceGithubActionsOrganizationVariableDelete,
+		CreateContext: resourceGithubActionsOrganizationVariableCreate,
+		ReadContext:   resourceGithubActionsOrganizationVariableRead,
+		UpdateContext: resourceGithubActionsOrganizationVariableUpdate,
+		DeleteContext: resourceGithubActionsOrganizationVariableDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -63,10 +64,9 @@ func resourceGithubActionsOrganizationVariable() *schema.Resource {
 	}
 }
 
-func resourceGithubActionsOrganizationVariableCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsOrganizationVariableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	name := d.Get("variable_name").(string)
 
@@ -74,7 +74,7 @@ func resourceGithubActionsOrganizationVariableCreate(d *schema.ResourceData, met
 	selectedRepositories, hasSelectedRepositories := d.GetOk("selected_repository_ids")
 
 	if visibility != "selected" && hasSelectedRepositories {
-		return fmt.Errorf("cannot use selected_repository_ids without visibility being set to selected")
+		return diag.FromErr(fmt.Errorf("cannot use selected_repository_ids without visibility being set to selected"))
 	}
 
 	selectedRepositoryIDs := []int64{}
@@ -97,17 +97,16 @@ func resourceGithubActionsOrganizationVariableCreate(d *schema.ResourceData, met
 	}
 	_, err := client.Actions.CreateOrgVariable(ctx, owner, variable)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(name)
-	return resourceGithubActionsOrganizationVariableRead(d, meta)
+	return resourceGithubActionsOrganizationVariableRead(ctx, d, meta)
 }
 
-func resourceGithubActionsOrganizationVariableUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsOrganizationVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	name := d.Get("variable_name").(string)
 
@@ -115,7 +114,7 @@ func resourceGithubActionsOrganizationVariableUpdate(d *schema.ResourceData, met
 	selectedRepositories, hasSelectedRepositories := d.GetOk("selected_repository_ids")
 
 	if visibility != "selected" && hasSelectedRepositories {
-		return fmt.Errorf("cannot use selected_repository_ids without visibility being set to selected")
+		return diag.FromErr(fmt.Errorf("cannot use selected_repository_ids without visibility being set to selected"))
 	}
 
 	selectedRepositoryIDs := []int64{}
@@ -139,17 +138,16 @@ func resourceGithubActionsOrganizationVariableUpdate(d *schema.ResourceData, met
 
 	_, err := client.Actions.UpdateOrgVariable(ctx, owner, variable)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(name)
-	return resourceGithubActionsOrganizationVariableRead(d, meta)
+	return resourceGithubActionsOrganizationVariableRead(ctx, d, meta)
 }
 
-func resourceGithubActionsOrganizationVariableRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsOrganizationVariableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	name := d.Id()
 
@@ -163,23 +161,23 @@ func resourceGithubActionsOrganizationVariableRead(d *schema.ResourceData, meta
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("variable_name", name); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("value", variable.Value); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("created_at", variable.CreatedAt.String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("updated_at", variable.UpdatedAt.String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("visibility", *variable.Visibility); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedRepositoryIDs := []int64{}
@@ -191,7 +189,7 @@ func resourceGithubActionsOrganizationVariableRead(d *schema.ResourceData, meta
 		for {
 			results, resp, err := client.Actions.ListSelectedReposForOrgVariable(ctx, owner, d.Id(), opt)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 
 			for _, repo := range results.Repositories {
@@ -206,20 +204,20 @@ func resourceGithubActionsOrganizationVariableRead(d *schema.ResourceData, meta
 	}
 
 	if err = d.Set("selected_repository_ids", selectedRepositoryIDs); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubActionsOrganizationVariableDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsOrganizationVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	name := d.Id()
 
 	_, err := client.Actions.DeleteOrgVariable(ctx, owner, name)
 
-	return err
+	return diag.FromErr(err)
 }
@@ -4,16 +4,17 @@ import (
 	"context"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubActionsRepositoryAccessLevel() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubActionsRepositoryAccessLevelCreateOrUpdate,
-		Read:   resourceGithubActionsRepositoryAccessLevelRead,
-		Update: resourceGithubActionsRepositoryAccessLevelCreateOrUpdate,
-		Delete: resourceGithubActionsRepositoryAccessLevelDelete,
+		CreateContext: resourceGithubActionsRepositoryAccessLevelCreateOrUpdate,
+		ReadContext:   resourceGithubActionsRepositoryAccessLevelRead,
+		UpdateContext: resourceGithubActionsRepositoryAccessLevelCreateOrUpdate,
+		DeleteContext: resourceGithubActionsRepositoryAccessLevelDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -35,11 +36,10 @@ func resourceGithubActionsRepositoryAccessLevel() *schema.Resource {
 	}
 }
 
-func resourceGithubActionsRepositoryAccessLevelCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsRepositoryAccessLevelCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
-	ctx := context.Background()
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxId, d.Id())
 	}
@@ -51,22 +51,22 @@ func resourceGithubActionsRepositoryAccessLevelCreateOrUpdate(d *schema.Resource
 
 	_, err := client.Repositories.EditActionsAccessLevel(ctx, owner, repoName, actionAccessLevel)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(repoName)
-	return resourceGithubActionsRepositoryAccessLevelRead(d, meta)
+	return resourceGithubActionsRepositoryAccessLevelRead(ctx, d, meta)
 }
 
-func resourceGithubActionsRepositoryAccessLevelRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsRepositoryAccessLevelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	repoName := d.Id()
-	ctx := context.WithValue(context.Background(), ctxId, repoName)
+	ctx = context.WithValue(ctx, ctxId, repoName)
 
 	actionAccessLevel, _, err := client.Repositories.GetActionsAccessLevel(ctx, owner, repoName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	_ = d.Set("access_level", actionAccessLevel.GetAccessLevel())
@@ -74,16 +74,16 @@ func resourceGithubActionsRepositoryAccessLevelRead(d *schema.ResourceData, meta
 	return nil
 }
 
-func resourceGithubActionsRepositoryAccessLevelDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsRepositoryAccessLevelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	repoName := d.Id()
-	ctx := context.WithValue(context.Background(), ctxId, repoName)
+	ctx = context.WithValue(ctx, ctxId, repoName)
 
 	actionAccessLevel := github.RepositoryActionsAccessLevel{
 		AccessLevel: github.String("none"),
 	}
 	_, err := client.Repositories.EditActionsAccessLevel(ctx, owner, repoName, actionAccessLevel)
 
-	return err
+	return diag.FromErr(err)
 }
@@ -5,16 +5,17 @@ import (
 	"log"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubActionsRepositoryPermissions() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubActionsRepositoryPermissionsCreateOrUpdate,
-		Read:   resourceGithubActionsRepositoryPermissionsRead,
-		Update: resourceGithubActionsRepositoryPermissionsCreateOrUpdate,
-		Delete: resourceGithubActionsRepositoryPermissionsDelete,
+		CreateContext: resourceGithubActionsRepositoryPermissionsCreateOrUpdate,
+		ReadContext:   resourceGithubActionsRepositoryPermissionsRead,
+		UpdateContext: resourceGithubActionsRepositoryPermissionsCreateOrUpdate,
+		DeleteContext: resourceGithubActionsRepositoryPermissionsDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -102,12 +103,11 @@ func resourceGithubActionsRepositoryAllowedObject(d *schema.ResourceData) (*gith
 	return allowed, nil
 }
 
-func resourceGithubActionsRepositoryPermissionsCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsRepositoryPermissionsCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
-	ctx := context.Background()
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxId, d.Id())
 	}
@@ -131,13 +131,13 @@ func resourceGithubActionsRepositoryPermissionsCreateOrUpdate(d *schema.Resource
 		repoActionPermissions,
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if allowedActions == "selected" {
 		actionsAllowedData, err := resourceGithubActionsRepositoryAllowedObject(d)
 		if err != nil {
-			return err
+			return attributeDiagnostics(err, "allowed_actions_config")
 		}
 		if actionsAllowedData != nil {
 			log.Printf("[DEBUG] Allowed actions config is set")
@@ -146,7 +146,7 @@ func resourceGithubActionsRepositoryPermissionsCreateOrUpdate(d *schema.Resource
 				repoName,
 				*actionsAllowedData)
 			if err != nil {
-				return err
+				return attributeDiagnostics(err, "allowed_actions_config")
 			}
 		} else {
 			log.Printf("[DEBUG] Allowed actions config not set, skipping")
@@ -154,19 +154,19 @@ func resourceGithubActionsRepositoryPermissionsCreateOrUpdate(d *schema.Resource
 	}
 
 	d.SetId(repoName)
-	return resourceGithubActionsRepositoryPermissionsRead(d, meta)
+	return resourceGithubActionsRepositoryPermissionsRead(ctx, d, meta)
 }
 
-func resourceGithubActionsRepositoryPermissionsRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsRepositoryPermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repoName := d.Id()
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	actionsPermissions, _, err := client.Repositories.GetActionsPermissions(ctx, owner, repoName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// only load and fill allowed_actions_config if allowed_actions_config is also set
@@ -182,7 +182,7 @@ func resourceGithubActionsRepositoryPermissionsRead(d *schema.ResourceData, meta
 	if serverHasAllowedActionsConfig && userWantsAllowedActionsConfig {
 		actionsAllowed, _, err := client.Repositories.GetActionsAllowed(ctx, owner, repoName)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		// If actionsAllowed set to local/all by removing all actions config settings, the response will be empty
@@ -194,34 +194,34 @@ func resourceGithubActionsRepositoryPermissionsRead(d *schema.ResourceData, meta
 					"verified_allowed":     actionsAllowed.GetVerifiedAllowed(),
 				},
 			}); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	} else {
 		if err = d.Set("allowed_actions_config", []interface{}{}); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if err = d.Set("allowed_actions", actionsPermissions.GetAllowedActions()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("enabled", actionsPermissions.GetEnabled()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("repository", repoName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubActionsRepositoryPermissionsDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsRepositoryPermissionsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	repoName := d.Id()
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	// Reset the repo to "default" settings
 	repoActionPermissions := github.ActionsPermissionsRepository{
@@ -235,7 +235,7 @@ func resourceGithubActionsRepositoryPermissionsDelete(d *schema.ResourceData, me
 		repoActionPermissions,
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
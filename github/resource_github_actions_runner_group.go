@@ -8,16 +8,17 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubActionsRunnerGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubActionsRunnerGroupCreate,
-		Read:   resourceGithubActionsRunnerGroupRead,
-		Update: resourceGithubActionsRunnerGroupUpdate,
-		Delete: resourceGithubActionsRunnerGroupDelete,
+		CreateContext: resourceGithubActionsRunnerGroupCreate,
+		ReadContext:   resourceGithubActionsRunnerGroupRead,
+		UpdateContext: resourceGithubActionsRunnerGroupUpdate,
+		DeleteContext: resourceGithubActionsRunnerGroupDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -95,10 +96,10 @@ func resourceGithubActionsRunnerGroup() *schema.Resource {
 	}
 }
 
-func resourceGithubActionsRunnerGroupCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsRunnerGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -117,7 +118,7 @@ func resourceGithubActionsRunnerGroupCreate(d *schema.ResourceData, meta interfa
 	}
 
 	if visibility != "selected" && hasSelectedRepositories {
-		return fmt.Errorf("cannot use selected_repository_ids without visibility being set to selected")
+		return diag.FromErr(fmt.Errorf("cannot use selected_repository_ids without visibility being set to selected"))
 	}
 
 	selectedRepositoryIDs := []int64{}
@@ -130,8 +131,6 @@ func resourceGithubActionsRunnerGroupCreate(d *schema.ResourceData, meta interfa
 		}
 	}
 
-	ctx := context.Background()
-
 	runnerGroup, resp, err := client.Actions.CreateOrganizationRunnerGroup(ctx,
 		orgName,
 		github.CreateRunnerGroupRequest{
@@ -144,48 +143,48 @@ func resourceGithubActionsRunnerGroupCreate(d *schema.ResourceData, meta interfa
 		},
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(runnerGroup.GetID(), 10))
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("allows_public_repositories", runnerGroup.GetAllowsPublicRepositories()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("default", runnerGroup.GetDefault()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("id", strconv.FormatInt(runnerGroup.GetID(), 10)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("inherited", runnerGroup.GetInherited()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", runnerGroup.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("runners_url", runnerGroup.GetRunnersURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("selected_repositories_url", runnerGroup.GetSelectedRepositoriesURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("visibility", runnerGroup.GetVisibility()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("selected_repository_ids", selectedRepositoryIDs); err != nil { // Note: runnerGroup has no method to get selected repository IDs
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("restricted_to_workflows", runnerGroup.GetRestrictedToWorkflows()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("selected_workflows", runnerGroup.SelectedWorkflows); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubActionsRunnerGroupRead(d, meta)
+	return resourceGithubActionsRunnerGroupRead(ctx, d, meta)
 }
 
 func getOrganizationRunnerGroup(client *github.Client, ctx context.Context, org string, groupID int64) (*github.RunnerGroup, *github.Response, error) {
@@ -199,10 +198,10 @@ func getOrganizationRunnerGroup(client *github.Client, ctx context.Context, org
 	return runnerGroup, resp, err
 }
 
-func resourceGithubActionsRunnerGroupRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsRunnerGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -210,9 +209,9 @@ func resourceGithubActionsRunnerGroupRead(d *schema.ResourceData, meta interface
 
 	runnerGroupID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -227,7 +226,7 @@ func resourceGithubActionsRunnerGroupRead(d *schema.ResourceData, meta interface
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	//if runner group is nil (typically not modified) we can return early
@@ -236,37 +235,37 @@ func resourceGithubActionsRunnerGroupRead(d *schema.ResourceData, meta interface
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("allows_public_repositories", runnerGroup.GetAllowsPublicRepositories()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("default", runnerGroup.GetDefault()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("id", strconv.FormatInt(runnerGroup.GetID(), 10)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("inherited", runnerGroup.GetInherited()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", runnerGroup.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("runners_url", runnerGroup.GetRunnersURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("selected_repositories_url", runnerGroup.GetSelectedRepositoriesURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("visibility", runnerGroup.GetVisibility()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("restricted_to_workflows", runnerGroup.GetRestrictedToWorkflows()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("selected_workflows", runnerGroup.SelectedWorkflows); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedRepositoryIDs := []int64{}
@@ -277,7 +276,7 @@ func resourceGithubActionsRunnerGroupRead(d *schema.ResourceData, meta interface
 	for {
 		runnerGroupRepositories, resp, err := client.Actions.ListRepositoryAccessRunnerGroup(ctx, orgName, runnerGroupID, &options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		for _, repo := range runnerGroupRepositories.Repositories {
@@ -292,16 +291,16 @@ func resourceGithubActionsRunnerGroupRead(d *schema.ResourceData, meta interface
 	}
 
 	if err = d.Set("selected_repository_ids", selectedRepositoryIDs); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubActionsRunnerGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsRunnerGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -328,12 +327,12 @@ func resourceGithubActionsRunnerGroupUpdate(d *schema.ResourceData, meta interfa
 
 	runnerGroupID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	if _, _, err := client.Actions.UpdateOrganizationRunnerGroup(ctx, orgName, runnerGroupID, options); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedRepositories, hasSelectedRepositories := d.GetOk("selected_repository_ids")
@@ -350,27 +349,27 @@ func resourceGithubActionsRunnerGroupUpdate(d *schema.ResourceData, meta interfa
 	reposOptions := github.SetRepoAccessRunnerGroupRequest{SelectedRepositoryIDs: selectedRepositoryIDs}
 
 	if _, err := client.Actions.SetRepositoryAccessRunnerGroup(ctx, orgName, runnerGroupID, reposOptions); err != nil {
-		return err
+		return attributeDiagnostics(err, "selected_repository_ids")
 	}
 
-	return resourceGithubActionsRunnerGroupRead(d, meta)
+	return resourceGithubActionsRunnerGroupRead(ctx, d, meta)
 }
 
-func resourceGithubActionsRunnerGroupDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsRunnerGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	runnerGroupID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	log.Printf("[INFO] Deleting organization runner group: %s (%s)", d.Id(), orgName)
 	_, err = client.Actions.DeleteOrganizationRunnerGroup(ctx, orgName, runnerGroupID)
-	return err
+	return diag.FromErr(err)
 }
@@ -0,0 +1,179 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubActionsRunnerJITConfig() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubActionsRunnerJITConfigCreate,
+		ReadContext:   resourceGithubActionsRunnerJITConfigRead,
+		DeleteContext: resourceGithubActionsRunnerJITConfigDelete,
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The repository to register the runner in. The runner is registered in the organization if not set.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the runner.",
+			},
+			"runner_group_id": {
+				Type:        schema.TypeInt,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     1,
+				Description: "The ID of the runner group to register the runner in. Defaults to the default runner group, the only one of repository runners.",
+			},
+			"labels": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				ForceNew:    true,
+				MinItems:    1,
+				MaxItems:    100,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The custom labels of the runner.",
+			},
+			"work_folder": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Default:     "_work",
+				Description: "The working directory of the runner, relative to its installation directory.",
+			},
+			"runner_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the runner.",
+			},
+			"encoded_jit_config": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Sensitive:   true,
+				Description: "The base64 encoded configuration to start the runner with, using './run.sh --jitconfig'.",
+			},
+			"os": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The operating system of the runner.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The status of the runner, 'online' or 'offline'.",
+			},
+		},
+	}
+}
+
+func resourceGithubActionsRunnerJITConfigCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	name := d.Get("name").(string)
+
+	request := &github.GenerateJITConfigRequest{
+		Name:          name,
+		RunnerGroupID: int64(d.Get("runner_group_id").(int)),
+		WorkFolder:    github.String(d.Get("work_folder").(string)),
+		Labels:        expandStringList(d.Get("labels").(*schema.Set).List()),
+	}
+
+	var config *github.JITRunnerConfig
+	var err error
+	if repoName, ok := d.GetOk("repository"); ok {
+		config, _, err = client.Actions.GenerateRepoJITConfig(ctx, owner, repoName.(string), request)
+	} else {
+		if err = checkOrganization(meta); err != nil {
+			return diag.FromErr(err)
+		}
+		config, _, err = client.Actions.GenerateOrgJITConfig(ctx, owner, request)
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error generating a just-in-time configuration for runner %s: %w", name, err))
+	}
+
+	d.SetId(strconv.FormatInt(config.GetRunner().GetID(), 10))
+	// The configuration is only returned when it is generated.
+	if err = d.Set("encoded_jit_config", config.GetEncodedJITConfig()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGithubActionsRunnerJITConfigRead(ctx, d, meta)
+}
+
+func resourceGithubActionsRunnerJITConfigRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+
+	path, err := actionsRunnersPath(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+	runner, err := getActionsRunner(ctx, client, path, id)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing runner %s from state because it no longer exists in GitHub", d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("runner_id", runner.GetID()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("name", runner.GetName()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("os", runner.GetOS()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("status", runner.GetStatus()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubActionsRunnerJITConfigDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+
+	var resp *github.Response
+	if repoName, ok := d.GetOk("repository"); ok {
+		resp, err = client.Actions.RemoveRunner(ctx, owner, repoName.(string), id)
+	} else {
+		resp, err = client.Actions.RemoveOrganizationRunner(ctx, owner, id)
+	}
+	// Ephemeral runners are removed once they have run a job.
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return diag.FromErr(fmt.Errorf("error removing runner %s: %w", d.Id(), err))
+	}
+
+	return nil
+}
@@ -0,0 +1,171 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubActionsRunnerJITConfig(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("registers a repository runner without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name = "tf-acc-test-%[1]s"
+			}
+
+			resource "github_actions_runner_jit_config" "test" {
+				repository = github_repository.test.name
+				name       = "tf-acc-test-%[1]s"
+				labels     = ["ephemeral", "tf-acc-test"]
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttrSet(
+				"github_actions_runner_jit_config.test", "runner_id",
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_actions_runner_jit_config.test", "encoded_jit_config",
+			),
+			resource.TestCheckResourceAttr(
+				"github_actions_runner_jit_config.test", "status",
+				"offline",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+
+	t.Run("registers an organization runner without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_actions_runner_jit_config" "test" {
+				name   = "tf-acc-test-%s"
+				labels = ["ephemeral"]
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttrSet(
+				"github_actions_runner_jit_config.test", "encoded_jit_config",
+			),
+			resource.TestCheckResourceAttr(
+				"github_actions_runner_jit_config.test", "runner_group_id",
+				"1",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubActionsRunnerJITConfigWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+	client := meta.(*Owner).v3client
+
+	config := map[string]interface{}{
+		"name":   "ephemeral-1",
+		"labels": []interface{}{"gpu", "ephemeral"},
+	}
+	runner := newFakeResource(t, meta, "github_actions_runner_jit_config")
+	runner.apply(config)
+	runner.expectNoChanges(config)
+	if runner.get("runner_id") != runner.state.ID || runner.get("status") != "offline" || runner.get("runner_group_id") != "1" {
+		t.Fatalf("Unexpected state: %v", runner.state.Attributes)
+	}
+	encoded, err := base64.StdEncoding.DecodeString(runner.get("encoded_jit_config"))
+	if err != nil || len(encoded) == 0 {
+		t.Fatalf("Expected a base64 encoded configuration, got %q: %v", runner.get("encoded_jit_config"), err)
+	}
+
+	// A runner with the same name cannot be registered twice.
+	duplicate := newFakeResource(t, meta, "github_actions_runner_jit_config")
+	diff := duplicate.plan(config)
+	if _, diags := duplicate.resource.Apply(context.Background(), nil, diff, meta); !diags.HasError() {
+		t.Fatal("Expected a runner with a duplicate name to be refused")
+	}
+
+	// Ephemeral runners are removed once they have run a job.
+	if _, err := client.Actions.RemoveOrganizationRunner(context.Background(), fakeOrganization, mustParseInt64(t, runner.state.ID)); err != nil {
+		t.Fatal(err)
+	}
+	runner.refresh()
+	if runner.state != nil {
+		t.Fatal("Expected a removed runner to be removed from state")
+	}
+	runner.expectChanges(config)
+
+	newFakeRepository(t, meta, "service")
+	repoConfig := map[string]interface{}{
+		"repository": "service",
+		"name":       "ephemeral-1",
+		"labels":     []interface{}{"ephemeral"},
+	}
+	repoRunner := newFakeResource(t, meta, "github_actions_runner_jit_config")
+	repoRunner.apply(repoConfig)
+	repoRunner.expectNoChanges(repoConfig)
+	runnerID := mustParseInt64(t, repoRunner.state.ID)
+	if _, _, err := client.Actions.GetRunner(context.Background(), fakeOrganization, "service", runnerID); err != nil {
+		t.Fatalf("Expected the runner to be registered in the repository: %v", err)
+	}
+
+	repoRunner.destroy()
+	if _, _, err := client.Actions.GetRunner(context.Background(), fakeOrganization, "service", runnerID); err == nil {
+		t.Fatal("Expected the runner to be removed")
+	}
+}
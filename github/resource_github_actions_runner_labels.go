@@ -0,0 +1,142 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubActionsRunnerLabels() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubActionsRunnerLabelsCreateOrUpdate,
+		ReadContext:   resourceGithubActionsRunnerLabelsRead,
+		UpdateContext: resourceGithubActionsRunnerLabelsCreateOrUpdate,
+		DeleteContext: resourceGithubActionsRunnerLabelsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubActionsRunnerLabelsImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "The repository the runner is registered in. The runner is an organization runner if not set.",
+			},
+			"runner_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the runner.",
+			},
+			"labels": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				MaxItems:    100,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The custom labels of the runner.",
+			},
+		},
+	}
+}
+
+func resourceGithubActionsRunnerLabelsCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+
+	path, err := actionsRunnersPath(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	runnerID := int64(d.Get("runner_id").(int))
+
+	labels := expandStringList(d.Get("labels").(*schema.Set).List())
+	if err = setActionsRunnerLabels(ctx, client, path, runnerID, labels); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting the labels of runner %d: %w", runnerID, err))
+	}
+
+	if repoName, ok := d.GetOk("repository"); ok {
+		d.SetId(buildTwoPartID(repoName.(string), strconv.FormatInt(runnerID, 10)))
+	} else {
+		d.SetId(strconv.FormatInt(runnerID, 10))
+	}
+
+	return resourceGithubActionsRunnerLabelsRead(ctx, d, meta)
+}
+
+func resourceGithubActionsRunnerLabelsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+
+	path, err := actionsRunnersPath(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	runnerID := int64(d.Get("runner_id").(int))
+
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+	runner, err := getActionsRunner(ctx, client, path, runnerID)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing labels of runner %s from state because the runner no longer exists in GitHub", d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("labels", customActionsRunnerLabels(runner.Labels)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubActionsRunnerLabelsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+
+	path, err := actionsRunnersPath(d, meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	runnerID := int64(d.Get("runner_id").(int))
+
+	err = setActionsRunnerLabels(ctx, client, path, runnerID, nil)
+	// Ephemeral runners are removed once they have run a job, and their
+	// labels along with them.
+	if ghErr, ok := err.(*github.ErrorResponse); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error removing the labels of runner %d: %w", runnerID, err))
+	}
+
+	return nil
+}
+
+func resourceGithubActionsRunnerLabelsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	runnerID := d.Id()
+	if repoName, id, err := parseTwoPartID(d.Id(), "repository", "runner_id"); err == nil {
+		if err = d.Set("repository", repoName); err != nil {
+			return nil, err
+		}
+		runnerID = id
+	}
+
+	id, err := strconv.ParseInt(runnerID, 10, 64)
+	if err != nil {
+		return nil, unconvertibleIdErr(runnerID, err)
+	}
+	if err = d.Set("runner_id", id); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,166 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubActionsRunnerLabels(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("manages the labels of a runner without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name = "tf-acc-test-%[1]s"
+			}
+
+			resource "github_actions_runner_jit_config" "test" {
+				repository = github_repository.test.name
+				name       = "tf-acc-test-%[1]s"
+				labels     = ["ephemeral"]
+			}
+
+			resource "github_actions_runner_labels" "test" {
+				repository = github_repository.test.name
+				runner_id  = github_actions_runner_jit_config.test.runner_id
+				labels     = ["gpu", "large"]
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_actions_runner_labels.test", "labels.#",
+				"2",
+			),
+			resource.TestCheckTypeSetElemAttr(
+				"github_actions_runner_labels.test", "labels.*",
+				"gpu",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_actions_runner_labels.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubActionsRunnerLabelsWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+	client := meta.(*Owner).v3client
+
+	runner := newFakeResource(t, meta, "github_actions_runner_jit_config")
+	runner.apply(map[string]interface{}{
+		"name":   "builder",
+		"labels": []interface{}{"ephemeral"},
+	})
+	runnerID := mustParseInt64(t, runner.state.ID)
+
+	config := map[string]interface{}{
+		"runner_id": int(runnerID),
+		"labels":    []interface{}{"gpu", "large"},
+	}
+	labels := newFakeResource(t, meta, "github_actions_runner_labels")
+	labels.apply(config)
+	labels.expectNoChanges(config)
+	if labels.state.ID != runner.state.ID || labels.get("labels.#") != "2" {
+		t.Fatalf("Unexpected state: %v", labels.state.Attributes)
+	}
+
+	// The labels GitHub gives runners from their platform are left alone.
+	current, _, err := client.Actions.GetOrganizationRunner(context.Background(), fakeOrganization, runnerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(current.Labels) != 5 {
+		t.Fatalf("Expected the read-only labels to be kept, got %d labels", len(current.Labels))
+	}
+
+	config["labels"] = []interface{}{"gpu"}
+	labels.apply(config)
+	labels.expectNoChanges(config)
+
+	// Labels changed outside of Terraform are detected.
+	path := fmt.Sprintf("orgs/%s/actions/runners", fakeOrganization)
+	if err := setActionsRunnerLabels(context.Background(), client, path, runnerID, []string{"cpu"}); err != nil {
+		t.Fatal(err)
+	}
+	labels.refresh()
+	labels.expectChanges(config)
+	labels.apply(config)
+
+	imported := newFakeResource(t, meta, "github_actions_runner_labels")
+	imported.importState(labels.state.ID)
+	imported.expectNoChanges(config)
+
+	labels.destroy()
+	current, _, err = client.Actions.GetOrganizationRunner(context.Background(), fakeOrganization, runnerID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(customActionsRunnerLabels(current.Labels)) != 0 {
+		t.Fatalf("Expected the custom labels to be removed, got %v", current.Labels)
+	}
+
+	newFakeRepository(t, meta, "service")
+	repoRunner := newFakeResource(t, meta, "github_actions_runner_jit_config")
+	repoRunner.apply(map[string]interface{}{
+		"repository": "service",
+		"name":       "builder",
+		"labels":     []interface{}{"ephemeral"},
+	})
+	repoConfig := map[string]interface{}{
+		"repository": "service",
+		"runner_id":  int(mustParseInt64(t, repoRunner.state.ID)),
+		"labels":     []interface{}{"gpu"},
+	}
+	repoLabels := newFakeResource(t, meta, "github_actions_runner_labels")
+	repoLabels.apply(repoConfig)
+	if repoLabels.state.ID != "service:"+repoRunner.state.ID {
+		t.Fatalf("Unexpected ID %q", repoLabels.state.ID)
+	}
+
+	importedRepoLabels := newFakeResource(t, meta, "github_actions_runner_labels")
+	importedRepoLabels.importState(repoLabels.state.ID)
+	importedRepoLabels.expectNoChanges(repoConfig)
+
+	// The labels go away with their runner.
+	repoRunner.destroy()
+	repoLabels.refresh()
+	if repoLabels.state != nil {
+		t.Fatal("Expected the labels of a removed runner to be removed from state")
+	}
+	importedRepoLabels.destroy()
+}
@@ -296,3 +296,47 @@ func TestAccGithubActionsSecret(t *testing.T) {
 
 	})
 }
+
+func TestGithubActionsSecretWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+
+	config := map[string]interface{}{
+		"repository":      "service",
+		"secret_name":     "TOKEN",
+		"plaintext_value": "super-secret",
+	}
+
+	secret := newFakeResource(t, meta, "github_actions_secret")
+	secret.apply(config)
+	value, ok := srv.Secret(fakeOrganization, "service", "TOKEN")
+	if !ok || value == "" || strings.Contains(value, "super-secret") {
+		t.Fatalf("Expected the secret to be stored encrypted, got: %q", value)
+	}
+	secret.refresh()
+	secret.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_actions_secret")
+	imported.importState("service/TOKEN")
+	if imported.get("created_at") != secret.get("created_at") {
+		t.Fatalf("Unexpected imported creation time: %q", imported.get("created_at"))
+	}
+
+	// A secret updated outside of Terraform is recreated, as its value is unknown
+	other := newFakeResource(t, meta, "github_actions_secret")
+	other.apply(map[string]interface{}{
+		"repository":      "service",
+		"secret_name":     "TOKEN",
+		"plaintext_value": "changed-elsewhere",
+	})
+	secret.refresh()
+	if secret.state != nil {
+		t.Fatal("Expected a secret updated outside of Terraform to be removed from state")
+	}
+
+	other.destroy()
+	if _, ok := srv.Secret(fakeOrganization, "service", "TOKEN"); ok {
+		t.Fatal("Expected the secret to be deleted")
+	}
+}
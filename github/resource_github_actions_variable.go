@@ -6,15 +6,16 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubActionsVariable() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubActionsVariableCreate,
-		Read:   resourceGithubActionsVariableRead,
-		Update: resourceGithubActionsVariableUpdate,
-		Delete: resourceGithubActionsVariableDelete,
+		CreateContext: resourceGithubActionsVariableCreate,
+		ReadContext:   resourceGithubActionsVariableRead,
+		UpdateContext: resourceGithubActionsVariableUpdate,
+		DeleteContext: resourceGithubActionsVariableDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -51,10 +52,9 @@ func resourceGithubActionsVariable() *schema.Resource {
 	}
 }
 
-func resourceGithubActionsVariableCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsVariableCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repo := d.Get("repository").(string)
 	variable := &github.ActionsVariable{
@@ -64,17 +64,16 @@ func resourceGithubActionsVariableCreate(d *schema.ResourceData, meta interface{
 
 	_, err := client.Actions.CreateRepoVariable(ctx, owner, repo, variable)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repo, d.Get("variable_name").(string)))
-	return resourceGithubActionsVariableRead(d, meta)
+	return resourceGithubActionsVariableRead(ctx, d, meta)
 }
 
-func resourceGithubActionsVariableUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsVariableUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repo := d.Get("repository").(string)
 	variable := &github.ActionsVariable{
@@ -84,21 +83,20 @@ func resourceGithubActionsVariableUpdate(d *schema.ResourceData, meta interface{
 
 	_, err := client.Actions.UpdateRepoVariable(ctx, owner, repo, variable)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repo, d.Get("variable_name").(string)))
-	return resourceGithubActionsVariableRead(d, meta)
+	return resourceGithubActionsVariableRead(ctx, d, meta)
 }
 
-func resourceGithubActionsVariableRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsVariableRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repoName, variableName, err := parseTwoPartID(d.Id(), "repository", "variable_name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	variable, _, err := client.Actions.GetRepoVariable(ctx, owner, repoName, variableName)
@@ -111,39 +109,39 @@ func resourceGithubActionsVariableRead(d *schema.ResourceData, meta interface{})
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("repository", repoName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("variable_name", variableName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("value", variable.Value); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("created_at", variable.CreatedAt.String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("updated_at", variable.UpdatedAt.String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubActionsVariableDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsVariableDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	repoName, variableName, err := parseTwoPartID(d.Id(), "repository", "variable_name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	_, err = client.Actions.DeleteRepoVariable(ctx, orgName, repoName, variableName)
 
-	return err
+	return diag.FromErr(err)
 }
@@ -6,15 +6,16 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubAppInstallationRepositories() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubAppInstallationRepositoriesCreateOrUpdate,
-		Read:   resourceGithubAppInstallationRepositoriesRead,
-		Update: resourceGithubAppInstallationRepositoriesCreateOrUpdate,
-		Delete: resourceGithubAppInstallationRepositoriesDelete,
+		CreateContext: resourceGithubAppInstallationRepositoriesCreateOrUpdate,
+		ReadContext:   resourceGithubAppInstallationRepositoriesRead,
+		UpdateContext: resourceGithubAppInstallationRepositoriesCreateOrUpdate,
+		DeleteContext: resourceGithubAppInstallationRepositoriesDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -39,13 +40,13 @@ func resourceGithubAppInstallationRepositories() *schema.Resource {
 	}
 }
 
-func resourceGithubAppInstallationRepositoriesCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubAppInstallationRepositoriesCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	installationIDString := d.Get("installation_id").(string)
 	selectedRepositories := d.Get("selected_repositories")
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, installationIDString)
+	ctx = context.WithValue(ctx, ctxId, installationIDString)
 
 	selectedRepositoryNames := []string{}
 
@@ -54,9 +55,9 @@ func resourceGithubAppInstallationRepositoriesCreateOrUpdate(d *schema.ResourceD
 		selectedRepositoryNames = append(selectedRepositoryNames, name.(string))
 	}
 
-	currentReposNameIDs, instID, err := getAllAccessibleRepos(meta, installationIDString)
+	currentReposNameIDs, instID, err := getAllAccessibleRepos(ctx, meta, installationIDString)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Add repos that are not in the current state on GitHub
@@ -65,15 +66,15 @@ func resourceGithubAppInstallationRepositoriesCreateOrUpdate(d *schema.ResourceD
 			// If it already exists, remove it from the map so we can delete all that are left at the end
 			delete(currentReposNameIDs, repoName)
 		} else {
-			repo, _, err := client.Repositories.Get(ctx, owner, repoName)
+			repo, err := getRepository(ctx, owner, repoName, meta)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			repoID := repo.GetID()
 			log.Printf("[DEBUG]: Adding %v:%v to app installation %v", repoName, repoID, instID)
 			_, _, err = client.Apps.AddRepository(ctx, instID, repoID)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -87,21 +88,21 @@ func resourceGithubAppInstallationRepositoriesCreateOrUpdate(d *schema.ResourceD
 			log.Printf("[DEBUG]: Removing %v:%v from app installation %v", repoName, repoID, instID)
 			_, err = client.Apps.RemoveRepository(ctx, instID, repoID)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 
 	d.SetId(installationIDString)
-	return resourceGithubAppInstallationRepositoriesRead(d, meta)
+	return resourceGithubAppInstallationRepositoriesRead(ctx, d, meta)
 }
 
-func resourceGithubAppInstallationRepositoriesRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubAppInstallationRepositoriesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	installationIDString := d.Id()
 
-	reposNameIDs, _, err := getAllAccessibleRepos(meta, installationIDString)
+	reposNameIDs, _, err := getAllAccessibleRepos(ctx, meta, installationIDString)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	repoNames := []string{}
@@ -111,10 +112,10 @@ func resourceGithubAppInstallationRepositoriesRead(d *schema.ResourceData, meta
 
 	if len(reposNameIDs) > 0 {
 		if err = d.Set("installation_id", installationIDString); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err = d.Set("selected_repositories", repoNames); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		return nil
 	}
@@ -125,16 +126,16 @@ func resourceGithubAppInstallationRepositoriesRead(d *schema.ResourceData, meta
 	return nil
 }
 
-func resourceGithubAppInstallationRepositoriesDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubAppInstallationRepositoriesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	installationIDString := d.Get("installation_id").(string)
 
-	reposNameIDs, instID, err := getAllAccessibleRepos(meta, installationIDString)
+	reposNameIDs, instID, err := getAllAccessibleRepos(ctx, meta, installationIDString)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, installationIDString)
+	ctx = context.WithValue(ctx, ctxId, installationIDString)
 
 	// There is a github limitation that means we can't remove the last repository from an installation.
 	// Therefore, we skip the first and delete the rest. The app will then need to be uninstalled via the GUI
@@ -149,14 +150,14 @@ func resourceGithubAppInstallationRepositoriesDelete(d *schema.ResourceData, met
 			_, err = client.Apps.RemoveRepository(ctx, instID, repoID)
 			log.Printf("[DEBUG]: Removing %v:%v from app installation %v", repoName, repoID, instID)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 	return nil
 }
 
-func getAllAccessibleRepos(meta interface{}, idString string) (map[string]int64, int64, error) {
+func getAllAccessibleRepos(ctx context.Context, meta interface{}, idString string) (map[string]int64, int64, error) {
 	err := checkOrganization(meta)
 	if err != nil {
 		return nil, 0, err
@@ -167,7 +168,7 @@ func getAllAccessibleRepos(meta interface{}, idString string) (map[string]int64,
 		return nil, 0, unconvertibleIdErr(idString, err)
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, idString)
+	ctx = context.WithValue(ctx, ctxId, idString)
 	opt := &github.ListOptions{PerPage: maxPerPage}
 	client := meta.(*Owner).v3client
 
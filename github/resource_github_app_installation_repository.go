@@ -6,14 +6,15 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubAppInstallationRepository() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubAppInstallationRepositoryCreate,
-		Read:   resourceGithubAppInstallationRepositoryRead,
-		Delete: resourceGithubAppInstallationRepositoryDelete,
+		CreateContext: resourceGithubAppInstallationRepositoryCreate,
+		ReadContext:   resourceGithubAppInstallationRepositoryRead,
+		DeleteContext: resourceGithubAppInstallationRepositoryDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -39,73 +40,72 @@ func resourceGithubAppInstallationRepository() *schema.Resource {
 	}
 }
 
-func resourceGithubAppInstallationRepositoryCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubAppInstallationRepositoryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	installationIDString := d.Get("installation_id").(string)
 	installationID, err := strconv.ParseInt(installationIDString, 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(installationIDString, err)
+		return diag.FromErr(unconvertibleIdErr(installationIDString, err))
 	}
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 	repoName := d.Get("repository").(string)
-	repo, _, err := client.Repositories.Get(ctx, owner, repoName)
+	repo, err := getRepository(ctx, owner, repoName, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	repoID := repo.GetID()
 
 	_, _, err = client.Apps.AddRepository(ctx, installationID, repoID)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(installationIDString, repoName))
-	return resourceGithubAppInstallationRepositoryRead(d, meta)
+	return resourceGithubAppInstallationRepositoryRead(ctx, d, meta)
 }
 
-func resourceGithubAppInstallationRepositoryRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubAppInstallationRepositoryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	installationIDString, repoName, err := parseTwoPartID(d.Id(), "installation_id", "repository")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	installationID, err := strconv.ParseInt(installationIDString, 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(installationIDString, err)
+		return diag.FromErr(unconvertibleIdErr(installationIDString, err))
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	opt := &github.ListOptions{PerPage: maxPerPage}
 
 	for {
 		repos, resp, err := client.Apps.ListUserRepos(ctx, installationID, opt)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		for _, r := range repos.Repositories {
 			if r.GetName() == repoName {
 				if err = d.Set("installation_id", installationIDString); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 				if err = d.Set("repository", repoName); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 				if err = d.Set("repo_id", r.GetID()); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 				return nil
 			}
@@ -123,26 +123,25 @@ func resourceGithubAppInstallationRepositoryRead(d *schema.ResourceData, meta in
 	return nil
 }
 
-func resourceGithubAppInstallationRepositoryDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubAppInstallationRepositoryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	installationIDString := d.Get("installation_id").(string)
 	installationID, err := strconv.ParseInt(installationIDString, 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(installationIDString, err)
+		return diag.FromErr(unconvertibleIdErr(installationIDString, err))
 	}
 
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	repoID := d.Get("repo_id").(int)
 
 	_, err = client.Apps.RemoveRepository(ctx, installationID, int64(repoID))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
@@ -8,16 +8,17 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubBranch() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubBranchCreate,
-		Read:   resourceGithubBranchRead,
-		Delete: resourceGithubBranchDelete,
+		CreateContext: resourceGithubBranchCreate,
+		ReadContext:   resourceGithubBranchRead,
+		DeleteContext: resourceGithubBranchDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubBranchImport,
+			StateContext: resourceGithubBranchImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -66,8 +67,7 @@ func resourceGithubBranch() *schema.Resource {
 	}
 }
 
-func resourceGithubBranchCreate(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.Background()
+func resourceGithubBranchCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxId, d.Id())
 	}
@@ -83,11 +83,11 @@ func resourceGithubBranchCreate(d *schema.ResourceData, meta interface{}) error
 	if _, hasSourceSHA := d.GetOk("source_sha"); !hasSourceSHA {
 		ref, _, err := client.Git.GetRef(ctx, orgName, repoName, sourceBranchRefName)
 		if err != nil {
-			return fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
-				orgName, repoName, sourceBranchRefName, err)
+			return diag.FromErr(fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
+				orgName, repoName, sourceBranchRefName, err))
 		}
 		if err = d.Set("source_sha", *ref.Object.SHA); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	sourceBranchSHA := d.Get("source_sha").(string)
@@ -99,17 +99,17 @@ func resourceGithubBranchCreate(d *schema.ResourceData, meta interface{}) error
 	// If the branch already exists, rather than erroring out just continue on to importing the branch
 	//   This avoids the case where a repo with gitignore_template and branch are being created at the same time crashing terraform
 	if err != nil && !strings.HasSuffix(err.Error(), "422 Reference already exists []") {
-		return fmt.Errorf("error creating GitHub branch reference %s/%s (%s): %s",
-			orgName, repoName, branchRefName, err)
+		return diag.FromErr(fmt.Errorf("error creating GitHub branch reference %s/%s (%s): %s",
+			orgName, repoName, branchRefName, err))
 	}
 
 	d.SetId(buildTwoPartID(repoName, branchName))
 
-	return resourceGithubBranchRead(d, meta)
+	return resourceGithubBranchRead(ctx, d, meta)
 }
 
-func resourceGithubBranchRead(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+func resourceGithubBranchRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -118,7 +118,7 @@ func resourceGithubBranchRead(d *schema.ResourceData, meta interface{}) error {
 	orgName := meta.(*Owner).name
 	repoName, branchName, err := parseTwoPartID(d.Id(), "repository", "branch")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	branchRefName := "refs/heads/" + branchName
 
@@ -135,51 +135,51 @@ func resourceGithubBranchRead(d *schema.ResourceData, meta interface{}) error {
 				return nil
 			}
 		}
-		return fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
-			orgName, repoName, branchRefName, err)
+		return diag.FromErr(fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
+			orgName, repoName, branchRefName, err))
 	}
 
 	d.SetId(buildTwoPartID(repoName, branchName))
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("repository", repoName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("branch", branchName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("ref", *ref.Ref); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("sha", *ref.Object.SHA); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubBranchDelete(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+func resourceGithubBranchDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	repoName, branchName, err := parseTwoPartID(d.Id(), "repository", "branch")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	branchRefName := "refs/heads/" + branchName
 
 	_, err = client.Git.DeleteRef(ctx, orgName, repoName, branchRefName)
 	if err != nil {
-		return fmt.Errorf("error deleting GitHub branch reference %s/%s (%s): %s",
-			orgName, repoName, branchRefName, err)
+		return diag.FromErr(fmt.Errorf("error deleting GitHub branch reference %s/%s (%s): %s",
+			orgName, repoName, branchRefName, err))
 	}
 
 	return nil
 }
 
-func resourceGithubBranchImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubBranchImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	repoName, branchName, err := parseTwoPartID(d.Id(), "repository", "branch")
 	if err != nil {
 		return nil, err
@@ -198,8 +198,7 @@ func resourceGithubBranchImport(d *schema.ResourceData, meta interface{}) ([]*sc
 		return nil, err
 	}
 
-	err = resourceGithubBranchRead(d, meta)
-	if err != nil {
+	if err = errorFromDiagnostics(resourceGithubBranchRead(ctx, d, meta)); err != nil {
 		return nil, err
 	}
 
@@ -6,15 +6,16 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubBranchDefault() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubBranchDefaultCreate,
-		Read:   resourceGithubBranchDefaultRead,
-		Delete: resourceGithubBranchDefaultDelete,
-		Update: resourceGithubBranchDefaultUpdate,
+		CreateContext: resourceGithubBranchDefaultCreate,
+		ReadContext:   resourceGithubBranchDefaultRead,
+		DeleteContext: resourceGithubBranchDefaultDelete,
+		UpdateContext: resourceGithubBranchDefaultUpdate,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -45,7 +46,7 @@ func resourceGithubBranchDefault() *schema.Resource {
 	}
 }
 
-func resourceGithubBranchDefaultCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchDefaultCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
@@ -53,15 +54,13 @@ func resourceGithubBranchDefaultCreate(d *schema.ResourceData, meta interface{})
 	defaultBranch := d.Get("branch").(string)
 	rename := d.Get("rename").(bool)
 
-	ctx := context.Background()
-
 	if rename {
 		repository, _, err := client.Repositories.Get(ctx, owner, repoName)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if _, _, err := client.Repositories.RenameBranch(ctx, owner, repoName, *repository.DefaultBranch, defaultBranch); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		repository := &github.Repository{
@@ -69,22 +68,23 @@ func resourceGithubBranchDefaultCreate(d *schema.ResourceData, meta interface{})
 		}
 
 		if _, _, err := client.Repositories.Edit(ctx, owner, repoName, repository); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
+	meta.(*Owner).cache.invalidate(repositoryCacheObject(owner, repoName))
 
 	d.SetId(repoName)
 
-	return resourceGithubBranchDefaultRead(d, meta)
+	return resourceGithubBranchDefaultRead(ctx, d, meta)
 }
 
-func resourceGithubBranchDefaultRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchDefaultRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	repoName := d.Id()
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -102,7 +102,7 @@ func resourceGithubBranchDefaultRead(d *schema.ResourceData, meta interface{}) e
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if repository.DefaultBranch == nil {
@@ -116,7 +116,7 @@ func resourceGithubBranchDefaultRead(d *schema.ResourceData, meta interface{}) e
 	return nil
 }
 
-func resourceGithubBranchDefaultDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchDefaultDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
@@ -126,13 +126,12 @@ func resourceGithubBranchDefaultDelete(d *schema.ResourceData, meta interface{})
 		DefaultBranch: nil,
 	}
 
-	ctx := context.Background()
-
 	_, _, err := client.Repositories.Edit(ctx, owner, repoName, repository)
-	return err
+	meta.(*Owner).cache.invalidate(repositoryCacheObject(owner, repoName))
+	return diag.FromErr(err)
 }
 
-func resourceGithubBranchDefaultUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchDefaultUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
@@ -140,15 +139,13 @@ func resourceGithubBranchDefaultUpdate(d *schema.ResourceData, meta interface{})
 	defaultBranch := d.Get("branch").(string)
 	rename := d.Get("rename").(bool)
 
-	ctx := context.Background()
-
 	if rename {
 		repository, _, err := client.Repositories.Get(ctx, owner, repoName)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if _, _, err := client.Repositories.RenameBranch(ctx, owner, repoName, *repository.DefaultBranch, defaultBranch); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		repository := &github.Repository{
@@ -156,9 +153,10 @@ func resourceGithubBranchDefaultUpdate(d *schema.ResourceData, meta interface{})
 		}
 
 		if _, _, err := client.Repositories.Edit(ctx, owner, repoName, repository); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
+	meta.(*Owner).cache.invalidate(repositoryCacheObject(owner, repoName))
 
-	return resourceGithubBranchDefaultRead(d, meta)
+	return resourceGithubBranchDefaultRead(ctx, d, meta)
 }
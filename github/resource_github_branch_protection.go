@@ -6,6 +6,7 @@ import (
 	"log"
 	"strings"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 	"github.com/shurcooL/githubv4"
@@ -168,13 +169,13 @@ func resourceGithubBranchProtection() *schema.Resource {
 			},
 		},
 
-		Create: resourceGithubBranchProtectionCreate,
-		Read:   resourceGithubBranchProtectionRead,
-		Update: resourceGithubBranchProtectionUpdate,
-		Delete: resourceGithubBranchProtectionDelete,
+		CreateContext: resourceGithubBranchProtectionCreate,
+		ReadContext:   resourceGithubBranchProtectionRead,
+		UpdateContext: resourceGithubBranchProtectionUpdate,
+		DeleteContext: resourceGithubBranchProtectionDelete,
 
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubBranchProtectionImport,
+			StateContext: resourceGithubBranchProtectionImport,
 		},
 
 		StateUpgraders: []schema.StateUpgrader{
@@ -192,7 +193,7 @@ func resourceGithubBranchProtection() *schema.Resource {
 	}
 }
 
-func resourceGithubBranchProtectionCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchProtectionCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var mutate struct {
 		CreateBranchProtectionRule struct {
 			BranchProtectionRule struct {
@@ -200,30 +201,30 @@ func resourceGithubBranchProtectionCreate(d *schema.ResourceData, meta interface
 			}
 		} `graphql:"createBranchProtectionRule(input: $input)"`
 	}
-	data, err := branchProtectionResourceData(d, meta)
+	data, err := branchProtectionResourceData(ctx, d, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var reviewIds, pushIds, bypassForcePushIds, bypassPullRequestIds []string
-	reviewIds, err = getActorIds(data.ReviewDismissalActorIDs, meta)
+	reviewIds, err = getActorIds(ctx, data.ReviewDismissalActorIDs, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	pushIds, err = getActorIds(data.PushActorIDs, meta)
+	pushIds, err = getActorIds(ctx, data.PushActorIDs, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	bypassForcePushIds, err = getActorIds(data.BypassForcePushActorIDs, meta)
+	bypassForcePushIds, err = getActorIds(ctx, data.BypassForcePushActorIDs, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	bypassPullRequestIds, err = getActorIds(data.BypassPullRequestActorIDs, meta)
+	bypassPullRequestIds, err = getActorIds(ctx, data.BypassPullRequestActorIDs, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	data.PushActorIDs = pushIds
@@ -258,19 +259,18 @@ func resourceGithubBranchProtectionCreate(d *schema.ResourceData, meta interface
 		RequireLastPushApproval:        githubv4.NewBoolean(githubv4.Boolean(data.RequireLastPushApproval)),
 	}
 
-	ctx := context.Background()
 	client := meta.(*Owner).v4client
 	err = client.Mutate(ctx, &mutate, input, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s", mutate.CreateBranchProtectionRule.BranchProtectionRule.ID))
 
-	return resourceGithubBranchProtectionRead(d, meta)
+	return resourceGithubBranchProtectionRead(ctx, d, meta)
 }
 
-func resourceGithubBranchProtectionRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchProtectionRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var query struct {
 		Node struct {
 			Node BranchProtectionRule `graphql:"... on BranchProtectionRule"`
@@ -279,7 +279,7 @@ func resourceGithubBranchProtectionRead(d *schema.ResourceData, meta interface{}
 	variables := map[string]interface{}{
 		"id": d.Id(),
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	client := meta.(*Owner).v4client
 	err := client.Query(ctx, &query, variables)
 	if err != nil {
@@ -289,7 +289,7 @@ func resourceGithubBranchProtectionRead(d *schema.ResourceData, meta interface{}
 			return nil
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 	protection := query.Node.Node
 
@@ -330,7 +330,7 @@ func resourceGithubBranchProtectionRead(d *schema.ResourceData, meta interface{}
 
 	data, err := branchProtectionResourceDataActors(d, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	approvingReviews := setApprovingReviews(protection, data, meta)
@@ -365,7 +365,7 @@ func resourceGithubBranchProtectionRead(d *schema.ResourceData, meta interface{}
 	return nil
 }
 
-func resourceGithubBranchProtectionUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchProtectionUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var mutate struct {
 		UpdateBranchProtectionRule struct {
 			BranchProtectionRule struct {
@@ -373,30 +373,30 @@ func resourceGithubBranchProtectionUpdate(d *schema.ResourceData, meta interface
 			}
 		} `graphql:"updateBranchProtectionRule(input: $input)"`
 	}
-	data, err := branchProtectionResourceData(d, meta)
+	data, err := branchProtectionResourceData(ctx, d, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var reviewIds, pushIds, bypassForcePushIds, bypassPullRequestIds []string
-	reviewIds, err = getActorIds(data.ReviewDismissalActorIDs, meta)
+	reviewIds, err = getActorIds(ctx, data.ReviewDismissalActorIDs, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	pushIds, err = getActorIds(data.PushActorIDs, meta)
+	pushIds, err = getActorIds(ctx, data.PushActorIDs, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	bypassForcePushIds, err = getActorIds(data.BypassForcePushActorIDs, meta)
+	bypassForcePushIds, err = getActorIds(ctx, data.BypassForcePushActorIDs, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	bypassPullRequestIds, err = getActorIds(data.BypassPullRequestActorIDs, meta)
+	bypassPullRequestIds, err = getActorIds(ctx, data.BypassPullRequestActorIDs, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	data.PushActorIDs = pushIds
@@ -431,19 +431,19 @@ func resourceGithubBranchProtectionUpdate(d *schema.ResourceData, meta interface
 		RequireLastPushApproval:        githubv4.NewBoolean(githubv4.Boolean(data.RequireLastPushApproval)),
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	client := meta.(*Owner).v4client
 	err = client.Mutate(ctx, &mutate, input, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s", mutate.UpdateBranchProtectionRule.BranchProtectionRule.ID))
 
-	return resourceGithubBranchProtectionRead(d, meta)
+	return resourceGithubBranchProtectionRead(ctx, d, meta)
 }
 
-func resourceGithubBranchProtectionDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchProtectionDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var mutate struct {
 		DeleteBranchProtectionRule struct { // Empty struct does not work
 			ClientMutationId githubv4.ID
@@ -453,20 +453,20 @@ func resourceGithubBranchProtectionDelete(d *schema.ResourceData, meta interface
 		BranchProtectionRuleID: d.Id(),
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	client := meta.(*Owner).v4client
 	err := client.Mutate(ctx, &mutate, input, nil)
 
-	return err
+	return diag.FromErr(err)
 }
 
-func resourceGithubBranchProtectionImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubBranchProtectionImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	repoName, pattern, err := parseTwoPartID(d.Id(), "repository", "pattern")
 	if err != nil {
 		return nil, err
 	}
 
-	repoID, err := getRepositoryID(repoName, meta)
+	repoID, err := getRepositoryID(ctx, repoName, meta)
 	if err != nil {
 		return nil, err
 	}
@@ -474,11 +474,11 @@ func resourceGithubBranchProtectionImport(d *schema.ResourceData, meta interface
 		return nil, err
 	}
 
-	id, err := getBranchProtectionID(repoID, pattern, meta)
+	id, err := getBranchProtectionID(ctx, repoID, pattern, meta)
 	if err != nil {
 		return nil, err
 	}
 	d.SetId(fmt.Sprintf("%s", id))
 
-	return []*schema.ResourceData{d}, resourceGithubBranchProtectionRead(d, meta)
+	return []*schema.ResourceData{d}, errorFromDiagnostics(resourceGithubBranchProtectionRead(ctx, d, meta))
 }
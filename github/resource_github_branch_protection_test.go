@@ -982,3 +982,64 @@ func TestAccGithubBranchProtectionV4StateUpgradeV1(t *testing.T) {
 		t.Fatalf("\n\nexpected:\n\n%#v\n\ngot:\n\n%#v\n\n", expected, actual)
 	}
 }
+
+func TestGithubBranchProtectionWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+	srv.AddUser("reviewer")
+
+	config := map[string]interface{}{
+		"repository_id":  "service",
+		"pattern":        "main",
+		"enforce_admins": true,
+		"required_pull_request_reviews": []interface{}{map[string]interface{}{
+			"required_approving_review_count": 2,
+			"restrict_dismissals":             true,
+			"dismissal_restrictions":          []interface{}{"/reviewer"},
+		}},
+		"required_status_checks": []interface{}{map[string]interface{}{
+			"strict":   true,
+			"contexts": []interface{}{"ci/build"},
+		}},
+	}
+
+	protection := newFakeResource(t, meta, "github_branch_protection")
+	protection.apply(config)
+	rule := srv.BranchProtectionRule(protection.state.ID)
+	if rule == nil {
+		t.Fatal("Expected a branch protection rule to be created")
+	}
+	if !rule.IsAdminEnforced || rule.RequiredApprovingReviewCount != 2 || len(rule.ReviewDismissalActorIDs) != 1 {
+		t.Fatalf("Unexpected branch protection rule: %+v", rule)
+	}
+	if count := protection.get("required_pull_request_reviews.0.required_approving_review_count"); count != "2" {
+		t.Fatalf("Unexpected required approving review count: %q", count)
+	}
+	protection.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_branch_protection")
+	imported.importState("service:main")
+	if imported.state.ID != protection.state.ID {
+		t.Fatalf("Expected the rule to be imported by pattern, got ID %q", imported.state.ID)
+	}
+
+	srv.BranchProtectionRule(protection.state.ID).IsAdminEnforced = false
+	protection.refresh()
+	protection.expectChanges(config)
+	protection.apply(config)
+	if !srv.BranchProtectionRule(protection.state.ID).IsAdminEnforced {
+		t.Fatal("Expected the drift to be corrected")
+	}
+
+	id := protection.state.ID
+	protection.destroy()
+	if srv.BranchProtectionRule(id) != nil {
+		t.Fatal("Expected the branch protection rule to be deleted")
+	}
+	protection.state = &terraform.InstanceState{ID: id}
+	protection.refresh()
+	if protection.state != nil {
+		t.Fatal("Expected a deleted branch protection rule to be removed from state")
+	}
+}
@@ -7,16 +7,17 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubBranchProtectionV3() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubBranchProtectionV3Create,
-		Read:   resourceGithubBranchProtectionV3Read,
-		Update: resourceGithubBranchProtectionV3Update,
-		Delete: resourceGithubBranchProtectionV3Delete,
+		CreateContext: resourceGithubBranchProtectionV3Create,
+		ReadContext:   resourceGithubBranchProtectionV3Read,
+		UpdateContext: resourceGithubBranchProtectionV3Update,
+		DeleteContext: resourceGithubBranchProtectionV3Delete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -215,10 +216,10 @@ func resourceGithubBranchProtectionV3() *schema.Resource {
 	}
 }
 
-func resourceGithubBranchProtectionV3Create(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchProtectionV3Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -229,9 +230,8 @@ func resourceGithubBranchProtectionV3Create(d *schema.ResourceData, meta interfa
 
 	protectionRequest, err := buildProtectionRequest(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.Background()
 
 	protection, _, err := client.Repositories.UpdateBranchProtection(ctx,
 		orgName,
@@ -240,37 +240,37 @@ func resourceGithubBranchProtectionV3Create(d *schema.ResourceData, meta interfa
 		protectionRequest,
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := checkBranchRestrictionsUsers(protection.GetRestrictions(), protectionRequest.GetRestrictions()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repoName, branch))
 
-	if err = requireSignedCommitsUpdate(d, meta); err != nil {
-		return err
+	if err = requireSignedCommitsUpdate(ctx, d, meta); err != nil {
+		return attributeDiagnostics(err, "require_signed_commits")
 	}
 
-	return resourceGithubBranchProtectionV3Read(d, meta)
+	return resourceGithubBranchProtectionV3Read(ctx, d, meta)
 }
 
-func resourceGithubBranchProtectionV3Read(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchProtectionV3Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 
 	repoName, branch, err := parseTwoPartID(d.Id(), "repository", "branch")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	orgName := meta.(*Owner).name
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -280,8 +280,8 @@ func resourceGithubBranchProtectionV3Read(d *schema.ResourceData, meta interface
 	if err != nil {
 		if ghErr, ok := err.(*github.ErrorResponse); ok {
 			if ghErr.Response.StatusCode == http.StatusNotModified {
-				if err := requireSignedCommitsRead(d, meta); err != nil {
-					return fmt.Errorf("error setting signed commit restriction: %v", err)
+				if err := requireSignedCommitsRead(ctx, d, meta); err != nil {
+					return diag.FromErr(fmt.Errorf("error setting signed commit restriction: %v", err))
 				}
 				return nil
 			}
@@ -293,65 +293,65 @@ func resourceGithubBranchProtectionV3Read(d *schema.ResourceData, meta interface
 			}
 		}
 
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("repository", repoName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("branch", branch); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("enforce_admins", githubProtection.GetEnforceAdmins().Enabled); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if rcr := githubProtection.GetRequiredConversationResolution(); rcr != nil {
 		if err = d.Set("require_conversation_resolution", rcr.Enabled); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	if err := flattenAndSetRequiredStatusChecks(d, githubProtection); err != nil {
-		return fmt.Errorf("error setting required_status_checks: %v", err)
+		return diag.FromErr(fmt.Errorf("error setting required_status_checks: %v", err))
 	}
 
 	if err := flattenAndSetRequiredPullRequestReviews(d, githubProtection); err != nil {
-		return fmt.Errorf("error setting required_pull_request_reviews: %v", err)
+		return diag.FromErr(fmt.Errorf("error setting required_pull_request_reviews: %v", err))
 	}
 
 	if err := flattenAndSetRestrictions(d, githubProtection); err != nil {
-		return fmt.Errorf("error setting restrictions: %v", err)
+		return diag.FromErr(fmt.Errorf("error setting restrictions: %v", err))
 	}
 
-	if err := requireSignedCommitsRead(d, meta); err != nil {
-		return fmt.Errorf("error setting signed commit restriction: %v", err)
+	if err := requireSignedCommitsRead(ctx, d, meta); err != nil {
+		return diag.FromErr(fmt.Errorf("error setting signed commit restriction: %v", err))
 	}
 
 	return nil
 }
 
-func resourceGithubBranchProtectionV3Update(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchProtectionV3Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	repoName, branch, err := parseTwoPartID(d.Id(), "repository", "branch")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	protectionRequest, err := buildProtectionRequest(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	protection, _, err := client.Repositories.UpdateBranchProtection(ctx,
 		orgName,
@@ -360,11 +360,11 @@ func resourceGithubBranchProtectionV3Update(d *schema.ResourceData, meta interfa
 		protectionRequest,
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err := checkBranchRestrictionsUsers(protection.GetRestrictions(), protectionRequest.GetRestrictions()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if protectionRequest.RequiredPullRequestReviews == nil {
@@ -374,35 +374,35 @@ func resourceGithubBranchProtectionV3Update(d *schema.ResourceData, meta interfa
 			branch,
 		)
 		if err != nil {
-			return err
+			return attributeDiagnostics(err, "required_pull_request_reviews")
 		}
 	}
 
 	d.SetId(buildTwoPartID(repoName, branch))
 
-	if err = requireSignedCommitsUpdate(d, meta); err != nil {
-		return err
+	if err = requireSignedCommitsUpdate(ctx, d, meta); err != nil {
+		return attributeDiagnostics(err, "require_signed_commits")
 	}
 
-	return resourceGithubBranchProtectionV3Read(d, meta)
+	return resourceGithubBranchProtectionV3Read(ctx, d, meta)
 }
 
-func resourceGithubBranchProtectionV3Delete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubBranchProtectionV3Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	repoName, branch, err := parseTwoPartID(d.Id(), "repository", "branch")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Repositories.RemoveBranchProtection(ctx,
 		orgName, repoName, branch)
-	return err
+	return diag.FromErr(err)
 }
@@ -78,7 +78,7 @@ func flattenAndSetRequiredStatusChecks(d *schema.ResourceData, protection *githu
 	return d.Set("required_status_checks", []interface{}{})
 }
 
-func requireSignedCommitsRead(d *schema.ResourceData, meta interface{}) error {
+func requireSignedCommitsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) error {
 	client := meta.(*Owner).v3client
 
 	repoName, branch, err := parseTwoPartID(d.Id(), "repository", "branch")
@@ -87,7 +87,7 @@ func requireSignedCommitsRead(d *schema.ResourceData, meta interface{}) error {
 	}
 	orgName := meta.(*Owner).name
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -102,7 +102,7 @@ func requireSignedCommitsRead(d *schema.ResourceData, meta interface{}) error {
 	return d.Set("require_signed_commits", signedCommitStatus.Enabled)
 }
 
-func requireSignedCommitsUpdate(d *schema.ResourceData, meta interface{}) (err error) {
+func requireSignedCommitsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) (err error) {
 	requiredSignedCommit := d.Get("require_signed_commits").(bool)
 	client := meta.(*Owner).v3client
 
@@ -112,7 +112,7 @@ func requireSignedCommitsUpdate(d *schema.ResourceData, meta interface{}) (err e
 	}
 	orgName := meta.(*Owner).name
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
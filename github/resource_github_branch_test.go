@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"regexp"
 	"testing"
@@ -210,3 +211,45 @@ func TestAccGithubBranch(t *testing.T) {
 	})
 
 }
+
+func TestGithubBranchWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+
+	config := map[string]interface{}{
+		"repository": "service",
+		"branch":     "feature/one",
+	}
+
+	branch := newFakeResource(t, meta, "github_branch")
+	branch.apply(config)
+	if ref := branch.get("ref"); ref != "refs/heads/feature/one" {
+		t.Fatalf("Unexpected ref: %q", ref)
+	}
+	if branch.get("sha") == "" {
+		t.Fatal("Expected the branch to point at the tip of main")
+	}
+	branch.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_branch")
+	imported.importState("service:feature/one")
+	if imported.get("sha") != branch.get("sha") {
+		t.Fatalf("Unexpected imported sha: %q", imported.get("sha"))
+	}
+
+	client := meta.(*Owner).v3client
+	if _, err := client.Git.DeleteRef(context.Background(), fakeOrganization, "service", "refs/heads/feature/one"); err != nil {
+		t.Fatal(err)
+	}
+	branch.refresh()
+	if branch.state != nil {
+		t.Fatal("Expected a branch deleted outside of Terraform to be removed from state")
+	}
+
+	branch.apply(config)
+	branch.destroy()
+	if _, _, err := client.Git.GetRef(context.Background(), fakeOrganization, "service", "refs/heads/feature/one"); err == nil {
+		t.Fatal("Expected the branch to be deleted")
+	}
+}
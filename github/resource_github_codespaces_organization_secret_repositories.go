@@ -4,15 +4,16 @@ import (
 	"context"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubCodespacesOrganizationSecretRepositories() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubCodespaceOrganizationSecretRepositoriesCreateOrUpdate,
-		Read:   resourceGithubCodespaceOrganizationSecretRepositoriesRead,
-		Update: resourceGithubCodespaceOrganizationSecretRepositoriesCreateOrUpdate,
-		Delete: resourceGithubCodespaceOrganizationSecretRepositoriesDelete,
+		CreateContext: resourceGithubCodespaceOrganizationSecretRepositoriesCreateOrUpdate,
+		ReadContext:   resourceGithubCodespaceOrganizationSecretRepositoriesRead,
+		UpdateContext: resourceGithubCodespaceOrganizationSecretRepositoriesCreateOrUpdate,
+		DeleteContext: resourceGithubCodespaceOrganizationSecretRepositoriesDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -38,14 +39,13 @@ func resourceGithubCodespacesOrganizationSecretRepositories() *schema.Resource {
 	}
 }
 
-func resourceGithubCodespaceOrganizationSecretRepositoriesCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubCodespaceOrganizationSecretRepositoriesCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	secretName := d.Get("secret_name").(string)
@@ -60,21 +60,20 @@ func resourceGithubCodespaceOrganizationSecretRepositoriesCreateOrUpdate(d *sche
 
 	_, err = client.Codespaces.SetSelectedReposForOrgSecret(ctx, owner, secretName, selectedRepositoryIDs)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(secretName)
-	return resourceGithubCodespaceOrganizationSecretRepositoriesRead(d, meta)
+	return resourceGithubCodespaceOrganizationSecretRepositoriesRead(ctx, d, meta)
 }
 
-func resourceGithubCodespaceOrganizationSecretRepositoriesRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubCodespaceOrganizationSecretRepositoriesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedRepositoryIDs := github.SelectedRepoIDs{}
@@ -84,7 +83,7 @@ func resourceGithubCodespaceOrganizationSecretRepositoriesRead(d *schema.Resourc
 	for {
 		results, resp, err := client.Codespaces.ListSelectedReposForOrgSecret(ctx, owner, d.Id(), opt)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		for _, repo := range results.Repositories {
@@ -102,20 +101,20 @@ func resourceGithubCodespaceOrganizationSecretRepositoriesRead(d *schema.Resourc
 	return nil
 }
 
-func resourceGithubCodespaceOrganizationSecretRepositoriesDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubCodespaceOrganizationSecretRepositoriesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedRepositoryIDs := github.SelectedRepoIDs{}
 	_, err = client.Codespaces.SetSelectedReposForOrgSecret(ctx, owner, d.Id(), selectedRepositoryIDs)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -9,16 +9,17 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubCodespacesSecret() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubCodespacesSecretCreateOrUpdate,
-		Read:   resourceGithubCodespacesSecretRead,
-		Delete: resourceGithubCodespacesSecretDelete,
+		CreateContext: resourceGithubCodespacesSecretCreateOrUpdate,
+		ReadContext:   resourceGithubCodespacesSecretRead,
+		DeleteContext: resourceGithubCodespacesSecretDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubCodespacesSecretImport,
+			StateContext: resourceGithubCodespacesSecretImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -65,19 +66,18 @@ func resourceGithubCodespacesSecret() *schema.Resource {
 	}
 }
 
-func resourceGithubCodespacesSecretCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubCodespacesSecretCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repo := d.Get("repository").(string)
 	secretName := d.Get("secret_name").(string)
 	plaintextValue := d.Get("plaintext_value").(string)
 	var encryptedValue string
 
-	keyId, publicKey, err := getCodespacesPublicKeyDetails(owner, repo, meta)
+	keyId, publicKey, err := getCodespacesPublicKeyDetails(ctx, owner, repo, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
@@ -85,7 +85,7 @@ func resourceGithubCodespacesSecretCreateOrUpdate(d *schema.ResourceData, meta i
 	} else {
 		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
 	}
@@ -99,21 +99,20 @@ func resourceGithubCodespacesSecretCreateOrUpdate(d *schema.ResourceData, meta i
 
 	_, err = client.Codespaces.CreateOrUpdateRepoSecret(ctx, owner, repo, eSecret)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repo, secretName))
-	return resourceGithubCodespacesSecretRead(d, meta)
+	return resourceGithubCodespacesSecretRead(ctx, d, meta)
 }
 
-func resourceGithubCodespacesSecretRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubCodespacesSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repoName, secretName, err := parseTwoPartID(d.Id(), "repository", "secret_name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	secret, _, err := client.Codespaces.GetRepoSecret(ctx, owner, repoName, secretName)
@@ -126,17 +125,17 @@ func resourceGithubCodespacesSecretRead(d *schema.ResourceData, meta interface{}
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("encrypted_value", d.Get("encrypted_value")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("plaintext_value", d.Get("plaintext_value")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("created_at", secret.CreatedAt.String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// This is a drift detection mechanism based on timestamps.
@@ -159,33 +158,32 @@ func resourceGithubCodespacesSecretRead(d *schema.ResourceData, meta interface{}
 		d.SetId("")
 	} else if !ok {
 		if err = d.Set("updated_at", secret.UpdatedAt.String()); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceGithubCodespacesSecretDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubCodespacesSecretDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	repoName, secretName, err := parseTwoPartID(d.Id(), "repository", "secret_name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] Deleting secret: %s", d.Id())
 	_, err = client.Codespaces.DeleteRepoSecret(ctx, orgName, repoName, secretName)
 
-	return err
+	return diag.FromErr(err)
 }
 
-func resourceGithubCodespacesSecretImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubCodespacesSecretImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	parts := strings.Split(d.Id(), "/")
 	if len(parts) != 2 {
@@ -223,9 +221,8 @@ func resourceGithubCodespacesSecretImport(d *schema.ResourceData, meta interface
 	return []*schema.ResourceData{d}, nil
 }
 
-func getCodespacesPublicKeyDetails(owner, repository string, meta interface{}) (keyId, pkValue string, err error) {
+func getCodespacesPublicKeyDetails(ctx context.Context, owner, repository string, meta interface{}) (keyId, pkValue string, err error) {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	publicKey, _, err := client.Codespaces.GetRepoPublicKey(ctx, owner, repository)
 	if err != nil {
@@ -7,18 +7,19 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubCodespacesUserSecret() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubCodespacesUserSecretCreateOrUpdate,
-		Read:   resourceGithubCodespacesUserSecretRead,
-		Update: resourceGithubCodespacesUserSecretCreateOrUpdate,
-		Delete: resourceGithubCodespacesUserSecretDelete,
+		CreateContext: resourceGithubCodespacesUserSecretCreateOrUpdate,
+		ReadContext:   resourceGithubCodespacesUserSecretRead,
+		UpdateContext: resourceGithubCodespacesUserSecretCreateOrUpdate,
+		DeleteContext: resourceGithubCodespacesUserSecretDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				if err := d.Set("secret_name", d.Id()); err != nil {
 					return nil, err
 				}
@@ -74,9 +75,8 @@ func resourceGithubCodespacesUserSecret() *schema.Resource {
 	}
 }
 
-func resourceGithubCodespacesUserSecretCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubCodespacesUserSecretCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	secretName := d.Get("secret_name").(string)
 	plaintextValue := d.Get("plaintext_value").(string)
@@ -94,9 +94,9 @@ func resourceGithubCodespacesUserSecretCreateOrUpdate(d *schema.ResourceData, me
 		}
 	}
 
-	keyId, publicKey, err := getCodespacesUserPublicKeyDetails(meta)
+	keyId, publicKey, err := getCodespacesUserPublicKeyDetails(ctx, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
@@ -104,7 +104,7 @@ func resourceGithubCodespacesUserSecretCreateOrUpdate(d *schema.ResourceData, me
 	} else {
 		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
 	}
@@ -119,16 +119,15 @@ func resourceGithubCodespacesUserSecretCreateOrUpdate(d *schema.ResourceData, me
 
 	_, err = client.Codespaces.CreateOrUpdateUserSecret(ctx, eSecret)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(secretName)
-	return resourceGithubCodespacesUserSecretRead(d, meta)
+	return resourceGithubCodespacesUserSecretRead(ctx, d, meta)
 }
 
-func resourceGithubCodespacesUserSecretRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubCodespacesUserSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	secret, _, err := client.Codespaces.GetUserSecret(ctx, d.Id())
 	if err != nil {
@@ -140,17 +139,17 @@ func resourceGithubCodespacesUserSecretRead(d *schema.ResourceData, meta interfa
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("encrypted_value", d.Get("encrypted_value")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("plaintext_value", d.Get("plaintext_value")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("created_at", secret.CreatedAt.String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedRepositoryIDs := []int64{}
@@ -161,7 +160,7 @@ func resourceGithubCodespacesUserSecretRead(d *schema.ResourceData, meta interfa
 	for {
 		results, resp, err := client.Codespaces.ListSelectedReposForUserSecret(ctx, d.Id(), opt)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		for _, repo := range results.Repositories {
@@ -175,7 +174,7 @@ func resourceGithubCodespacesUserSecretRead(d *schema.ResourceData, meta interfa
 	}
 
 	if err = d.Set("selected_repository_ids", selectedRepositoryIDs); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// This is a drift detection mechanism based on timestamps.
@@ -198,25 +197,24 @@ func resourceGithubCodespacesUserSecretRead(d *schema.ResourceData, meta interfa
 		d.SetId("")
 	} else if !ok {
 		if err = d.Set("updated_at", secret.UpdatedAt.String()); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceGithubCodespacesUserSecretDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubCodespacesUserSecretDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	log.Printf("[DEBUG] Deleting secret: %s", d.Id())
 	_, err := client.Codespaces.DeleteUserSecret(ctx, d.Id())
-	return err
+	return diag.FromErr(err)
 }
 
-func getCodespacesUserPublicKeyDetails(meta interface{}) (keyId, pkValue string, err error) {
+func getCodespacesUserPublicKeyDetails(ctx context.Context, meta interface{}) (keyId, pkValue string, err error) {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	publicKey, _, err := client.Codespaces.GetUserPublicKey(ctx)
 	if err != nil {
@@ -0,0 +1,152 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubCopilotOrganizationSeatAssignment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubCopilotOrganizationSeatAssignmentCreate,
+		ReadContext:   resourceGithubCopilotOrganizationSeatAssignmentRead,
+		DeleteContext: resourceGithubCopilotOrganizationSeatAssignmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubCopilotOrganizationSeatAssignmentImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"team_slug": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"team_slug", "username"},
+				Description:  "The slug of the team whose members are assigned a Copilot seat.",
+			},
+			"username": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				ExactlyOneOf:     []string{"team_slug", "username"},
+				DiffSuppressFunc: caseInsensitive(),
+				Description:      "The login of the user assigned a Copilot seat.",
+			},
+		},
+	}
+}
+
+func resourceGithubCopilotOrganizationSeatAssignmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	if teamSlug, ok := d.GetOk("team_slug"); ok {
+		_, _, err = client.Copilot.AddCopilotTeams(ctx, orgName, []string{teamSlug.(string)})
+		if err != nil {
+			return attributeDiagnostics(fmt.Errorf("error assigning Copilot seats to team %s/%s: %w", orgName, teamSlug, err), "team_slug")
+		}
+		d.SetId(buildTwoPartID("team", teamSlug.(string)))
+	} else {
+		username := d.Get("username").(string)
+		_, _, err = client.Copilot.AddCopilotUsers(ctx, orgName, []string{username})
+		if err != nil {
+			return attributeDiagnostics(fmt.Errorf("error assigning a Copilot seat to user %s in %s: %w", username, orgName, err), "username")
+		}
+		d.SetId(buildTwoPartID("user", username))
+	}
+
+	return resourceGithubCopilotOrganizationSeatAssignmentRead(ctx, d, meta)
+}
+
+func resourceGithubCopilotOrganizationSeatAssignmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	seats, err := listActiveCopilotSeats(ctx, client, orgName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Copilot seats of %s: %w", orgName, err))
+	}
+
+	assigned := false
+	if teamSlug, ok := d.GetOk("team_slug"); ok {
+		assigned, err = copilotTeamSeatAssigned(ctx, client, orgName, teamSlug.(string), seats)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	} else {
+		username := d.Get("username").(string)
+		for _, seat := range seats {
+			if user, ok := seat.GetUser(); ok && strings.EqualFold(user.GetLogin(), username) {
+				assigned = true
+				break
+			}
+		}
+	}
+
+	if !assigned {
+		log.Printf("[INFO] Removing Copilot seat assignment %s/%s from state because it no longer exists in GitHub", orgName, d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func resourceGithubCopilotOrganizationSeatAssignmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	if teamSlug, ok := d.GetOk("team_slug"); ok {
+		_, _, err = client.Copilot.RemoveCopilotTeams(ctx, orgName, []string{teamSlug.(string)})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error cancelling the Copilot seats of team %s/%s: %w", orgName, teamSlug, err))
+		}
+	} else {
+		username := d.Get("username").(string)
+		_, _, err = client.Copilot.RemoveCopilotUsers(ctx, orgName, []string{username})
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error cancelling the Copilot seat of user %s in %s: %w", username, orgName, err))
+		}
+	}
+
+	return nil
+}
+
+func resourceGithubCopilotOrganizationSeatAssignmentImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	kind, name, err := parseTwoPartID(d.Id(), "team|user", "name")
+	if err != nil {
+		return nil, err
+	}
+
+	switch kind {
+	case "team":
+		err = d.Set("team_slug", name)
+	case "user":
+		err = d.Set("username", name)
+	default:
+		return nil, fmt.Errorf("unexpected ID format (%q); expected team:<team_slug> or user:<username>", d.Id())
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubCopilotOrganizationSeatAssignment(t *testing.T) {
+	if testCollaborator == "" {
+		t.Skip("Skipping because `GITHUB_TEST_COLLABORATOR` is not set")
+	}
+
+	t.Run("assigns a Copilot seat to a user without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_copilot_organization_seat_assignment" "test" {
+				username = "%s"
+			}
+		`, testCollaborator)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_copilot_organization_seat_assignment.test", "id",
+				"user:"+testCollaborator,
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_copilot_organization_seat_assignment.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubCopilotOrganizationSeatAssignmentWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	srv.AddUser("hubot")
+	newFakeResource(t, meta, "github_membership").apply(map[string]interface{}{"username": "hubot"})
+	newFakeResource(t, meta, "github_team").apply(map[string]interface{}{"name": "engineering"})
+
+	teamConfig := map[string]interface{}{"team_slug": "engineering"}
+	teamSeats := newFakeResource(t, meta, "github_copilot_organization_seat_assignment")
+	teamSeats.apply(teamConfig)
+	if id := teamSeats.state.ID; id != "team:engineering" {
+		t.Fatalf("Unexpected ID: %q", id)
+	}
+	teamSeats.expectNoChanges(teamConfig)
+
+	userConfig := map[string]interface{}{"username": "Hubot"}
+	userSeat := newFakeResource(t, meta, "github_copilot_organization_seat_assignment")
+	userSeat.apply(userConfig)
+	userSeat.expectNoChanges(userConfig)
+
+	imported := newFakeResource(t, meta, "github_copilot_organization_seat_assignment")
+	imported.importState("user:hubot")
+	imported.expectNoChanges(userConfig)
+
+	client := meta.(*Owner).v3client
+	if _, _, err := client.Copilot.RemoveCopilotUsers(context.Background(), fakeOrganization, []string{"hubot"}); err != nil {
+		t.Fatal(err)
+	}
+	userSeat.refresh()
+	if userSeat.state != nil {
+		t.Fatal("Expected a seat cancelled outside of Terraform to be removed from state")
+	}
+
+	// Removing the seats of the team leaves the seats of other teams and users
+	// alone.
+	if _, _, err := client.Copilot.AddCopilotUsers(context.Background(), fakeOrganization, []string{"hubot"}); err != nil {
+		t.Fatal(err)
+	}
+	teamSeats.destroy()
+	imported.refresh()
+	if imported.state == nil {
+		t.Fatal("Expected the seat of the user to be kept")
+	}
+}
@@ -0,0 +1,248 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubCopilotOrganizationSeatAssignments() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubCopilotOrganizationSeatAssignmentsCreate,
+		ReadContext:   resourceGithubCopilotOrganizationSeatAssignmentsRead,
+		UpdateContext: resourceGithubCopilotOrganizationSeatAssignmentsUpdate,
+		DeleteContext: resourceGithubCopilotOrganizationSeatAssignmentsDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"teams": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The slugs of the teams whose members are assigned a Copilot seat. Seats assigned through any other team are cancelled.",
+			},
+			"users": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The logins of the users assigned a Copilot seat directly. Seats assigned directly to any other user are cancelled.",
+			},
+		},
+	}
+}
+
+func resourceGithubCopilotOrganizationSeatAssignmentsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	if teams := expandStringList(d.Get("teams").(*schema.Set).List()); len(teams) > 0 {
+		_, _, err = client.Copilot.AddCopilotTeams(ctx, orgName, teams)
+		if err != nil {
+			return attributeDiagnostics(fmt.Errorf("error assigning Copilot seats to teams of %s: %w", orgName, err), "teams")
+		}
+	}
+	if users := expandStringList(d.Get("users").(*schema.Set).List()); len(users) > 0 {
+		_, _, err = client.Copilot.AddCopilotUsers(ctx, orgName, users)
+		if err != nil {
+			return attributeDiagnostics(fmt.Errorf("error assigning Copilot seats to users of %s: %w", orgName, err), "users")
+		}
+	}
+
+	d.SetId(orgName)
+	return resourceGithubCopilotOrganizationSeatAssignmentsRead(ctx, d, meta)
+}
+
+func resourceGithubCopilotOrganizationSeatAssignmentsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	seats, err := listActiveCopilotSeats(ctx, client, orgName)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error reading Copilot seats of %s: %w", orgName, err))
+	}
+
+	// Seats of team members only tell which teams are assigned when the
+	// teams have members, so configured teams without members are kept.
+	teams := []string{}
+	seen := map[string]bool{}
+	for _, seat := range seats {
+		if slug := seat.GetAssigningTeam().GetSlug(); slug != "" && !seen[slug] {
+			seen[slug] = true
+			teams = append(teams, slug)
+		}
+	}
+	for _, slug := range expandStringList(d.Get("teams").(*schema.Set).List()) {
+		if seen[slug] {
+			continue
+		}
+		assigned, err := copilotTeamSeatAssigned(ctx, client, orgName, slug, seats)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+		if assigned {
+			teams = append(teams, slug)
+		}
+	}
+
+	// A configured user who also gets a seat through a team may be reported
+	// as assigned by the team, so any seat of theirs counts.
+	users := []string{}
+	configured := map[string]string{}
+	for _, login := range expandStringList(d.Get("users").(*schema.Set).List()) {
+		configured[strings.ToLower(login)] = login
+	}
+	for _, seat := range seats {
+		user, ok := seat.GetUser()
+		if !ok {
+			continue
+		}
+		if login, ok := configured[strings.ToLower(user.GetLogin())]; ok {
+			users = append(users, login)
+		} else if seat.AssigningTeam == nil {
+			users = append(users, user.GetLogin())
+		}
+	}
+
+	if err = d.Set("teams", teams); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("users", users); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubCopilotOrganizationSeatAssignmentsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	if d.HasChange("teams") {
+		o, n := d.GetChange("teams")
+		if removed := expandStringList(o.(*schema.Set).Difference(n.(*schema.Set)).List()); len(removed) > 0 {
+			_, _, err = client.Copilot.RemoveCopilotTeams(ctx, orgName, removed)
+			if err != nil {
+				return attributeDiagnostics(fmt.Errorf("error cancelling Copilot seats of teams of %s: %w", orgName, err), "teams")
+			}
+		}
+		if added := expandStringList(n.(*schema.Set).Difference(o.(*schema.Set)).List()); len(added) > 0 {
+			_, _, err = client.Copilot.AddCopilotTeams(ctx, orgName, added)
+			if err != nil {
+				return attributeDiagnostics(fmt.Errorf("error assigning Copilot seats to teams of %s: %w", orgName, err), "teams")
+			}
+		}
+	}
+
+	if d.HasChange("users") {
+		o, n := d.GetChange("users")
+		if removed := expandStringList(o.(*schema.Set).Difference(n.(*schema.Set)).List()); len(removed) > 0 {
+			_, _, err = client.Copilot.RemoveCopilotUsers(ctx, orgName, removed)
+			if err != nil {
+				return attributeDiagnostics(fmt.Errorf("error cancelling Copilot seats of users of %s: %w", orgName, err), "users")
+			}
+		}
+		if added := expandStringList(n.(*schema.Set).Difference(o.(*schema.Set)).List()); len(added) > 0 {
+			_, _, err = client.Copilot.AddCopilotUsers(ctx, orgName, added)
+			if err != nil {
+				return attributeDiagnostics(fmt.Errorf("error assigning Copilot seats to users of %s: %w", orgName, err), "users")
+			}
+		}
+	}
+
+	return resourceGithubCopilotOrganizationSeatAssignmentsRead(ctx, d, meta)
+}
+
+func resourceGithubCopilotOrganizationSeatAssignmentsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	if teams := expandStringList(d.Get("teams").(*schema.Set).List()); len(teams) > 0 {
+		_, _, err = client.Copilot.RemoveCopilotTeams(ctx, orgName, teams)
+		if err != nil {
+			return attributeDiagnostics(fmt.Errorf("error cancelling Copilot seats of teams of %s: %w", orgName, err), "teams")
+		}
+	}
+	if users := expandStringList(d.Get("users").(*schema.Set).List()); len(users) > 0 {
+		_, _, err = client.Copilot.RemoveCopilotUsers(ctx, orgName, users)
+		if err != nil {
+			return attributeDiagnostics(fmt.Errorf("error cancelling Copilot seats of users of %s: %w", orgName, err), "users")
+		}
+	}
+
+	return nil
+}
+
+// listActiveCopilotSeats lists the Copilot seats of an organization, except
+// the ones pending cancellation at the end of the billing cycle.
+func listActiveCopilotSeats(ctx context.Context, client *github.Client, orgName string) ([]*github.CopilotSeatDetails, error) {
+	var seats []*github.CopilotSeatDetails
+	options := &github.ListOptions{PerPage: maxPerPage}
+	for {
+		page, resp, err := client.Copilot.ListCopilotSeats(ctx, orgName, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, seat := range page.Seats {
+			if seat.PendingCancellationDate == nil {
+				seats = append(seats, seat)
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return seats, nil
+}
+
+// copilotTeamSeatAssigned tells whether a team is assigned Copilot seats. GitHub
+// only lists the seats of the team members, so a team without members is
+// assumed to still be assigned.
+func copilotTeamSeatAssigned(ctx context.Context, client *github.Client, orgName, slug string, seats []*github.CopilotSeatDetails) (bool, error) {
+	for _, seat := range seats {
+		if seat.GetAssigningTeam().GetSlug() == slug {
+			return true, nil
+		}
+	}
+
+	team, _, err := client.Teams.GetTeamBySlug(ctx, orgName, slug)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Team %s/%s no longer exists in GitHub", orgName, slug)
+				return false, nil
+			}
+		}
+		return false, err
+	}
+	return team.GetMembersCount() == 0, nil
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubCopilotOrganizationSeatAssignments(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("assigns Copilot seats to teams authoritatively", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_team" "test" {
+				name = "tf-acc-test-%s"
+			}
+
+			resource "github_copilot_organization_seat_assignments" "test" {
+				teams = [github_team.test.slug]
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_copilot_organization_seat_assignments.test", "teams.#",
+				"1",
+			),
+			resource.TestCheckTypeSetElemAttrPair(
+				"github_copilot_organization_seat_assignments.test", "teams.*",
+				"github_team.test", "slug",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubCopilotOrganizationSeatAssignmentsWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	for _, login := range []string{"hubot", "monalisa"} {
+		srv.AddUser(login)
+		newFakeResource(t, meta, "github_membership").apply(map[string]interface{}{"username": login})
+	}
+
+	team := newFakeResource(t, meta, "github_team")
+	team.apply(map[string]interface{}{"name": "Engineering"})
+	newFakeResource(t, meta, "github_team_membership").apply(map[string]interface{}{
+		"team_id":  team.state.ID,
+		"username": "monalisa",
+	})
+
+	config := map[string]interface{}{
+		"teams": []interface{}{"engineering"},
+		"users": []interface{}{"hubot"},
+	}
+
+	assignments := newFakeResource(t, meta, "github_copilot_organization_seat_assignments")
+	assignments.apply(config)
+	if assignments.state.ID != fakeOrganization || assignments.get("teams.#") != "1" || assignments.get("users.#") != "1" {
+		t.Fatalf("Unexpected seat assignments: %v", assignments.state.Attributes)
+	}
+	assignments.expectNoChanges(config)
+
+	client := meta.(*Owner).v3client
+	if _, _, err := client.Copilot.AddCopilotUsers(context.Background(), fakeOrganization, []string{"octocat"}); err != nil {
+		t.Fatal(err)
+	}
+	assignments.refresh()
+	if assignments.get("users.#") != "2" {
+		t.Fatalf("Expected a seat assigned outside of Terraform to be read, got: %v", assignments.state.Attributes)
+	}
+	assignments.expectChanges(config)
+	assignments.apply(config)
+	assignments.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_copilot_organization_seat_assignments")
+	imported.importState(fakeOrganization)
+	imported.expectNoChanges(config)
+
+	config["teams"] = []interface{}{}
+	assignments.apply(config)
+	seats, _, err := client.Copilot.ListCopilotSeats(context.Background(), fakeOrganization, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seats.TotalSeats != 1 {
+		t.Fatalf("Expected the seats of the team to be cancelled, got %d seats", seats.TotalSeats)
+	}
+
+	// Failures point at the attribute the failing request was for.
+	failing := map[string]interface{}{
+		"teams": []interface{}{},
+		"users": []interface{}{"hubot", "stranger"},
+	}
+	_, diags := assignments.resource.Apply(context.Background(), assignments.state, assignments.plan(failing), meta)
+	if !diags.HasError() || !diags[0].AttributePath.Equals(cty.GetAttrPath("users")) {
+		t.Fatalf("Expected an error for the users attribute, got: %v", diags)
+	}
+
+	assignments.destroy()
+	seats, _, err = client.Copilot.ListCopilotSeats(context.Background(), fakeOrganization, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if seats.TotalSeats != 0 {
+		t.Fatalf("Expected all seats to be cancelled, got %d seats", seats.TotalSeats)
+	}
+}
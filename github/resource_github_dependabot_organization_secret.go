@@ -8,18 +8,19 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubDependabotOrganizationSecret() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubDependabotOrganizationSecretCreateOrUpdate,
-		Read:   resourceGithubDependabotOrganizationSecretRead,
-		Update: resourceGithubDependabotOrganizationSecretCreateOrUpdate,
-		Delete: resourceGithubDependabotOrganizationSecretDelete,
+		CreateContext: resourceGithubDependabotOrganizationSecretCreateOrUpdate,
+		ReadContext:   resourceGithubDependabotOrganizationSecretRead,
+		UpdateContext: resourceGithubDependabotOrganizationSecretCreateOrUpdate,
+		DeleteContext: resourceGithubDependabotOrganizationSecretDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				if err := d.Set("secret_name", d.Id()); err != nil {
 					return nil, err
 				}
@@ -82,10 +83,9 @@ func resourceGithubDependabotOrganizationSecret() *schema.Resource {
 	}
 }
 
-func resourceGithubDependabotOrganizationSecretCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubDependabotOrganizationSecretCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	secretName := d.Get("secret_name").(string)
 	plaintextValue := d.Get("plaintext_value").(string)
@@ -95,7 +95,7 @@ func resourceGithubDependabotOrganizationSecretCreateOrUpdate(d *schema.Resource
 	selectedRepositories, hasSelectedRepositories := d.GetOk("selected_repository_ids")
 
 	if visibility != "selected" && hasSelectedRepositories {
-		return fmt.Errorf("cannot use selected_repository_ids without visibility being set to selected")
+		return diag.FromErr(fmt.Errorf("cannot use selected_repository_ids without visibility being set to selected"))
 	}
 
 	selectedRepositoryIDs := github.DependabotSecretsSelectedRepoIDs{}
@@ -108,9 +108,9 @@ func resourceGithubDependabotOrganizationSecretCreateOrUpdate(d *schema.Resource
 		}
 	}
 
-	keyId, publicKey, err := getDependabotOrganizationPublicKeyDetails(owner, meta)
+	keyId, publicKey, err := getDependabotOrganizationPublicKeyDetails(ctx, owner, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
@@ -118,7 +118,7 @@ func resourceGithubDependabotOrganizationSecretCreateOrUpdate(d *schema.Resource
 	} else {
 		encryptedBytes, err := encryptPlaintext(plaintextValue, publicKey)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
 	}
@@ -134,17 +134,16 @@ func resourceGithubDependabotOrganizationSecretCreateOrUpdate(d *schema.Resource
 
 	_, err = client.Dependabot.CreateOrUpdateOrgSecret(ctx, owner, eSecret)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(secretName)
-	return resourceGithubDependabotOrganizationSecretRead(d, meta)
+	return resourceGithubDependabotOrganizationSecretRead(ctx, d, meta)
 }
 
-func resourceGithubDependabotOrganizationSecretRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubDependabotOrganizationSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	secret, _, err := client.Dependabot.GetOrgSecret(ctx, owner, d.Id())
 	if err != nil {
@@ -156,20 +155,20 @@ func resourceGithubDependabotOrganizationSecretRead(d *schema.ResourceData, meta
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("encrypted_value", d.Get("encrypted_value")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("plaintext_value", d.Get("plaintext_value")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("created_at", secret.CreatedAt.String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("visibility", secret.Visibility); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedRepositoryIDs := []int64{}
@@ -181,7 +180,7 @@ func resourceGithubDependabotOrganizationSecretRead(d *schema.ResourceData, meta
 		for {
 			results, resp, err := client.Dependabot.ListSelectedReposForOrgSecret(ctx, owner, d.Id(), opt)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 
 			for _, repo := range results.Repositories {
@@ -196,7 +195,7 @@ func resourceGithubDependabotOrganizationSecretRead(d *schema.ResourceData, meta
 	}
 
 	if err = d.Set("selected_repository_ids", selectedRepositoryIDs); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// This is a drift detection mechanism based on timestamps.
@@ -219,26 +218,25 @@ func resourceGithubDependabotOrganizationSecretRead(d *schema.ResourceData, meta
 		d.SetId("")
 	} else if !ok {
 		if err = d.Set("updated_at", secret.UpdatedAt.String()); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceGithubDependabotOrganizationSecretDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubDependabotOrganizationSecretDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	log.Printf("[DEBUG] Deleting secret: %s", d.Id())
 	_, err := client.Dependabot.DeleteOrgSecret(ctx, orgName, d.Id())
-	return err
+	return diag.FromErr(err)
 }
 
-func getDependabotOrganizationPublicKeyDetails(owner string, meta interface{}) (keyId, pkValue string, err error) {
+func getDependabotOrganizationPublicKeyDetails(ctx context.Context, owner string, meta interface{}) (keyId, pkValue string, err error) {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	publicKey, _, err := client.Dependabot.GetOrgPublicKey(ctx, owner)
 	if err != nil {
@@ -4,15 +4,16 @@ import (
 	"context"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubDependabotOrganizationSecretRepositories() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubDependabotOrganizationSecretRepositoriesCreateOrUpdate,
-		Read:   resourceGithubDependabotOrganizationSecretRepositoriesRead,
-		Update: resourceGithubDependabotOrganizationSecretRepositoriesCreateOrUpdate,
-		Delete: resourceGithubDependabotOrganizationSecretRepositoriesDelete,
+		CreateContext: resourceGithubDependabotOrganizationSecretRepositoriesCreateOrUpdate,
+		ReadContext:   resourceGithubDependabotOrganizationSecretRepositoriesRead,
+		UpdateContext: resourceGithubDependabotOrganizationSecretRepositoriesCreateOrUpdate,
+		DeleteContext: resourceGithubDependabotOrganizationSecretRepositoriesDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -38,14 +39,13 @@ func resourceGithubDependabotOrganizationSecretRepositories() *schema.Resource {
 	}
 }
 
-func resourceGithubDependabotOrganizationSecretRepositoriesCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubDependabotOrganizationSecretRepositoriesCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	secretName := d.Get("secret_name").(string)
@@ -60,21 +60,20 @@ func resourceGithubDependabotOrganizationSecretRepositoriesCreateOrUpdate(d *sch
 
 	_, err = client.Dependabot.SetSelectedReposForOrgSecret(ctx, owner, secretName, selectedRepositoryIDs)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(secretName)
-	return resourceGithubDependabotOrganizationSecretRepositoriesRead(d, meta)
+	return resourceGithubDependabotOrganizationSecretRepositoriesRead(ctx, d, meta)
 }
 
-func resourceGithubDependabotOrganizationSecretRepositoriesRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubDependabotOrganizationSecretRepositoriesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedRepositoryIDs := github.DependabotSecretsSelectedRepoIDs{}
@@ -84,7 +83,7 @@ func resourceGithubDependabotOrganizationSecretRepositoriesRead(d *schema.Resour
 	for {
 		results, resp, err := client.Dependabot.ListSelectedReposForOrgSecret(ctx, owner, d.Id(), opt)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		for _, repo := range results.Repositories {
@@ -98,26 +97,26 @@ func resourceGithubDependabotOrganizationSecretRepositoriesRead(d *schema.Resour
 	}
 
 	if err = d.Set("selected_repository_ids", selectedRepositoryIDs); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubDependabotOrganizationSecretRepositoriesDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubDependabotOrganizationSecretRepositoriesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedRepositoryIDs := github.DependabotSecretsSelectedRepoIDs{}
 	_, err = client.Dependabot.SetSelectedReposForOrgSecret(ctx, owner, d.Id(), selectedRepositoryIDs)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
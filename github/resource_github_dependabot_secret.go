@@ -9,17 +9,18 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"golang.org/x/crypto/nacl/box"
 )
 
 func resourceGithubDependabotSecret() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubDependabotSecretCreateOrUpdate,
-		Read:   resourceGithubDependabotSecretRead,
-		Delete: resourceGithubDependabotSecretDelete,
+		CreateContext: resourceGithubDependabotSecretCreateOrUpdate,
+		ReadContext:   resourceGithubDependabotSecretRead,
+		DeleteContext: resourceGithubDependabotSecretDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubDependabotSecretImport,
+			StateContext: resourceGithubDependabotSecretImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -66,19 +67,18 @@ func resourceGithubDependabotSecret() *schema.Resource {
 	}
 }
 
-func resourceGithubDependabotSecretCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubDependabotSecretCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repo := d.Get("repository").(string)
 	secretName := d.Get("secret_name").(string)
 	plaintextValue := d.Get("plaintext_value").(string)
 	var encryptedValue string
 
-	keyId, publicKey, err := getDependabotPublicKeyDetails(owner, repo, meta)
+	keyId, publicKey, err := getDependabotPublicKeyDetails(ctx, owner, repo, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if encryptedText, ok := d.GetOk("encrypted_value"); ok {
@@ -86,7 +86,7 @@ func resourceGithubDependabotSecretCreateOrUpdate(d *schema.ResourceData, meta i
 	} else {
 		encryptedBytes, err := encryptDependabotPlaintext(plaintextValue, publicKey)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		encryptedValue = base64.StdEncoding.EncodeToString(encryptedBytes)
 	}
@@ -100,21 +100,20 @@ func resourceGithubDependabotSecretCreateOrUpdate(d *schema.ResourceData, meta i
 
 	_, err = client.Dependabot.CreateOrUpdateRepoSecret(ctx, owner, repo, eSecret)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repo, secretName))
-	return resourceGithubDependabotSecretRead(d, meta)
+	return resourceGithubDependabotSecretRead(ctx, d, meta)
 }
 
-func resourceGithubDependabotSecretRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubDependabotSecretRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repoName, secretName, err := parseTwoPartID(d.Id(), "repository", "secret_name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	secret, _, err := client.Dependabot.GetRepoSecret(ctx, owner, repoName, secretName)
@@ -127,17 +126,17 @@ func resourceGithubDependabotSecretRead(d *schema.ResourceData, meta interface{}
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("encrypted_value", d.Get("encrypted_value")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("plaintext_value", d.Get("plaintext_value")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("created_at", secret.CreatedAt.String()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// This is a drift detection mechanism based on timestamps.
@@ -160,33 +159,32 @@ func resourceGithubDependabotSecretRead(d *schema.ResourceData, meta interface{}
 		d.SetId("")
 	} else if !ok {
 		if err = d.Set("updated_at", secret.UpdatedAt.String()); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceGithubDependabotSecretDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubDependabotSecretDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	repoName, secretName, err := parseTwoPartID(d.Id(), "repository", "secret_name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	log.Printf("[DEBUG] Deleting secret: %s", d.Id())
 	_, err = client.Dependabot.DeleteRepoSecret(ctx, orgName, repoName, secretName)
 
-	return err
+	return diag.FromErr(err)
 }
 
-func resourceGithubDependabotSecretImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubDependabotSecretImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	parts := strings.Split(d.Id(), "/")
 	if len(parts) != 2 {
@@ -224,9 +222,8 @@ func resourceGithubDependabotSecretImport(d *schema.ResourceData, meta interface
 	return []*schema.ResourceData{d}, nil
 }
 
-func getDependabotPublicKeyDetails(owner, repository string, meta interface{}) (keyId, pkValue string, err error) {
+func getDependabotPublicKeyDetails(ctx context.Context, owner, repository string, meta interface{}) (keyId, pkValue string, err error) {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	publicKey, _, err := client.Dependabot.GetRepoPublicKey(ctx, owner, repository)
 	if err != nil {
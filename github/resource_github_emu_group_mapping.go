@@ -6,17 +6,18 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubEMUGroupMapping() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubEMUGroupMappingCreate,
-		Read:   resourceGithubEMUGroupMappingRead,
-		Update: resourceGithubEMUGroupMappingUpdate,
-		Delete: resourceGithubEMUGroupMappingDelete,
+		CreateContext: resourceGithubEMUGroupMappingCreate,
+		ReadContext:   resourceGithubEMUGroupMappingRead,
+		UpdateContext: resourceGithubEMUGroupMappingUpdate,
+		DeleteContext: resourceGithubEMUGroupMappingDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				id, err := strconv.Atoi(d.Id())
 				if err != nil {
 					return nil, err
@@ -24,7 +25,7 @@ func resourceGithubEMUGroupMapping() *schema.Resource {
 				if err := d.Set("group_id", id); err != nil {
 					return nil, err
 				}
-				ctx := context.WithValue(context.Background(), ctxId, d.Id())
+				ctx = context.WithValue(ctx, ctxId, d.Id())
 				client := meta.(*Owner).v3client
 				orgName := meta.(*Owner).name
 				group, _, err := client.Teams.GetExternalGroup(ctx, orgName, int64(id))
@@ -60,28 +61,28 @@ func resourceGithubEMUGroupMapping() *schema.Resource {
 	}
 }
 
-func resourceGithubEMUGroupMappingCreate(d *schema.ResourceData, meta interface{}) error {
-	return resourceGithubEMUGroupMappingUpdate(d, meta)
+func resourceGithubEMUGroupMappingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGithubEMUGroupMappingUpdate(ctx, d, meta)
 }
 
-func resourceGithubEMUGroupMappingRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubEMUGroupMappingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 
 	id, ok := d.GetOk("group_id")
 	if !ok {
-		return fmt.Errorf("could not get group id from provided value")
+		return diag.FromErr(fmt.Errorf("could not get group id from provided value"))
 	}
 	id64, err := getInt64FromInterface(id)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	group, resp, err := client.Teams.GetExternalGroup(ctx, orgName, id64)
 	if err != nil {
@@ -90,7 +91,7 @@ func resourceGithubEMUGroupMappingRead(d *schema.ResourceData, meta interface{})
 			d.SetId("")
 			return nil
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if len(group.Teams) < 1 {
@@ -101,35 +102,35 @@ func resourceGithubEMUGroupMappingRead(d *schema.ResourceData, meta interface{})
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("group_id", int(*group.GroupID)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceGithubEMUGroupMappingUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubEMUGroupMappingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	teamSlug, ok := d.GetOk("team_slug")
 	if !ok {
-		return fmt.Errorf("could not get team slug from provided value")
+		return diag.FromErr(fmt.Errorf("could not get team slug from provided value"))
 	}
 
 	id, ok := d.GetOk("group_id")
 	if !ok {
-		return fmt.Errorf("could not get group id from provided value")
+		return diag.FromErr(fmt.Errorf("could not get group id from provided value"))
 	}
 	id64, err := getInt64FromInterface(id)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	eg := &github.ExternalGroup{
@@ -138,31 +139,31 @@ func resourceGithubEMUGroupMappingUpdate(d *schema.ResourceData, meta interface{
 
 	_, _, err = client.Teams.UpdateConnectedExternalGroup(ctx, orgName, teamSlug.(string), eg)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("teams/%s/external-groups", teamSlug))
-	return resourceGithubEMUGroupMappingRead(d, meta)
+	return resourceGithubEMUGroupMappingRead(ctx, d, meta)
 }
 
-func resourceGithubEMUGroupMappingDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubEMUGroupMappingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 
 	teamSlug, ok := d.GetOk("team_slug")
 	if !ok {
-		return fmt.Errorf("could not parse team slug from provided value")
+		return diag.FromErr(fmt.Errorf("could not parse team slug from provided value"))
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Teams.RemoveConnectedExternalGroup(ctx, orgName, teamSlug.(string))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
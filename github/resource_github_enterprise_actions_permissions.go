@@ -5,16 +5,17 @@ import (
 	"errors"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubActionsEnterprisePermissions() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubActionsEnterprisePermissionsCreateOrUpdate,
-		Read:   resourceGithubActionsEnterprisePermissionsRead,
-		Update: resourceGithubActionsEnterprisePermissionsCreateOrUpdate,
-		Delete: resourceGithubActionsEnterprisePermissionsDelete,
+		CreateContext: resourceGithubActionsEnterprisePermissionsCreateOrUpdate,
+		ReadContext:   resourceGithubActionsEnterprisePermissionsRead,
+		UpdateContext: resourceGithubActionsEnterprisePermissionsCreateOrUpdate,
+		DeleteContext: resourceGithubActionsEnterprisePermissionsDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -137,10 +138,9 @@ func resourceGithubActionsEnabledOrganizationsObject(d *schema.ResourceData) ([]
 	return enabled, nil
 }
 
-func resourceGithubActionsEnterprisePermissionsCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnterprisePermissionsCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
-	ctx := context.Background()
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxId, d.Id())
 	}
@@ -156,52 +156,51 @@ func resourceGithubActionsEnterprisePermissionsCreateOrUpdate(d *schema.Resource
 			EnabledOrganizations: &enabledOrganizations,
 		})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if allowedActions == "selected" {
 		actionsAllowedData, err := resourceGithubActionsEnterpriseAllowedObject(d)
 		if err != nil {
-			return err
+			return attributeDiagnostics(err, "allowed_actions_config")
 		}
 		_, _, err = client.Actions.EditActionsAllowedInEnterprise(ctx,
 			enterpriseId,
 			*actionsAllowedData)
 		if err != nil {
-			return err
+			return attributeDiagnostics(err, "allowed_actions_config")
 		}
 	}
 
 	if enabledOrganizations == "selected" {
 		enabledOrgsData, err := resourceGithubActionsEnabledOrganizationsObject(d)
 		if err != nil {
-			return err
+			return attributeDiagnostics(err, "enabled_organizations_config")
 		}
 		_, err = client.Actions.SetEnabledOrgsInEnterprise(ctx,
 			enterpriseId,
 			enabledOrgsData)
 		if err != nil {
-			return err
+			return attributeDiagnostics(err, "enabled_organizations_config")
 		}
 	}
 
 	d.SetId(enterpriseId)
-	return resourceGithubActionsEnterprisePermissionsRead(d, meta)
+	return resourceGithubActionsEnterprisePermissionsRead(ctx, d, meta)
 }
 
-func resourceGithubActionsEnterprisePermissionsRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnterprisePermissionsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	actionsPermissions, _, err := client.Actions.GetActionsPermissionsInEnterprise(ctx, d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if actionsPermissions.GetAllowedActions() == "selected" {
 		actionsAllowed, _, err := client.Actions.GetActionsAllowedInEnterprise(ctx, d.Id())
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		// If actionsAllowed set to local/all by removing all actions config settings, the response will be empty
@@ -213,12 +212,12 @@ func resourceGithubActionsEnterprisePermissionsRead(d *schema.ResourceData, meta
 					"verified_allowed":     actionsAllowed.GetVerifiedAllowed(),
 				},
 			}); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	} else {
 		if err = d.Set("allowed_actions_config", []interface{}{}); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -230,7 +229,7 @@ func resourceGithubActionsEnterprisePermissionsRead(d *schema.ResourceData, meta
 		for {
 			enabledOrgs, resp, err := client.Actions.ListEnabledOrgsInEnterprise(ctx, d.Id(), &opts)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			allOrgs = append(allOrgs, enabledOrgs.Organizations...)
 
@@ -249,32 +248,32 @@ func resourceGithubActionsEnterprisePermissionsRead(d *schema.ResourceData, meta
 					"organization_ids": orgList,
 				},
 			}); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			if err = d.Set("enabled_organizations_config", []interface{}{}); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 
 	if err = d.Set("allowed_actions", actionsPermissions.GetAllowedActions()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("enabled_organizations", actionsPermissions.GetEnabledOrganizations()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("enterprise_slug", d.Id()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubActionsEnterprisePermissionsDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnterprisePermissionsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	// This will nullify any allowedActions elements
 	_, _, err := client.Actions.EditActionsPermissionsInEnterprise(ctx,
@@ -284,7 +283,7 @@ func resourceGithubActionsEnterprisePermissionsDelete(d *schema.ResourceData, me
 			EnabledOrganizations: github.String("all"),
 		})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -9,18 +9,19 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubActionsEnterpriseRunnerGroup() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubActionsEnterpriseRunnerGroupCreate,
-		Read:   resourceGithubActionsEnterpriseRunnerGroupRead,
-		Update: resourceGithubActionsEnterpriseRunnerGroupUpdate,
-		Delete: resourceGithubActionsEnterpriseRunnerGroupDelete,
+		CreateContext: resourceGithubActionsEnterpriseRunnerGroupCreate,
+		ReadContext:   resourceGithubActionsEnterpriseRunnerGroupRead,
+		UpdateContext: resourceGithubActionsEnterpriseRunnerGroupUpdate,
+		DeleteContext: resourceGithubActionsEnterpriseRunnerGroupDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubActionsEnterpriseRunnerGroupImport,
+			StateContext: resourceGithubActionsEnterpriseRunnerGroupImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -91,7 +92,7 @@ func resourceGithubActionsEnterpriseRunnerGroup() *schema.Resource {
 	}
 }
 
-func resourceGithubActionsEnterpriseRunnerGroupCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnterpriseRunnerGroupCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	name := d.Get("name").(string)
@@ -109,7 +110,7 @@ func resourceGithubActionsEnterpriseRunnerGroupCreate(d *schema.ResourceData, me
 	}
 
 	if visibility != "selected" && hasSelectedOrganizations {
-		return fmt.Errorf("cannot use selected_organization_ids without visibility being set to selected")
+		return diag.FromErr(fmt.Errorf("cannot use selected_organization_ids without visibility being set to selected"))
 	}
 
 	selectedOrganizationIDs := []int64{}
@@ -122,8 +123,6 @@ func resourceGithubActionsEnterpriseRunnerGroupCreate(d *schema.ResourceData, me
 		}
 	}
 
-	ctx := context.Background()
-
 	enterpriseRunnerGroup, resp, err := client.Enterprise.CreateEnterpriseRunnerGroup(ctx,
 		enterpriseSlug,
 		github.CreateEnterpriseRunnerGroupRequest{
@@ -136,44 +135,44 @@ func resourceGithubActionsEnterpriseRunnerGroupCreate(d *schema.ResourceData, me
 		},
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(enterpriseRunnerGroup.GetID(), 10))
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("allows_public_repositories", enterpriseRunnerGroup.GetAllowsPublicRepositories()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("default", enterpriseRunnerGroup.GetDefault()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", enterpriseRunnerGroup.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("runners_url", enterpriseRunnerGroup.GetRunnersURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("selected_organizations_url", enterpriseRunnerGroup.GetSelectedOrganizationsURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("visibility", enterpriseRunnerGroup.GetVisibility()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("selected_organization_ids", selectedOrganizationIDs); err != nil { // Note: enterpriseRunnerGroup has no method to get selected organization IDs
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("restricted_to_workflows", enterpriseRunnerGroup.GetRestrictedToWorkflows()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("selected_workflows", enterpriseRunnerGroup.SelectedWorkflows); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("enterprise_slug", enterpriseSlug); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubActionsEnterpriseRunnerGroupRead(d, meta)
+	return resourceGithubActionsEnterpriseRunnerGroupRead(ctx, d, meta)
 }
 
 func getEnterpriseRunnerGroup(client *github.Client, ctx context.Context, ent string, groupID int64) (*github.EnterpriseRunnerGroup, *github.Response, error) {
@@ -187,15 +186,15 @@ func getEnterpriseRunnerGroup(client *github.Client, ctx context.Context, ent st
 	return enterpriseRunnerGroup, resp, err
 }
 
-func resourceGithubActionsEnterpriseRunnerGroupRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnterpriseRunnerGroupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	enterpriseSlug := d.Get("enterprise_slug").(string)
 	runnerGroupID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -210,7 +209,7 @@ func resourceGithubActionsEnterpriseRunnerGroupRead(d *schema.ResourceData, meta
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	//if runner group is nil (typically not modified) we can return early
@@ -219,34 +218,34 @@ func resourceGithubActionsEnterpriseRunnerGroupRead(d *schema.ResourceData, meta
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("allows_public_repositories", enterpriseRunnerGroup.GetAllowsPublicRepositories()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("default", enterpriseRunnerGroup.GetDefault()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", enterpriseRunnerGroup.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("runners_url", enterpriseRunnerGroup.GetRunnersURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("selected_organizations_url", enterpriseRunnerGroup.GetSelectedOrganizationsURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("visibility", enterpriseRunnerGroup.GetVisibility()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("restricted_to_workflows", enterpriseRunnerGroup.GetRestrictedToWorkflows()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("selected_workflows", enterpriseRunnerGroup.SelectedWorkflows); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("enterprise_slug", enterpriseSlug); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedOrganizationIDs := []int64{}
@@ -257,7 +256,7 @@ func resourceGithubActionsEnterpriseRunnerGroupRead(d *schema.ResourceData, meta
 	for {
 		enterpriseRunnerGroupOrganizations, resp, err := client.Enterprise.ListOrganizationAccessRunnerGroup(ctx, enterpriseSlug, runnerGroupID, &optionsOrgs)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		for _, org := range enterpriseRunnerGroupOrganizations.Organizations {
@@ -272,13 +271,13 @@ func resourceGithubActionsEnterpriseRunnerGroupRead(d *schema.ResourceData, meta
 	}
 
 	if err = d.Set("selected_organization_ids", selectedOrganizationIDs); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubActionsEnterpriseRunnerGroupUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnterpriseRunnerGroupUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	name := d.Get("name").(string)
@@ -303,12 +302,12 @@ func resourceGithubActionsEnterpriseRunnerGroupUpdate(d *schema.ResourceData, me
 
 	runnerGroupID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	if _, _, err := client.Enterprise.UpdateEnterpriseRunnerGroup(ctx, enterpriseSlug, runnerGroupID, options); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	selectedOrganizations, hasSelectedOrganizations := d.GetOk("selected_organization_ids")
@@ -325,27 +324,27 @@ func resourceGithubActionsEnterpriseRunnerGroupUpdate(d *schema.ResourceData, me
 	orgOptions := github.SetOrgAccessRunnerGroupRequest{SelectedOrganizationIDs: selectedOrganizationIDs}
 
 	if _, err := client.Enterprise.SetOrganizationAccessRunnerGroup(ctx, enterpriseSlug, runnerGroupID, orgOptions); err != nil {
-		return err
+		return attributeDiagnostics(err, "selected_organization_ids")
 	}
 
-	return resourceGithubActionsEnterpriseRunnerGroupRead(d, meta)
+	return resourceGithubActionsEnterpriseRunnerGroupRead(ctx, d, meta)
 }
 
-func resourceGithubActionsEnterpriseRunnerGroupDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubActionsEnterpriseRunnerGroupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	enterpriseSlug := d.Get("enterprise_slug").(string)
 	enterpriseRunnerGroupID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	log.Printf("[INFO] Deleting enterprise runner group: %s/%s (%s)", enterpriseSlug, d.Get("name"), d.Id())
 	_, err = client.Enterprise.DeleteEnterpriseRunnerGroup(ctx, enterpriseSlug, enterpriseRunnerGroupID)
-	return err
+	return diag.FromErr(err)
 }
 
-func resourceGithubActionsEnterpriseRunnerGroupImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubActionsEnterpriseRunnerGroupImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(d.Id(), "/")
 	if len(parts) != 2 {
 		return nil, fmt.Errorf("invalid import specified: supplied import must be written as <enterprise_slug>/<runner_group_id>")
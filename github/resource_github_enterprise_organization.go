@@ -7,18 +7,19 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/shurcooL/githubv4"
 )
 
 func resourceGithubEnterpriseOrganization() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubEnterpriseOrganizationCreate,
-		Read:   resourceGithubEnterpriseOrganizationRead,
-		Delete: resourceGithubEnterpriseOrganizationDelete,
-		Update: resourceGithubEnterpriseOrganizationUpdate,
+		CreateContext: resourceGithubEnterpriseOrganizationCreate,
+		ReadContext:   resourceGithubEnterpriseOrganizationRead,
+		DeleteContext: resourceGithubEnterpriseOrganizationDelete,
+		UpdateContext: resourceGithubEnterpriseOrganizationUpdate,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubEnterpriseOrganizationImport,
+			StateContext: resourceGithubEnterpriseOrganizationImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"enterprise_id": {
@@ -70,7 +71,7 @@ func resourceGithubEnterpriseOrganization() *schema.Resource {
 	}
 }
 
-func resourceGithubEnterpriseOrganizationCreate(data *schema.ResourceData, meta interface{}) error {
+func resourceGithubEnterpriseOrganizationCreate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var mutate struct {
 		CreateEnterpriseOrganization struct {
 			Organization struct {
@@ -96,9 +97,9 @@ func resourceGithubEnterpriseOrganizationCreate(data *schema.ResourceData, meta
 		AdminLogins:  adminLogins,
 	}
 
-	err := v4.Mutate(context.Background(), &mutate, input, nil)
+	err := v4.Mutate(ctx, &mutate, input, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	data.SetId(fmt.Sprintf("%s", mutate.CreateEnterpriseOrganization.Organization.ID))
 
@@ -120,20 +121,20 @@ func resourceGithubEnterpriseOrganizationCreate(data *schema.ResourceData, meta
 	displayName := data.Get("display_name").(string)
 	if description != "" || displayName != "" {
 		_, _, err = v3.Organizations.Edit(
-			context.Background(),
+			ctx,
 			data.Get("name").(string),
 			&github.Organization{
 				Description: github.String(description),
 				Name:        github.String(displayName),
 			},
 		)
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 
 }
 
-func resourceGithubEnterpriseOrganizationRead(data *schema.ResourceData, meta interface{}) error {
+func resourceGithubEnterpriseOrganizationRead(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	var query struct {
 		Node struct {
 			Organization struct {
@@ -165,14 +166,14 @@ func resourceGithubEnterpriseOrganizationRead(data *schema.ResourceData, meta in
 
 	for {
 		v4 := meta.(*Owner).v4client
-		err := v4.Query(context.Background(), &query, variables)
+		err := v4.Query(ctx, &query, variables)
 		if err != nil {
 			if strings.Contains(err.Error(), "Could not resolve to a node with the global id") {
 				log.Printf("[INFO] Removing organization (%s) from state because it no longer exists in GitHub", data.Id())
 				data.SetId("")
 				return nil
 			}
-			return err
+			return diag.FromErr(err)
 		}
 
 		for _, v := range query.Node.Organization.MembersWithRole.Edges {
@@ -190,40 +191,40 @@ func resourceGithubEnterpriseOrganizationRead(data *schema.ResourceData, meta in
 
 	err := data.Set("admin_logins", schema.NewSet(schema.HashString, adminLogins))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = data.Set("name", query.Node.Organization.Login)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if query.Node.Organization.Name != query.Node.Organization.Login {
 		err = data.Set("display_name", query.Node.Organization.Name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	err = data.Set("billing_email", query.Node.Organization.OrganizationBillingEmail)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = data.Set("database_id", query.Node.Organization.DatabaseId)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = data.Set("description", query.Node.Organization.Description)
-	return err
+	return diag.FromErr(err)
 }
 
-func resourceGithubEnterpriseOrganizationDelete(data *schema.ResourceData, meta interface{}) error {
+func resourceGithubEnterpriseOrganizationDelete(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	owner := meta.(*Owner)
 	v3 := owner.v3client
 
-	ctx := context.WithValue(context.Background(), ctxId, data.Id())
+	ctx = context.WithValue(ctx, ctxId, data.Id())
 
 	_, err := v3.Organizations.Delete(ctx, data.Get("name").(string))
 
@@ -232,17 +233,16 @@ func resourceGithubEnterpriseOrganizationDelete(data *schema.ResourceData, meta
 		return nil
 	}
 
-	return err
+	return diag.FromErr(err)
 }
 
-func resourceGithubEnterpriseOrganizationImport(data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubEnterpriseOrganizationImport(ctx context.Context, data *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	parts := strings.Split(data.Id(), "/")
 	if len(parts) != 2 {
 		return nil, fmt.Errorf("invalid ID specified: supplied ID must be written as <enterprise_slug>/<org_name>")
 	}
 
 	v4 := meta.(*Owner).v4client
-	ctx := context.Background()
 
 	enterpriseId, err := getEnterpriseId(ctx, v4, parts[0])
 	if err != nil {
@@ -256,8 +256,7 @@ func resourceGithubEnterpriseOrganizationImport(data *schema.ResourceData, meta
 	}
 	data.SetId(orgId)
 
-	err = resourceGithubEnterpriseOrganizationRead(data, meta)
-	if err != nil {
+	if err = errorFromDiagnostics(resourceGithubEnterpriseOrganizationRead(ctx, data, meta)); err != nil {
 		return nil, err
 	}
 	return []*schema.ResourceData{data}, nil
@@ -398,7 +397,7 @@ func addUsers(ctx context.Context, data *schema.ResourceData, v4 *githubv4.Clien
 		}
 
 		adminRole := githubv4.OrganizationMemberRoleAdmin
-		userIds, err := getUserIds(v4, toAdd)
+		userIds, err := getUserIds(ctx, v4, toAdd)
 		if err != nil {
 			return err
 		}
@@ -436,31 +435,30 @@ func updateBillingEmail(ctx context.Context, data *schema.ResourceData, orgName
 	return nil
 }
 
-func resourceGithubEnterpriseOrganizationUpdate(data *schema.ResourceData, meta interface{}) error {
+func resourceGithubEnterpriseOrganizationUpdate(ctx context.Context, data *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	v3 := meta.(*Owner).v3client
 	v4 := meta.(*Owner).v4client
-	ctx := context.Background()
 
 	err := updateDisplayName(ctx, data, v3)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = updateDescription(ctx, data, v3)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	orgName := data.Get("name").(string)
 	err = updateAdminList(ctx, data, orgName, v3, v4)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return updateBillingEmail(ctx, data, orgName, v3)
+	return diag.FromErr(updateBillingEmail(ctx, data, orgName, v3))
 }
 
-func getUserIds(v4 *githubv4.Client, loginNames []interface{}) ([]githubv4.ID, error) {
+func getUserIds(ctx context.Context, v4 *githubv4.Client, loginNames []interface{}) ([]githubv4.ID, error) {
 	var query struct {
 		User struct {
 			ID githubv4.String
@@ -470,7 +468,7 @@ func getUserIds(v4 *githubv4.Client, loginNames []interface{}) ([]githubv4.ID, e
 	var ret []githubv4.ID
 
 	for _, l := range loginNames {
-		err := v4.Query(context.Background(), &query, map[string]interface{}{"login": githubv4.String(l.(string))})
+		err := v4.Query(ctx, &query, map[string]interface{}{"login": githubv4.String(l.(string))})
 		if err != nil {
 			return nil, err
 		}
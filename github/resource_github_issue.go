@@ -7,15 +7,16 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubIssue() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubIssueCreateOrUpdate,
-		Read:   resourceGithubIssueRead,
-		Update: resourceGithubIssueCreateOrUpdate,
-		Delete: resourceGithubIssueDelete,
+		CreateContext: resourceGithubIssueCreateOrUpdate,
+		ReadContext:   resourceGithubIssueRead,
+		UpdateContext: resourceGithubIssueCreateOrUpdate,
+		DeleteContext: resourceGithubIssueDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -66,6 +67,11 @@ func resourceGithubIssue() *schema.Resource {
 				Computed:    true,
 				Description: "The issue id.",
 			},
+			"node_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The node ID of the issue.",
+			},
 			"etag": {
 				Type:     schema.TypeString,
 				Computed: true,
@@ -74,8 +80,7 @@ func resourceGithubIssue() *schema.Resource {
 	}
 }
 
-func resourceGithubIssueCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.Background()
+func resourceGithubIssueCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -120,30 +125,30 @@ func resourceGithubIssueCreateOrUpdate(d *schema.ResourceData, meta interface{})
 		}
 	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repoName, strconv.Itoa(issue.GetNumber())))
 	if err = d.Set("issue_id", issue.GetID()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceGithubIssueRead(d, meta)
+	return resourceGithubIssueRead(ctx, d, meta)
 }
 
-func resourceGithubIssueRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubIssueRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	repoName, idNumber, err := parseTwoPartID(d.Id(), "repository", "issue_number")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	number, err := strconv.Atoi(idNumber)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -163,26 +168,26 @@ func resourceGithubIssueRead(d *schema.ResourceData, meta interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("repository", repoName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("number", number); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("title", issue.GetTitle()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("body", issue.GetBody()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("milestone_number", issue.GetMilestone().GetNumber()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var labels []string
@@ -190,7 +195,7 @@ func resourceGithubIssueRead(d *schema.ResourceData, meta interface{}) error {
 		labels = append(labels, v.GetName())
 	}
 	if err = d.Set("labels", flattenStringList(labels)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var assignees []string
@@ -198,22 +203,25 @@ func resourceGithubIssueRead(d *schema.ResourceData, meta interface{}) error {
 		assignees = append(assignees, v.GetLogin())
 	}
 	if err = d.Set("assignees", flattenStringList(assignees)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("issue_id", issue.GetID()); err != nil {
-		return err
+		return diag.FromErr(err)
+	}
+	if err = d.Set("node_id", issue.GetNodeID()); err != nil {
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceGithubIssueDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubIssueDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 	number := d.Get("number").(int)
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	log.Printf("[DEBUG] Deleting issue by closing: %d (%s/%s)", number, orgName, repoName)
 
@@ -221,7 +229,7 @@ func resourceGithubIssueDelete(d *schema.ResourceData, meta interface{}) error {
 
 	_, _, err := client.Issues.Edit(ctx, orgName, repoName, number, request)
 
-	return err
+	return diag.FromErr(err)
 }
 
 func intPtr(i int) *int {
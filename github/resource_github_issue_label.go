@@ -6,15 +6,16 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubIssueLabel() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubIssueLabelCreateOrUpdate,
-		Read:   resourceGithubIssueLabelRead,
-		Update: resourceGithubIssueLabelCreateOrUpdate,
-		Delete: resourceGithubIssueLabelDelete,
+		CreateContext: resourceGithubIssueLabelCreateOrUpdate,
+		ReadContext:   resourceGithubIssueLabelRead,
+		UpdateContext: resourceGithubIssueLabelCreateOrUpdate,
+		DeleteContext: resourceGithubIssueLabelDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -64,7 +65,7 @@ func resourceGithubIssueLabel() *schema.Resource {
 // otherwise it will create. This is also advantageous in that we get to use the
 // same function for two schema funcs.
 
-func resourceGithubIssueLabelCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubIssueLabelCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -75,7 +76,6 @@ func resourceGithubIssueLabelCreateOrUpdate(d *schema.ResourceData, meta interfa
 		Name:  github.String(name),
 		Color: github.String(color),
 	}
-	ctx := context.Background()
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxId, d.Id())
 	}
@@ -89,14 +89,14 @@ func resourceGithubIssueLabelCreateOrUpdate(d *schema.ResourceData, meta interfa
 		var err error
 		_, originalName, err = parseTwoPartID(d.Id(), "repository", "name")
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	existing, resp, err := client.Issues.GetLabel(ctx,
 		orgName, repoName, originalName)
 	if err != nil && resp.StatusCode != http.StatusNotFound {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if existing != nil {
@@ -111,14 +111,14 @@ func resourceGithubIssueLabelCreateOrUpdate(d *schema.ResourceData, meta interfa
 			var err error
 			_, originalName, err = parseTwoPartID(d.Id(), "repository", "name")
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 
 		_, _, err := client.Issues.EditLabel(ctx,
 			orgName, repoName, originalName, label)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		if v, ok := d.GetOk("description"); ok {
@@ -128,24 +128,24 @@ func resourceGithubIssueLabelCreateOrUpdate(d *schema.ResourceData, meta interfa
 		_, _, err := client.Issues.CreateLabel(ctx,
 			orgName, repoName, label)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	d.SetId(buildTwoPartID(repoName, name))
 
-	return resourceGithubIssueLabelRead(d, meta)
+	return resourceGithubIssueLabelRead(ctx, d, meta)
 }
 
-func resourceGithubIssueLabelRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubIssueLabelRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	repoName, name, err := parseTwoPartID(d.Id(), "repository", "name")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -164,40 +164,40 @@ func resourceGithubIssueLabelRead(d *schema.ResourceData, meta interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("repository", repoName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", name); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("color", githubLabel.GetColor()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("description", githubLabel.GetDescription()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("url", githubLabel.GetURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubIssueLabelDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubIssueLabelDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 	name := d.Get("name").(string)
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err := client.Issues.DeleteLabel(ctx,
 		orgName, repoName, name)
-	return err
+	return diag.FromErr(err)
 }
@@ -6,15 +6,16 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubIssueLabels() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubIssueLabelsCreateOrUpdate,
-		Read:   resourceGithubIssueLabelsRead,
-		Update: resourceGithubIssueLabelsCreateOrUpdate,
-		Delete: resourceGithubIssueLabelsDelete,
+		CreateContext: resourceGithubIssueLabelsCreateOrUpdate,
+		ReadContext:   resourceGithubIssueLabelsRead,
+		UpdateContext: resourceGithubIssueLabelsCreateOrUpdate,
+		DeleteContext: resourceGithubIssueLabelsDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -59,7 +60,7 @@ func resourceGithubIssueLabels() *schema.Resource {
 	}
 }
 
-func resourceGithubIssueLabelsRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubIssueLabelsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
@@ -67,7 +68,7 @@ func resourceGithubIssueLabelsRead(d *schema.ResourceData, meta interface{}) err
 
 	log.Printf("[DEBUG] Reading GitHub issue labels for %s/%s", owner, repository)
 
-	ctx := context.WithValue(context.Background(), ctxId, repository)
+	ctx = context.WithValue(ctx, ctxId, repository)
 
 	options := &github.ListOptions{
 		PerPage: maxPerPage,
@@ -78,7 +79,7 @@ func resourceGithubIssueLabelsRead(d *schema.ResourceData, meta interface{}) err
 	for {
 		ls, resp, err := client.Issues.ListLabels(ctx, owner, repository, options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		for _, l := range ls {
 			labels = append(labels, map[string]interface{}{
@@ -100,23 +101,23 @@ func resourceGithubIssueLabelsRead(d *schema.ResourceData, meta interface{}) err
 
 	err := d.Set("repository", repository)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	err = d.Set("label", labels)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubIssueLabelsCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubIssueLabelsCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repository := d.Get("repository").(string)
-	ctx := context.WithValue(context.Background(), ctxId, repository)
+	ctx = context.WithValue(ctx, ctxId, repository)
 
 	o, n := d.GetChange("label")
 
@@ -150,7 +151,7 @@ func resourceGithubIssueLabelsCreateOrUpdate(d *schema.ResourceData, meta interf
 				Description: github.String(n["description"].(string)),
 			})
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 
 			labels = append(labels, map[string]interface{}{
@@ -169,7 +170,7 @@ func resourceGithubIssueLabelsCreateOrUpdate(d *schema.ResourceData, meta interf
 
 			_, err := client.Issues.DeleteLabel(ctx, owner, repository, o["name"].(string))
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -186,7 +187,7 @@ func resourceGithubIssueLabelsCreateOrUpdate(d *schema.ResourceData, meta interf
 					Description: github.String(n["description"].(string)),
 				})
 				if err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 
 				labels = append(labels, map[string]interface{}{
@@ -205,18 +206,18 @@ func resourceGithubIssueLabelsCreateOrUpdate(d *schema.ResourceData, meta interf
 
 	err := d.Set("label", labels)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubIssueLabelsDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubIssueLabelsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repository := d.Get("repository").(string)
-	ctx := context.WithValue(context.Background(), ctxId, repository)
+	ctx = context.WithValue(ctx, ctxId, repository)
 
 	labels := d.Get("label").(*schema.Set).List()
 
@@ -232,7 +233,7 @@ func resourceGithubIssueLabelsDelete(d *schema.ResourceData, meta interface{}) e
 
 		_, err := client.Issues.DeleteLabel(ctx, owner, repository, name)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -240,7 +241,7 @@ func resourceGithubIssueLabelsDelete(d *schema.ResourceData, meta interface{}) e
 
 	err := d.Set("label", make([]map[string]interface{}, 0))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
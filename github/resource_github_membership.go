@@ -6,15 +6,16 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubMembership() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubMembershipCreateOrUpdate,
-		Read:   resourceGithubMembershipRead,
-		Update: resourceGithubMembershipCreateOrUpdate,
-		Delete: resourceGithubMembershipDelete,
+		CreateContext: resourceGithubMembershipCreateOrUpdate,
+		ReadContext:   resourceGithubMembershipRead,
+		UpdateContext: resourceGithubMembershipCreateOrUpdate,
+		DeleteContext: resourceGithubMembershipDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -39,19 +40,27 @@ func resourceGithubMembership() *schema.Resource {
 				Computed: true,
 			},
 			"downgrade_on_destroy": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Default:     false,
-				Description: "Instead of removing the member from the org, you can choose to downgrade their membership to 'member' when this resource is destroyed. This is useful when wanting to downgrade admins while keeping them in the organization",
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"convert_to_outside_collaborator_on_destroy"},
+				Description:   "Instead of removing the member from the org, you can choose to downgrade their membership to 'member' when this resource is destroyed. This is useful when wanting to downgrade admins while keeping them in the organization",
+			},
+			"convert_to_outside_collaborator_on_destroy": {
+				Type:          schema.TypeBool,
+				Optional:      true,
+				Default:       false,
+				ConflictsWith: []string{"downgrade_on_destroy"},
+				Description:   "Instead of removing the member from the org, you can choose to convert them to an outside collaborator when this resource is destroyed, keeping their access to the repositories they collaborate on.",
 			},
 		},
 	}
 }
 
-func resourceGithubMembershipCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubMembershipCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -59,7 +68,6 @@ func resourceGithubMembershipCreateOrUpdate(d *schema.ResourceData, meta interfa
 	orgName := meta.(*Owner).name
 	username := d.Get("username").(string)
 	roleName := d.Get("role").(string)
-	ctx := context.Background()
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxId, d.Id())
 	}
@@ -72,18 +80,18 @@ func resourceGithubMembershipCreateOrUpdate(d *schema.ResourceData, meta interfa
 		},
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(orgName, username))
 
-	return resourceGithubMembershipRead(d, meta)
+	return resourceGithubMembershipRead(ctx, d, meta)
 }
 
-func resourceGithubMembershipRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubMembershipRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -91,9 +99,9 @@ func resourceGithubMembershipRead(d *schema.ResourceData, meta interface{}) erro
 	orgName := meta.(*Owner).name
 	_, username, err := parseTwoPartID(d.Id(), "organization", "username")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -112,31 +120,31 @@ func resourceGithubMembershipRead(d *schema.ResourceData, meta interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("username", username); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("role", membership.GetRole()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubMembershipDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubMembershipDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	username := d.Get("username").(string)
 	downgradeOnDestroy := d.Get("downgrade_on_destroy").(bool)
@@ -157,7 +165,7 @@ func resourceGithubMembershipDelete(d *schema.ResourceData, meta interface{}) er
 				}
 			}
 
-			return err
+			return diag.FromErr(err)
 		}
 
 		if *membership.Role == downgradeTo {
@@ -168,10 +176,27 @@ func resourceGithubMembershipDelete(d *schema.ResourceData, meta interface{}) er
 		_, _, err = client.Organizations.EditOrgMembership(ctx, username, orgName, &github.Membership{
 			Role: github.String(downgradeTo),
 		})
+	} else if d.Get("convert_to_outside_collaborator_on_destroy").(bool) {
+		log.Printf("[INFO] Converting '%s' member '%s' to an outside collaborator", orgName, username)
+
+		_, err = client.Organizations.ConvertMemberToOutsideCollaborator(ctx, orgName, username)
+		if err != nil {
+			if ghErr, ok := err.(*github.ErrorResponse); ok {
+				if ghErr.Response.StatusCode == http.StatusNotFound {
+					log.Printf("[INFO] Not converting '%s' member '%s' because they are not a member of the org anymore", orgName, username)
+					return nil
+				}
+			}
+			// GitHub answers 202 Accepted when the conversion is done
+			// asynchronously.
+			if _, ok := err.(*github.AcceptedError); ok {
+				err = nil
+			}
+		}
 	} else {
 		log.Printf("[INFO] Revoking '%s' membership for '%s'", orgName, username)
 		_, err = client.Organizations.RemoveOrgMembership(ctx, username, orgName)
 	}
 
-	return err
+	return diag.FromErr(err)
 }
@@ -248,3 +248,66 @@ func testAccGithubMembershipTheSame(orig, other *github.Membership) resource.Tes
 		return nil
 	}
 }
+
+func TestGithubMembershipWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	srv.AddUser("new-member")
+
+	config := map[string]interface{}{
+		"username": "new-member",
+		"role":     "member",
+	}
+
+	membership := newFakeResource(t, meta, "github_membership")
+	membership.apply(config)
+	if id := membership.state.ID; id != fakeOrganization+":new-member" {
+		t.Fatalf("Unexpected ID: %q", id)
+	}
+	membership.expectNoChanges(config)
+
+	config["role"] = "admin"
+	membership.apply(config)
+
+	imported := newFakeResource(t, meta, "github_membership")
+	imported.importState(fakeOrganization + ":new-member")
+	if role := imported.get("role"); role != "admin" {
+		t.Fatalf("Unexpected imported role: %q", role)
+	}
+
+	client := meta.(*Owner).v3client
+	if _, err := client.Organizations.RemoveOrgMembership(context.Background(), "new-member", fakeOrganization); err != nil {
+		t.Fatal(err)
+	}
+	membership.refresh()
+	if membership.state != nil {
+		t.Fatal("Expected a membership removed outside of Terraform to be removed from state")
+	}
+
+	// Members can be converted to outside collaborators instead of being
+	// removed.
+	invitation, _, err := client.Organizations.CreateOrgInvitation(context.Background(), fakeOrganization, &github.CreateOrgInvitationOptions{
+		Email: github.String("new-member@example.com"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv.AcceptInvitation(fakeOrganization, invitation.GetID(), "new-member")
+
+	converted := newFakeResource(t, meta, "github_membership")
+	converted.apply(map[string]interface{}{
+		"username": "new-member",
+		"convert_to_outside_collaborator_on_destroy": true,
+	})
+	converted.destroy()
+	if _, _, err := client.Organizations.GetOrgMembership(context.Background(), "new-member", fakeOrganization); err == nil {
+		t.Fatal("Expected the member to be removed from the organization")
+	}
+	collaborators, _, err := client.Organizations.ListOutsideCollaborators(context.Background(), fakeOrganization, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(collaborators) != 1 || collaborators[0].GetLogin() != "new-member" {
+		t.Fatalf("Expected the member to be an outside collaborator, got %v", collaborators)
+	}
+}
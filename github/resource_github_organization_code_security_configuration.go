@@ -0,0 +1,419 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// codeSecurityFeatureStates are the states of the features of a code security
+// configuration.
+var codeSecurityFeatureStates = []string{"enabled", "disabled", "not_set"}
+
+// codeSecurityConfiguration is a code security configuration with the
+// delegated bypass of push protection, which go-github does not support.
+type codeSecurityConfiguration struct {
+	github.CodeSecurityConfiguration
+	SecretScanningDelegatedBypass        *string                               `json:"secret_scanning_delegated_bypass,omitempty"`
+	SecretScanningDelegatedBypassOptions *secretScanningDelegatedBypassOptions `json:"secret_scanning_delegated_bypass_options,omitempty"`
+}
+
+type secretScanningDelegatedBypassOptions struct {
+	Reviewers []*secretScanningDelegatedBypassReviewer `json:"reviewers"`
+}
+
+type secretScanningDelegatedBypassReviewer struct {
+	ReviewerID   int64  `json:"reviewer_id"`
+	ReviewerType string `json:"reviewer_type"`
+}
+
+func (c *codeSecurityConfiguration) GetSecretScanningDelegatedBypass() string {
+	if c == nil || c.SecretScanningDelegatedBypass == nil {
+		return ""
+	}
+	return *c.SecretScanningDelegatedBypass
+}
+
+func resourceGithubOrganizationCodeSecurityConfiguration() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubOrganizationCodeSecurityConfigurationCreate,
+		ReadContext:   resourceGithubOrganizationCodeSecurityConfigurationRead,
+		UpdateContext: resourceGithubOrganizationCodeSecurityConfigurationUpdate,
+		DeleteContext: resourceGithubOrganizationCodeSecurityConfigurationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the code security configuration. Must be unique within the organization.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "A description of the code security configuration.",
+			},
+			"advanced_security": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of GitHub Advanced Security. Can be one of: 'enabled' or 'disabled'.",
+				ValidateDiagFunc: validateValueFunc([]string{"enabled", "disabled"}),
+			},
+			"dependency_graph": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of the dependency graph. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"dependency_graph_autosubmit_action": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of the automatic dependency submission action. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"dependency_graph_autosubmit_action_labeled_runners": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the automatic dependency submission action runs on runners labeled 'dependency-submission'. Defaults to 'false'.",
+			},
+			"dependabot_alerts": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of Dependabot alerts. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"dependabot_security_updates": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of Dependabot security updates. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"code_scanning_default_setup": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of code scanning default setup. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"secret_scanning": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of secret scanning. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"secret_scanning_push_protection": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of secret scanning push protection. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"secret_scanning_validity_checks": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of secret scanning validity checks. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"secret_scanning_delegated_bypass": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of the delegated bypass of secret scanning push protection. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"secret_scanning_delegated_bypass_reviewer": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The teams and roles which can review requests to bypass secret scanning push protection.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"reviewer_id": {
+							Type:        schema.TypeInt,
+							Required:    true,
+							Description: "The ID of the team or organization role.",
+						},
+						"reviewer_type": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "The type of the reviewer. Can be one of: 'TEAM' or 'ROLE'.",
+							ValidateDiagFunc: validateValueFunc([]string{"TEAM", "ROLE"}),
+						},
+					},
+				},
+			},
+			"secret_scanning_non_provider_patterns": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of secret scanning of non-provider patterns. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"private_vulnerability_reporting": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "The enablement status of private vulnerability reporting. Can be one of: 'enabled', 'disabled' or 'not_set'.",
+				ValidateDiagFunc: validateValueFunc(codeSecurityFeatureStates),
+			},
+			"enforcement": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Whether repositories can change the settings of the configuration. Can be one of: 'enforced' or 'unenforced'.",
+				ValidateDiagFunc: validateValueFunc([]string{"enforced", "unenforced"}),
+			},
+			"configuration_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the code security configuration.",
+			},
+			"target_type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the code security configuration, 'organization' for configurations managed by this resource.",
+			},
+			"html_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the code security configuration.",
+			},
+		},
+	}
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationObject(d *schema.ResourceData) *codeSecurityConfiguration {
+	configuration := &codeSecurityConfiguration{
+		CodeSecurityConfiguration: github.CodeSecurityConfiguration{
+			Name:        github.String(d.Get("name").(string)),
+			Description: github.String(d.Get("description").(string)),
+		},
+	}
+
+	optionalStrings := map[string]**string{
+		"advanced_security":                     &configuration.AdvancedSecurity,
+		"dependency_graph":                      &configuration.DependencyGraph,
+		"dependency_graph_autosubmit_action":    &configuration.DependencyGraphAutosubmitAction,
+		"dependabot_alerts":                     &configuration.DependabotAlerts,
+		"dependabot_security_updates":           &configuration.DependabotSecurityUpdates,
+		"code_scanning_default_setup":           &configuration.CodeScanningDefaultSetup,
+		"secret_scanning":                       &configuration.SecretScanning,
+		"secret_scanning_push_protection":       &configuration.SecretScanningPushProtection,
+		"secret_scanning_validity_checks":       &configuration.SecretScanningValidityChecks,
+		"secret_scanning_delegated_bypass":      &configuration.SecretScanningDelegatedBypass,
+		"secret_scanning_non_provider_patterns": &configuration.SecretScanningNonProviderPatterns,
+		"private_vulnerability_reporting":       &configuration.PrivateVulnerabilityReporting,
+		"enforcement":                           &configuration.Enforcement,
+	}
+	for k, field := range optionalStrings {
+		if v, ok := d.GetOk(k); ok {
+			*field = github.String(v.(string))
+		}
+	}
+
+	configuration.DependencyGraphAutosubmitActionOptions = &github.DependencyGraphAutosubmitActionOptions{
+		LabeledRunners: github.Bool(d.Get("dependency_graph_autosubmit_action_labeled_runners").(bool)),
+	}
+
+	reviewers := []*secretScanningDelegatedBypassReviewer{}
+	for _, v := range d.Get("secret_scanning_delegated_bypass_reviewer").(*schema.Set).List() {
+		reviewer := v.(map[string]interface{})
+		reviewers = append(reviewers, &secretScanningDelegatedBypassReviewer{
+			ReviewerID:   int64(reviewer["reviewer_id"].(int)),
+			ReviewerType: reviewer["reviewer_type"].(string),
+		})
+	}
+	configuration.SecretScanningDelegatedBypassOptions = &secretScanningDelegatedBypassOptions{
+		Reviewers: reviewers,
+	}
+
+	return configuration
+}
+
+func flattenSecretScanningDelegatedBypassReviewers(options *secretScanningDelegatedBypassOptions) []interface{} {
+	reviewers := []interface{}{}
+	if options == nil {
+		return reviewers
+	}
+	for _, reviewer := range options.Reviewers {
+		reviewers = append(reviewers, map[string]interface{}{
+			"reviewer_id":   int(reviewer.ReviewerID),
+			"reviewer_type": reviewer.ReviewerType,
+		})
+	}
+	return reviewers
+}
+
+// getCodeSecurityConfiguration and updateCodeSecurityConfiguration talk to the
+// API directly, because go-github drops the delegated bypass settings of code
+// security configurations.
+func getCodeSecurityConfiguration(ctx context.Context, client *github.Client, org string, id int64) (*codeSecurityConfiguration, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%v/code-security/configurations/%v", org, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration := &codeSecurityConfiguration{}
+	if _, err := client.Do(ctx, req, configuration); err != nil {
+		return nil, err
+	}
+	return configuration, nil
+}
+
+func createCodeSecurityConfiguration(ctx context.Context, client *github.Client, org string, body *codeSecurityConfiguration) (*codeSecurityConfiguration, error) {
+	req, err := client.NewRequest("POST", fmt.Sprintf("orgs/%v/code-security/configurations", org), body)
+	if err != nil {
+		return nil, err
+	}
+
+	configuration := &codeSecurityConfiguration{}
+	if _, err := client.Do(ctx, req, configuration); err != nil {
+		return nil, err
+	}
+	return configuration, nil
+}
+
+func updateCodeSecurityConfiguration(ctx context.Context, client *github.Client, org string, id int64, body *codeSecurityConfiguration) error {
+	req, err := client.NewRequest("PATCH", fmt.Sprintf("orgs/%v/code-security/configurations/%v", org, id), body)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	configuration, err := createCodeSecurityConfiguration(ctx, client, orgName, resourceGithubOrganizationCodeSecurityConfigurationObject(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating code security configuration %s in %s: %w", d.Get("name").(string), orgName, err))
+	}
+
+	d.SetId(strconv.FormatInt(configuration.GetID(), 10))
+	return resourceGithubOrganizationCodeSecurityConfigurationRead(ctx, d, meta)
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+
+	configuration, err := getCodeSecurityConfiguration(ctx, client, orgName, id)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing code security configuration %s/%s from state because it no longer exists in GitHub",
+					orgName, d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	values := map[string]interface{}{
+		"name":                                               configuration.GetName(),
+		"description":                                        configuration.GetDescription(),
+		"advanced_security":                                  configuration.GetAdvancedSecurity(),
+		"dependency_graph":                                   configuration.GetDependencyGraph(),
+		"dependency_graph_autosubmit_action":                 configuration.GetDependencyGraphAutosubmitAction(),
+		"dependabot_alerts":                                  configuration.GetDependabotAlerts(),
+		"dependabot_security_updates":                        configuration.GetDependabotSecurityUpdates(),
+		"code_scanning_default_setup":                        configuration.GetCodeScanningDefaultSetup(),
+		"secret_scanning":                                    configuration.GetSecretScanning(),
+		"secret_scanning_push_protection":                    configuration.GetSecretScanningPushProtection(),
+		"secret_scanning_validity_checks":                    configuration.GetSecretScanningValidityChecks(),
+		"secret_scanning_delegated_bypass":                   configuration.GetSecretScanningDelegatedBypass(),
+		"secret_scanning_delegated_bypass_reviewer":          flattenSecretScanningDelegatedBypassReviewers(configuration.SecretScanningDelegatedBypassOptions),
+		"secret_scanning_non_provider_patterns":              configuration.GetSecretScanningNonProviderPatterns(),
+		"private_vulnerability_reporting":                    configuration.GetPrivateVulnerabilityReporting(),
+		"enforcement":                                        configuration.GetEnforcement(),
+		"dependency_graph_autosubmit_action_labeled_runners": configuration.GetDependencyGraphAutosubmitActionOptions().GetLabeledRunners(),
+		"configuration_id":                                   configuration.GetID(),
+		"target_type":                                        configuration.GetTargetType(),
+		"html_url":                                           configuration.GetHTMLURL(),
+	}
+	for k, v := range values {
+		if err = d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+
+	err = updateCodeSecurityConfiguration(ctx, client, orgName, id, resourceGithubOrganizationCodeSecurityConfigurationObject(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating code security configuration %s/%s: %w", orgName, d.Id(), err))
+	}
+
+	return resourceGithubOrganizationCodeSecurityConfigurationRead(ctx, d, meta)
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+
+	_, err = client.Organizations.DeleteCodeSecurityConfiguration(ctx, orgName, id)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting code security configuration %s/%s: %w", orgName, d.Id(), err))
+	}
+
+	return nil
+}
@@ -0,0 +1,280 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// codeSecurityConfigurationRepository is a repository a code security
+// configuration is attached to. go-github decodes the response of the
+// endpoint listing them as bare repositories, losing their IDs.
+type codeSecurityConfigurationRepository struct {
+	Status     string             `json:"status"`
+	Repository *github.Repository `json:"repository"`
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationAttachment() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubOrganizationCodeSecurityConfigurationAttachmentCreateOrUpdate,
+		ReadContext:   resourceGithubOrganizationCodeSecurityConfigurationAttachmentRead,
+		UpdateContext: resourceGithubOrganizationCodeSecurityConfigurationAttachmentCreateOrUpdate,
+		DeleteContext: resourceGithubOrganizationCodeSecurityConfigurationAttachmentDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"configuration_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the code security configuration to attach.",
+			},
+			"repository_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Description: "The IDs of the repositories the configuration is attached to. Repositories which are not listed are detached from the configuration.",
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+			},
+			"default_for_new_repos": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "none",
+				Description:      "The type of new repositories the configuration is applied to by default. Can be one of: 'all', 'none', 'private_and_internal' or 'public'.",
+				ValidateDiagFunc: validateValueFunc([]string{"all", "none", "private_and_internal", "public"}),
+			},
+		},
+	}
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationAttachmentCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	id := int64(d.Get("configuration_id").(int))
+
+	o, n := d.GetChange("repository_ids")
+	attached := expandInt64Set(n.(*schema.Set).Difference(o.(*schema.Set)))
+	detached := expandInt64Set(o.(*schema.Set).Difference(n.(*schema.Set)))
+
+	if len(detached) > 0 {
+		// Repositories removed from the configuration may have been attached
+		// to another one since, they are left alone
+		detached, err = filterAttachedRepositoryIDs(ctx, client, orgName, id, detached)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	if len(attached) > 0 {
+		_, err = client.Organizations.AttachCodeSecurityConfigurationsToRepositories(ctx, orgName, id, "selected", attached)
+		if err != nil {
+			return attributeDiagnostics(fmt.Errorf("error attaching code security configuration %s/%d to repositories: %w", orgName, id, err), "repository_ids")
+		}
+	}
+	if len(detached) > 0 {
+		_, err = client.Organizations.DetachCodeSecurityConfigurationsFromRepositories(ctx, orgName, detached)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error detaching code security configuration %s/%d from repositories: %w", orgName, id, err))
+		}
+	}
+
+	if d.IsNewResource() || d.HasChange("default_for_new_repos") {
+		_, _, err = client.Organizations.SetDefaultCodeSecurityConfiguration(ctx, orgName, id, d.Get("default_for_new_repos").(string))
+		if err != nil {
+			return attributeDiagnostics(fmt.Errorf("error setting code security configuration %s/%d as default: %w", orgName, id, err), "default_for_new_repos")
+		}
+	}
+
+	d.SetId(strconv.FormatInt(id, 10))
+
+	return resourceGithubOrganizationCodeSecurityConfigurationAttachmentRead(ctx, d, meta)
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationAttachmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+
+	repositoryIDs, err := listAttachedRepositoryIDs(ctx, client, orgName, id)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing code security configuration attachment %s/%s from state because the configuration no longer exists in GitHub",
+					orgName, d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	defaultForNewRepos, err := getDefaultForNewRepos(ctx, client, orgName, id)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("configuration_id", id); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("repository_ids", repositoryIDs); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("default_for_new_repos", defaultForNewRepos); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationCodeSecurityConfigurationAttachmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	id := int64(d.Get("configuration_id").(int))
+
+	// Only the repositories still attached to this configuration are
+	// detached, detaching moves them off any other configuration as well
+	repositoryIDs, err := filterAttachedRepositoryIDs(ctx, client, orgName, id, expandInt64Set(d.Get("repository_ids").(*schema.Set)))
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	if len(repositoryIDs) > 0 {
+		_, err = client.Organizations.DetachCodeSecurityConfigurationsFromRepositories(ctx, orgName, repositoryIDs)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error detaching code security configuration %s/%d from repositories: %w", orgName, id, err))
+		}
+	}
+
+	if d.Get("default_for_new_repos").(string) != "none" {
+		_, _, err = client.Organizations.SetDefaultCodeSecurityConfiguration(ctx, orgName, id, "none")
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error unsetting code security configuration %s/%d as default: %w", orgName, id, err))
+		}
+	}
+
+	return nil
+}
+
+// listCodeSecurityConfigurationRepositories returns all the repositories the
+// code security configuration is attached to.
+func listCodeSecurityConfigurationRepositories(ctx context.Context, client *github.Client, org string, id int64) ([]*codeSecurityConfigurationRepository, error) {
+	var repositories []*codeSecurityConfigurationRepository
+	after := ""
+	for {
+		u := fmt.Sprintf("orgs/%v/code-security/configurations/%v/repositories?per_page=%v", org, id, maxPerPage)
+		if after != "" {
+			u += "&after=" + url.QueryEscape(after)
+		}
+
+		req, err := client.NewRequest("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page []*codeSecurityConfigurationRepository
+		resp, err := client.Do(ctx, req, &page)
+		if err != nil {
+			return nil, err
+		}
+		repositories = append(repositories, page...)
+
+		if resp.After == "" {
+			break
+		}
+		after = resp.After
+	}
+	return repositories, nil
+}
+
+// listAttachedRepositoryIDs returns the IDs of the repositories the code
+// security configuration is attached to, or being attached to.
+func listAttachedRepositoryIDs(ctx context.Context, client *github.Client, org string, id int64) ([]int64, error) {
+	repositories, err := listCodeSecurityConfigurationRepositories(ctx, client, org, id)
+	if err != nil {
+		return nil, err
+	}
+
+	repositoryIDs := make([]int64, 0, len(repositories))
+	for _, repository := range repositories {
+		switch repository.Status {
+		case "detached", "removed", "removed_by_enterprise", "failed":
+			continue
+		}
+		repositoryIDs = append(repositoryIDs, repository.Repository.GetID())
+	}
+	return repositoryIDs, nil
+}
+
+// filterAttachedRepositoryIDs returns the repositories among repositoryIDs
+// which are still attached to the code security configuration.
+func filterAttachedRepositoryIDs(ctx context.Context, client *github.Client, org string, id int64, repositoryIDs []int64) ([]int64, error) {
+	if len(repositoryIDs) == 0 {
+		return nil, nil
+	}
+
+	attachedIDs, err := listAttachedRepositoryIDs(ctx, client, org, id)
+	if err != nil {
+		return nil, err
+	}
+	attached := make(map[int64]bool, len(attachedIDs))
+	for _, repositoryID := range attachedIDs {
+		attached[repositoryID] = true
+	}
+
+	var filtered []int64
+	for _, repositoryID := range repositoryIDs {
+		if attached[repositoryID] {
+			filtered = append(filtered, repositoryID)
+		}
+	}
+	return filtered, nil
+}
+
+// getDefaultForNewRepos returns the type of new repositories the code
+// security configuration is applied to, 'none' when it is not a default one.
+func getDefaultForNewRepos(ctx context.Context, client *github.Client, org string, id int64) (string, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("orgs/%v/code-security/configurations/defaults", org), nil)
+	if err != nil {
+		return "", err
+	}
+
+	var defaults []*github.CodeSecurityConfigurationWithDefaultForNewRepos
+	if _, err := client.Do(ctx, req, &defaults); err != nil {
+		return "", err
+	}
+	for _, d := range defaults {
+		if d.GetConfiguration().GetID() == id {
+			return d.GetDefaultForNewRepos(), nil
+		}
+	}
+	return "none", nil
+}
@@ -0,0 +1,138 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationCodeSecurityConfigurationAttachment(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("attaches a code security configuration to repositories without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_organization_code_security_configuration" "test" {
+			  name              = "tf-acc-test-%[1]s"
+			  description       = "Terraform acceptance tests"
+			  dependabot_alerts = "enabled"
+			}
+
+			resource "github_repository" "test" {
+			  name = "tf-acc-test-%[1]s"
+			}
+
+			resource "github_organization_code_security_configuration_attachment" "test" {
+			  configuration_id      = github_organization_code_security_configuration.test.configuration_id
+			  repository_ids        = [github_repository.test.repo_id]
+			  default_for_new_repos = "public"
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_organization_code_security_configuration_attachment.test", "repository_ids.#",
+				"1",
+			),
+			resource.TestCheckResourceAttr(
+				"github_organization_code_security_configuration_attachment.test", "default_for_new_repos",
+				"public",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubOrganizationCodeSecurityConfigurationAttachmentWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+	service := newFakeRepository(t, meta, "service")
+	website := newFakeRepository(t, meta, "website")
+
+	configuration := newFakeResource(t, meta, "github_organization_code_security_configuration")
+	configuration.apply(map[string]interface{}{
+		"name":        "ghas-rollout",
+		"description": "Advanced Security for production repositories",
+	})
+	configurationID := mustParseInt64(t, configuration.state.ID)
+
+	config := map[string]interface{}{
+		"configuration_id":      int(configurationID),
+		"repository_ids":        []interface{}{int(mustParseInt64(t, service.get("repo_id"))), int(mustParseInt64(t, website.get("repo_id")))},
+		"default_for_new_repos": "private_and_internal",
+	}
+
+	attachment := newFakeResource(t, meta, "github_organization_code_security_configuration_attachment")
+	attachment.apply(config)
+	if attachment.get("repository_ids.#") != "2" || attachment.get("default_for_new_repos") != "private_and_internal" {
+		t.Fatalf("Unexpected attachment: %v", attachment.state.Attributes)
+	}
+	attachment.expectNoChanges(config)
+
+	config["repository_ids"] = []interface{}{int(mustParseInt64(t, website.get("repo_id")))}
+	config["default_for_new_repos"] = "none"
+	attachment.apply(config)
+	if attachment.get("repository_ids.#") != "1" {
+		t.Fatalf("Expected the removed repository to be detached, got: %v", attachment.state.Attributes)
+	}
+	attachment.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_organization_code_security_configuration_attachment")
+	imported.importState(attachment.state.ID)
+	imported.expectNoChanges(config)
+
+	// Repositories moved to another configuration are left attached to it on
+	// destroy.
+	other := newFakeResource(t, meta, "github_organization_code_security_configuration")
+	other.apply(map[string]interface{}{
+		"name":        "ghas-pilot",
+		"description": "Advanced Security for pilot repositories",
+	})
+	otherID := mustParseInt64(t, other.state.ID)
+	client := meta.(*Owner).v3client
+	websiteID := mustParseInt64(t, website.get("repo_id"))
+	if _, err := client.Organizations.AttachCodeSecurityConfigurationsToRepositories(context.Background(), fakeOrganization, otherID, "selected", []int64{websiteID}); err != nil {
+		t.Fatal(err)
+	}
+
+	attachment.destroy()
+	imported.refresh()
+	if imported.get("repository_ids.#") != "0" {
+		t.Fatalf("Expected the repositories to be detached, got: %v", imported.state.Attributes)
+	}
+	repositoryIDs, err := listAttachedRepositoryIDs(context.Background(), client, fakeOrganization, otherID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(repositoryIDs) != 1 || repositoryIDs[0] != websiteID {
+		t.Fatalf("Expected the repository to stay attached to the other configuration, got: %v", repositoryIDs)
+	}
+}
@@ -0,0 +1,142 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationCodeSecurityConfiguration(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("creates and updates code security configurations without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_organization_code_security_configuration" "test" {
+			  name                            = "tf-acc-test-%s"
+			  description                     = "Terraform acceptance tests"
+			  dependabot_alerts               = "enabled"
+			  secret_scanning                 = "%%s"
+			  private_vulnerability_reporting = "enabled"
+			}
+		`, randomID)
+
+		checks := map[string]resource.TestCheckFunc{
+			"before": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_organization_code_security_configuration.test", "secret_scanning",
+					"disabled",
+				),
+				resource.TestCheckResourceAttr(
+					"github_organization_code_security_configuration.test", "target_type",
+					"organization",
+				),
+			),
+			"after": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_organization_code_security_configuration.test", "secret_scanning",
+					"enabled",
+				),
+			),
+		}
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(config, "disabled"),
+						Check:  checks["before"],
+					},
+					{
+						Config: fmt.Sprintf(config, "enabled"),
+						Check:  checks["after"],
+					},
+					{
+						ResourceName:      "github_organization_code_security_configuration.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubOrganizationCodeSecurityConfigurationWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+
+	config := map[string]interface{}{
+		"name":              "ghas-rollout",
+		"description":       "Advanced Security for production repositories",
+		"advanced_security": "enabled",
+		"secret_scanning":   "enabled",
+	}
+
+	configuration := newFakeResource(t, meta, "github_organization_code_security_configuration")
+	configuration.apply(config)
+	if configuration.get("dependency_graph") != "enabled" || configuration.get("enforcement") != "enforced" {
+		t.Fatalf("Expected the settings which are not configured to be read, got: %v", configuration.state.Attributes)
+	}
+	configuration.expectNoChanges(config)
+
+	config["secret_scanning_push_protection"] = "enabled"
+	config["dependency_graph_autosubmit_action_labeled_runners"] = true
+	configuration.apply(config)
+	configuration.expectNoChanges(config)
+
+	team := newFakeResource(t, meta, "github_team")
+	team.apply(map[string]interface{}{"name": "security-reviewers"})
+	config["secret_scanning_delegated_bypass"] = "enabled"
+	config["secret_scanning_delegated_bypass_reviewer"] = []interface{}{
+		map[string]interface{}{"reviewer_id": int(mustParseInt64(t, team.state.ID)), "reviewer_type": "TEAM"},
+	}
+	configuration.apply(config)
+	configuration.expectNoChanges(config)
+	if configuration.get("secret_scanning_delegated_bypass_reviewer.#") != "1" {
+		t.Fatalf("Expected the bypass reviewer to be read, got: %v", configuration.state.Attributes)
+	}
+
+	invalid := map[string]interface{}{}
+	for k, v := range config {
+		invalid[k] = v
+	}
+	invalid["secret_scanning_delegated_bypass_reviewer"] = []interface{}{
+		map[string]interface{}{"reviewer_id": 42, "reviewer_type": "ROLE"},
+	}
+	diff := configuration.plan(invalid)
+	if _, diags := configuration.resource.Apply(context.Background(), configuration.state, diff, meta); !diags.HasError() {
+		t.Fatal("Expected an unknown bypass reviewer to be refused")
+	}
+
+	imported := newFakeResource(t, meta, "github_organization_code_security_configuration")
+	imported.importState(configuration.state.ID)
+	imported.expectNoChanges(config)
+	if imported.get("configuration_id") != configuration.state.ID {
+		t.Fatalf("Unexpected imported state: %v", imported.state.Attributes)
+	}
+
+	configuration.destroy()
+	imported.refresh()
+	if imported.state != nil {
+		t.Fatal("Expected a deleted configuration to be removed from state")
+	}
+}
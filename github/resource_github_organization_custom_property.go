@@ -0,0 +1,198 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const (
+	customPropertyTypeString       = "string"
+	customPropertyTypeSingleSelect = "single_select"
+	customPropertyTypeMultiSelect  = "multi_select"
+	customPropertyTypeTrueFalse    = "true_false"
+)
+
+func resourceGithubOrganizationCustomProperty() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubOrganizationCustomPropertyCreateOrUpdate,
+		ReadContext:   resourceGithubOrganizationCustomPropertyRead,
+		UpdateContext: resourceGithubOrganizationCustomPropertyCreateOrUpdate,
+		DeleteContext: resourceGithubOrganizationCustomPropertyDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := d.Set("name", d.Id()); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the custom property.",
+			},
+			"value_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				Description:      "The type of the value of the property. Can be one of: 'string', 'single_select', 'multi_select' or 'true_false'.",
+				ValidateDiagFunc: validateValueFunc([]string{customPropertyTypeString, customPropertyTypeSingleSelect, customPropertyTypeMultiSelect, customPropertyTypeTrueFalse}),
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "A short description of the custom property.",
+			},
+			"allowed_values": {
+				Type:        schema.TypeList,
+				Optional:    true,
+				Description: "An ordered list of the values allowed for 'single_select' and 'multi_select' properties.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"required": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether every repository must have a value for the property. Required properties need a default value.",
+			},
+			"default_value": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The value of the property for repositories which do not set one.",
+			},
+			"values_editable_by": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				Description:      "Who can edit the values of the property. Can be one of: 'org_actors' or 'org_and_repo_actors'.",
+				ValidateDiagFunc: validateValueFunc([]string{"org_actors", "org_and_repo_actors"}),
+			},
+		},
+	}
+}
+
+func resourceGithubOrganizationCustomPropertyCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	name := d.Get("name").(string)
+
+	property := &github.CustomProperty{
+		ValueType:     d.Get("value_type").(string),
+		Required:      github.Bool(d.Get("required").(bool)),
+		Description:   github.String(d.Get("description").(string)),
+		AllowedValues: expandStringList(d.Get("allowed_values").([]interface{})),
+	}
+	if v, ok := d.GetOk("values_editable_by"); ok {
+		property.ValuesEditableBy = github.String(v.(string))
+	}
+
+	// GitHub keeps the default value when it is omitted, it is only removed
+	// when sent as null.
+	body := &customPropertyRequest{CustomProperty: property}
+	if v, ok := d.GetOk("default_value"); ok {
+		body.DefaultValue = github.String(v.(string))
+	}
+
+	err = setCustomProperty(ctx, client, orgName, name, body)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error setting GitHub custom property %s (%s): %w", orgName, name, err))
+	}
+
+	d.SetId(name)
+	return resourceGithubOrganizationCustomPropertyRead(ctx, d, meta)
+}
+
+func resourceGithubOrganizationCustomPropertyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	property, _, err := client.Organizations.GetCustomProperty(ctx, orgName, d.Id())
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing custom property %s/%s from state because it no longer exists in GitHub",
+					orgName, d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("name", property.GetPropertyName()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("value_type", property.ValueType); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("description", property.GetDescription()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("allowed_values", property.AllowedValues); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("required", property.GetRequired()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("default_value", property.GetDefaultValue()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("values_editable_by", property.GetValuesEditableBy()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationCustomPropertyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	_, err = client.Organizations.RemoveCustomProperty(ctx, orgName, d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting GitHub custom property %s (%s): %w", orgName, d.Id(), err))
+	}
+
+	return nil
+}
+
+// customPropertyRequest is a custom property as sent to GitHub, whose default
+// value is sent as null rather than omitted when it is not set, so that
+// removing it from the configuration removes it from the property.
+type customPropertyRequest struct {
+	*github.CustomProperty
+	DefaultValue *string `json:"default_value"`
+}
+
+func setCustomProperty(ctx context.Context, client *github.Client, org, name string, body *customPropertyRequest) error {
+	req, err := client.NewRequest("PUT", fmt.Sprintf("orgs/%v/properties/schema/%v", org, name), body)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
@@ -0,0 +1,132 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationCustomProperty(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("creates and updates custom properties without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_organization_custom_property" "test" {
+			  name           = "tf-acc-test-%s"
+			  value_type     = "single_select"
+			  description    = "Test property description"
+			  allowed_values = ["gold", "silver", "bronze"]
+			  required       = %%t
+			  default_value  = "bronze"
+			}
+		`, randomID)
+
+		checks := map[string]resource.TestCheckFunc{
+			"before": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_organization_custom_property.test", "allowed_values.#",
+					"3",
+				),
+				resource.TestCheckResourceAttr(
+					"github_organization_custom_property.test", "required",
+					"false",
+				),
+			),
+			"after": resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_organization_custom_property.test", "required",
+					"true",
+				),
+				resource.TestCheckResourceAttr(
+					"github_organization_custom_property.test", "default_value",
+					"bronze",
+				),
+			),
+		}
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: fmt.Sprintf(config, false),
+						Check:  checks["before"],
+					},
+					{
+						Config: fmt.Sprintf(config, true),
+						Check:  checks["after"],
+					},
+					{
+						ResourceName:      "github_organization_custom_property.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubOrganizationCustomPropertyWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+
+	config := map[string]interface{}{
+		"name":           "tier",
+		"value_type":     "single_select",
+		"allowed_values": []interface{}{"gold", "silver", "bronze"},
+	}
+
+	property := newFakeResource(t, meta, "github_organization_custom_property")
+	property.apply(config)
+	if editableBy := property.get("values_editable_by"); editableBy != "org_actors" {
+		t.Fatalf("Unexpected values_editable_by: %q", editableBy)
+	}
+	property.expectNoChanges(config)
+
+	config["required"] = true
+	config["default_value"] = "bronze"
+	property.apply(config)
+	property.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_organization_custom_property")
+	imported.importState("tier")
+	if imported.get("allowed_values.2") != "bronze" || imported.get("default_value") != "bronze" {
+		t.Fatalf("Unexpected imported state: %v", imported.state.Attributes)
+	}
+
+	// Removing the default value removes it from the property.
+	delete(config, "default_value")
+	config["required"] = false
+	property.apply(config)
+	property.expectNoChanges(config)
+	if property.get("default_value") != "" {
+		t.Fatalf("Expected the default value to be removed, got: %v", property.state.Attributes)
+	}
+	config["required"] = true
+	config["default_value"] = "bronze"
+	property.apply(config)
+
+	property.destroy()
+	imported.refresh()
+	if imported.state != nil {
+		t.Fatal("Expected a deleted custom property to be removed from state")
+	}
+}
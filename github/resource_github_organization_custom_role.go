@@ -7,15 +7,16 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubOrganizationCustomRole() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubOrganizationCustomRoleCreate,
-		Read:   resourceGithubOrganizationCustomRoleRead,
-		Update: resourceGithubOrganizationCustomRoleUpdate,
-		Delete: resourceGithubOrganizationCustomRoleDelete,
+		CreateContext: resourceGithubOrganizationCustomRoleCreate,
+		ReadContext:   resourceGithubOrganizationCustomRoleRead,
+		UpdateContext: resourceGithubOrganizationCustomRoleUpdate,
+		DeleteContext: resourceGithubOrganizationCustomRoleDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -48,14 +49,13 @@ func resourceGithubOrganizationCustomRole() *schema.Resource {
 	}
 }
 
-func resourceGithubOrganizationCustomRoleCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationCustomRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := context.Background()
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	permissions := d.Get("permissions").(*schema.Set).List()
@@ -72,21 +72,20 @@ func resourceGithubOrganizationCustomRoleCreate(d *schema.ResourceData, meta int
 	})
 
 	if err != nil {
-		return fmt.Errorf("error creating GitHub custom repository role %s (%s): %s", orgName, d.Get("name").(string), err)
+		return diag.FromErr(fmt.Errorf("error creating GitHub custom repository role %s (%s): %s", orgName, d.Get("name").(string), err))
 	}
 
 	d.SetId(fmt.Sprint(*role.ID))
-	return resourceGithubOrganizationCustomRoleRead(d, meta)
+	return resourceGithubOrganizationCustomRoleRead(ctx, d, meta)
 }
 
-func resourceGithubOrganizationCustomRoleRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationCustomRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 	orgName := meta.(*Owner).name
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	roleID := d.Id()
@@ -96,7 +95,7 @@ func resourceGithubOrganizationCustomRoleRead(d *schema.ResourceData, meta inter
 	// implemented in the go-github library.
 	roleList, _, err := client.Organizations.ListCustomRepoRoles(ctx, orgName)
 	if err != nil {
-		return fmt.Errorf("error querying GitHub custom repository roles %s: %s", orgName, err)
+		return diag.FromErr(fmt.Errorf("error querying GitHub custom repository roles %s: %s", orgName, err))
 	}
 
 	var role *github.CustomRepoRoles
@@ -114,35 +113,34 @@ func resourceGithubOrganizationCustomRoleRead(d *schema.ResourceData, meta inter
 	}
 
 	if err = d.Set("name", role.Name); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("description", role.Description); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("base_role", role.BaseRole); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("permissions", role.Permissions); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubOrganizationCustomRoleUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationCustomRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 	orgName := meta.(*Owner).name
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	// convert d.Id() from string to int64
 	roleIDStr := d.Id()
 	roleID, err := strconv.ParseInt(roleIDStr, 10, 64)
 	if err != nil {
-		return fmt.Errorf("Error converting role ID %s to int64: %s", roleIDStr, err)
+		return diag.FromErr(fmt.Errorf("Error converting role ID %s to int64: %s", roleIDStr, err))
 	}
 
 	permissions := d.Get("permissions").(*schema.Set).List()
@@ -159,30 +157,29 @@ func resourceGithubOrganizationCustomRoleUpdate(d *schema.ResourceData, meta int
 	}
 
 	if _, _, err := client.Organizations.UpdateCustomRepoRole(ctx, orgName, roleID, update); err != nil {
-		return fmt.Errorf("error updating GitHub custom repository role %s (%d): %s", orgName, roleID, err)
+		return diag.FromErr(fmt.Errorf("error updating GitHub custom repository role %s (%d): %s", orgName, roleID, err))
 	}
 
-	return resourceGithubOrganizationCustomRoleRead(d, meta)
+	return resourceGithubOrganizationCustomRoleRead(ctx, d, meta)
 }
 
-func resourceGithubOrganizationCustomRoleDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationCustomRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 	orgName := meta.(*Owner).name
 
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	roleIDStr := d.Id()
 	roleID, err := strconv.ParseInt(roleIDStr, 10, 64)
 	if err != nil {
-		return fmt.Errorf("Error converting role ID %s to int64: %s", roleIDStr, err)
+		return diag.FromErr(fmt.Errorf("Error converting role ID %s to int64: %s", roleIDStr, err))
 	}
 
 	_, err = client.Organizations.DeleteCustomRepoRole(ctx, orgName, roleID)
 	if err != nil {
-		return fmt.Errorf("Error deleting GitHub custom repository role %s (%d): %s", orgName, roleID, err)
+		return diag.FromErr(fmt.Errorf("Error deleting GitHub custom repository role %s (%d): %s", orgName, roleID, err))
 	}
 
 	return nil
@@ -0,0 +1,328 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
+)
+
+func resourceGithubOrganizationInvitation() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubOrganizationInvitationCreate,
+		ReadContext:   resourceGithubOrganizationInvitationRead,
+		DeleteContext: resourceGithubOrganizationInvitationDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"email": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				ForceNew:     true,
+				ExactlyOneOf: []string{"email", "login"},
+				Description:  "The email address of the person to invite, who does not need to have a GitHub account yet.",
+			},
+			"login": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: caseInsensitive(),
+				ExactlyOneOf:     []string{"email", "login"},
+				Description:      "The login of the user to invite.",
+			},
+			"role": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				ForceNew:         true,
+				Default:          "direct_member",
+				ValidateDiagFunc: validateValueFunc([]string{"admin", "direct_member", "billing_manager", "reinstate"}),
+				Description:      "The role of the invitee in the organization. Must be one of 'admin', 'direct_member', 'billing_manager' or 'reinstate'.",
+			},
+			"team_ids": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				ForceNew:    true,
+				Elem:        &schema.Schema{Type: schema.TypeInt},
+				Description: "The IDs of the teams the invitee is added to.",
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the invitation, 'pending' or 'accepted'.",
+			},
+			"inviter": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The login of the user who sent the invitation.",
+			},
+			"created_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time the invitation was sent.",
+			},
+		},
+	}
+}
+
+func resourceGithubOrganizationInvitationCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	options := &github.CreateOrgInvitationOptions{
+		Role: github.String(d.Get("role").(string)),
+	}
+	invitee := d.Get("email").(string)
+	if invitee != "" {
+		options.Email = github.String(invitee)
+	} else {
+		invitee = d.Get("login").(string)
+		user, _, err := client.Users.Get(ctx, invitee)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error querying user %s: %w", invitee, err))
+		}
+		options.InviteeID = user.ID
+	}
+	for _, id := range d.Get("team_ids").(*schema.Set).List() {
+		options.TeamID = append(options.TeamID, int64(id.(int)))
+	}
+
+	invitation, _, err := client.Organizations.CreateOrgInvitation(ctx, orgName, options)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error inviting %s to %s: %w", invitee, orgName, err))
+	}
+
+	d.SetId(strconv.FormatInt(invitation.GetID(), 10))
+	return resourceGithubOrganizationInvitationRead(ctx, d, meta)
+}
+
+// findOrganizationInvitation returns the invitation with the given ID from
+// the pending or the failed invitations of an organization, or nil.
+func findOrganizationInvitation(ctx context.Context, org string, id int64,
+	list func(ctx context.Context, org string, opts *github.ListOptions) ([]*github.Invitation, *github.Response, error)) (*github.Invitation, error) {
+	options := &github.ListOptions{PerPage: maxPerPage}
+	for {
+		invitations, resp, err := list(ctx, org, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, invitation := range invitations {
+			if invitation.GetID() == id {
+				return invitation, nil
+			}
+		}
+
+		if resp.NextPage == 0 {
+			return nil, nil
+		}
+		options.Page = resp.NextPage
+	}
+}
+
+func resourceGithubOrganizationInvitationRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+
+	invitation, err := findOrganizationInvitation(ctx, orgName, id, client.Organizations.ListPendingOrgInvitations)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing the pending invitations of %s: %w", orgName, err))
+	}
+	if invitation != nil {
+		return resourceGithubOrganizationInvitationSetPending(ctx, d, meta, invitation)
+	}
+
+	// Invitations which expired or failed are invited again.
+	invitation, err = findOrganizationInvitation(ctx, orgName, id, client.Organizations.ListFailedOrgInvitations)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error listing the failed invitations of %s: %w", orgName, err))
+	}
+	if invitation != nil {
+		log.Printf("[INFO] Removing organization invitation %s from state because it failed: %s", d.Id(), invitation.GetFailedReason())
+		d.SetId("")
+		return nil
+	}
+
+	// The invitation is neither pending nor failed, so it was either accepted
+	// or cancelled. Which one can only be told from the membership of the
+	// invitee, whose login or email must be known.
+	login := d.Get("login").(string)
+	email := d.Get("email").(string)
+	if login == "" && email == "" {
+		return diag.Errorf("organization invitation %s not found among the pending invitations of %s: only pending invitations can be imported", d.Id(), orgName)
+	}
+
+	var diags diag.Diagnostics
+	if login != "" {
+		_, _, err = client.Organizations.GetOrgMembership(ctx, login, orgName)
+		if err != nil {
+			if ghErr, ok := err.(*github.ErrorResponse); ok {
+				if ghErr.Response.StatusCode == http.StatusNotFound {
+					log.Printf("[INFO] Removing organization invitation %s from state because it no longer exists in GitHub", d.Id())
+					d.SetId("")
+					return nil
+				}
+			}
+			return diag.FromErr(err)
+		}
+	} else {
+		// GitHub does not tell which account accepted an invitation by
+		// email, so the invitee is looked up among the members by their
+		// public email.
+		member, err := findOrganizationMemberByEmail(ctx, meta.(*Owner).v4client, orgName, email)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error listing the members of %s: %w", orgName, err))
+		}
+		if member == "" {
+			diags = append(diags, diag.Diagnostic{
+				Severity: diag.Warning,
+				Summary:  fmt.Sprintf("Unable to verify that the invitation of %s was accepted", email),
+				Detail: fmt.Sprintf("Organization invitation %s is no longer pending, but no member of %s has %s "+
+					"as their public email, so it is assumed to be accepted. If it was cancelled instead, "+
+					"taint the resource to invite %s again.", d.Id(), orgName, email, email),
+			})
+		}
+	}
+	if err = d.Set("state", "accepted"); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return diags
+}
+
+// findOrganizationMemberByEmail returns the login of the member of the
+// organization whose public email is the given one, or an empty string if
+// there is none.
+func findOrganizationMemberByEmail(ctx context.Context, client *githubv4.Client, orgName string, email string) (string, error) {
+	var query struct {
+		Organization struct {
+			MembersWithRole struct {
+				Nodes []struct {
+					Login githubv4.String
+					Email githubv4.String
+				}
+				PageInfo struct {
+					EndCursor   githubv4.String
+					HasNextPage bool
+				}
+			} `graphql:"membersWithRole(first: 100, after: $after)"`
+		} `graphql:"organization(login: $login)"`
+	}
+	variables := map[string]interface{}{
+		"login": githubv4.String(orgName),
+		"after": (*githubv4.String)(nil),
+	}
+	for {
+		if err := client.Query(ctx, &query, variables); err != nil {
+			return "", err
+		}
+		for _, member := range query.Organization.MembersWithRole.Nodes {
+			if member.Email != "" && strings.EqualFold(string(member.Email), email) {
+				return string(member.Login), nil
+			}
+		}
+		if !query.Organization.MembersWithRole.PageInfo.HasNextPage {
+			return "", nil
+		}
+		variables["after"] = githubv4.NewString(query.Organization.MembersWithRole.PageInfo.EndCursor)
+	}
+}
+
+func resourceGithubOrganizationInvitationSetPending(ctx context.Context, d *schema.ResourceData, meta interface{}, invitation *github.Invitation) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	teamIDs := []int64{}
+	options := &github.ListOptions{PerPage: maxPerPage}
+	for {
+		teams, resp, err := client.Organizations.ListOrgInvitationTeams(ctx, orgName, d.Id(), options)
+		if err != nil {
+			return diag.FromErr(fmt.Errorf("error listing the teams of invitation %s: %w", d.Id(), err))
+		}
+		for _, team := range teams {
+			teamIDs = append(teamIDs, team.GetID())
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	// Invitations by email to existing users have both an email and a login,
+	// only the one the invitation was sent to is kept.
+	values := map[string]interface{}{
+		"team_ids":   teamIDs,
+		"state":      "pending",
+		"inviter":    invitation.GetInviter().GetLogin(),
+		"created_at": invitation.GetCreatedAt().String(),
+	}
+	// Invitations reinstating former members report the role they are
+	// reinstated with.
+	if d.Get("role").(string) != "reinstate" {
+		values["role"] = invitation.GetRole()
+	}
+	if invitation.GetEmail() != "" && d.Get("login").(string) == "" {
+		values["email"] = invitation.GetEmail()
+	} else {
+		values["login"] = invitation.GetLogin()
+	}
+	for k, v := range values {
+		if err := d.Set(k, v); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationInvitationDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	// Accepted invitations leave a membership behind, which is not removed.
+	if d.Get("state").(string) != "pending" {
+		return nil
+	}
+
+	id, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+
+	resp, err := client.Organizations.CancelInvite(ctx, orgName, id)
+	if err != nil && (resp == nil || resp.StatusCode != http.StatusNotFound) {
+		return diag.FromErr(fmt.Errorf("error cancelling invitation %s to %s: %w", d.Id(), orgName, err))
+	}
+
+	return nil
+}
@@ -0,0 +1,186 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+)
+
+func TestAccGithubOrganizationInvitation(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("invites a person by email without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_team" "test" {
+				name = "tf-acc-test-%[1]s"
+			}
+
+			resource "github_organization_invitation" "test" {
+				email    = "tf-acc-test-%[1]s@example.com"
+				team_ids = [github_team.test.id]
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_organization_invitation.test", "state",
+				"pending",
+			),
+			resource.TestCheckResourceAttr(
+				"github_organization_invitation.test", "role",
+				"direct_member",
+			),
+			resource.TestCheckResourceAttr(
+				"github_organization_invitation.test", "team_ids.#",
+				"1",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_organization_invitation.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubOrganizationInvitationWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+
+	team := newFakeResource(t, meta, "github_team")
+	team.apply(map[string]interface{}{
+		"name": "developers",
+	})
+
+	config := map[string]interface{}{
+		"email":    "someone@example.com",
+		"team_ids": []interface{}{int(mustParseInt64(t, team.state.ID))},
+	}
+	invitation := newFakeResource(t, meta, "github_organization_invitation")
+	invitation.apply(config)
+	invitation.expectNoChanges(config)
+	if invitation.get("state") != "pending" || invitation.get("role") != "direct_member" || invitation.get("inviter") == "" {
+		t.Fatalf("Unexpected state: %v", invitation.state.Attributes)
+	}
+
+	imported := newFakeResource(t, meta, "github_organization_invitation")
+	imported.importState(invitation.state.ID)
+	imported.expectNoChanges(config)
+
+	// Expired invitations are sent again.
+	expiredID := invitation.state.ID
+	if !srv.ExpireInvitation(fakeOrganization, mustParseInt64(t, expiredID)) {
+		t.Fatal("Expected the invitation to be pending")
+	}
+	invitation.refresh()
+	if invitation.state != nil {
+		t.Fatal("Expected an expired invitation to be removed from state")
+	}
+	invitation.apply(config)
+	if invitation.state.ID == expiredID || invitation.get("state") != "pending" {
+		t.Fatalf("Expected a new pending invitation, got: %v", invitation.state.Attributes)
+	}
+
+	// Accepted invitations are kept, and the membership is left alone on
+	// destroy.
+	if !srv.AcceptInvitation(fakeOrganization, mustParseInt64(t, invitation.state.ID), "someone") {
+		t.Fatal("Expected the invitation to be pending")
+	}
+	invitation.refresh()
+	if invitation.get("state") != "accepted" {
+		t.Fatalf("Unexpected state: %v", invitation.state.Attributes)
+	}
+	invitation.expectNoChanges(config)
+
+	// The invitee is only found by email when it is their public email.
+	_, diags := invitation.resource.RefreshWithoutUpgrade(context.Background(), invitation.state, meta)
+	if len(diags) != 1 || diags[0].Severity != diag.Warning {
+		t.Fatalf("Expected a warning that the acceptance cannot be verified, got: %v", diags)
+	}
+	srv.SetUserEmail("someone", "Someone@example.com")
+	_, diags = invitation.resource.RefreshWithoutUpgrade(context.Background(), invitation.state, meta)
+	if len(diags) != 0 {
+		t.Fatalf("Expected the invitee to be found by email, got: %v", diags)
+	}
+
+	// Only pending invitations can be imported, the invitee of the others is
+	// not known.
+	d := invitation.resource.Data(&terraform.InstanceState{ID: invitation.state.ID})
+	if diags := invitation.resource.ReadContext(context.Background(), d, meta); !diags.HasError() {
+		t.Fatalf("Expected importing an accepted invitation to fail, got: %v", d.State())
+	}
+	invitation.destroy()
+
+	client := meta.(*Owner).v3client
+	if _, _, err := client.Organizations.GetOrgMembership(context.Background(), "someone", fakeOrganization); err != nil {
+		t.Fatalf("Expected the membership to be kept: %s", err)
+	}
+
+	srv.AddUser("newcomer")
+	loginConfig := map[string]interface{}{
+		"login": "Newcomer",
+		"role":  "admin",
+	}
+	loginInvitation := newFakeResource(t, meta, "github_organization_invitation")
+	loginInvitation.apply(loginConfig)
+	loginInvitation.expectNoChanges(loginConfig)
+	if loginInvitation.get("role") != "admin" {
+		t.Fatalf("Unexpected state: %v", loginInvitation.state.Attributes)
+	}
+
+	// Pending invitations are cancelled on destroy.
+	loginInvitation.destroy()
+
+	srv.AddUser("former")
+	reinstateConfig := map[string]interface{}{
+		"login": "former",
+		"role":  "reinstate",
+	}
+	reinstateInvitation := newFakeResource(t, meta, "github_organization_invitation")
+	reinstateInvitation.apply(reinstateConfig)
+	reinstateInvitation.expectNoChanges(reinstateConfig)
+	if reinstateInvitation.get("role") != "reinstate" {
+		t.Fatalf("Expected the configured role to be kept, got: %v", reinstateInvitation.state.Attributes)
+	}
+	reinstateInvitation.destroy()
+
+	pending, _, err := client.Organizations.ListPendingOrgInvitations(context.Background(), fakeOrganization, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 0 {
+		t.Fatalf("Expected the invitation to be cancelled, got %d pending invitations", len(pending))
+	}
+}
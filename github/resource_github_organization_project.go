@@ -8,15 +8,16 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubOrganizationProject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubOrganizationProjectCreate,
-		Read:   resourceGithubOrganizationProjectRead,
-		Update: resourceGithubOrganizationProjectUpdate,
-		Delete: resourceGithubOrganizationProjectDelete,
+		CreateContext: resourceGithubOrganizationProjectCreate,
+		ReadContext:   resourceGithubOrganizationProjectRead,
+		UpdateContext: resourceGithubOrganizationProjectUpdate,
+		DeleteContext: resourceGithubOrganizationProjectDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -45,17 +46,16 @@ func resourceGithubOrganizationProject() *schema.Resource {
 	}
 }
 
-func resourceGithubOrganizationProjectCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	name := d.Get("name").(string)
 	body := d.Get("body").(string)
-	ctx := context.Background()
 
 	project, _, err := client.Organizations.CreateProject(ctx,
 		orgName,
@@ -65,17 +65,17 @@ func resourceGithubOrganizationProjectCreate(d *schema.ResourceData, meta interf
 		},
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(project.GetID(), 10))
 
-	return resourceGithubOrganizationProjectRead(d, meta)
+	return resourceGithubOrganizationProjectRead(ctx, d, meta)
 }
 
-func resourceGithubOrganizationProjectRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -83,9 +83,9 @@ func resourceGithubOrganizationProjectRead(d *schema.ResourceData, meta interfac
 
 	projectID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -103,30 +103,30 @@ func resourceGithubOrganizationProjectRead(d *schema.ResourceData, meta interfac
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", project.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("body", project.GetBody()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("url", fmt.Sprintf("https://github.com/orgs/%s/projects/%d",
 		orgName, project.GetNumber())); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubOrganizationProjectUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -141,30 +141,30 @@ func resourceGithubOrganizationProjectUpdate(d *schema.ResourceData, meta interf
 
 	projectID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	if _, _, err := client.Projects.UpdateProject(ctx, projectID, &options); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubOrganizationProjectRead(d, meta)
+	return resourceGithubOrganizationProjectRead(ctx, d, meta)
 }
 
-func resourceGithubOrganizationProjectDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	projectID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Projects.DeleteProject(ctx, projectID)
-	return err
+	return diag.FromErr(err)
 }
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubOrganizationRole() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubOrganizationRoleCreate,
+		ReadContext:   resourceGithubOrganizationRoleRead,
+		UpdateContext: resourceGithubOrganizationRoleUpdate,
+		DeleteContext: resourceGithubOrganizationRoleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the organization role.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The description of the organization role.",
+			},
+			"base_role": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Description:      "The system role from which the role inherits permissions on the repositories of the organization. Can be one of: 'read', 'triage', 'write', 'maintain' or 'admin'.",
+				ValidateDiagFunc: validateValueFunc([]string{"read", "triage", "write", "maintain", "admin"}),
+			},
+			"permissions": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				MinItems:    1,
+				Elem:        &schema.Schema{Type: schema.TypeString},
+				Description: "The permissions of the organization role, e.g. 'read_audit_logs'.",
+			},
+			"role_id": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The ID of the organization role.",
+			},
+		},
+	}
+}
+
+func resourceGithubOrganizationRoleObject(d *schema.ResourceData) *github.CreateOrUpdateOrgRoleOptions {
+	options := &github.CreateOrUpdateOrgRoleOptions{
+		Name:        github.String(d.Get("name").(string)),
+		Description: github.String(d.Get("description").(string)),
+		Permissions: expandStringList(d.Get("permissions").(*schema.Set).List()),
+	}
+	if v, ok := d.GetOk("base_role"); ok {
+		options.BaseRole = github.String(v.(string))
+	}
+	return options
+}
+
+func resourceGithubOrganizationRoleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	role, _, err := client.Organizations.CreateCustomOrgRole(ctx, orgName, resourceGithubOrganizationRoleObject(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error creating GitHub organization role %s (%s): %w", orgName, d.Get("name").(string), err))
+	}
+
+	d.SetId(strconv.FormatInt(role.GetID(), 10))
+	return resourceGithubOrganizationRoleRead(ctx, d, meta)
+}
+
+func resourceGithubOrganizationRoleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	roleID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+
+	role, _, err := client.Organizations.GetOrgRole(ctx, orgName, roleID)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing organization role %s/%s from state because it no longer exists in GitHub",
+					orgName, d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("name", role.GetName()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("description", role.GetDescription()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("base_role", role.GetBaseRole()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("permissions", role.Permissions); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("role_id", role.GetID()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationRoleUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	roleID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+
+	_, _, err = client.Organizations.UpdateCustomOrgRole(ctx, orgName, roleID, resourceGithubOrganizationRoleObject(d))
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error updating GitHub organization role %s (%d): %w", orgName, roleID, err))
+	}
+
+	return resourceGithubOrganizationRoleRead(ctx, d, meta)
+}
+
+func resourceGithubOrganizationRoleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+
+	roleID, err := strconv.ParseInt(d.Id(), 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+
+	_, err = client.Organizations.DeleteCustomOrgRole(ctx, orgName, roleID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting GitHub organization role %s (%d): %w", orgName, roleID, err))
+	}
+
+	return nil
+}
@@ -0,0 +1,148 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubOrganizationRoleTeam() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubOrganizationRoleTeamCreate,
+		ReadContext:   resourceGithubOrganizationRoleTeamRead,
+		DeleteContext: resourceGithubOrganizationRoleTeamDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubOrganizationRoleTeamImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"role_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the organization role.",
+			},
+			"team_slug": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The slug of the team to assign the role to.",
+			},
+		},
+	}
+}
+
+func resourceGithubOrganizationRoleTeamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	roleID := int64(d.Get("role_id").(int))
+	teamSlug := d.Get("team_slug").(string)
+
+	_, err = client.Organizations.AssignOrgRoleToTeam(ctx, orgName, teamSlug, roleID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error assigning organization role %d to team %s/%s: %w", roleID, orgName, teamSlug, err))
+	}
+
+	d.SetId(buildTwoPartID(strconv.FormatInt(roleID, 10), teamSlug))
+	return resourceGithubOrganizationRoleTeamRead(ctx, d, meta)
+}
+
+func resourceGithubOrganizationRoleTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	roleID := int64(d.Get("role_id").(int))
+	teamSlug := d.Get("team_slug").(string)
+
+	// There is no endpoint for getting a single team assignment, so get the
+	// list and filter.
+	var team *github.Team
+	options := &github.ListOptions{PerPage: maxPerPage}
+	for {
+		teams, resp, err := client.Organizations.ListTeamsAssignedToOrgRole(ctx, orgName, roleID, options)
+		if err != nil {
+			if ghErr, ok := err.(*github.ErrorResponse); ok {
+				if ghErr.Response.StatusCode == http.StatusNotFound {
+					log.Printf("[INFO] Removing organization role team %s from state because the role no longer exists in GitHub", d.Id())
+					d.SetId("")
+					return nil
+				}
+			}
+			return diag.FromErr(err)
+		}
+
+		for _, t := range teams {
+			if t.GetSlug() == teamSlug {
+				team = t
+				break
+			}
+		}
+
+		if team != nil || resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	if team == nil {
+		log.Printf("[INFO] Removing organization role team %s from state because it no longer exists in GitHub", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationRoleTeamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	roleID := int64(d.Get("role_id").(int))
+	teamSlug := d.Get("team_slug").(string)
+
+	_, err = client.Organizations.RemoveOrgRoleFromTeam(ctx, orgName, teamSlug, roleID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error removing organization role %d from team %s/%s: %w", roleID, orgName, teamSlug, err))
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationRoleTeamImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	roleIDString, teamSlug, err := parseTwoPartID(d.Id(), "role_id", "team_slug")
+	if err != nil {
+		return nil, err
+	}
+	roleID, err := strconv.Atoi(roleIDString)
+	if err != nil {
+		return nil, unconvertibleIdErr(roleIDString, err)
+	}
+
+	if err = d.Set("role_id", roleID); err != nil {
+		return nil, err
+	}
+	if err = d.Set("team_slug", teamSlug); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
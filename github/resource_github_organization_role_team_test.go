@@ -0,0 +1,120 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationRoleTeam(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("assigns an organization role to a team without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_team" "test" {
+				name = "tf-acc-test-%s"
+			}
+
+			data "github_organization_roles" "all" {}
+
+			locals {
+				security_manager = one([
+					for role in data.github_organization_roles.all.roles : role if role.name == "security_manager"
+				])
+			}
+
+			resource "github_organization_role_team" "test" {
+				role_id   = local.security_manager.role_id
+				team_slug = github_team.test.slug
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttrPair(
+				"github_organization_role_team.test", "team_slug",
+				"github_team.test", "slug",
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_organization_role_team.test", "role_id",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_organization_role_team.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubOrganizationRoleTeamWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+
+	team := newFakeResource(t, meta, "github_team")
+	team.apply(map[string]interface{}{"name": "security"})
+
+	role := newFakeResource(t, meta, "github_organization_role")
+	role.apply(map[string]interface{}{
+		"name":        "auditors",
+		"permissions": []interface{}{"read_audit_logs"},
+	})
+	roleID := mustParseInt64(t, role.state.ID)
+
+	config := map[string]interface{}{
+		"role_id":   int(roleID),
+		"team_slug": team.get("slug"),
+	}
+
+	assignment := newFakeResource(t, meta, "github_organization_role_team")
+	assignment.apply(config)
+	if id := assignment.state.ID; id != role.state.ID+":"+team.get("slug") {
+		t.Fatalf("Unexpected ID: %q", id)
+	}
+	assignment.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_organization_role_team")
+	imported.importState(assignment.state.ID)
+	imported.expectNoChanges(config)
+
+	client := meta.(*Owner).v3client
+	if _, err := client.Organizations.RemoveOrgRoleFromTeam(context.Background(), fakeOrganization, team.get("slug"), roleID); err != nil {
+		t.Fatal(err)
+	}
+	assignment.refresh()
+	if assignment.state != nil {
+		t.Fatal("Expected an assignment removed outside of Terraform to be removed from state")
+	}
+
+	imported.destroy()
+	role.destroy()
+}
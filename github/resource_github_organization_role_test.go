@@ -0,0 +1,147 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationRole(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("creates and updates an organization role without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_organization_role" "test" {
+				name        = "tf-acc-test-%s"
+				description = "Test role description"
+				base_role   = "read"
+				permissions = [
+					"read_audit_logs",
+				]
+			}
+		`, randomID)
+
+		updatedConfig := fmt.Sprintf(`
+			resource "github_organization_role" "test" {
+				name        = "tf-acc-test-%s"
+				description = "Updated role description"
+				permissions = [
+					"read_audit_logs",
+					"read_organization_custom_org_role",
+				]
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_organization_role.test", "name",
+				fmt.Sprintf(`tf-acc-test-%s`, randomID),
+			),
+			resource.TestCheckResourceAttr(
+				"github_organization_role.test", "base_role",
+				"read",
+			),
+			resource.TestCheckResourceAttr(
+				"github_organization_role.test", "permissions.#",
+				"1",
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_organization_role.test", "role_id",
+			),
+		)
+
+		updatedCheck := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_organization_role.test", "description",
+				"Updated role description",
+			),
+			resource.TestCheckResourceAttr(
+				"github_organization_role.test", "base_role",
+				"",
+			),
+			resource.TestCheckResourceAttr(
+				"github_organization_role.test", "permissions.#",
+				"2",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						Config: updatedConfig,
+						Check:  updatedCheck,
+					},
+					{
+						ResourceName:      "github_organization_role.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubOrganizationRoleWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+
+	config := map[string]interface{}{
+		"name":        "auditors",
+		"description": "Reads the audit log",
+		"base_role":   "read",
+		"permissions": []interface{}{"read_audit_logs"},
+	}
+
+	role := newFakeResource(t, meta, "github_organization_role")
+	role.apply(config)
+	if role.get("role_id") != role.state.ID {
+		t.Fatalf("Unexpected role_id %q for role %q", role.get("role_id"), role.state.ID)
+	}
+	role.expectNoChanges(config)
+
+	config["permissions"] = []interface{}{"read_audit_logs", "read_organization_custom_org_role"}
+	delete(config, "base_role")
+	role.apply(config)
+	if role.get("permissions.#") != "2" || role.get("base_role") != "" {
+		t.Fatalf("Unexpected role: %v", role.state.Attributes)
+	}
+	role.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_organization_role")
+	imported.importState(role.state.ID)
+	imported.expectNoChanges(config)
+
+	client := meta.(*Owner).v3client
+	if _, err := client.Organizations.DeleteCustomOrgRole(context.Background(), fakeOrganization, mustParseInt64(t, role.state.ID)); err != nil {
+		t.Fatal(err)
+	}
+	role.refresh()
+	if role.state != nil {
+		t.Fatal("Expected a role deleted outside of Terraform to be removed from state")
+	}
+}
@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubOrganizationRoleUser() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubOrganizationRoleUserCreate,
+		ReadContext:   resourceGithubOrganizationRoleUserRead,
+		DeleteContext: resourceGithubOrganizationRoleUserDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: resourceGithubOrganizationRoleUserImport,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"role_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the organization role.",
+			},
+			"login": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: caseInsensitive(),
+				Description:      "The login of the user to assign the role to.",
+			},
+		},
+	}
+}
+
+func resourceGithubOrganizationRoleUserCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	roleID := int64(d.Get("role_id").(int))
+	login := d.Get("login").(string)
+
+	_, err = client.Organizations.AssignOrgRoleToUser(ctx, orgName, login, roleID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error assigning organization role %d to user %s in %s: %w", roleID, login, orgName, err))
+	}
+
+	d.SetId(buildTwoPartID(strconv.FormatInt(roleID, 10), login))
+	return resourceGithubOrganizationRoleUserRead(ctx, d, meta)
+}
+
+func resourceGithubOrganizationRoleUserRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	roleID := int64(d.Get("role_id").(int))
+	login := d.Get("login").(string)
+
+	// There is no endpoint for getting a single user assignment, so get the
+	// list and filter.
+	var user *github.User
+	options := &github.ListOptions{PerPage: maxPerPage}
+	for {
+		users, resp, err := client.Organizations.ListUsersAssignedToOrgRole(ctx, orgName, roleID, options)
+		if err != nil {
+			if ghErr, ok := err.(*github.ErrorResponse); ok {
+				if ghErr.Response.StatusCode == http.StatusNotFound {
+					log.Printf("[INFO] Removing organization role user %s from state because the role no longer exists in GitHub", d.Id())
+					d.SetId("")
+					return nil
+				}
+			}
+			return diag.FromErr(err)
+		}
+
+		for _, u := range users {
+			if strings.EqualFold(u.GetLogin(), login) {
+				user = u
+				break
+			}
+		}
+
+		if user != nil || resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+
+	if user == nil {
+		log.Printf("[INFO] Removing organization role user %s from state because it no longer exists in GitHub", d.Id())
+		d.SetId("")
+		return nil
+	}
+
+	if err = d.Set("login", user.GetLogin()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationRoleUserDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	orgName := meta.(*Owner).name
+	roleID := int64(d.Get("role_id").(int))
+	login := d.Get("login").(string)
+
+	_, err = client.Organizations.RemoveOrgRoleFromUser(ctx, orgName, login, roleID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error removing organization role %d from user %s in %s: %w", roleID, login, orgName, err))
+	}
+
+	return nil
+}
+
+func resourceGithubOrganizationRoleUserImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	roleIDString, login, err := parseTwoPartID(d.Id(), "role_id", "login")
+	if err != nil {
+		return nil, err
+	}
+	roleID, err := strconv.Atoi(roleIDString)
+	if err != nil {
+		return nil, unconvertibleIdErr(roleIDString, err)
+	}
+
+	if err = d.Set("role_id", roleID); err != nil {
+		return nil, err
+	}
+	if err = d.Set("login", login); err != nil {
+		return nil, err
+	}
+
+	return []*schema.ResourceData{d}, nil
+}
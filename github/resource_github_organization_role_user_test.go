@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubOrganizationRoleUser(t *testing.T) {
+	if testCollaborator == "" {
+		t.Skip("Skipping because `GITHUB_TEST_COLLABORATOR` is not set")
+	}
+
+	t.Run("assigns an organization role to a user without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			data "github_organization_roles" "all" {}
+
+			locals {
+				security_manager = one([
+					for role in data.github_organization_roles.all.roles : role if role.name == "security_manager"
+				])
+			}
+
+			resource "github_organization_role_user" "test" {
+				role_id = local.security_manager.role_id
+				login   = "%s"
+			}
+		`, testCollaborator)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_organization_role_user.test", "login",
+				testCollaborator,
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_organization_role_user.test", "role_id",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_organization_role_user.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubOrganizationRoleUserWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	srv.AddUser("auditor")
+
+	membership := newFakeResource(t, meta, "github_membership")
+	membership.apply(map[string]interface{}{"username": "auditor"})
+
+	role := newFakeResource(t, meta, "github_organization_role")
+	role.apply(map[string]interface{}{
+		"name":        "auditors",
+		"permissions": []interface{}{"read_audit_logs"},
+	})
+	roleID := mustParseInt64(t, role.state.ID)
+
+	config := map[string]interface{}{
+		"role_id": int(roleID),
+		"login":   "Auditor",
+	}
+
+	assignment := newFakeResource(t, meta, "github_organization_role_user")
+	assignment.apply(config)
+	if login := assignment.get("login"); login != "auditor" {
+		t.Fatalf("Unexpected login: %q", login)
+	}
+	assignment.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_organization_role_user")
+	imported.importState(role.state.ID + ":auditor")
+	imported.expectNoChanges(config)
+
+	client := meta.(*Owner).v3client
+	if _, err := client.Organizations.RemoveOrgRoleFromUser(context.Background(), fakeOrganization, "auditor", roleID); err != nil {
+		t.Fatal(err)
+	}
+	assignment.refresh()
+	if assignment.state != nil {
+		t.Fatal("Expected an assignment removed outside of Terraform to be removed from state")
+	}
+}
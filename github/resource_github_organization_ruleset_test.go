@@ -1,12 +1,14 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestGithubOrganizationRulesets(t *testing.T) {
@@ -260,4 +262,181 @@ func TestGithubOrganizationRulesets(t *testing.T) {
 
 	})
 
+	t.Run("Targets repositories by custom property without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_organization_custom_property" "test" {
+				name           = "tf-acc-test-tier-%s"
+				value_type     = "single_select"
+				allowed_values = ["prod", "staging"]
+			}
+
+			resource "github_organization_ruleset" "test" {
+				name        = "test-%[1]s"
+				target      = "branch"
+				enforcement = "active"
+
+				conditions {
+					ref_name {
+						include = ["~DEFAULT_BRANCH"]
+						exclude = []
+					}
+
+					repository_property {
+						include {
+							name            = github_organization_custom_property.test.name
+							property_values = ["prod"]
+						}
+					}
+				}
+
+				rules {
+					deletion = true
+				}
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_organization_ruleset.test", "conditions.0.repository_property.0.include.0.property_values.0",
+				"prod",
+			),
+			resource.TestCheckResourceAttr(
+				"github_organization_ruleset.test", "conditions.0.repository_property.0.include.0.source",
+				"custom",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_organization_ruleset.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an enterprise account", func(t *testing.T) {
+			testCase(t, enterprise)
+		})
+
+	})
+
+}
+
+func TestGithubOrganizationRulesetWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+
+	target := func(name string, values ...interface{}) map[string]interface{} {
+		return map[string]interface{}{"name": name, "property_values": values}
+	}
+	config := map[string]interface{}{
+		"name":        "protect-prod",
+		"target":      "branch",
+		"enforcement": "active",
+		"conditions": []interface{}{map[string]interface{}{
+			"ref_name": []interface{}{map[string]interface{}{
+				"include": []interface{}{"~DEFAULT_BRANCH"},
+				"exclude": []interface{}{},
+			}},
+			"repository_property": []interface{}{map[string]interface{}{
+				"include": []interface{}{target("tier", "prod", "critical")},
+				"exclude": []interface{}{target("lifecycle", "archived")},
+			}},
+		}},
+		"rules": []interface{}{map[string]interface{}{
+			"deletion": true,
+		}},
+	}
+
+	ruleset := newFakeResource(t, meta, "github_organization_ruleset")
+	ruleset.apply(config)
+	ruleset.expectNoChanges(config)
+
+	remote, _, err := meta.(*Owner).v3client.Organizations.GetOrganizationRuleset(context.Background(), fakeOrganization, mustParseInt64(t, ruleset.state.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	property := remote.GetConditions().RepositoryProperty
+	if property == nil || len(property.Include) != 1 || property.Include[0].Name != "tier" || len(property.Exclude[0].Values) != 1 {
+		t.Fatalf("Unexpected repository property conditions: %+v", remote.GetConditions())
+	}
+
+	imported := newFakeResource(t, meta, "github_organization_ruleset")
+	imported.importState(ruleset.state.ID)
+	imported.expectNoChanges(config)
+	if source := imported.get("conditions.0.repository_property.0.include.0.source"); source != "custom" {
+		t.Fatalf("Unexpected imported source: %q", source)
+	}
+
+	pushConfig := map[string]interface{}{
+		"name":        "restrict-pushes",
+		"target":      "push",
+		"enforcement": "active",
+		"conditions": []interface{}{map[string]interface{}{
+			"repository_name": []interface{}{map[string]interface{}{
+				"include": []interface{}{"~ALL"},
+				"exclude": []interface{}{},
+			}},
+		}},
+		"rules": []interface{}{map[string]interface{}{
+			"max_file_size": []interface{}{map[string]interface{}{
+				"max_file_size": 5,
+			}},
+		}},
+	}
+
+	pushRuleset := newFakeResource(t, meta, "github_organization_ruleset")
+	pushRuleset.apply(pushConfig)
+	pushRuleset.expectNoChanges(pushConfig)
+	if refName := pushRuleset.get("conditions.0.ref_name.#"); refName != "0" {
+		t.Fatalf("Expected push rulesets not to target refs, got: %v", pushRuleset.state.Attributes)
+	}
+}
+
+func TestGithubOrganizationRulesetRefNameDiff(t *testing.T) {
+
+	config := func(target string) *terraform.ResourceConfig {
+		return terraform.NewResourceConfigRaw(map[string]interface{}{
+			"name":        "no-ref-name",
+			"target":      target,
+			"enforcement": "active",
+			"conditions": []interface{}{map[string]interface{}{
+				"repository_name": []interface{}{map[string]interface{}{
+					"include": []interface{}{"~ALL"},
+					"exclude": []interface{}{},
+				}},
+			}},
+			"rules": []interface{}{map[string]interface{}{
+				"deletion": true,
+			}},
+		})
+	}
+
+	r := resourceGithubOrganizationRuleset()
+
+	for _, target := range []string{"branch", "tag"} {
+		t.Run(fmt.Sprintf("rejects %s rulesets without ref_name", target), func(t *testing.T) {
+			_, err := r.Diff(context.Background(), nil, config(target), nil)
+			if err == nil || !strings.Contains(err.Error(), "conditions.ref_name is required") {
+				t.Fatalf("Expected ref_name to be required, got: %v", err)
+			}
+		})
+	}
+
+	t.Run("accepts push rulesets without ref_name", func(t *testing.T) {
+		if _, err := r.Diff(context.Background(), nil, config("push"), nil); err != nil {
+			t.Fatalf("Unexpected error: %s", err)
+		}
+	})
 }
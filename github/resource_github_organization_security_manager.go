@@ -7,15 +7,16 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubOrganizationSecurityManager() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubOrganizationSecurityManagerCreate,
-		Read:   resourceGithubOrganizationSecurityManagerRead,
-		Update: resourceGithubOrganizationSecurityManagerUpdate,
-		Delete: resourceGithubOrganizationSecurityManagerDelete,
+		CreateContext: resourceGithubOrganizationSecurityManagerCreate,
+		ReadContext:   resourceGithubOrganizationSecurityManagerRead,
+		UpdateContext: resourceGithubOrganizationSecurityManagerUpdate,
+		DeleteContext: resourceGithubOrganizationSecurityManagerDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -45,56 +46,55 @@ func getSecurityManagerRole(client *github.Client, ctx context.Context, orgName
 	return nil, errors.New("security manager role not found")
 }
 
-func resourceGithubOrganizationSecurityManagerCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationSecurityManagerCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	orgName := meta.(*Owner).name
 	teamSlug := d.Get("team_slug").(string)
 
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	team, _, err := client.Teams.GetTeamBySlug(ctx, orgName, teamSlug)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	smRole, err := getSecurityManagerRole(client, ctx, orgName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	_, err = client.Organizations.AssignOrgRoleToTeam(ctx, orgName, teamSlug, smRole.GetID())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.FormatInt(team.GetID(), 10))
 
-	return resourceGithubOrganizationSecurityManagerRead(d, meta)
+	return resourceGithubOrganizationSecurityManagerRead(ctx, d, meta)
 }
 
-func resourceGithubOrganizationSecurityManagerRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationSecurityManagerRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	orgName := meta.(*Owner).name
 	teamId, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	smRole, err := getSecurityManagerRole(client, ctx, orgName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// There is no endpoint for getting a single security manager team, so get the list and filter.
@@ -103,7 +103,7 @@ func resourceGithubOrganizationSecurityManagerRead(d *schema.ResourceData, meta
 	for {
 		smTeams, resp, err := client.Organizations.ListTeamsAssignedToOrgRole(ctx, orgName, smRole.GetID(), options)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		for _, t := range smTeams {
@@ -128,64 +128,64 @@ func resourceGithubOrganizationSecurityManagerRead(d *schema.ResourceData, meta
 	}
 
 	if err = d.Set("team_slug", smTeam.GetSlug()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubOrganizationSecurityManagerUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationSecurityManagerUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	orgId := meta.(*Owner).id
 	orgName := meta.(*Owner).name
 	teamId, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	team, _, err := client.Teams.GetTeamByID(ctx, orgId, teamId)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	smRole, err := getSecurityManagerRole(client, ctx, orgName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Adding the same team is a no-op.
 	_, err = client.Organizations.AssignOrgRoleToTeam(ctx, orgName, team.GetSlug(), smRole.GetID())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubOrganizationSecurityManagerRead(d, meta)
+	return resourceGithubOrganizationSecurityManagerRead(ctx, d, meta)
 }
 
-func resourceGithubOrganizationSecurityManagerDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationSecurityManagerDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	orgName := meta.(*Owner).name
 	teamSlug := d.Get("team_slug").(string)
 
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	smRole, err := getSecurityManagerRole(client, ctx, orgName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	_, err = client.Organizations.RemoveOrgRoleFromTeam(ctx, orgName, teamSlug, smRole.GetID())
-	return err
+	return diag.FromErr(err)
 }
@@ -6,16 +6,17 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubOrganizationSettings() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubOrganizationSettingsCreateOrUpdate,
-		Read:   resourceGithubOrganizationSettingsRead,
-		Update: resourceGithubOrganizationSettingsCreateOrUpdate,
-		Delete: resourceGithubOrganizationSettingsDelete,
+		CreateContext: resourceGithubOrganizationSettingsCreateOrUpdate,
+		ReadContext:   resourceGithubOrganizationSettingsRead,
+		UpdateContext: resourceGithubOrganizationSettingsCreateOrUpdate,
+		DeleteContext: resourceGithubOrganizationSettingsDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -172,13 +173,13 @@ func resourceGithubOrganizationSettings() *schema.Resource {
 	}
 }
 
-func resourceGithubOrganizationSettingsCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationSettingsCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	org := meta.(*Owner).name
 
 	settings := github.Organization{
@@ -268,21 +269,21 @@ func resourceGithubOrganizationSettingsCreateOrUpdate(d *schema.ResourceData, me
 
 	orgPlan, _, err := client.Organizations.Edit(ctx, org, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if orgPlan.GetPlan().GetName() == "enterprise" {
 		if _, ok := d.GetOk("members_can_fork_private_repositories"); !ok {
 			orgSettings, _, err := client.Organizations.Edit(ctx, org, &enterpriseSettingsNoFork)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			id := strconv.FormatInt(orgSettings.GetID(), 10)
 			d.SetId(id)
 		} else if _, ok := d.GetOk("members_can_fork_private_repositories"); ok {
 			orgSettings, _, err := client.Organizations.Edit(ctx, org, &enterpriseSettings)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 			id := strconv.FormatInt(orgSettings.GetID(), 10)
 			d.SetId(id)
@@ -290,118 +291,117 @@ func resourceGithubOrganizationSettingsCreateOrUpdate(d *schema.ResourceData, me
 	} else {
 		orgSettings, _, err := client.Organizations.Edit(ctx, org, &settings)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		id := strconv.FormatInt(orgSettings.GetID(), 10)
 		d.SetId(id)
 	}
 
-	return resourceGithubOrganizationSettingsRead(d, meta)
+	return resourceGithubOrganizationSettingsRead(ctx, d, meta)
 }
 
-func resourceGithubOrganizationSettingsRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 	org := meta.(*Owner).name
 
 	orgSettings, _, err := client.Organizations.Get(ctx, org)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("billing_email", orgSettings.GetBillingEmail()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("company", orgSettings.GetCompany()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("email", orgSettings.GetEmail()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("twitter_username", orgSettings.GetTwitterUsername()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("location", orgSettings.GetLocation()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", orgSettings.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("description", orgSettings.GetDescription()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("has_organization_projects", orgSettings.GetHasOrganizationProjects()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("has_repository_projects", orgSettings.GetHasRepositoryProjects()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("default_repository_permission", orgSettings.GetDefaultRepoPermission()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("members_can_create_repositories", orgSettings.GetMembersCanCreateRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("members_can_create_internal_repositories", orgSettings.GetMembersCanCreateInternalRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("members_can_create_private_repositories", orgSettings.GetMembersCanCreatePrivateRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("members_can_create_public_repositories", orgSettings.GetMembersCanCreatePublicRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("members_can_create_pages", orgSettings.GetMembersCanCreatePages()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("members_can_create_public_pages", orgSettings.GetMembersCanCreatePublicPages()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("members_can_create_private_pages", orgSettings.GetMembersCanCreatePrivatePages()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("members_can_fork_private_repositories", orgSettings.GetMembersCanForkPrivateRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("web_commit_signoff_required", orgSettings.GetWebCommitSignoffRequired()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("blog", orgSettings.GetBlog()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("advanced_security_enabled_for_new_repositories", orgSettings.GetAdvancedSecurityEnabledForNewRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("dependabot_alerts_enabled_for_new_repositories", orgSettings.GetDependabotAlertsEnabledForNewRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("dependabot_security_updates_enabled_for_new_repositories", orgSettings.GetDependabotSecurityUpdatesEnabledForNewRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("dependency_graph_enabled_for_new_repositories", orgSettings.GetDependencyGraphEnabledForNewRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("secret_scanning_enabled_for_new_repositories", orgSettings.GetSecretScanningEnabledForNewRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("secret_scanning_push_protection_enabled_for_new_repositories", orgSettings.GetSecretScanningPushProtectionEnabledForNewRepos()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceGithubOrganizationSettingsDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	org := meta.(*Owner).name
 
 	// This will set org settings to default values
@@ -493,24 +493,24 @@ func resourceGithubOrganizationSettingsDelete(d *schema.ResourceData, meta inter
 	log.Printf("[DEBUG] Reverting Organization Settings to default values: %s", org)
 	orgPlan, _, err := client.Organizations.Edit(ctx, org, nil)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if orgPlan.GetPlan().GetName() == "enterprise" {
 		if _, ok := d.GetOk("members_can_fork_private_repositories"); !ok {
 			_, _, err := client.Organizations.Edit(ctx, org, &enterpriseSettingsNoFork)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		} else if _, ok := d.GetOk("members_can_fork_private_repositories"); ok {
 			_, _, err := client.Organizations.Edit(ctx, org, &enterpriseSettings)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	} else {
 		_, _, err := client.Organizations.Edit(ctx, org, &settings)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -2,21 +2,23 @@ package github
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubOrganizationWebhook() *schema.Resource {
 
 	return &schema.Resource{
-		Create: resourceGithubOrganizationWebhookCreate,
-		Read:   resourceGithubOrganizationWebhookRead,
-		Update: resourceGithubOrganizationWebhookUpdate,
-		Delete: resourceGithubOrganizationWebhookDelete,
+		CreateContext: resourceGithubOrganizationWebhookCreate,
+		ReadContext:   resourceGithubOrganizationWebhookRead,
+		UpdateContext: resourceGithubOrganizationWebhookUpdate,
+		DeleteContext: resourceGithubOrganizationWebhookDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -24,6 +26,8 @@ func resourceGithubOrganizationWebhook() *schema.Resource {
 		SchemaVersion: 1,
 		MigrateState:  resourceGithubWebhookMigrateState,
 
+		ValidateRawResourceConfigFuncs: webhookPreferWriteOnlySecret(),
+
 		Schema: map[string]*schema.Schema{
 			"events": {
 				Type:        schema.TypeSet,
@@ -48,6 +52,8 @@ func resourceGithubOrganizationWebhook() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"secret_version":         webhookSecretVersionSchema(),
+			"redeliver_failed_since": redeliverFailedSinceSchema(),
 		},
 	}
 }
@@ -73,22 +79,24 @@ func resourceGithubOrganizationWebhookObject(d *schema.ResourceData) *github.Hoo
 	return hook
 }
 
-func resourceGithubOrganizationWebhookCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationWebhookCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 
 	orgName := meta.(*Owner).name
 	webhookObj := resourceGithubOrganizationWebhookObject(d)
-	ctx := context.Background()
+	if diags := setWebhookWriteOnlySecret(d, webhookObj.Config); diags.HasError() {
+		return diags
+	}
 
 	hook, _, err := client.Organizations.CreateHook(ctx, orgName, webhookObj)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(hook.GetID(), 10))
 
@@ -96,20 +104,20 @@ func resourceGithubOrganizationWebhookCreate(d *schema.ResourceData, meta interf
 	// We would prefer to store the real secret in state, so we'll
 	// write the configuration secret in state from our request to GitHub
 	if hook.Config.Secret != nil {
-		hook.Config.Secret = webhookObj.Config.Secret
+		hook.Config.Secret = github.String(d.Get("configuration.0.secret").(string))
 	}
 
 	if err = d.Set("configuration", interfaceFromWebhookConfig(hook.Config)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubOrganizationWebhookRead(d, meta)
+	return resourceGithubOrganizationWebhookRead(ctx, d, meta)
 }
 
-func resourceGithubOrganizationWebhookRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationWebhookRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -117,9 +125,9 @@ func resourceGithubOrganizationWebhookRead(d *schema.ResourceData, meta interfac
 	orgName := meta.(*Owner).name
 	hookID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -137,20 +145,20 @@ func resourceGithubOrganizationWebhookRead(d *schema.ResourceData, meta interfac
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("url", hook.GetURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("active", hook.GetActive()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("events", hook.Events); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// GitHub returns the secret as a string of 8 astrisks "********"
@@ -166,16 +174,16 @@ func resourceGithubOrganizationWebhookRead(d *schema.ResourceData, meta interfac
 	}
 
 	if err = d.Set("configuration", interfaceFromWebhookConfig(hook.Config)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubOrganizationWebhookUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationWebhookUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -184,23 +192,51 @@ func resourceGithubOrganizationWebhookUpdate(d *schema.ResourceData, meta interf
 	webhookObj := resourceGithubOrganizationWebhookObject(d)
 	hookID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+
+	// The configuration has its own endpoint, which does not require the
+	// events and the state of the webhook to be sent again.
+	config := webhookObj.Config
+	webhookObj.Config = nil
+	if d.HasChanges("events", "active") {
+		_, _, err = client.Organizations.EditHook(ctx,
+			orgName, hookID, webhookObj)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChanges("configuration", "secret_version") && config != nil {
+		if diags := setWebhookWriteOnlySecret(d, config); diags.HasError() {
+			return diags
+		}
+		_, _, err = client.Organizations.EditHookConfiguration(ctx, orgName, hookID, config)
+		if err != nil {
+			return attributeDiagnostics(err, "configuration")
+		}
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
 
-	_, _, err = client.Organizations.EditHook(ctx,
-		orgName, hookID, webhookObj)
-	if err != nil {
-		return err
+	if d.HasChange("redeliver_failed_since") {
+		err = redeliverFailedHookDeliveries(ctx, d,
+			func(ctx context.Context, opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error) {
+				return client.Organizations.ListHookDeliveries(ctx, orgName, hookID, opts)
+			},
+			func(ctx context.Context, deliveryID int64) (*github.HookDelivery, *github.Response, error) {
+				return client.Organizations.RedeliverHookDelivery(ctx, orgName, hookID, deliveryID)
+			})
+		if err != nil {
+			return attributeDiagnostics(fmt.Errorf("error redelivering failed deliveries of webhook %s: %w", d.Id(), err), "redeliver_failed_since")
+		}
 	}
 
-	return resourceGithubOrganizationWebhookRead(d, meta)
+	return resourceGithubOrganizationWebhookRead(ctx, d, meta)
 }
 
-func resourceGithubOrganizationWebhookDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubOrganizationWebhookDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -208,12 +244,12 @@ func resourceGithubOrganizationWebhookDelete(d *schema.ResourceData, meta interf
 	orgName := meta.(*Owner).name
 	hookID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Organizations.DeleteHook(ctx, orgName, hookID)
-	return err
+	return diag.FromErr(err)
 }
 
 func webhookConfigFromInterface(config map[string]interface{}) *github.HookConfig {
@@ -1,9 +1,11 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -141,3 +143,34 @@ func TestAccGithubOrganizationWebhook(t *testing.T) {
 	})
 
 }
+
+func TestGithubOrganizationWebhookRedeliverFailedWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+
+	config := map[string]interface{}{
+		"events": []interface{}{"repository"},
+		"configuration": []interface{}{map[string]interface{}{
+			"url":          "https://example.com/hook",
+			"content_type": "json",
+		}},
+	}
+	webhook := newFakeResource(t, meta, "github_organization_webhook")
+	webhook.apply(config)
+	hookID := mustParseInt64(t, webhook.state.ID)
+
+	failed := srv.AddHookDelivery(fakeOrganization, "", hookID, "repository", 503)
+
+	config["redeliver_failed_since"] = failed.GetDeliveredAt().Format(time.RFC3339)
+	webhook.apply(config)
+	webhook.expectNoChanges(config)
+
+	deliveries, _, err := meta.(*Owner).v3client.Organizations.ListHookDeliveries(context.Background(), fakeOrganization, hookID, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	latest := deliveries[0]
+	if !latest.GetRedelivery() || latest.GetGUID() != failed.GetGUID() || latest.GetStatusCode() != 200 {
+		t.Fatalf("Expected the failed delivery to be redelivered, got %v", latest)
+	}
+}
@@ -9,17 +9,18 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubProjectCard() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubProjectCardCreate,
-		Read:   resourceGithubProjectCardRead,
-		Update: resourceGithubProjectCardUpdate,
-		Delete: resourceGithubProjectCardDelete,
+		CreateContext: resourceGithubProjectCardCreate,
+		ReadContext:   resourceGithubProjectCardRead,
+		UpdateContext: resourceGithubProjectCardUpdate,
+		DeleteContext: resourceGithubProjectCardDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubProjectCardImport,
+			StateContext: resourceGithubProjectCardImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"column_id": {
@@ -56,16 +57,16 @@ func resourceGithubProjectCard() *schema.Resource {
 	}
 }
 
-func resourceGithubProjectCardCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubProjectCardCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	columnIDStr := d.Get("column_id").(string)
 	columnID, err := strconv.ParseInt(columnIDStr, 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(columnIDStr, err)
+		return diag.FromErr(unconvertibleIdErr(columnIDStr, err))
 	}
 
 	log.Printf("[DEBUG] Creating project card note in column ID: %d", columnID)
@@ -83,28 +84,27 @@ func resourceGithubProjectCardCreate(d *schema.ResourceData, meta interface{}) e
 
 		options.ContentType = d.Get("content_type").(string)
 		if options.ContentType != "Issue" && options.ContentType != "PullRequest" {
-			return fmt.Errorf("content_type must be set to either Issue or PullRequest")
+			return diag.FromErr(fmt.Errorf("content_type must be set to either Issue or PullRequest"))
 		}
 	}
-	ctx := context.Background()
 	card, _, err := client.Projects.CreateProjectCard(ctx, columnID, &options)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("card_id", card.GetID()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(card.GetNodeID())
 
-	return resourceGithubProjectCardRead(d, meta)
+	return resourceGithubProjectCardRead(ctx, d, meta)
 }
 
-func resourceGithubProjectCardRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubProjectCardRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	nodeID := d.Id()
 	cardID := d.Get("card_id").(int)
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -119,30 +119,30 @@ func resourceGithubProjectCardRead(d *schema.ResourceData, meta interface{}) err
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	// FIXME: Remove URL parsing if a better option becomes available
 	columnURL := card.GetColumnURL()
 	columnIDStr := strings.TrimPrefix(columnURL, client.BaseURL.String()+`projects/columns/`)
 	if err != nil {
-		return unconvertibleIdErr(columnIDStr, err)
+		return diag.FromErr(unconvertibleIdErr(columnIDStr, err))
 	}
 
 	if err = d.Set("note", card.GetNote()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("column_id", columnIDStr); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("card_id", card.GetID()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubProjectCardUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubProjectCardUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	cardID := d.Get("card_id").(int)
 
@@ -160,30 +160,30 @@ func resourceGithubProjectCardUpdate(d *schema.ResourceData, meta interface{}) e
 
 		options.ContentType = d.Get("content_type").(string)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	_, _, err := client.Projects.UpdateProjectCard(ctx, int64(cardID), &options)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubProjectCardRead(d, meta)
+	return resourceGithubProjectCardRead(ctx, d, meta)
 }
 
-func resourceGithubProjectCardDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubProjectCardDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	log.Printf("[DEBUG] Deleting project Card: %s", d.Id())
 	cardID := d.Get("card_id").(int)
 	_, err := client.Projects.DeleteProjectCard(ctx, int64(cardID))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubProjectCardImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubProjectCardImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 
 	cardIDStr := d.Id()
 	cardID, err := strconv.ParseInt(cardIDStr, 10, 64)
@@ -193,7 +193,6 @@ func resourceGithubProjectCardImport(d *schema.ResourceData, meta interface{}) (
 
 	log.Printf("[DEBUG] Importing project card with card ID: %d", cardID)
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 	card, _, err := client.Projects.GetProjectCard(ctx, cardID)
 	if card == nil || err != nil {
 		return []*schema.ResourceData{d}, err
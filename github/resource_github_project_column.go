@@ -8,15 +8,16 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubProjectColumn() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubProjectColumnCreate,
-		Read:   resourceGithubProjectColumnRead,
-		Update: resourceGithubProjectColumnUpdate,
-		Delete: resourceGithubProjectColumnDelete,
+		CreateContext: resourceGithubProjectColumnCreate,
+		ReadContext:   resourceGithubProjectColumnRead,
+		UpdateContext: resourceGithubProjectColumnUpdate,
+		DeleteContext: resourceGithubProjectColumnDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -46,10 +47,10 @@ func resourceGithubProjectColumn() *schema.Resource {
 	}
 }
 
-func resourceGithubProjectColumnCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubProjectColumnCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -61,34 +62,33 @@ func resourceGithubProjectColumnCreate(d *schema.ResourceData, meta interface{})
 	projectIDStr := d.Get("project_id").(string)
 	projectID, err := strconv.ParseInt(projectIDStr, 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(projectIDStr, err)
+		return diag.FromErr(unconvertibleIdErr(projectIDStr, err))
 	}
-	ctx := context.Background()
 
 	column, _, err := client.Projects.CreateProjectColumn(ctx,
 		projectID,
 		&options,
 	)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.FormatInt(column.GetID(), 10))
 	if err = d.Set("column_id", column.GetID()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubProjectColumnRead(d, meta)
+	return resourceGithubProjectColumnRead(ctx, d, meta)
 }
 
-func resourceGithubProjectColumnRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubProjectColumnRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	columnID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -102,25 +102,25 @@ func resourceGithubProjectColumnRead(d *schema.ResourceData, meta interface{}) e
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	projectURL := column.GetProjectURL()
 	projectID := strings.TrimPrefix(projectURL, client.BaseURL.String()+`projects/`)
 
 	if err = d.Set("name", column.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("project_id", projectID); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("column_id", column.GetID()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	return nil
 }
 
-func resourceGithubProjectColumnUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubProjectColumnUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	options := github.ProjectColumnOptions{
@@ -129,27 +129,27 @@ func resourceGithubProjectColumnUpdate(d *schema.ResourceData, meta interface{})
 
 	columnID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, _, err = client.Projects.UpdateProjectColumn(ctx, columnID, &options)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubProjectColumnRead(d, meta)
+	return resourceGithubProjectColumnRead(ctx, d, meta)
 }
 
-func resourceGithubProjectColumnDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubProjectColumnDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	columnID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Projects.DeleteProjectColumn(ctx, columnID)
-	return err
+	return diag.FromErr(err)
 }
@@ -0,0 +1,260 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
+)
+
+func resourceGithubProjectV2() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubProjectV2Create,
+		ReadContext:   resourceGithubProjectV2Read,
+		UpdateContext: resourceGithubProjectV2Update,
+		DeleteContext: resourceGithubProjectV2Delete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"owner": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Computed:         true,
+				ForceNew:         true,
+				DiffSuppressFunc: caseInsensitive(),
+				Description:      "The login of the organization or user owning the project. Defaults to the owner of the provider.",
+			},
+			"title": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The title of the project.",
+			},
+			"description": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The short description of the project.",
+			},
+			"readme": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The readme of the project, in Markdown.",
+			},
+			"public": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the project is visible to anyone.",
+			},
+			"closed": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     false,
+				Description: "Whether the project is closed.",
+			},
+			"number": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "The number of the project, unique for its owner.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the project.",
+			},
+		},
+	}
+}
+
+type DeleteProjectV2Input struct {
+	ProjectID        githubv4.ID      `json:"projectId"`
+	ClientMutationID *githubv4.String `json:"clientMutationId,omitempty"`
+}
+
+type projectV2 struct {
+	ID               githubv4.ID
+	Number           githubv4.Int
+	Title            githubv4.String
+	ShortDescription githubv4.String
+	Readme           githubv4.String
+	Public           githubv4.Boolean
+	Closed           githubv4.Boolean
+	URL              githubv4.String `graphql:"url"`
+	Owner            struct {
+		Organization struct {
+			Login githubv4.String
+		} `graphql:"... on Organization"`
+		User struct {
+			Login githubv4.String
+		} `graphql:"... on User"`
+	}
+}
+
+// getRepositoryOwnerID returns the node ID of the organization or user with
+// the given login.
+func getRepositoryOwnerID(ctx context.Context, client *githubv4.Client, login string) (githubv4.ID, error) {
+	var query struct {
+		RepositoryOwner struct {
+			ID githubv4.ID
+		} `graphql:"repositoryOwner(login: $login)"`
+	}
+	variables := map[string]interface{}{
+		"login": githubv4.String(login),
+	}
+	if err := client.Query(ctx, &query, variables); err != nil {
+		return nil, err
+	}
+	if query.RepositoryOwner.ID == nil {
+		return nil, fmt.Errorf("could not resolve to an organization or user with the login of %q", login)
+	}
+	return query.RepositoryOwner.ID, nil
+}
+
+func resourceGithubProjectV2UpdateInput(d *schema.ResourceData) githubv4.UpdateProjectV2Input {
+	return githubv4.UpdateProjectV2Input{
+		ProjectID:        githubv4.ID(d.Id()),
+		Title:            githubv4.NewString(githubv4.String(d.Get("title").(string))),
+		ShortDescription: githubv4.NewString(githubv4.String(d.Get("description").(string))),
+		Readme:           githubv4.NewString(githubv4.String(d.Get("readme").(string))),
+		Public:           githubv4.NewBoolean(githubv4.Boolean(d.Get("public").(bool))),
+		Closed:           githubv4.NewBoolean(githubv4.Boolean(d.Get("closed").(bool))),
+	}
+}
+
+func resourceGithubProjectV2Create(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	owner := d.Get("owner").(string)
+	if owner == "" {
+		owner = meta.(*Owner).name
+	}
+	ownerID, err := getRepositoryOwnerID(ctx, client, owner)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error resolving the owner %s of the project: %w", owner, err))
+	}
+
+	var mutation struct {
+		CreateProjectV2 struct {
+			ProjectV2 struct {
+				ID githubv4.ID
+			}
+		} `graphql:"createProjectV2(input: $input)"`
+	}
+	input := githubv4.CreateProjectV2Input{
+		OwnerID: ownerID,
+		Title:   githubv4.String(d.Get("title").(string)),
+	}
+	if err = client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating project %s for %s: %w", d.Get("title").(string), owner, err))
+	}
+	d.SetId(fmt.Sprint(mutation.CreateProjectV2.ProjectV2.ID))
+
+	// The description, readme and visibility of a project can only be set
+	// once it exists.
+	if err = updateProjectV2(ctx, client, resourceGithubProjectV2UpdateInput(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating project %s: %w", d.Id(), err))
+	}
+
+	return resourceGithubProjectV2Read(ctx, d, meta)
+}
+
+func resourceGithubProjectV2Read(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	var query struct {
+		Node struct {
+			ProjectV2 projectV2 `graphql:"... on ProjectV2"`
+		} `graphql:"node(id: $id)"`
+	}
+	variables := map[string]interface{}{
+		"id": githubv4.ID(d.Id()),
+	}
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+	err := client.Query(ctx, &query, variables)
+	if err != nil {
+		if strings.Contains(err.Error(), "Could not resolve to a node with the global id") {
+			log.Printf("[INFO] Removing project %s from state because it no longer exists in GitHub", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	project := query.Node.ProjectV2
+
+	owner := project.Owner.Organization.Login
+	if owner == "" {
+		owner = project.Owner.User.Login
+	}
+	if err = d.Set("owner", string(owner)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("title", string(project.Title)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("description", string(project.ShortDescription)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("readme", string(project.Readme)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("public", bool(project.Public)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("closed", bool(project.Closed)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("number", int(project.Number)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("url", string(project.URL)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubProjectV2Update(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	if err := updateProjectV2(ctx, client, resourceGithubProjectV2UpdateInput(d)); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating project %s: %w", d.Id(), err))
+	}
+
+	return resourceGithubProjectV2Read(ctx, d, meta)
+}
+
+func resourceGithubProjectV2Delete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	var mutation struct {
+		DeleteProjectV2 struct {
+			ProjectV2 struct {
+				ID githubv4.ID
+			}
+		} `graphql:"deleteProjectV2(input: $input)"`
+	}
+	input := DeleteProjectV2Input{
+		ProjectID: githubv4.ID(d.Id()),
+	}
+	if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting project %s: %w", d.Id(), err))
+	}
+
+	return nil
+}
+
+func updateProjectV2(ctx context.Context, client *githubv4.Client, input githubv4.UpdateProjectV2Input) error {
+	var mutation struct {
+		UpdateProjectV2 struct {
+			ProjectV2 struct {
+				ID githubv4.ID
+			}
+		} `graphql:"updateProjectV2(input: $input)"`
+	}
+	return client.Mutate(ctx, &mutation, input, nil)
+}
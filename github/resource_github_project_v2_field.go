@@ -0,0 +1,408 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"github.com/shurcooL/githubv4"
+)
+
+func resourceGithubProjectV2Field() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubProjectV2FieldCreate,
+		ReadContext:   resourceGithubProjectV2FieldRead,
+		UpdateContext: resourceGithubProjectV2FieldUpdate,
+		DeleteContext: resourceGithubProjectV2FieldDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The node ID of the project.",
+			},
+			"name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the field.",
+			},
+			"data_type": {
+				Type:             schema.TypeString,
+				Required:         true,
+				ForceNew:         true,
+				Description:      "The type of the field. Can be one of: 'TEXT', 'NUMBER', 'DATE', 'SINGLE_SELECT' or 'ITERATION'.",
+				ValidateDiagFunc: validateValueFunc([]string{"TEXT", "NUMBER", "DATE", "SINGLE_SELECT", "ITERATION"}),
+			},
+			"single_select_option": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				ConflictsWith: []string{"iteration_configuration"},
+				Description:   "The options of a 'SINGLE_SELECT' field, in order.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the option.",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the option.",
+						},
+						"color": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "GRAY",
+							Description:      "The color of the option. Can be one of: 'GRAY', 'BLUE', 'GREEN', 'YELLOW', 'ORANGE', 'RED', 'PINK' or 'PURPLE'.",
+							ValidateDiagFunc: validateValueFunc([]string{"GRAY", "BLUE", "GREEN", "YELLOW", "ORANGE", "RED", "PINK", "PURPLE"}),
+						},
+						"description": {
+							Type:        schema.TypeString,
+							Optional:    true,
+							Default:     "",
+							Description: "The description of the option.",
+						},
+					},
+				},
+			},
+			"iteration_configuration": {
+				Type:          schema.TypeList,
+				Optional:      true,
+				MaxItems:      1,
+				ConflictsWith: []string{"single_select_option"},
+				Description:   "The configuration of an 'ITERATION' field.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"start_date": {
+							Type:             schema.TypeString,
+							Required:         true,
+							Description:      "The start date of the first iteration, in the YYYY-MM-DD format.",
+							ValidateDiagFunc: toDiagFunc(validation.StringMatch(regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`), "must be a date in the YYYY-MM-DD format"), "start_date"),
+						},
+						"duration": {
+							Type:             schema.TypeInt,
+							Required:         true,
+							Description:      "The duration of the iterations, in days.",
+							ValidateDiagFunc: toDiagFunc(validation.IntAtLeast(1), "duration"),
+						},
+					},
+				},
+			},
+			"iteration": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The iterations of an 'ITERATION' field, completed ones included.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the iteration.",
+						},
+						"title": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The title of the iteration.",
+						},
+						"start_date": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The start date of the iteration.",
+						},
+						"duration": {
+							Type:        schema.TypeInt,
+							Computed:    true,
+							Description: "The duration of the iteration, in days.",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+type ProjectV2SingleSelectFieldOptionInput struct {
+	Name        githubv4.String `json:"name"`
+	Color       githubv4.String `json:"color"`
+	Description githubv4.String `json:"description"`
+}
+
+type ProjectV2Iteration struct {
+	StartDate githubv4.String `json:"startDate"`
+	Duration  githubv4.Int    `json:"duration"`
+	Title     githubv4.String `json:"title"`
+}
+
+type ProjectV2IterationFieldConfigurationInput struct {
+	StartDate  githubv4.String      `json:"startDate"`
+	Duration   githubv4.Int         `json:"duration"`
+	Iterations []ProjectV2Iteration `json:"iterations"`
+}
+
+type CreateProjectV2FieldInput struct {
+	ProjectID              githubv4.ID                                `json:"projectId"`
+	DataType               githubv4.String                            `json:"dataType"`
+	Name                   githubv4.String                            `json:"name"`
+	SingleSelectOptions    *[]ProjectV2SingleSelectFieldOptionInput   `json:"singleSelectOptions,omitempty"`
+	IterationConfiguration *ProjectV2IterationFieldConfigurationInput `json:"iterationConfiguration,omitempty"`
+	ClientMutationID       *githubv4.String                           `json:"clientMutationId,omitempty"`
+}
+
+type UpdateProjectV2FieldInput struct {
+	FieldID                githubv4.ID                                `json:"fieldId"`
+	Name                   *githubv4.String                           `json:"name,omitempty"`
+	SingleSelectOptions    *[]ProjectV2SingleSelectFieldOptionInput   `json:"singleSelectOptions,omitempty"`
+	IterationConfiguration *ProjectV2IterationFieldConfigurationInput `json:"iterationConfiguration,omitempty"`
+	ClientMutationID       *githubv4.String                           `json:"clientMutationId,omitempty"`
+}
+
+type DeleteProjectV2FieldInput struct {
+	FieldID          githubv4.ID      `json:"fieldId"`
+	ClientMutationID *githubv4.String `json:"clientMutationId,omitempty"`
+}
+
+type projectV2FieldCommon struct {
+	ID       githubv4.ID
+	Name     githubv4.String
+	DataType githubv4.String
+	Project  struct {
+		ID githubv4.ID
+	}
+}
+
+type projectV2Iteration struct {
+	ID        githubv4.String
+	Title     githubv4.String
+	StartDate githubv4.String
+	Duration  githubv4.Int
+}
+
+// projectV2FieldNode is a field of a project, selected through the fragments
+// of each type of field.
+type projectV2FieldNode struct {
+	Field struct {
+		projectV2FieldCommon
+	} `graphql:"... on ProjectV2Field"`
+	SingleSelectField struct {
+		projectV2FieldCommon
+		Options []struct {
+			ID          githubv4.String
+			Name        githubv4.String
+			Color       githubv4.String
+			Description githubv4.String
+		}
+	} `graphql:"... on ProjectV2SingleSelectField"`
+	IterationField struct {
+		projectV2FieldCommon
+		Configuration struct {
+			Duration            githubv4.Int
+			Iterations          []projectV2Iteration
+			CompletedIterations []projectV2Iteration
+		}
+	} `graphql:"... on ProjectV2IterationField"`
+}
+
+func (n projectV2FieldNode) common() projectV2FieldCommon {
+	switch {
+	case n.SingleSelectField.ID != nil:
+		return n.SingleSelectField.projectV2FieldCommon
+	case n.IterationField.ID != nil:
+		return n.IterationField.projectV2FieldCommon
+	}
+	return n.Field.projectV2FieldCommon
+}
+
+func expandProjectV2SingleSelectOptions(d *schema.ResourceData) *[]ProjectV2SingleSelectFieldOptionInput {
+	if d.Get("data_type").(string) != "SINGLE_SELECT" {
+		return nil
+	}
+	options := []ProjectV2SingleSelectFieldOptionInput{}
+	for _, raw := range d.Get("single_select_option").([]interface{}) {
+		option := raw.(map[string]interface{})
+		options = append(options, ProjectV2SingleSelectFieldOptionInput{
+			Name:        githubv4.String(option["name"].(string)),
+			Color:       githubv4.String(option["color"].(string)),
+			Description: githubv4.String(option["description"].(string)),
+		})
+	}
+	return &options
+}
+
+func expandProjectV2IterationConfiguration(d *schema.ResourceData) *ProjectV2IterationFieldConfigurationInput {
+	configurations := d.Get("iteration_configuration").([]interface{})
+	if d.Get("data_type").(string) != "ITERATION" || len(configurations) == 0 {
+		return nil
+	}
+	configuration := configurations[0].(map[string]interface{})
+	return &ProjectV2IterationFieldConfigurationInput{
+		StartDate:  githubv4.String(configuration["start_date"].(string)),
+		Duration:   githubv4.Int(configuration["duration"].(int)),
+		Iterations: []ProjectV2Iteration{},
+	}
+}
+
+func resourceGithubProjectV2FieldCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	dataType := d.Get("data_type").(string)
+	if _, ok := d.GetOk("single_select_option"); ok != (dataType == "SINGLE_SELECT") {
+		return diag.Errorf("single_select_option must be set for, and only for, SINGLE_SELECT fields")
+	}
+	if _, ok := d.GetOk("iteration_configuration"); ok != (dataType == "ITERATION") {
+		return diag.Errorf("iteration_configuration must be set for, and only for, ITERATION fields")
+	}
+
+	var mutation struct {
+		CreateProjectV2Field struct {
+			ProjectV2Field projectV2FieldNode `graphql:"projectV2Field"`
+		} `graphql:"createProjectV2Field(input: $input)"`
+	}
+	input := CreateProjectV2FieldInput{
+		ProjectID:              githubv4.ID(d.Get("project_id").(string)),
+		DataType:               githubv4.String(dataType),
+		Name:                   githubv4.String(d.Get("name").(string)),
+		SingleSelectOptions:    expandProjectV2SingleSelectOptions(d),
+		IterationConfiguration: expandProjectV2IterationConfiguration(d),
+	}
+	if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error creating field %s of project %s: %w", d.Get("name").(string), d.Get("project_id").(string), err))
+	}
+
+	d.SetId(fmt.Sprint(mutation.CreateProjectV2Field.ProjectV2Field.common().ID))
+	return resourceGithubProjectV2FieldRead(ctx, d, meta)
+}
+
+func resourceGithubProjectV2FieldRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	var query struct {
+		Node projectV2FieldNode `graphql:"node(id: $id)"`
+	}
+	variables := map[string]interface{}{
+		"id": githubv4.ID(d.Id()),
+	}
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+	err := client.Query(ctx, &query, variables)
+	if err != nil {
+		if strings.Contains(err.Error(), "Could not resolve to a node with the global id") {
+			log.Printf("[INFO] Removing project field %s from state because it no longer exists in GitHub", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	field := query.Node.common()
+
+	if err = d.Set("project_id", fmt.Sprint(field.Project.ID)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("name", string(field.Name)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("data_type", string(field.DataType)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	options := []interface{}{}
+	for _, option := range query.Node.SingleSelectField.Options {
+		options = append(options, map[string]interface{}{
+			"id":          string(option.ID),
+			"name":        string(option.Name),
+			"color":       string(option.Color),
+			"description": string(option.Description),
+		})
+	}
+	if err = d.Set("single_select_option", options); err != nil {
+		return diag.FromErr(err)
+	}
+
+	configuration := []interface{}{}
+	iterations := []interface{}{}
+	if field.DataType == "ITERATION" {
+		iterationConfiguration := query.Node.IterationField.Configuration
+		all := append(iterationConfiguration.CompletedIterations, iterationConfiguration.Iterations...)
+		for _, iteration := range all {
+			iterations = append(iterations, map[string]interface{}{
+				"id":         string(iteration.ID),
+				"title":      string(iteration.Title),
+				"start_date": string(iteration.StartDate),
+				"duration":   int(iteration.Duration),
+			})
+		}
+
+		// GitHub only returns the start day of the week of the iterations,
+		// so the configured start date is kept, or the one of the first
+		// iteration is used when importing.
+		startDate := ""
+		if v, ok := d.GetOk("iteration_configuration.0.start_date"); ok {
+			startDate = v.(string)
+		} else if len(all) > 0 {
+			startDate = string(all[0].StartDate)
+		}
+		configuration = append(configuration, map[string]interface{}{
+			"start_date": startDate,
+			"duration":   int(iterationConfiguration.Duration),
+		})
+	}
+	if err = d.Set("iteration_configuration", configuration); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("iteration", iterations); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubProjectV2FieldUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	var mutation struct {
+		UpdateProjectV2Field struct {
+			ProjectV2Field projectV2FieldNode `graphql:"projectV2Field"`
+		} `graphql:"updateProjectV2Field(input: $input)"`
+	}
+	input := UpdateProjectV2FieldInput{
+		FieldID: githubv4.ID(d.Id()),
+		Name:    githubv4.NewString(githubv4.String(d.Get("name").(string))),
+	}
+	if d.HasChange("single_select_option") {
+		input.SingleSelectOptions = expandProjectV2SingleSelectOptions(d)
+	}
+	if d.HasChange("iteration_configuration") {
+		input.IterationConfiguration = expandProjectV2IterationConfiguration(d)
+	}
+	if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating project field %s: %w", d.Id(), err))
+	}
+
+	return resourceGithubProjectV2FieldRead(ctx, d, meta)
+}
+
+func resourceGithubProjectV2FieldDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	var mutation struct {
+		DeleteProjectV2Field struct {
+			ProjectV2Field projectV2FieldNode `graphql:"projectV2Field"`
+		} `graphql:"deleteProjectV2Field(input: $input)"`
+	}
+	input := DeleteProjectV2FieldInput{
+		FieldID: githubv4.ID(d.Id()),
+	}
+	if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error deleting project field %s: %w", d.Id(), err))
+	}
+
+	return nil
+}
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubProjectV2Field(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("creates project fields without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_project_v2" "test" {
+				title = "tf-acc-test-%s"
+			}
+
+			resource "github_project_v2_field" "priority" {
+				project_id = github_project_v2.test.id
+				name       = "Priority"
+				data_type  = "SINGLE_SELECT"
+
+				single_select_option {
+					name  = "High"
+					color = "RED"
+				}
+
+				single_select_option {
+					name = "Low"
+				}
+			}
+
+			resource "github_project_v2_field" "estimate" {
+				project_id = github_project_v2.test.id
+				name       = "Estimate"
+				data_type  = "NUMBER"
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_project_v2_field.priority", "single_select_option.#",
+				"2",
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_project_v2_field.priority", "single_select_option.0.id",
+			),
+			resource.TestCheckResourceAttr(
+				"github_project_v2_field.estimate", "data_type",
+				"NUMBER",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_project_v2_field.priority",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubProjectV2FieldWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+
+	project := newFakeResource(t, meta, "github_project_v2")
+	project.apply(map[string]interface{}{"title": "Roadmap"})
+
+	textConfig := map[string]interface{}{
+		"project_id": project.state.ID,
+		"name":       "Notes",
+		"data_type":  "TEXT",
+	}
+	text := newFakeResource(t, meta, "github_project_v2_field")
+	text.apply(textConfig)
+	text.expectNoChanges(textConfig)
+	textConfig["name"] = "Comments"
+	text.apply(textConfig)
+	if text.get("name") != "Comments" {
+		t.Fatalf("Unexpected field: %v", text.state.Attributes)
+	}
+
+	selectConfig := map[string]interface{}{
+		"project_id": project.state.ID,
+		"name":       "Priority",
+		"data_type":  "SINGLE_SELECT",
+		"single_select_option": []interface{}{
+			map[string]interface{}{"name": "High", "color": "RED", "description": "Do it now"},
+			map[string]interface{}{"name": "Low"},
+		},
+	}
+	singleSelect := newFakeResource(t, meta, "github_project_v2_field")
+	singleSelect.apply(selectConfig)
+	if singleSelect.get("single_select_option.#") != "2" || singleSelect.get("single_select_option.1.color") != "GRAY" || singleSelect.get("single_select_option.0.id") == "" {
+		t.Fatalf("Unexpected field: %v", singleSelect.state.Attributes)
+	}
+	singleSelect.expectNoChanges(selectConfig)
+
+	selectConfig["single_select_option"] = append(selectConfig["single_select_option"].([]interface{}), map[string]interface{}{"name": "Medium", "color": "YELLOW"})
+	singleSelect.apply(selectConfig)
+	if singleSelect.get("single_select_option.2.name") != "Medium" {
+		t.Fatalf("Unexpected field: %v", singleSelect.state.Attributes)
+	}
+	singleSelect.expectNoChanges(selectConfig)
+
+	imported := newFakeResource(t, meta, "github_project_v2_field")
+	imported.importState(singleSelect.state.ID)
+	imported.expectNoChanges(selectConfig)
+
+	iterationConfig := map[string]interface{}{
+		"project_id": project.state.ID,
+		"name":       "Sprint",
+		"data_type":  "ITERATION",
+		"iteration_configuration": []interface{}{
+			map[string]interface{}{"start_date": "2026-01-05", "duration": 14},
+		},
+	}
+	iteration := newFakeResource(t, meta, "github_project_v2_field")
+	iteration.apply(iterationConfig)
+	if iteration.get("iteration.#") != "3" || iteration.get("iteration.1.start_date") != "2026-01-19" {
+		t.Fatalf("Unexpected field: %v", iteration.state.Attributes)
+	}
+	iteration.expectNoChanges(iterationConfig)
+
+	importedIteration := newFakeResource(t, meta, "github_project_v2_field")
+	importedIteration.importState(iteration.state.ID)
+	importedIteration.expectNoChanges(iterationConfig)
+
+	invalid := newFakeResource(t, meta, "github_project_v2_field")
+	diff := invalid.plan(map[string]interface{}{
+		"project_id": project.state.ID,
+		"name":       "Size",
+		"data_type":  "TEXT",
+		"single_select_option": []interface{}{
+			map[string]interface{}{"name": "Large"},
+		},
+	})
+	if _, diags := invalid.resource.Apply(context.Background(), nil, diff, meta); !diags.HasError() {
+		t.Fatal("Expected options on a text field to be refused")
+	}
+
+	project.destroy()
+	text.refresh()
+	if text.state != nil {
+		t.Fatal("Expected the fields of a deleted project to be removed from state")
+	}
+}
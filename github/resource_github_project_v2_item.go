@@ -0,0 +1,147 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
+)
+
+func resourceGithubProjectV2Item() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubProjectV2ItemCreate,
+		ReadContext:   resourceGithubProjectV2ItemRead,
+		DeleteContext: resourceGithubProjectV2ItemDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The node ID of the project.",
+			},
+			"content_id": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The node ID of the issue or pull request to add to the project.",
+			},
+			"type": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The type of the item, 'ISSUE', 'PULL_REQUEST', 'DRAFT_ISSUE' or 'REDACTED' when its content cannot be seen.",
+			},
+		},
+	}
+}
+
+func resourceGithubProjectV2ItemCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	var mutation struct {
+		AddProjectV2ItemById struct {
+			Item struct {
+				ID githubv4.ID
+			}
+		} `graphql:"addProjectV2ItemById(input: $input)"`
+	}
+	input := githubv4.AddProjectV2ItemByIdInput{
+		ProjectID: githubv4.ID(d.Get("project_id").(string)),
+		ContentID: githubv4.ID(d.Get("content_id").(string)),
+	}
+	if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error adding %s to project %s: %w", d.Get("content_id").(string), d.Get("project_id").(string), err))
+	}
+
+	d.SetId(fmt.Sprint(mutation.AddProjectV2ItemById.Item.ID))
+	return resourceGithubProjectV2ItemRead(ctx, d, meta)
+}
+
+func resourceGithubProjectV2ItemRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	var query struct {
+		Node struct {
+			Item struct {
+				Type    githubv4.String
+				Project struct {
+					ID githubv4.ID
+				}
+				Content struct {
+					Issue struct {
+						ID githubv4.ID
+					} `graphql:"... on Issue"`
+					PullRequest struct {
+						ID githubv4.ID
+					} `graphql:"... on PullRequest"`
+					DraftIssue struct {
+						ID githubv4.ID
+					} `graphql:"... on DraftIssue"`
+				}
+			} `graphql:"... on ProjectV2Item"`
+		} `graphql:"node(id: $id)"`
+	}
+	variables := map[string]interface{}{
+		"id": githubv4.ID(d.Id()),
+	}
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+	err := client.Query(ctx, &query, variables)
+	if err != nil {
+		if strings.Contains(err.Error(), "Could not resolve to a node with the global id") {
+			log.Printf("[INFO] Removing project item %s from state because it no longer exists in GitHub", d.Id())
+			d.SetId("")
+			return nil
+		}
+		return diag.FromErr(err)
+	}
+	item := query.Node.Item
+
+	contentID := item.Content.Issue.ID
+	if contentID == nil {
+		contentID = item.Content.PullRequest.ID
+	}
+	if contentID == nil {
+		contentID = item.Content.DraftIssue.ID
+	}
+	if err = d.Set("project_id", fmt.Sprint(item.Project.ID)); err != nil {
+		return diag.FromErr(err)
+	}
+	// The content of items the authenticated user cannot see is redacted, the
+	// configured one is kept
+	if contentID != nil {
+		if err = d.Set("content_id", fmt.Sprint(contentID)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if err = d.Set("type", string(item.Type)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubProjectV2ItemDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v4client
+
+	var mutation struct {
+		DeleteProjectV2Item struct {
+			DeletedItemID githubv4.ID `graphql:"deletedItemId"`
+		} `graphql:"deleteProjectV2Item(input: $input)"`
+	}
+	input := githubv4.DeleteProjectV2ItemInput{
+		ProjectID: githubv4.ID(d.Get("project_id").(string)),
+		ItemID:    githubv4.ID(d.Id()),
+	}
+	if err := client.Mutate(ctx, &mutation, input, nil); err != nil {
+		return diag.FromErr(fmt.Errorf("error removing item %s from project %s: %w", d.Id(), d.Get("project_id").(string), err))
+	}
+
+	return nil
+}
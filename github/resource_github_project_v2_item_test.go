@@ -0,0 +1,124 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubProjectV2Item(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("adds an issue to a project without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name       = "tf-acc-test-%[1]s"
+				has_issues = true
+			}
+
+			resource "github_issue" "test" {
+				repository = github_repository.test.name
+				title      = "tf-acc-test-%[1]s"
+			}
+
+			resource "github_project_v2" "test" {
+				title = "tf-acc-test-%[1]s"
+			}
+
+			resource "github_project_v2_item" "test" {
+				project_id = github_project_v2.test.id
+				content_id = github_issue.test.node_id
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_project_v2_item.test", "type",
+				"ISSUE",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_project_v2_item.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubProjectV2ItemWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+	issue := srv.AddIssue(fakeOrganization, "service", "Ship the feature")
+
+	project := newFakeResource(t, meta, "github_project_v2")
+	project.apply(map[string]interface{}{"title": "Roadmap"})
+
+	config := map[string]interface{}{
+		"project_id": project.state.ID,
+		"content_id": issue.GetNodeID(),
+	}
+	item := newFakeResource(t, meta, "github_project_v2_item")
+	item.apply(config)
+	if item.get("type") != "ISSUE" {
+		t.Fatalf("Unexpected item: %v", item.state.Attributes)
+	}
+	item.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_project_v2_item")
+	imported.importState(item.state.ID)
+	imported.expectNoChanges(config)
+
+	// Draft issues are read back as the content of their item.
+	draftItemID, draftID := srv.AddDraftIssue(project.state.ID, "Sketch the design")
+	draft := newFakeResource(t, meta, "github_project_v2_item")
+	draft.importState(draftItemID)
+	if draft.get("content_id") != draftID || draft.get("type") != "DRAFT_ISSUE" {
+		t.Fatalf("Unexpected draft item: %v", draft.state.Attributes)
+	}
+
+	// The content of items which cannot be seen is kept.
+	if !srv.RemoveIssue(issue.GetNodeID()) {
+		t.Fatal("Expected the issue to exist")
+	}
+	item.refresh()
+	if item.get("type") != "REDACTED" {
+		t.Fatalf("Unexpected item: %v", item.state.Attributes)
+	}
+	item.expectNoChanges(config)
+
+	imported.destroy()
+	item.refresh()
+	if item.state != nil {
+		t.Fatal("Expected an item removed outside of Terraform to be removed from state")
+	}
+}
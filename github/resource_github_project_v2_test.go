@@ -0,0 +1,148 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubProjectV2(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("creates and updates a project without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_project_v2" "test" {
+				title       = "tf-acc-test-%s"
+				description = "A project"
+			}
+		`, randomID)
+
+		updatedConfig := fmt.Sprintf(`
+			resource "github_project_v2" "test" {
+				title       = "tf-acc-test-%s"
+				description = "An updated project"
+				readme      = "# Roadmap"
+				closed      = true
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_project_v2.test", "title",
+				fmt.Sprintf("tf-acc-test-%s", randomID),
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_project_v2.test", "number",
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_project_v2.test", "url",
+			),
+		)
+
+		updatedCheck := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_project_v2.test", "description",
+				"An updated project",
+			),
+			resource.TestCheckResourceAttr(
+				"github_project_v2.test", "readme",
+				"# Roadmap",
+			),
+			resource.TestCheckResourceAttr(
+				"github_project_v2.test", "closed",
+				"true",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						Config: updatedConfig,
+						Check:  updatedCheck,
+					},
+					{
+						ResourceName:      "github_project_v2.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubProjectV2WithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+
+	config := map[string]interface{}{
+		"title": "Roadmap",
+	}
+
+	project := newFakeResource(t, meta, "github_project_v2")
+	project.apply(config)
+	if project.get("owner") != fakeOrganization || project.get("number") != "1" {
+		t.Fatalf("Unexpected project: %v", project.state.Attributes)
+	}
+	if url := project.get("url"); !strings.HasSuffix(url, "/orgs/"+fakeOrganization+"/projects/1") {
+		t.Fatalf("Unexpected URL: %q", url)
+	}
+	project.expectNoChanges(config)
+
+	config["description"] = "What we are working on"
+	config["readme"] = "# Roadmap"
+	config["public"] = true
+	config["closed"] = true
+	project.apply(config)
+	if project.get("description") != "What we are working on" || project.get("public") != "true" || project.get("closed") != "true" {
+		t.Fatalf("Unexpected project: %v", project.state.Attributes)
+	}
+	project.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_project_v2")
+	imported.importState(project.state.ID)
+	imported.expectNoChanges(config)
+
+	userProject := newFakeResource(t, meta, "github_project_v2")
+	userProject.apply(map[string]interface{}{
+		"owner": "Octocat",
+		"title": "Personal",
+	})
+	if userProject.get("owner") != "octocat" || userProject.get("number") != "1" {
+		t.Fatalf("Unexpected user project: %v", userProject.state.Attributes)
+	}
+	userProject.expectNoChanges(map[string]interface{}{
+		"owner": "Octocat",
+		"title": "Personal",
+	})
+
+	imported.destroy()
+	project.refresh()
+	if project.state != nil {
+		t.Fatal("Expected a project deleted outside of Terraform to be removed from state")
+	}
+}
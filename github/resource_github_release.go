@@ -8,17 +8,18 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRelease() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubReleaseCreateUpdate,
-		Update: resourceGithubReleaseCreateUpdate,
-		Read:   resourceGithubReleaseRead,
-		Delete: resourceGithubReleaseDelete,
+		CreateContext: resourceGithubReleaseCreateUpdate,
+		UpdateContext: resourceGithubReleaseCreateUpdate,
+		ReadContext:   resourceGithubReleaseRead,
+		DeleteContext: resourceGithubReleaseDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubReleaseImport,
+			StateContext: resourceGithubReleaseImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -136,8 +137,7 @@ func resourceGithubRelease() *schema.Resource {
 	}
 }
 
-func resourceGithubReleaseCreateUpdate(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.Background()
+func resourceGithubReleaseCreateUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxId, d.Id())
 	}
@@ -192,23 +192,23 @@ func resourceGithubReleaseCreateUpdate(d *schema.ResourceData, meta interface{})
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	transformResponseToResourceData(d, release, repoName)
 	return nil
 }
 
-func resourceGithubReleaseRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubReleaseRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	repository := d.Get("repository").(string)
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	releaseID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if releaseID == 0 {
-		return fmt.Errorf("`release_id` must be present")
+		return diag.FromErr(fmt.Errorf("`release_id` must be present"))
 	}
 
 	release, _, err := client.Repositories.GetRelease(ctx, owner, repository, releaseID)
@@ -220,14 +220,14 @@ func resourceGithubReleaseRead(d *schema.ResourceData, meta interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	transformResponseToResourceData(d, release, repository)
 	return nil
 }
 
-func resourceGithubReleaseDelete(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+func resourceGithubReleaseDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	repository := d.Get("repository").(string)
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
@@ -235,21 +235,21 @@ func resourceGithubReleaseDelete(d *schema.ResourceData, meta interface{}) error
 	releaseIDStr := d.Id()
 	releaseID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(releaseIDStr, err)
+		return diag.FromErr(unconvertibleIdErr(releaseIDStr, err))
 	}
 	if releaseID == 0 {
-		return fmt.Errorf("`release_id` must be present")
+		return diag.FromErr(fmt.Errorf("`release_id` must be present"))
 	}
 
 	_, err = client.Repositories.DeleteRelease(ctx, owner, repository, releaseID)
 	if err != nil {
-		return fmt.Errorf("error deleting GitHub release reference %s/%s (%s): %s",
-			fmt.Sprint(releaseID), repository, owner, err)
+		return diag.FromErr(fmt.Errorf("error deleting GitHub release reference %s/%s (%s): %s",
+			fmt.Sprint(releaseID), repository, owner, err))
 	}
 	return nil
 }
 
-func resourceGithubReleaseImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubReleaseImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	repoName, releaseIDStr, err := parseTwoPartID(d.Id(), "repository", "release")
 	if err != nil {
 		return []*schema.ResourceData{d}, err
@@ -266,8 +266,7 @@ func resourceGithubReleaseImport(d *schema.ResourceData, meta interface{}) ([]*s
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
-	repository, _, err := client.Repositories.Get(ctx, owner, repoName)
+	repository, err := getRepository(ctx, owner, repoName, meta)
 	if repository == nil || err != nil {
 		return []*schema.ResourceData{d}, err
 	}
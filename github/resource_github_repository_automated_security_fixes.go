@@ -3,17 +3,18 @@ package github
 import (
 	"context"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRepositoryDependabotSecurityUpdates() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryDependabotSecurityUpdatesCreateOrUpdate,
-		Read:   resourceGithubRepositoryDependabotSecurityUpdatesRead,
-		Update: resourceGithubRepositoryDependabotSecurityUpdatesCreateOrUpdate,
-		Delete: resourceGithubRepositoryDependabotSecurityUpdatesDelete,
+		CreateContext: resourceGithubRepositoryDependabotSecurityUpdatesCreateOrUpdate,
+		ReadContext:   resourceGithubRepositoryDependabotSecurityUpdatesRead,
+		UpdateContext: resourceGithubRepositoryDependabotSecurityUpdatesCreateOrUpdate,
+		DeleteContext: resourceGithubRepositoryDependabotSecurityUpdatesDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubRepositoryDependabotSecurityUpdatesImport,
+			StateContext: resourceGithubRepositoryDependabotSecurityUpdatesImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -32,13 +33,12 @@ func resourceGithubRepositoryDependabotSecurityUpdates() *schema.Resource {
 	}
 }
 
-func resourceGithubRepositoryDependabotSecurityUpdatesCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryDependabotSecurityUpdatesCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 	enabled := d.Get("enabled").(bool)
 
-	ctx := context.Background()
 	var err error
 	if enabled {
 		_, err = client.Repositories.EnableAutomatedSecurityFixes(ctx, owner, repoName)
@@ -47,50 +47,45 @@ func resourceGithubRepositoryDependabotSecurityUpdatesCreateOrUpdate(d *schema.R
 	}
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(repoName)
-	return resourceGithubRepositoryDependabotSecurityUpdatesRead(d, meta)
+	return resourceGithubRepositoryDependabotSecurityUpdatesRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryDependabotSecurityUpdatesRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryDependabotSecurityUpdatesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 
-	ctx := context.Background()
-
 	p, _, err := client.Repositories.GetAutomatedSecurityFixes(ctx, orgName, repoName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.Set("enabled", p.Enabled)
 
 	return nil
 }
 
-func resourceGithubRepositoryDependabotSecurityUpdatesDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryDependabotSecurityUpdatesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 
-	ctx := context.Background()
-
 	_, err := client.Repositories.DisableAutomatedSecurityFixes(ctx, orgName, repoName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubRepositoryDependabotSecurityUpdatesRead(d, meta)
+	return resourceGithubRepositoryDependabotSecurityUpdatesRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryDependabotSecurityUpdatesImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubRepositoryDependabotSecurityUpdatesImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	repoName := d.Id()
 
 	d.Set("repository", repoName)
 
-	err := resourceGithubRepositoryDependabotSecurityUpdatesRead(d, meta)
-	if err != nil {
+	if err := errorFromDiagnostics(resourceGithubRepositoryDependabotSecurityUpdatesRead(ctx, d, meta)); err != nil {
 		return nil, err
 	}
 
@@ -0,0 +1,253 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubRepositoryCodeScanningDefaultSetup() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubRepositoryCodeScanningDefaultSetupCreateOrUpdate,
+		ReadContext:   resourceGithubRepositoryCodeScanningDefaultSetupRead,
+		UpdateContext: resourceGithubRepositoryCodeScanningDefaultSetupCreateOrUpdate,
+		DeleteContext: resourceGithubRepositoryCodeScanningDefaultSetupDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(20 * time.Minute),
+			Update: schema.DefaultTimeout(20 * time.Minute),
+			Delete: schema.DefaultTimeout(20 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the repository.",
+			},
+			"state": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "configured",
+				Description:      "Whether code scanning default setup is enabled, 'configured' or 'not-configured'.",
+				ValidateDiagFunc: validateValueFunc([]string{"configured", "not-configured"}),
+			},
+			"query_suite": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "default",
+				Description:      "The CodeQL query suite to use, 'default' or 'extended'.",
+				ValidateDiagFunc: validateValueFunc([]string{"default", "extended"}),
+			},
+			"languages": {
+				Type:        schema.TypeSet,
+				Optional:    true,
+				Computed:    true,
+				Description: "The languages to analyze. Defaults to the languages GitHub detects in the repository.",
+				Elem:        &schema.Schema{Type: schema.TypeString},
+			},
+			"runner_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "standard",
+				Description:      "The type of runner analyzing the repository, 'standard' for GitHub-hosted runners or 'labeled' for runners with the runner_label label.",
+				ValidateDiagFunc: validateValueFunc([]string{"standard", "labeled"}),
+			},
+			"runner_label": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The label of the runners analyzing the repository, when runner_type is 'labeled'.",
+			},
+			"updated_at": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The time default setup was last updated.",
+			},
+		},
+	}
+}
+
+// codeScanningDefaultSetup extends the default setup configuration with the
+// runner it uses, which go-github does not know about.
+type codeScanningDefaultSetup struct {
+	github.DefaultSetupConfiguration
+	RunnerType  *string `json:"runner_type,omitempty"`
+	RunnerLabel *string `json:"runner_label,omitempty"`
+}
+
+func (s *codeScanningDefaultSetup) GetRunnerType() string {
+	if s.RunnerType == nil {
+		return ""
+	}
+	return *s.RunnerType
+}
+
+func (s *codeScanningDefaultSetup) GetRunnerLabel() string {
+	if s.RunnerLabel == nil {
+		return ""
+	}
+	return *s.RunnerLabel
+}
+
+// codeScanningDefaultSetupOptions extends the options updating default setup
+// with the runner to use, which go-github does not know about.
+type codeScanningDefaultSetupOptions struct {
+	github.UpdateDefaultSetupConfigurationOptions
+	RunnerType  *string `json:"runner_type,omitempty"`
+	RunnerLabel *string `json:"runner_label,omitempty"`
+}
+
+func resourceGithubRepositoryCodeScanningDefaultSetupCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+
+	options := &codeScanningDefaultSetupOptions{
+		UpdateDefaultSetupConfigurationOptions: github.UpdateDefaultSetupConfigurationOptions{
+			State: d.Get("state").(string),
+		},
+	}
+	if options.State == "configured" {
+		options.QuerySuite = github.String(d.Get("query_suite").(string))
+		options.Languages = expandStringList(d.Get("languages").(*schema.Set).List())
+		options.RunnerType = github.String(d.Get("runner_type").(string))
+		if label := d.Get("runner_label").(string); label != "" {
+			options.RunnerLabel = github.String(label)
+		}
+	}
+
+	timeout := d.Timeout(schema.TimeoutUpdate)
+	if d.IsNewResource() {
+		timeout = d.Timeout(schema.TimeoutCreate)
+	}
+	if err := updateCodeScanningDefaultSetup(ctx, client, owner, repoName, options, timeout); err != nil {
+		return diag.FromErr(fmt.Errorf("error updating code scanning default setup of %s/%s: %w", owner, repoName, err))
+	}
+
+	d.SetId(repoName)
+	return resourceGithubRepositoryCodeScanningDefaultSetupRead(ctx, d, meta)
+}
+
+func resourceGithubRepositoryCodeScanningDefaultSetupRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Id()
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+
+	req, err := client.NewRequest("GET", fmt.Sprintf("repos/%v/%v/code-scanning/default-setup", owner, repoName), nil)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	var setup codeScanningDefaultSetup
+	if _, err = client.Do(ctx, req, &setup); err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing code scanning default setup of %s/%s from state because the repository no longer exists in GitHub",
+					owner, repoName)
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("repository", repoName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("state", setup.GetState()); err != nil {
+		return diag.FromErr(err)
+	}
+	// The other settings are only kept by GitHub while default setup is
+	// enabled.
+	if setup.GetState() != "configured" {
+		return nil
+	}
+	if err = d.Set("query_suite", setup.GetQuerySuite()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("languages", flattenStringList(setup.Languages)); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("runner_type", setup.GetRunnerType()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("runner_label", setup.GetRunnerLabel()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("updated_at", setup.GetUpdatedAt().String()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubRepositoryCodeScanningDefaultSetupDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+
+	options := &codeScanningDefaultSetupOptions{
+		UpdateDefaultSetupConfigurationOptions: github.UpdateDefaultSetupConfigurationOptions{
+			State: "not-configured",
+		},
+	}
+	if err := updateCodeScanningDefaultSetup(ctx, client, owner, d.Id(), options, d.Timeout(schema.TimeoutDelete)); err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok && ghErr.Response.StatusCode == http.StatusNotFound {
+			log.Printf("[INFO] Not disabling code scanning default setup of %s/%s because the repository no longer exists in GitHub", owner, d.Id())
+			return nil
+		}
+		return diag.FromErr(fmt.Errorf("error disabling code scanning default setup of %s/%s: %w", owner, d.Id(), err))
+	}
+
+	return nil
+}
+
+// updateCodeScanningDefaultSetup updates default setup, then waits for the
+// GitHub Actions run applying the update to complete.
+func updateCodeScanningDefaultSetup(ctx context.Context, client *github.Client, owner, repoName string, options *codeScanningDefaultSetupOptions, timeout time.Duration) error {
+	req, err := client.NewRequest("PATCH", fmt.Sprintf("repos/%v/%v/code-scanning/default-setup", owner, repoName), options)
+	if err != nil {
+		return err
+	}
+	var response github.UpdateDefaultSetupConfigurationResponse
+	_, err = client.Do(ctx, req, &response)
+	// The update is applied asynchronously, the run applying it is only in
+	// the body of the 202 Accepted response.
+	if acceptedErr, ok := err.(*github.AcceptedError); ok {
+		err = json.Unmarshal(acceptedErr.Raw, &response)
+	}
+	if err != nil {
+		return err
+	}
+	if response.GetRunID() == 0 {
+		return nil
+	}
+
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		run, resp, err := client.Actions.GetWorkflowRunByID(ctx, owner, repoName, response.GetRunID())
+		if err != nil {
+			// The run is often not readable yet right after the update
+			if resp != nil && resp.StatusCode == http.StatusNotFound {
+				return retry.RetryableError(fmt.Errorf("the run %d applying the update is not found yet", response.GetRunID()))
+			}
+			return retry.NonRetryableError(err)
+		}
+		if run.GetStatus() != "completed" {
+			return retry.RetryableError(fmt.Errorf("the run %s applying the update is %s", run.GetHTMLURL(), run.GetStatus()))
+		}
+		if run.GetConclusion() != "success" {
+			return retry.NonRetryableError(fmt.Errorf("the run %s applying the update concluded with %s", run.GetHTMLURL(), run.GetConclusion()))
+		}
+		return nil
+	})
+}
@@ -0,0 +1,141 @@
+package github
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubRepositoryCodeScanningDefaultSetup(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("enables code scanning default setup without error", func(t *testing.T) {
+
+		config := func(querySuite string) string {
+			return fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name       = "tf-acc-test-%s"
+				visibility = "public"
+				auto_init  = true
+			}
+
+			resource "github_repository_file" "test" {
+				repository = github_repository.test.name
+				file       = "main.py"
+				content    = "print('Hello, World!')"
+			}
+
+			resource "github_repository_code_scanning_default_setup" "test" {
+				repository  = github_repository.test.name
+				query_suite = "%s"
+
+				depends_on = [github_repository_file.test]
+			}
+		`, randomID, querySuite)
+		}
+
+		check := func(querySuite string) resource.TestCheckFunc {
+			return resource.ComposeTestCheckFunc(
+				resource.TestCheckResourceAttr(
+					"github_repository_code_scanning_default_setup.test", "state",
+					"configured",
+				),
+				resource.TestCheckResourceAttr(
+					"github_repository_code_scanning_default_setup.test", "query_suite",
+					querySuite,
+				),
+				resource.TestCheckTypeSetElemAttr(
+					"github_repository_code_scanning_default_setup.test", "languages.*",
+					"python",
+				),
+			)
+		}
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config("default"),
+						Check:  check("default"),
+					},
+					{
+						Config: config("extended"),
+						Check:  check("extended"),
+					},
+					{
+						ResourceName:      "github_repository_code_scanning_default_setup.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubRepositoryCodeScanningDefaultSetupWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+
+	config := map[string]interface{}{
+		"repository": "service",
+	}
+
+	// The run applying the update is not found right away, then reported in
+	// progress before it completes.
+	setup := newFakeResource(t, meta, "github_repository_code_scanning_default_setup")
+	setup.apply(config)
+	if setup.get("state") != "configured" || setup.get("languages.#") != "2" || setup.get("runner_type") != "standard" {
+		t.Fatalf("Unexpected default setup: %v", setup.state.Attributes)
+	}
+	setup.expectNoChanges(config)
+
+	config["query_suite"] = "extended"
+	config["languages"] = []interface{}{"python"}
+	config["runner_type"] = "labeled"
+	config["runner_label"] = "code-scanning"
+	setup.apply(config)
+	if setup.get("query_suite") != "extended" || setup.get("languages.#") != "1" || setup.get("runner_label") != "code-scanning" {
+		t.Fatalf("Unexpected default setup: %v", setup.state.Attributes)
+	}
+	setup.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_repository_code_scanning_default_setup")
+	imported.importState("service")
+	imported.expectNoChanges(config)
+
+	// Default setup disabled outside of Terraform is detected.
+	imported.destroy()
+	setup.refresh()
+	if setup.get("state") != "not-configured" {
+		t.Fatalf("Unexpected default setup: %v", setup.state.Attributes)
+	}
+	setup.expectChanges(config)
+	setup.apply(config)
+	setup.expectNoChanges(config)
+
+	// Destroying default setup of a deleted repository succeeds.
+	repo := newFakeRepository(t, meta, "deleted")
+	deleted := newFakeResource(t, meta, "github_repository_code_scanning_default_setup")
+	deleted.apply(map[string]interface{}{"repository": "deleted"})
+	repo.destroy()
+	deleted.destroy()
+}
@@ -8,15 +8,16 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRepositoryCollaborator() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryCollaboratorCreate,
-		Read:   resourceGithubRepositoryCollaboratorRead,
-		Update: resourceGithubRepositoryCollaboratorUpdate,
-		Delete: resourceGithubRepositoryCollaboratorDelete,
+		CreateContext: resourceGithubRepositoryCollaboratorCreate,
+		ReadContext:   resourceGithubRepositoryCollaboratorRead,
+		UpdateContext: resourceGithubRepositoryCollaboratorUpdate,
+		DeleteContext: resourceGithubRepositoryCollaboratorDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -66,7 +67,7 @@ func resourceGithubRepositoryCollaborator() *schema.Resource {
 	}
 }
 
-func resourceGithubRepositoryCollaboratorCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryCollaboratorCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	username := d.Get("username").(string)
@@ -74,8 +75,6 @@ func resourceGithubRepositoryCollaboratorCreate(d *schema.ResourceData, meta int
 
 	owner, repoNameWithoutOwner := parseRepoName(repoName, meta.(*Owner).name)
 
-	ctx := context.Background()
-
 	_, _, err := client.Repositories.AddCollaborator(ctx,
 		owner,
 		repoNameWithoutOwner,
@@ -85,23 +84,23 @@ func resourceGithubRepositoryCollaboratorCreate(d *schema.ResourceData, meta int
 		})
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repoName, username))
 
-	return resourceGithubRepositoryCollaboratorRead(d, meta)
+	return resourceGithubRepositoryCollaboratorRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryCollaboratorRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryCollaboratorRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	repoName, username, err := parseTwoPartID(d.Id(), "repository", "username")
 	owner, repoNameWithoutOwner := parseRepoName(repoName, meta.(*Owner).name)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	// First, check if the user has been invited but has not yet accepted
 	invitation, err := findRepoInvitation(client, ctx, owner, repoNameWithoutOwner, username)
@@ -116,7 +115,7 @@ func resourceGithubRepositoryCollaboratorRead(d *schema.ResourceData, meta inter
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	if invitation != nil {
 		username = invitation.GetInvitee().GetLogin()
@@ -124,16 +123,16 @@ func resourceGithubRepositoryCollaboratorRead(d *schema.ResourceData, meta inter
 		permissionName := getPermission(invitation.GetPermissions())
 
 		if err = d.Set("repository", repoName); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err = d.Set("username", username); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err = d.Set("permission", permissionName); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err = d.Set("invitation_id", fmt.Sprintf("%d", invitation.GetID())); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		return nil
 	}
@@ -147,19 +146,19 @@ func resourceGithubRepositoryCollaboratorRead(d *schema.ResourceData, meta inter
 		collaborators, resp, err := client.Repositories.ListCollaborators(ctx,
 			owner, repoNameWithoutOwner, opt)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		for _, c := range collaborators {
 			if strings.EqualFold(c.GetLogin(), username) {
 				if err = d.Set("repository", repoName); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 				if err = d.Set("username", c.GetLogin()); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 				if err = d.Set("permission", getPermission(c.GetRoleName())); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 				return nil
 			}
@@ -179,11 +178,11 @@ func resourceGithubRepositoryCollaboratorRead(d *schema.ResourceData, meta inter
 	return nil
 }
 
-func resourceGithubRepositoryCollaboratorUpdate(d *schema.ResourceData, meta interface{}) error {
-	return resourceGithubRepositoryCollaboratorRead(d, meta)
+func resourceGithubRepositoryCollaboratorUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGithubRepositoryCollaboratorRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryCollaboratorDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryCollaboratorDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	username := d.Get("username").(string)
@@ -191,19 +190,19 @@ func resourceGithubRepositoryCollaboratorDelete(d *schema.ResourceData, meta int
 
 	owner, repoNameWithoutOwner := parseRepoName(repoName, meta.(*Owner).name)
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	// Delete any pending invitations
 	invitation, err := findRepoInvitation(client, ctx, owner, repoNameWithoutOwner, username)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	} else if invitation != nil {
 		_, err = client.Repositories.DeleteInvitation(ctx, owner, repoNameWithoutOwner, invitation.GetID())
-		return err
+		return diag.FromErr(err)
 	}
 
 	_, err = client.Repositories.RemoveCollaborator(ctx, owner, repoNameWithoutOwner, username)
-	return err
+	return diag.FromErr(err)
 }
 
 func findRepoInvitation(client *github.Client, ctx context.Context, owner, repo, collaborator string) (*github.RepositoryInvitation, error) {
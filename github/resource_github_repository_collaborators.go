@@ -9,16 +9,17 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRepositoryCollaborators() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryCollaboratorsCreate,
-		Read:   resourceGithubRepositoryCollaboratorsRead,
-		Update: resourceGithubRepositoryCollaboratorsUpdate,
-		Delete: resourceGithubRepositoryCollaboratorsDelete,
+		CreateContext: resourceGithubRepositoryCollaboratorsCreate,
+		ReadContext:   resourceGithubRepositoryCollaboratorsRead,
+		UpdateContext: resourceGithubRepositoryCollaboratorsUpdate,
+		DeleteContext: resourceGithubRepositoryCollaboratorsDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -285,13 +286,12 @@ func listAllCollaborators(client *github.Client, isOrg bool, ctx context.Context
 	return userCollaborators, invitations, teamCollaborators, err
 }
 
-func matchUserCollaboratorsAndInvites(
+func matchUserCollaboratorsAndInvites(ctx context.Context,
 	repoName string, want []interface{}, hasUsers []userCollaborator, hasInvites []invitedCollaborator,
 	meta interface{}) error {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	for _, has := range hasUsers {
 		var wantPermission string
@@ -383,12 +383,11 @@ func matchUserCollaboratorsAndInvites(
 	return nil
 }
 
-func matchTeamCollaborators(
+func matchTeamCollaborators(ctx context.Context,
 	repoName string, want []interface{}, has []teamCollaborator, meta interface{}) error {
 	client := meta.(*Owner).v3client
 	orgID := meta.(*Owner).id
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	remove := make([]teamCollaborator, 0)
 	for _, hasTeam := range has {
@@ -396,7 +395,7 @@ func matchTeamCollaborators(
 		for _, w := range want {
 			teamData := w.(map[string]interface{})
 			teamIDString := teamData["team_id"].(string)
-			teamID, err := getTeamID(teamIDString, meta)
+			teamID, err := getTeamID(ctx, teamIDString, meta)
 			if err != nil {
 				return err
 			}
@@ -423,7 +422,7 @@ func matchTeamCollaborators(
 	for _, t := range want {
 		teamData := t.(map[string]interface{})
 		teamIDString := teamData["team_id"].(string)
-		teamID, err := getTeamID(teamIDString, meta)
+		teamID, err := getTeamID(ctx, teamIDString, meta)
 		if err != nil {
 			return err
 		}
@@ -461,7 +460,7 @@ func matchTeamCollaborators(
 	return nil
 }
 
-func resourceGithubRepositoryCollaboratorsCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryCollaboratorsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
@@ -469,13 +468,12 @@ func resourceGithubRepositoryCollaboratorsCreate(d *schema.ResourceData, meta in
 	users := d.Get("user").(*schema.Set).List()
 	teams := d.Get("team").(*schema.Set).List()
 	repoName := d.Get("repository").(string)
-	ctx := context.Background()
 
 	teamsMap := make(map[string]struct{})
 	for _, team := range teams {
 		teamIDString := team.(map[string]interface{})["team_id"].(string)
 		if _, found := teamsMap[teamIDString]; found {
-			return fmt.Errorf("duplicate set member: %s", teamIDString)
+			return diag.FromErr(fmt.Errorf("duplicate set member: %s", teamIDString))
 		}
 		teamsMap[teamIDString] = struct{}{}
 	}
@@ -483,42 +481,42 @@ func resourceGithubRepositoryCollaboratorsCreate(d *schema.ResourceData, meta in
 	for _, user := range users {
 		username := user.(map[string]interface{})["username"].(string)
 		if _, found := usersMap[username]; found {
-			return fmt.Errorf("duplicate set member found: %s", username)
+			return diag.FromErr(fmt.Errorf("duplicate set member found: %s", username))
 		}
 		usersMap[username] = struct{}{}
 	}
 
 	userCollaborators, invitations, teamCollaborators, err := listAllCollaborators(client, isOrg, ctx, owner, repoName)
 	if err != nil {
-		return deleteResourceOn404AndSwallow304OtherwiseReturnError(err, d, "repository collaborators (%s/%s)", owner, repoName)
+		return diag.FromErr(deleteResourceOn404AndSwallow304OtherwiseReturnError(err, d, "repository collaborators (%s/%s)", owner, repoName))
 	}
 
-	err = matchTeamCollaborators(repoName, teams, teamCollaborators, meta)
+	err = matchTeamCollaborators(ctx, repoName, teams, teamCollaborators, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	err = matchUserCollaboratorsAndInvites(repoName, users, userCollaborators, invitations, meta)
+	err = matchUserCollaboratorsAndInvites(ctx, repoName, users, userCollaborators, invitations, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(repoName)
 
-	return resourceGithubRepositoryCollaboratorsRead(d, meta)
+	return resourceGithubRepositoryCollaboratorsRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryCollaboratorsRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryCollaboratorsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	isOrg := meta.(*Owner).IsOrganization
 	repoName := d.Id()
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	userCollaborators, invitedCollaborators, teamCollaborators, err := listAllCollaborators(client, isOrg, ctx, owner, repoName)
 	if err != nil {
-		return deleteResourceOn404AndSwallow304OtherwiseReturnError(err, d, "repository collaborators (%s/%s)", owner, repoName)
+		return diag.FromErr(deleteResourceOn404AndSwallow304OtherwiseReturnError(err, d, "repository collaborators (%s/%s)", owner, repoName))
 	}
 
 	invitationIds := make(map[string]string, len(invitedCollaborators))
@@ -533,50 +531,49 @@ func resourceGithubRepositoryCollaboratorsRead(d *schema.ResourceData, meta inte
 
 	err = d.Set("repository", repoName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("user", flattenUserCollaborators(userCollaborators, invitedCollaborators))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("team", flattenTeamCollaborators(teamCollaborators, teamIDs))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	err = d.Set("invitation_ids", invitationIds)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubRepositoryCollaboratorsUpdate(d *schema.ResourceData, meta interface{}) error {
-	return resourceGithubRepositoryCollaboratorsCreate(d, meta)
+func resourceGithubRepositoryCollaboratorsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	return resourceGithubRepositoryCollaboratorsCreate(ctx, d, meta)
 }
 
-func resourceGithubRepositoryCollaboratorsDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryCollaboratorsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	isOrg := meta.(*Owner).IsOrganization
 	repoName := d.Get("repository").(string)
-	ctx := context.Background()
 
 	userCollaborators, invitations, teamCollaborators, err := listAllCollaborators(client, isOrg, ctx, owner, repoName)
 	if err != nil {
-		return deleteResourceOn404AndSwallow304OtherwiseReturnError(err, d, "repository collaborators (%s/%s)", owner, repoName)
+		return diag.FromErr(deleteResourceOn404AndSwallow304OtherwiseReturnError(err, d, "repository collaborators (%s/%s)", owner, repoName))
 	}
 
 	log.Printf("[DEBUG] Deleting all users, invites and collaborators for repo: %s.", repoName)
 
 	// delete all users
-	err = matchUserCollaboratorsAndInvites(repoName, nil, userCollaborators, invitations, meta)
+	err = matchUserCollaboratorsAndInvites(ctx, repoName, nil, userCollaborators, invitations, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// delete all teams
-	err = matchTeamCollaborators(repoName, nil, teamCollaborators, meta)
-	return err
+	err = matchTeamCollaborators(ctx, repoName, nil, teamCollaborators, meta)
+	return diag.FromErr(err)
 }
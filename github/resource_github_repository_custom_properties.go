@@ -0,0 +1,229 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubRepositoryCustomProperties() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubRepositoryCustomPropertiesCreateOrUpdate,
+		ReadContext:   resourceGithubRepositoryCustomPropertiesRead,
+		UpdateContext: resourceGithubRepositoryCustomPropertiesCreateOrUpdate,
+		DeleteContext: resourceGithubRepositoryCustomPropertiesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				if err := d.Set("repository", d.Id()); err != nil {
+					return nil, err
+				}
+				return []*schema.ResourceData{d}, nil
+			},
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the repository.",
+			},
+			"property": {
+				Type:        schema.TypeSet,
+				Required:    true,
+				Description: "The values of the custom properties of the repository. Properties which are not listed are unset.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"name": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The name of the custom property.",
+						},
+						"value": {
+							Type:        schema.TypeSet,
+							Required:    true,
+							MinItems:    1,
+							Description: "The value of the custom property. 'multi_select' properties take several values, other properties a single one.",
+							Elem:        &schema.Schema{Type: schema.TypeString},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func resourceGithubRepositoryCustomPropertiesCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+
+	// The organization's schema tells whether properties take a single value
+	// or several
+	properties, _, err := client.Organizations.GetAllCustomProperties(ctx, owner)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	valueTypes := make(map[string]string, len(properties))
+	for _, property := range properties {
+		valueTypes[property.GetPropertyName()] = property.ValueType
+	}
+
+	var values []*github.CustomPropertyValue
+	configured := map[string]bool{}
+	for _, v := range d.Get("property").(*schema.Set).List() {
+		property := v.(map[string]interface{})
+		name := property["name"].(string)
+		value := expandStringList(property["value"].(*schema.Set).List())
+		sort.Strings(value)
+
+		valueType, ok := valueTypes[name]
+		if !ok {
+			return diag.Errorf("custom property %s is not defined by organization %s", name, owner)
+		}
+		customPropertyValue := &github.CustomPropertyValue{PropertyName: name, Value: value}
+		if valueType != customPropertyTypeMultiSelect {
+			if len(value) != 1 {
+				return diag.Errorf("custom property %s of type %s takes a single value, got %d", name, valueType, len(value))
+			}
+			customPropertyValue.Value = value[0]
+		}
+
+		values = append(values, customPropertyValue)
+		configured[name] = true
+	}
+
+	// Properties removed from the configuration are unset
+	if d.HasChange("property") {
+		old, _ := d.GetChange("property")
+		for _, v := range old.(*schema.Set).List() {
+			name := v.(map[string]interface{})["name"].(string)
+			if !configured[name] {
+				values = append(values, &github.CustomPropertyValue{PropertyName: name, Value: nil})
+			}
+		}
+	}
+
+	_, err = client.Repositories.CreateOrUpdateCustomProperties(ctx, owner, repoName, values)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error setting custom properties of repository %s/%s: %w", owner, repoName, err))
+	}
+
+	d.SetId(repoName)
+	return resourceGithubRepositoryCustomPropertiesRead(ctx, d, meta)
+}
+
+func resourceGithubRepositoryCustomPropertiesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+
+	values, _, err := client.Repositories.GetAllCustomPropertyValues(ctx, owner, repoName)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing custom properties of repository %s/%s from state because it no longer exists in GitHub",
+					owner, repoName)
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("property", flattenCustomPropertyValues(values)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubRepositoryCustomPropertiesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	err := checkOrganization(meta)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+
+	var values []*github.CustomPropertyValue
+	for _, v := range d.Get("property").(*schema.Set).List() {
+		values = append(values, &github.CustomPropertyValue{
+			PropertyName: v.(map[string]interface{})["name"].(string),
+			Value:        nil,
+		})
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	_, err = client.Repositories.CreateOrUpdateCustomProperties(ctx, owner, repoName, values)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error unsetting custom properties of repository %s/%s: %w", owner, repoName, err))
+	}
+
+	return nil
+}
+
+// flattenCustomPropertyValues returns the properties with a value, which is
+// either a single string or a list of them for 'multi_select' properties.
+func flattenCustomPropertyValues(values []*github.CustomPropertyValue) []interface{} {
+	properties := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		var flattened []string
+		switch v := value.Value.(type) {
+		case string:
+			flattened = []string{v}
+		case []string:
+			flattened = v
+		}
+		if len(flattened) == 0 {
+			continue
+		}
+		properties = append(properties, map[string]interface{}{
+			"name":  value.PropertyName,
+			"value": flattenStringList(flattened),
+		})
+	}
+	return properties
+}
+
+// flattenCustomProperties returns the custom properties of a repository as a
+// map, joining the values of 'multi_select' properties by commas.
+func flattenCustomProperties(properties map[string]interface{}) map[string]interface{} {
+	flattened := make(map[string]interface{}, len(properties))
+	for name, value := range properties {
+		switch v := value.(type) {
+		case nil:
+			continue
+		case []interface{}:
+			values := make([]string, len(v))
+			for i, item := range v {
+				values[i] = fmt.Sprint(item)
+			}
+			flattened[name] = strings.Join(values, ",")
+		default:
+			flattened[name] = fmt.Sprint(v)
+		}
+	}
+	return flattened
+}
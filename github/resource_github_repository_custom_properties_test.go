@@ -0,0 +1,165 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubRepositoryCustomProperties(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("sets the custom properties of a repository without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_organization_custom_property" "tier" {
+			  name           = "tf-acc-test-tier-%[1]s"
+			  value_type     = "single_select"
+			  allowed_values = ["gold", "silver"]
+			}
+
+			resource "github_organization_custom_property" "classification" {
+			  name           = "tf-acc-test-classification-%[1]s"
+			  value_type     = "multi_select"
+			  allowed_values = ["pii", "financial", "public"]
+			}
+
+			resource "github_repository" "test" {
+			  name = "tf-acc-test-%[1]s"
+			}
+
+			resource "github_repository_custom_properties" "test" {
+			  repository = github_repository.test.name
+
+			  property {
+			    name  = github_organization_custom_property.tier.name
+			    value = ["gold"]
+			  }
+
+			  property {
+			    name  = github_organization_custom_property.classification.name
+			    value = ["pii", "financial"]
+			  }
+			}
+
+			data "github_repository" "test" {
+			  name = github_repository_custom_properties.test.repository
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_repository_custom_properties.test", "property.#",
+				"2",
+			),
+			resource.TestCheckResourceAttr(
+				"data.github_repository.test", fmt.Sprintf("custom_properties.tf-acc-test-tier-%s", randomID),
+				"gold",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_repository_custom_properties.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubRepositoryCustomPropertiesWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+
+	for name, valueType := range map[string]string{"tier": "single_select", "classification": "multi_select", "team": "string"} {
+		newFakeResource(t, meta, "github_organization_custom_property").apply(map[string]interface{}{
+			"name":       name,
+			"value_type": valueType,
+		})
+	}
+
+	config := map[string]interface{}{
+		"repository": "service",
+		"property": []interface{}{
+			map[string]interface{}{"name": "tier", "value": []interface{}{"gold"}},
+			map[string]interface{}{"name": "classification", "value": []interface{}{"pii", "financial"}},
+			map[string]interface{}{"name": "team", "value": []interface{}{"platform"}},
+		},
+	}
+
+	properties := newFakeResource(t, meta, "github_repository_custom_properties")
+	properties.apply(config)
+	expected := map[string]interface{}{
+		"tier":           "gold",
+		"classification": []string{"financial", "pii"},
+		"team":           "platform",
+	}
+	if actual := srv.Repository(fakeOrganization, "service").CustomProperties; !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("Unexpected custom properties: %v", actual)
+	}
+	properties.expectNoChanges(config)
+
+	repo := newFakeResource(t, meta, "github_repository")
+	repo.importState("service")
+	if repo.get("custom_properties.classification") != "financial,pii" || repo.get("custom_properties.tier") != "gold" {
+		t.Fatalf("Unexpected custom properties of the repository: %v", repo.state.Attributes)
+	}
+
+	config["property"] = config["property"].([]interface{})[:1]
+	properties.apply(config)
+	if actual := srv.Repository(fakeOrganization, "service").CustomProperties; !reflect.DeepEqual(actual, map[string]interface{}{"tier": "gold"}) {
+		t.Fatalf("Expected properties removed from the configuration to be unset, got: %v", actual)
+	}
+
+	srv.Repository(fakeOrganization, "service").CustomProperties["team"] = "security"
+	properties.refresh()
+	properties.expectChanges(config)
+
+	imported := newFakeResource(t, meta, "github_repository_custom_properties")
+	imported.importState("service")
+	if imported.get("property.#") != "2" {
+		t.Fatalf("Unexpected imported state: %v", imported.state.Attributes)
+	}
+
+	config["property"] = []interface{}{
+		map[string]interface{}{"name": "tier", "value": []interface{}{"gold", "silver"}},
+	}
+	_, diags := properties.resource.Apply(context.Background(), properties.state, properties.plan(config), meta)
+	if !diags.HasError() {
+		t.Fatal("Expected several values of a single_select property to be refused")
+	}
+
+	properties.destroy()
+	if actual := srv.Repository(fakeOrganization, "service").CustomProperties; !reflect.DeepEqual(actual, map[string]interface{}{}) {
+		t.Fatalf("Expected the custom properties to be unset, got: %v", actual)
+	}
+}
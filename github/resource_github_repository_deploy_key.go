@@ -9,14 +9,15 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRepositoryDeployKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryDeployKeyCreate,
-		Read:   resourceGithubRepositoryDeployKeyRead,
-		Delete: resourceGithubRepositoryDeployKeyDelete,
+		CreateContext: resourceGithubRepositoryDeployKeyCreate,
+		ReadContext:   resourceGithubRepositoryDeployKeyRead,
+		DeleteContext: resourceGithubRepositoryDeployKeyDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -57,7 +58,7 @@ func resourceGithubRepositoryDeployKey() *schema.Resource {
 	}
 }
 
-func resourceGithubRepositoryDeployKeyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryDeployKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	repoName := d.Get("repository").(string)
@@ -65,7 +66,6 @@ func resourceGithubRepositoryDeployKeyCreate(d *schema.ResourceData, meta interf
 	title := d.Get("title").(string)
 	readOnly := d.Get("read_only").(bool)
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	resultKey, _, err := client.Repositories.CreateKey(ctx, owner, repoName, &github.Key{
 		Key:      github.String(key),
@@ -74,30 +74,30 @@ func resourceGithubRepositoryDeployKeyCreate(d *schema.ResourceData, meta interf
 	})
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	id := strconv.FormatInt(resultKey.GetID(), 10)
 
 	d.SetId(buildTwoPartID(repoName, id))
 
-	return resourceGithubRepositoryDeployKeyRead(d, meta)
+	return resourceGithubRepositoryDeployKeyRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryDeployKeyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryDeployKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repoName, idString, err := parseTwoPartID(d.Id(), "repository", "ID")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	id, err := strconv.ParseInt(idString, 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(idString, err)
+		return diag.FromErr(unconvertibleIdErr(idString, err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -115,49 +115,49 @@ func resourceGithubRepositoryDeployKeyRead(d *schema.ResourceData, meta interfac
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("key", key.GetKey()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("read_only", key.GetReadOnly()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("repository", repoName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("title", key.GetTitle()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubRepositoryDeployKeyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryDeployKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repoName, idString, err := parseTwoPartID(d.Id(), "repository", "ID")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	id, err := strconv.ParseInt(idString, 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(idString, err)
+		return diag.FromErr(unconvertibleIdErr(idString, err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Repositories.DeleteKey(ctx, owner, repoName, id)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return err
+	return diag.FromErr(err)
 }
 
 func suppressDeployKeyDiff(k, oldV, newV string, d *schema.ResourceData) bool {
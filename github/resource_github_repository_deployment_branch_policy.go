@@ -7,17 +7,18 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRepositoryDeploymentBranchPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryDeploymentBranchPolicyCreate,
-		Read:   resourceGithubRepositoryDeploymentBranchPolicyRead,
-		Update: resourceGithubRepositoryDeploymentBranchPolicyUpdate,
-		Delete: resourceGithubRepositoryDeploymentBranchPolicyDelete,
+		CreateContext: resourceGithubRepositoryDeploymentBranchPolicyCreate,
+		ReadContext:   resourceGithubRepositoryDeploymentBranchPolicyRead,
+		UpdateContext: resourceGithubRepositoryDeploymentBranchPolicyUpdate,
+		DeleteContext: resourceGithubRepositoryDeploymentBranchPolicyDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubRepositoryDeploymentBranchPolicyImport,
+			StateContext: resourceGithubRepositoryDeploymentBranchPolicyImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -47,8 +48,7 @@ func resourceGithubRepositoryDeploymentBranchPolicy() *schema.Resource {
 	}
 }
 
-func resourceGithubRepositoryDeploymentBranchPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.Background()
+func resourceGithubRepositoryDeploymentBranchPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -57,19 +57,18 @@ func resourceGithubRepositoryDeploymentBranchPolicyUpdate(d *schema.ResourceData
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	_, _, err = client.Repositories.UpdateDeploymentBranchPolicy(ctx, owner, repoName, environmentName, int64(id), &github.DeploymentBranchPolicyRequest{Name: &name})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubRepositoryDeploymentBranchPolicyRead(d, meta)
+	return resourceGithubRepositoryDeploymentBranchPolicyRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryDeploymentBranchPolicyCreate(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.Background()
+func resourceGithubRepositoryDeploymentBranchPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxId, d.Id())
 	}
@@ -82,16 +81,16 @@ func resourceGithubRepositoryDeploymentBranchPolicyCreate(d *schema.ResourceData
 
 	policy, _, err := client.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repoName, environmentName, &github.DeploymentBranchPolicyRequest{Name: &name, Type: github.String("branch")})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.FormatInt(*policy.ID, 10))
 
-	return resourceGithubRepositoryDeploymentBranchPolicyRead(d, meta)
+	return resourceGithubRepositoryDeploymentBranchPolicyRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryDeploymentBranchPolicyRead(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+func resourceGithubRepositoryDeploymentBranchPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -103,7 +102,7 @@ func resourceGithubRepositoryDeploymentBranchPolicyRead(d *schema.ResourceData,
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	policy, resp, err := client.Repositories.GetDeploymentBranchPolicy(ctx, owner, repoName, environmentName, int64(id))
@@ -119,27 +118,27 @@ func resourceGithubRepositoryDeploymentBranchPolicyRead(d *schema.ResourceData,
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("repository", repoName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("environment_name", environmentName); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", policy.Name); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubRepositoryDeploymentBranchPolicyDelete(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+func resourceGithubRepositoryDeploymentBranchPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
@@ -148,17 +147,17 @@ func resourceGithubRepositoryDeploymentBranchPolicyDelete(d *schema.ResourceData
 
 	id, err := strconv.Atoi(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	_, error := client.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repoName, environmentName, int64(id))
 	if error != nil {
-		return error
+		return diag.FromErr(error)
 	}
 	return nil
 }
 
-func resourceGithubRepositoryDeploymentBranchPolicyImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubRepositoryDeploymentBranchPolicyImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	repoName, environmentName, id, err := parseThreePartID(d.Id(), "repository", "environment_name", "id")
 	if err != nil {
 		return nil, err
@@ -172,8 +171,7 @@ func resourceGithubRepositoryDeploymentBranchPolicyImport(d *schema.ResourceData
 		return nil, err
 	}
 
-	err = resourceGithubRepositoryDeploymentBranchPolicyRead(d, meta)
-	if err != nil {
+	if err = errorFromDiagnostics(resourceGithubRepositoryDeploymentBranchPolicyRead(ctx, d, meta)); err != nil {
 		return nil, err
 	}
 
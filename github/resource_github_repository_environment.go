@@ -7,16 +7,17 @@ import (
 	"net/url"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubRepositoryEnvironment() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryEnvironmentCreate,
-		Read:   resourceGithubRepositoryEnvironmentRead,
-		Update: resourceGithubRepositoryEnvironmentUpdate,
-		Delete: resourceGithubRepositoryEnvironmentDelete,
+		CreateContext: resourceGithubRepositoryEnvironmentCreate,
+		ReadContext:   resourceGithubRepositoryEnvironmentRead,
+		UpdateContext: resourceGithubRepositoryEnvironmentUpdate,
+		DeleteContext: resourceGithubRepositoryEnvironmentDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -97,7 +98,7 @@ func resourceGithubRepositoryEnvironment() *schema.Resource {
 	}
 }
 
-func resourceGithubRepositoryEnvironmentCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryEnvironmentCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
@@ -106,30 +107,28 @@ func resourceGithubRepositoryEnvironmentCreate(d *schema.ResourceData, meta inte
 	escapedEnvName := url.PathEscape(envName)
 	updateData := createUpdateEnvironmentData(d, meta)
 
-	ctx := context.Background()
-
 	_, _, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repoName, escapedEnvName, &updateData)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repoName, envName))
 
-	return resourceGithubRepositoryEnvironmentRead(d, meta)
+	return resourceGithubRepositoryEnvironmentRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryEnvironmentRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryEnvironmentRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repoName, envName, err := parseTwoPartID(d.Id(), "repository", "environment")
 	escapedEnvName := url.PathEscape(envName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	env, _, err := client.Repositories.GetEnvironment(ctx, owner, repoName, escapedEnvName)
 	if err != nil {
@@ -141,7 +140,7 @@ func resourceGithubRepositoryEnvironmentRead(d *schema.ResourceData, meta interf
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("repository", repoName)
@@ -153,7 +152,7 @@ func resourceGithubRepositoryEnvironmentRead(d *schema.ResourceData, meta interf
 		switch *pr.Type {
 		case "wait_timer":
 			if err = d.Set("wait_timer", pr.WaitTimer); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 
 		case "required_reviewers":
@@ -178,11 +177,11 @@ func resourceGithubRepositoryEnvironmentRead(d *schema.ResourceData, meta interf
 					"users": users,
 				},
 			}); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 
 			if err = d.Set("prevent_self_review", pr.PreventSelfReview); err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
@@ -194,7 +193,7 @@ func resourceGithubRepositoryEnvironmentRead(d *schema.ResourceData, meta interf
 				"custom_branch_policies": env.DeploymentBranchPolicy.CustomBranchPolicies,
 			},
 		}); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		d.Set("deployment_branch_policy", []interface{}{})
@@ -203,7 +202,7 @@ func resourceGithubRepositoryEnvironmentRead(d *schema.ResourceData, meta interf
 	return nil
 }
 
-func resourceGithubRepositoryEnvironmentUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryEnvironmentUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
@@ -212,32 +211,30 @@ func resourceGithubRepositoryEnvironmentUpdate(d *schema.ResourceData, meta inte
 	escapedEnvName := url.PathEscape(envName)
 	updateData := createUpdateEnvironmentData(d, meta)
 
-	ctx := context.Background()
-
 	resultKey, _, err := client.Repositories.CreateUpdateEnvironment(ctx, owner, repoName, escapedEnvName, &updateData)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(repoName, resultKey.GetName()))
 
-	return resourceGithubRepositoryEnvironmentRead(d, meta)
+	return resourceGithubRepositoryEnvironmentRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryEnvironmentDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryEnvironmentDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repoName, envName, err := parseTwoPartID(d.Id(), "repository", "environment")
 	escapedEnvName := url.PathEscape(envName)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Repositories.DeleteEnvironment(ctx, owner, repoName, escapedEnvName)
-	return err
+	return diag.FromErr(err)
 }
 
 func createUpdateEnvironmentData(d *schema.ResourceData, meta interface{}) github.CreateUpdateEnvironment {
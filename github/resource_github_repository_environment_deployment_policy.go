@@ -9,15 +9,16 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRepositoryEnvironmentDeploymentPolicy() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryEnvironmentDeploymentPolicyCreate,
-		Read:   resourceGithubRepositoryEnvironmentDeploymentPolicyRead,
-		Update: resourceGithubRepositoryEnvironmentDeploymentPolicyUpdate,
-		Delete: resourceGithubRepositoryEnvironmentDeploymentPolicyDelete,
+		CreateContext: resourceGithubRepositoryEnvironmentDeploymentPolicyCreate,
+		ReadContext:   resourceGithubRepositoryEnvironmentDeploymentPolicyRead,
+		UpdateContext: resourceGithubRepositoryEnvironmentDeploymentPolicyUpdate,
+		DeleteContext: resourceGithubRepositoryEnvironmentDeploymentPolicyDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -54,9 +55,8 @@ func resourceGithubRepositoryEnvironmentDeploymentPolicy() *schema.Resource {
 
 }
 
-func resourceGithubRepositoryEnvironmentDeploymentPolicyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryEnvironmentDeploymentPolicyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -75,31 +75,31 @@ func resourceGithubRepositoryEnvironmentDeploymentPolicyCreate(d *schema.Resourc
 			Type: github.String("tag"),
 		}
 	} else {
-		return fmt.Errorf("exactly one of %q and %q must be specified", "branch_pattern", "tag_pattern")
+		return diag.FromErr(fmt.Errorf("exactly one of %q and %q must be specified", "branch_pattern", "tag_pattern"))
 	}
 
 	resultKey, _, err := client.Repositories.CreateDeploymentBranchPolicy(ctx, owner, repoName, escapedEnvName, &createData)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildThreePartID(repoName, escapedEnvName, strconv.FormatInt(resultKey.GetID(), 10)))
-	return resourceGithubRepositoryEnvironmentDeploymentPolicyRead(d, meta)
+	return resourceGithubRepositoryEnvironmentDeploymentPolicyRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryEnvironmentDeploymentPolicyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryEnvironmentDeploymentPolicyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	owner := meta.(*Owner).name
 	repoName, envName, branchPolicyIdString, err := parseThreePartID(d.Id(), "repository", "environment", "branchPolicyId")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	branchPolicyId, err := strconv.ParseInt(branchPolicyIdString, 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	branchPolicy, _, err := client.Repositories.GetDeploymentBranchPolicy(ctx, owner, repoName, envName, branchPolicyId)
@@ -115,7 +115,7 @@ func resourceGithubRepositoryEnvironmentDeploymentPolicyRead(d *schema.ResourceD
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if branchPolicy.GetType() == "branch" {
@@ -126,9 +126,8 @@ func resourceGithubRepositoryEnvironmentDeploymentPolicyRead(d *schema.ResourceD
 	return nil
 }
 
-func resourceGithubRepositoryEnvironmentDeploymentPolicyUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryEnvironmentDeploymentPolicyUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -138,12 +137,12 @@ func resourceGithubRepositoryEnvironmentDeploymentPolicyUpdate(d *schema.Resourc
 	escapedEnvName := url.PathEscape(envName)
 	_, _, branchPolicyIdString, err := parseThreePartID(d.Id(), "repository", "environment", "branchPolicyId")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	branchPolicyId, err := strconv.ParseInt(branchPolicyIdString, 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	pattern := branchPattern
@@ -157,30 +156,29 @@ func resourceGithubRepositoryEnvironmentDeploymentPolicyUpdate(d *schema.Resourc
 
 	resultKey, _, err := client.Repositories.UpdateDeploymentBranchPolicy(ctx, owner, repoName, escapedEnvName, branchPolicyId, &updateData)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(buildThreePartID(repoName, escapedEnvName, strconv.FormatInt(resultKey.GetID(), 10)))
-	return resourceGithubRepositoryEnvironmentDeploymentPolicyRead(d, meta)
+	return resourceGithubRepositoryEnvironmentDeploymentPolicyRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryEnvironmentDeploymentPolicyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryEnvironmentDeploymentPolicyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	owner := meta.(*Owner).name
 	repoName, envName, branchPolicyIdString, err := parseThreePartID(d.Id(), "repository", "environment", "branchPolicyId")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	branchPolicyId, err := strconv.ParseInt(branchPolicyIdString, 10, 64)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	_, err = client.Repositories.DeleteDeploymentBranchPolicy(ctx, owner, repoName, envName, branchPolicyId)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
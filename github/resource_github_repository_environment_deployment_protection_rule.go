@@ -0,0 +1,143 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubRepositoryEnvironmentDeploymentProtectionRule() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubRepositoryEnvironmentDeploymentProtectionRuleCreate,
+		ReadContext:   resourceGithubRepositoryEnvironmentDeploymentProtectionRuleRead,
+		DeleteContext: resourceGithubRepositoryEnvironmentDeploymentProtectionRuleDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the repository. The name is not case sensitive.",
+			},
+			"environment": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the environment.",
+			},
+			"integration_id": {
+				Type:        schema.TypeInt,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The ID of the GitHub App to enable as a custom deployment protection rule.",
+			},
+			"app_slug": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The slug of the GitHub App.",
+			},
+			"node_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The node ID of the custom deployment protection rule.",
+			},
+		},
+	}
+}
+
+func resourceGithubRepositoryEnvironmentDeploymentProtectionRuleCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+	envName := d.Get("environment").(string)
+	escapedEnvName := url.PathEscape(envName)
+
+	request := github.CustomDeploymentProtectionRuleRequest{
+		IntegrationID: github.Int64(int64(d.Get("integration_id").(int))),
+	}
+	rule, _, err := client.Repositories.CreateCustomDeploymentProtectionRule(ctx, owner, repoName, escapedEnvName, &request)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error enabling custom deployment protection rule %d on environment %s of %s/%s: %w",
+			request.GetIntegrationID(), envName, owner, repoName, err))
+	}
+
+	d.SetId(buildThreePartID(repoName, envName, strconv.FormatInt(rule.GetID(), 10)))
+	return resourceGithubRepositoryEnvironmentDeploymentProtectionRuleRead(ctx, d, meta)
+}
+
+func resourceGithubRepositoryEnvironmentDeploymentProtectionRuleRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+
+	owner := meta.(*Owner).name
+	repoName, envName, ruleIDString, err := parseThreePartID(d.Id(), "repository", "environment", "protectionRuleId")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ruleID, err := strconv.ParseInt(ruleIDString, 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(ruleIDString, err))
+	}
+
+	rule, _, err := client.Repositories.GetCustomDeploymentProtectionRule(ctx, owner, repoName, url.PathEscape(envName), ruleID)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing custom deployment protection rule %s from state because it no longer exists in GitHub",
+					d.Id())
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	if err = d.Set("repository", repoName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("environment", envName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("integration_id", rule.GetApp().GetID()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("app_slug", rule.GetApp().GetSlug()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("node_id", rule.GetNodeID()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubRepositoryEnvironmentDeploymentProtectionRuleDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+
+	owner := meta.(*Owner).name
+	repoName, envName, ruleIDString, err := parseThreePartID(d.Id(), "repository", "environment", "protectionRuleId")
+	if err != nil {
+		return diag.FromErr(err)
+	}
+	ruleID, err := strconv.ParseInt(ruleIDString, 10, 64)
+	if err != nil {
+		return diag.FromErr(unconvertibleIdErr(ruleIDString, err))
+	}
+
+	_, err = client.Repositories.DisableCustomDeploymentProtectionRule(ctx, owner, repoName, url.PathEscape(envName), ruleID)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error disabling custom deployment protection rule %s: %w", d.Id(), err))
+	}
+
+	return nil
+}
@@ -0,0 +1,131 @@
+package github
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubRepositoryEnvironmentDeploymentProtectionRule(t *testing.T) {
+
+	appID := os.Getenv("GITHUB_TEST_DEPLOYMENT_PROTECTION_RULE_APP_ID")
+	if appID == "" {
+		t.Skip("Skipping because `GITHUB_TEST_DEPLOYMENT_PROTECTION_RULE_APP_ID` is not set")
+	}
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("enables a custom deployment protection rule without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name = "tf-acc-test-%s"
+			}
+
+			resource "github_repository_environment" "test" {
+				repository  = github_repository.test.name
+				environment = "environment / test"
+			}
+
+			resource "github_repository_environment_deployment_protection_rule" "test" {
+				repository     = github_repository.test.name
+				environment    = github_repository_environment.test.environment
+				integration_id = %s
+			}
+		`, randomID, appID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_repository_environment_deployment_protection_rule.test", "integration_id",
+				appID,
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_repository_environment_deployment_protection_rule.test", "app_slug",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						ResourceName:      "github_repository_environment_deployment_protection_rule.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubRepositoryEnvironmentDeploymentProtectionRuleWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+	app := srv.AddApp("change-management")
+
+	environment := newFakeResource(t, meta, "github_repository_environment")
+	environment.apply(map[string]interface{}{
+		"repository":  "service",
+		"environment": "production/eu",
+	})
+
+	config := map[string]interface{}{
+		"repository":     "service",
+		"environment":    "production/eu",
+		"integration_id": int(app.GetID()),
+	}
+	rule := newFakeResource(t, meta, "github_repository_environment_deployment_protection_rule")
+	rule.apply(config)
+	if rule.get("app_slug") != "change-management" {
+		t.Fatalf("Unexpected rule: %v", rule.state.Attributes)
+	}
+	rule.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_repository_environment_deployment_protection_rule")
+	imported.importState(rule.state.ID)
+	imported.expectNoChanges(config)
+
+	// Updating the environment keeps its custom deployment protection rules.
+	environment.apply(map[string]interface{}{
+		"repository":  "service",
+		"environment": "production/eu",
+		"wait_timer":  10,
+	})
+	rule.refresh()
+	rule.expectNoChanges(config)
+
+	imported.destroy()
+	rule.refresh()
+	if rule.state != nil {
+		t.Fatal("Expected a disabled rule to be removed from state")
+	}
+
+	rule.apply(config)
+	environment.destroy()
+	rule.refresh()
+	if rule.state != nil {
+		t.Fatal("Expected the rule of a deleted environment to be removed from state")
+	}
+}
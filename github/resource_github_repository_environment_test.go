@@ -76,3 +76,47 @@ func TestAccGithubRepositoryEnvironment(t *testing.T) {
 
 	})
 }
+
+func TestGithubRepositoryEnvironmentWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+	reviewer := srv.AddUser("reviewer")
+
+	config := map[string]interface{}{
+		"repository":  "service",
+		"environment": "production/eu",
+		"wait_timer":  10,
+		"reviewers": []interface{}{map[string]interface{}{
+			"users": []interface{}{int(reviewer.GetID())},
+		}},
+		"deployment_branch_policy": []interface{}{map[string]interface{}{
+			"protected_branches":     true,
+			"custom_branch_policies": false,
+		}},
+	}
+
+	environment := newFakeResource(t, meta, "github_repository_environment")
+	environment.apply(config)
+	if users := environment.get("reviewers.0.users.#"); users != "1" {
+		t.Fatalf("Unexpected reviewers: %s", users)
+	}
+	environment.expectNoChanges(config)
+
+	config["wait_timer"] = 20
+	environment.apply(config)
+	if timer := environment.get("wait_timer"); timer != "20" {
+		t.Fatalf("Unexpected wait timer: %s", timer)
+	}
+
+	imported := newFakeResource(t, meta, "github_repository_environment")
+	imported.importState("service:production/eu")
+	imported.expectNoChanges(config)
+
+	environment.destroy()
+	environment.state = imported.state
+	environment.refresh()
+	if environment.state != nil {
+		t.Fatal("Expected a deleted environment to be removed from state")
+	}
+}
@@ -11,17 +11,18 @@ import (
 	"fmt"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRepositoryFile() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryFileCreate,
-		Read:   resourceGithubRepositoryFileRead,
-		Update: resourceGithubRepositoryFileUpdate,
-		Delete: resourceGithubRepositoryFileDelete,
+		CreateContext: resourceGithubRepositoryFileCreate,
+		ReadContext:   resourceGithubRepositoryFileRead,
+		UpdateContext: resourceGithubRepositoryFileUpdate,
+		DeleteContext: resourceGithubRepositoryFileDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				parts := strings.Split(d.Id(), ":")
 
 				if len(parts) > 2 {
@@ -32,7 +33,7 @@ func resourceGithubRepositoryFile() *schema.Resource {
 				owner := meta.(*Owner).name
 				repo, file := splitRepoFilePath(parts[0])
 				// test if a file exists in a repository.
-				ctx := context.WithValue(context.Background(), ctxId, fmt.Sprintf("%s/%s", repo, file))
+				ctx = context.WithValue(ctx, ctxId, fmt.Sprintf("%s/%s", repo, file))
 				opts := &github.RepositoryContentGetOptions{}
 				if len(parts) == 2 {
 					opts.Ref = parts[1]
@@ -188,11 +189,10 @@ func resourceGithubRepositoryFileOptions(d *schema.ResourceData) (*github.Reposi
 	return opts, nil
 }
 
-func resourceGithubRepositoryFileCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryFileCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repo := d.Get("repository").(string)
 	file := d.Get("file").(string)
@@ -201,7 +201,7 @@ func resourceGithubRepositoryFileCreate(d *schema.ResourceData, meta interface{}
 
 	if branch, ok := d.GetOk("branch"); ok {
 		log.Printf("[DEBUG] Using explicitly set branch: %s", branch.(string))
-		if err := checkRepositoryBranchExists(client, owner, repo, branch.(string)); err != nil {
+		if err := checkRepositoryBranchExists(ctx, client, owner, repo, branch.(string)); err != nil {
 			if d.Get("autocreate_branch").(bool) {
 				branchRefName := "refs/heads/" + branch.(string)
 				sourceBranchName := d.Get("autocreate_branch_source_branch").(string)
@@ -210,8 +210,8 @@ func resourceGithubRepositoryFileCreate(d *schema.ResourceData, meta interface{}
 				if _, hasSourceSHA := d.GetOk("autocreate_branch_source_sha"); !hasSourceSHA {
 					ref, _, err := client.Git.GetRef(ctx, owner, repo, sourceBranchRefName)
 					if err != nil {
-						return fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
-							owner, repo, sourceBranchRefName, err)
+						return diag.FromErr(fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
+							owner, repo, sourceBranchRefName, err))
 					}
 					d.Set("autocreate_branch_source_sha", *ref.Object.SHA)
 				}
@@ -220,10 +220,10 @@ func resourceGithubRepositoryFileCreate(d *schema.ResourceData, meta interface{}
 					Ref:    &branchRefName,
 					Object: &github.GitObject{SHA: &sourceBranchSHA},
 				}); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 			} else {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		checkOpt.Ref = branch.(string)
@@ -231,7 +231,7 @@ func resourceGithubRepositoryFileCreate(d *schema.ResourceData, meta interface{}
 
 	opts, err := resourceGithubRepositoryFileOptions(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if opts.Message == nil {
@@ -245,11 +245,11 @@ func resourceGithubRepositoryFileCreate(d *schema.ResourceData, meta interface{}
 		if resp != nil {
 			if resp.StatusCode != 404 {
 				// 404 is a valid response if the file does not exist
-				return err
+				return diag.FromErr(err)
 			}
 		} else {
 			// Response should be non-nil
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -260,29 +260,29 @@ func resourceGithubRepositoryFileCreate(d *schema.ResourceData, meta interface{}
 			opts.SHA = fileContent.SHA
 		} else {
 			// Error if overwriting a file is not requested
-			return fmt.Errorf("refusing to overwrite existing file: configure `overwrite_on_create` to `true` to override")
+			return diag.FromErr(fmt.Errorf("refusing to overwrite existing file: configure `overwrite_on_create` to `true` to override"))
 		}
 	}
 
 	// Create a new or overwritten file
 	create, _, err := client.Repositories.CreateFile(ctx, owner, repo, file, opts)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s", repo, file))
 	if err = d.Set("commit_sha", create.Commit.GetSHA()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubRepositoryFileRead(d, meta)
+	return resourceGithubRepositoryFileRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryFileRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryFileRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	repo, file := splitRepoFilePath(d.Id())
 
@@ -290,7 +290,7 @@ func resourceGithubRepositoryFileRead(d *schema.ResourceData, meta interface{})
 
 	if branch, ok := d.GetOk("branch"); ok {
 		log.Printf("[DEBUG] Using explicitly set branch: %s", branch.(string))
-		if err := checkRepositoryBranchExists(client, owner, repo, branch.(string)); err != nil {
+		if err := checkRepositoryBranchExists(ctx, client, owner, repo, branch.(string)); err != nil {
 			if d.Get("autocreate_branch").(bool) {
 				branch = d.Get("autocreate_branch_source_branch").(string)
 			} else {
@@ -307,7 +307,7 @@ func resourceGithubRepositoryFileRead(d *schema.ResourceData, meta interface{})
 	if err != nil {
 		var errorResponse *github.ErrorResponse
 		if errors.As(err, &errorResponse) && errorResponse.Response.StatusCode == http.StatusTooManyRequests {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if fc == nil {
@@ -319,35 +319,35 @@ func resourceGithubRepositoryFileRead(d *schema.ResourceData, meta interface{})
 
 	content, err := fc.GetContent()
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("content", content); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("repository", repo); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("file", file); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("sha", fc.GetSHA()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	var commit *github.RepositoryCommit
 
 	parsedUrl, err := url.Parse(fc.GetURL())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	parsedQuery, err := url.ParseQuery(parsedUrl.RawQuery)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	ref := parsedQuery["ref"][0]
 	if err = d.Set("ref", ref); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Use the SHA to lookup the commit info if we know it, otherwise loop through commits
@@ -356,15 +356,15 @@ func resourceGithubRepositoryFileRead(d *schema.ResourceData, meta interface{})
 		commit, _, err = client.Repositories.GetCommit(ctx, owner, repo, sha.(string), nil)
 	} else {
 		log.Printf("[DEBUG] Commit SHA unknown for file: %s/%s/%s, looking for commit...", owner, repo, file)
-		commit, err = getFileCommit(client, owner, repo, file, ref)
+		commit, err = getFileCommit(ctx, client, owner, repo, file, ref)
 		log.Printf("[DEBUG] Found file: %s/%s/%s, in commit SHA: %s ", owner, repo, file, commit.GetSHA())
 	}
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("commit_sha", commit.GetSHA()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	commit_author := commit.Commit.GetCommitter().GetName()
@@ -376,31 +376,30 @@ func resourceGithubRepositoryFileRead(d *schema.ResourceData, meta interface{})
 	//read from state if author+email is set explicitly, and if it was not github signing it for you previously
 	if commit_author != "GitHub" && commit_email != "noreply@github.com" && hasCommitAuthor && hasCommitEmail {
 		if err = d.Set("commit_author", commit_author); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err = d.Set("commit_email", commit_email); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if err = d.Set("commit_message", commit.GetCommit().GetMessage()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubRepositoryFileUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryFileUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repo := d.Get("repository").(string)
 	file := d.Get("file").(string)
 
 	if branch, ok := d.GetOk("branch"); ok {
 		log.Printf("[DEBUG] Using explicitly set branch: %s", branch.(string))
-		if err := checkRepositoryBranchExists(client, owner, repo, branch.(string)); err != nil {
+		if err := checkRepositoryBranchExists(ctx, client, owner, repo, branch.(string)); err != nil {
 			if d.Get("autocreate_branch").(bool) {
 				branchRefName := "refs/heads/" + branch.(string)
 				sourceBranchName := d.Get("autocreate_branch_source_branch").(string)
@@ -409,8 +408,8 @@ func resourceGithubRepositoryFileUpdate(d *schema.ResourceData, meta interface{}
 				if _, hasSourceSHA := d.GetOk("autocreate_branch_source_sha"); !hasSourceSHA {
 					ref, _, err := client.Git.GetRef(ctx, owner, repo, sourceBranchRefName)
 					if err != nil {
-						return fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
-							owner, repo, sourceBranchRefName, err)
+						return diag.FromErr(fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
+							owner, repo, sourceBranchRefName, err))
 					}
 					d.Set("autocreate_branch_source_sha", *ref.Object.SHA)
 				}
@@ -419,17 +418,17 @@ func resourceGithubRepositoryFileUpdate(d *schema.ResourceData, meta interface{}
 					Ref:    &branchRefName,
 					Object: &github.GitObject{SHA: &sourceBranchSHA},
 				}); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 			} else {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 
 	opts, err := resourceGithubRepositoryFileOptions(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if *opts.Message == fmt.Sprintf("Add %s", file) {
@@ -439,21 +438,20 @@ func resourceGithubRepositoryFileUpdate(d *schema.ResourceData, meta interface{}
 
 	create, _, err := client.Repositories.CreateFile(ctx, owner, repo, file, opts)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("commit_sha", create.GetSHA()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubRepositoryFileRead(d, meta)
+	return resourceGithubRepositoryFileRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryFileDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryFileDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
 
 	repo := d.Get("repository").(string)
 	file := d.Get("file").(string)
@@ -474,7 +472,7 @@ func resourceGithubRepositoryFileDelete(d *schema.ResourceData, meta interface{}
 
 	if b, ok := d.GetOk("branch"); ok {
 		log.Printf("[DEBUG] Using explicitly set branch: %s", b.(string))
-		if err := checkRepositoryBranchExists(client, owner, repo, b.(string)); err != nil {
+		if err := checkRepositoryBranchExists(ctx, client, owner, repo, b.(string)); err != nil {
 			if d.Get("autocreate_branch").(bool) {
 				branchRefName := "refs/heads/" + b.(string)
 				sourceBranchName := d.Get("autocreate_branch_source_branch").(string)
@@ -483,8 +481,8 @@ func resourceGithubRepositoryFileDelete(d *schema.ResourceData, meta interface{}
 				if _, hasSourceSHA := d.GetOk("autocreate_branch_source_sha"); !hasSourceSHA {
 					ref, _, err := client.Git.GetRef(ctx, owner, repo, sourceBranchRefName)
 					if err != nil {
-						return fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
-							owner, repo, sourceBranchRefName, err)
+						return diag.FromErr(fmt.Errorf("error querying GitHub branch reference %s/%s (%s): %s",
+							owner, repo, sourceBranchRefName, err))
 					}
 					d.Set("autocreate_branch_source_sha", *ref.Object.SHA)
 				}
@@ -493,10 +491,10 @@ func resourceGithubRepositoryFileDelete(d *schema.ResourceData, meta interface{}
 					Ref:    &branchRefName,
 					Object: &github.GitObject{SHA: &sourceBranchSHA},
 				}); err != nil {
-					return err
+					return diag.FromErr(err)
 				}
 			} else {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 		branch = b.(string)
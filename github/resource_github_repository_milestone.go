@@ -10,18 +10,19 @@ import (
 	"time"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubRepositoryMilestone() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryMilestoneCreate,
-		Read:   resourceGithubRepositoryMilestoneRead,
-		Update: resourceGithubRepositoryMilestoneUpdate,
-		Delete: resourceGithubRepositoryMilestoneDelete,
+		CreateContext: resourceGithubRepositoryMilestoneCreate,
+		ReadContext:   resourceGithubRepositoryMilestoneRead,
+		UpdateContext: resourceGithubRepositoryMilestoneUpdate,
+		DeleteContext: resourceGithubRepositoryMilestoneDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
 					return nil, fmt.Errorf("invalid ID format, must be provided as OWNER/REPOSITORY/NUMBER")
@@ -93,9 +94,8 @@ const (
 	layoutISO = "2006-01-02"
 )
 
-func resourceGithubRepositoryMilestoneCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryMilestoneCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn := meta.(*Owner).v3client
-	ctx := context.Background()
 	owner := d.Get("owner").(string)
 	repoName := d.Get("repository").(string)
 
@@ -109,7 +109,7 @@ func resourceGithubRepositoryMilestoneCreate(d *schema.ResourceData, meta interf
 	if v, ok := d.GetOk("due_date"); ok && len(v.(string)) > 0 {
 		dueDate, err := time.Parse(layoutISO, v.(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		date := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 23, 39, 0, 0, time.UTC)
 		milestone.DueOn = &github.Timestamp{
@@ -122,23 +122,23 @@ func resourceGithubRepositoryMilestoneCreate(d *schema.ResourceData, meta interf
 
 	milestone, _, err := conn.Issues.CreateMilestone(ctx, owner, repoName, milestone)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("%s/%s/%d", owner, repoName, milestone.GetNumber()))
 
-	return resourceGithubRepositoryMilestoneRead(d, meta)
+	return resourceGithubRepositoryMilestoneRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryMilestoneRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryMilestoneRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	owner := d.Get("owner").(string)
 	repoName := d.Get("repository").(string)
 	number, err := parseMilestoneNumber(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	milestone, _, err := conn.Issues.GetMilestone(ctx, owner, repoName, number)
@@ -154,38 +154,38 @@ func resourceGithubRepositoryMilestoneRead(d *schema.ResourceData, meta interfac
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("title", milestone.GetTitle()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("description", milestone.GetDescription()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("number", milestone.GetNumber()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("state", milestone.GetState()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if dueOn := milestone.GetDueOn(); !dueOn.IsZero() {
 		if err := d.Set("due_date", milestone.GetDueOn().Format(layoutISO)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceGithubRepositoryMilestoneUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryMilestoneUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	owner := d.Get("owner").(string)
 	repoName := d.Get("repository").(string)
 	number, err := parseMilestoneNumber(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	milestone := &github.Milestone{}
@@ -203,7 +203,7 @@ func resourceGithubRepositoryMilestoneUpdate(d *schema.ResourceData, meta interf
 		_, n := d.GetChange("due_date")
 		dueDate, err := time.Parse(layoutISO, n.(string))
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		date := time.Date(dueDate.Year(), dueDate.Month(), dueDate.Day(), 7, 0, 0, 0, time.UTC)
 		milestone.DueOn = &github.Timestamp{
@@ -218,25 +218,25 @@ func resourceGithubRepositoryMilestoneUpdate(d *schema.ResourceData, meta interf
 
 	_, _, err = conn.Issues.EditMilestone(ctx, owner, repoName, number, milestone)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubRepositoryMilestoneRead(d, meta)
+	return resourceGithubRepositoryMilestoneRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryMilestoneDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryMilestoneDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	conn := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	owner := d.Get("owner").(string)
 	repoName := d.Get("repository").(string)
 	number, err := parseMilestoneNumber(d.Id())
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	_, err = conn.Issues.DeleteMilestone(ctx, owner, repoName, number)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
@@ -0,0 +1,295 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceGithubRepositoryPages() *schema.Resource {
+	return &schema.Resource{
+		CreateContext: resourceGithubRepositoryPagesCreate,
+		ReadContext:   resourceGithubRepositoryPagesRead,
+		UpdateContext: resourceGithubRepositoryPagesUpdate,
+		DeleteContext: resourceGithubRepositoryPagesDelete,
+		Importer: &schema.ResourceImporter{
+			StateContext: schema.ImportStatePassthroughContext,
+		},
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(10 * time.Minute),
+			Update: schema.DefaultTimeout(10 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"repository": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the repository.",
+			},
+			"build_type": {
+				Type:             schema.TypeString,
+				Optional:         true,
+				Default:          "legacy",
+				Description:      "How the site is built, 'legacy' to publish it from a branch or 'workflow' to build it with GitHub Actions.",
+				ValidateDiagFunc: validateValueFunc([]string{"legacy", "workflow"}),
+			},
+			"source": {
+				Type:        schema.TypeList,
+				MaxItems:    1,
+				Optional:    true,
+				Description: "The source branch and directory of a 'legacy' site.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"branch": {
+							Type:        schema.TypeString,
+							Required:    true,
+							Description: "The repository branch used to publish the site's source files. (i.e. 'main' or 'gh-pages')",
+						},
+						"path": {
+							Type:             schema.TypeString,
+							Optional:         true,
+							Default:          "/",
+							Description:      "The repository directory from which the site publishes, '/' or '/docs'.",
+							ValidateDiagFunc: validateValueFunc([]string{"/", "/docs"}),
+						},
+					},
+				},
+			},
+			"cname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Description: "The custom domain of the site.",
+			},
+			"https_enforced": {
+				Type:        schema.TypeBool,
+				Optional:    true,
+				Default:     true,
+				Description: "Whether the site is only served over HTTPS. Enforcing HTTPS on a custom domain waits for its certificate to be issued.",
+			},
+			"url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The API URL of the site.",
+			},
+			"html_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The URL of the rendered site.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The build status of the site, e.g. 'building' or 'built'.",
+			},
+			"custom_404": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the site has a custom 404 page.",
+			},
+			"https_certificate_state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "The state of the certificate of the custom domain, e.g. 'approved'.",
+			},
+		},
+	}
+}
+
+func expandRepositoryPagesSource(d *schema.ResourceData) *github.PagesSource {
+	source := d.Get("source").([]interface{})
+	if len(source) == 0 || source[0] == nil {
+		return nil
+	}
+	s := source[0].(map[string]interface{})
+	return &github.PagesSource{
+		Branch: github.String(s["branch"].(string)),
+		Path:   github.String(s["path"].(string)),
+	}
+}
+
+func resourceGithubRepositoryPagesCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Get("repository").(string)
+
+	pages := &github.Pages{
+		BuildType: github.String(d.Get("build_type").(string)),
+	}
+	if pages.GetBuildType() == "legacy" {
+		pages.Source = expandRepositoryPagesSource(d)
+	}
+	_, _, err := client.Repositories.EnablePages(ctx, owner, repoName, pages)
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error enabling GitHub Pages on %s/%s: %w", owner, repoName, err))
+	}
+	d.SetId(repoName)
+
+	// Custom domains and HTTPS enforcement can only be set once the site
+	// exists.
+	if d.Get("cname").(string) != "" || !d.Get("https_enforced").(bool) {
+		if err = updateRepositoryPages(ctx, d, meta, d.Timeout(schema.TimeoutCreate)); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return resourceGithubRepositoryPagesRead(ctx, d, meta)
+}
+
+func resourceGithubRepositoryPagesRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Id()
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+
+	pages, _, err := client.Repositories.GetPagesInfo(ctx, owner, repoName)
+	if err != nil {
+		if ghErr, ok := err.(*github.ErrorResponse); ok {
+			if ghErr.Response.StatusCode == http.StatusNotFound {
+				log.Printf("[INFO] Removing GitHub Pages of %s/%s from state because they are no longer enabled", owner, repoName)
+				d.SetId("")
+				return nil
+			}
+		}
+		return diag.FromErr(err)
+	}
+
+	source := []interface{}{}
+	if pages.GetBuildType() == "legacy" && pages.Source != nil {
+		source = append(source, map[string]interface{}{
+			"branch": pages.GetSource().GetBranch(),
+			"path":   pages.GetSource().GetPath(),
+		})
+	}
+
+	if err = d.Set("repository", repoName); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("build_type", pages.GetBuildType()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("source", source); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("cname", pages.GetCNAME()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("https_enforced", pages.GetHTTPSEnforced()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("url", pages.GetURL()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("html_url", pages.GetHTMLURL()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("status", pages.GetStatus()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("custom_404", pages.GetCustom404()); err != nil {
+		return diag.FromErr(err)
+	}
+	if err = d.Set("https_certificate_state", pages.GetHTTPSCertificate().GetState()); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return nil
+}
+
+func resourceGithubRepositoryPagesUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	if err := updateRepositoryPages(ctx, d, meta, d.Timeout(schema.TimeoutUpdate)); err != nil {
+		return diag.FromErr(err)
+	}
+
+	return resourceGithubRepositoryPagesRead(ctx, d, meta)
+}
+
+func resourceGithubRepositoryPagesDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+
+	_, err := client.Repositories.DisablePages(ctx, owner, d.Id())
+	if err != nil {
+		return diag.FromErr(fmt.Errorf("error disabling GitHub Pages on %s/%s: %w", owner, d.Id(), err))
+	}
+
+	return nil
+}
+
+// updateRepositoryPages updates the site to match the configuration. HTTPS
+// can only be enforced on a custom domain once its certificate is issued, so
+// it is then enforced separately, after waiting for the certificate.
+func updateRepositoryPages(ctx context.Context, d *schema.ResourceData, meta interface{}, timeout time.Duration) error {
+	client := meta.(*Owner).v3client
+	owner := meta.(*Owner).name
+	repoName := d.Id()
+
+	cname := d.Get("cname").(string)
+	httpsEnforced := d.Get("https_enforced").(bool)
+	if cname == "" && !httpsEnforced {
+		return errors.New("https_enforced can only be disabled on sites with a custom domain")
+	}
+
+	update := &github.PagesUpdate{
+		BuildType: github.String(d.Get("build_type").(string)),
+	}
+	// A missing custom domain removes the custom domain of the site.
+	if cname != "" {
+		update.CNAME = github.String(cname)
+	}
+	if update.GetBuildType() == "legacy" {
+		update.Source = expandRepositoryPagesSource(d)
+	}
+	waitForCertificate := cname != "" && httpsEnforced
+	if !waitForCertificate {
+		update.HTTPSEnforced = github.Bool(httpsEnforced)
+	}
+	_, err := client.Repositories.UpdatePages(ctx, owner, repoName, update)
+	if err != nil {
+		return fmt.Errorf("error updating GitHub Pages of %s/%s: %w", owner, repoName, err)
+	}
+
+	if !waitForCertificate {
+		return nil
+	}
+	if err = waitForRepositoryPagesCertificate(ctx, client, owner, repoName, timeout); err != nil {
+		return fmt.Errorf("error waiting for the certificate of %s: %w", cname, err)
+	}
+	_, err = client.Repositories.UpdatePages(ctx, owner, repoName, &github.PagesUpdate{
+		CNAME:         github.String(cname),
+		HTTPSEnforced: github.Bool(true),
+	})
+	if err != nil {
+		return fmt.Errorf("error enforcing HTTPS on GitHub Pages of %s/%s: %w", owner, repoName, err)
+	}
+
+	return nil
+}
+
+// waitForRepositoryPagesCertificate waits for the custom domain of a site to
+// be served over HTTPS, which GitHub only does once the DNS records of the
+// domain point to it and its certificate has been issued.
+func waitForRepositoryPagesCertificate(ctx context.Context, client *github.Client, owner, repoName string, timeout time.Duration) error {
+	return retry.RetryContext(ctx, timeout, func() *retry.RetryError {
+		health, _, err := client.Repositories.GetPageHealthCheck(ctx, owner, repoName)
+		if err != nil {
+			// The health of the domain is being checked.
+			if _, ok := err.(*github.AcceptedError); ok {
+				return retry.RetryableError(err)
+			}
+			return retry.NonRetryableError(err)
+		}
+		if domain := health.GetDomain(); !domain.GetRespondsToHTTPS() {
+			return retry.RetryableError(fmt.Errorf("%s does not respond to HTTPS yet: %s", domain.GetHost(), domain.GetHTTPSError()))
+		}
+		return nil
+	})
+}
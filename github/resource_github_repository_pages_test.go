@@ -0,0 +1,182 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccGithubRepositoryPagesResource(t *testing.T) {
+
+	randomID := acctest.RandStringFromCharSet(5, acctest.CharSetAlphaNum)
+
+	t.Run("enables and updates GitHub Pages without error", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name       = "tf-acc-test-%s"
+				visibility = "public"
+				auto_init  = true
+			}
+
+			resource "github_repository_pages" "test" {
+				repository = github_repository.test.name
+
+				source {
+					branch = "main"
+				}
+			}
+		`, randomID)
+
+		updatedConfig := fmt.Sprintf(`
+			resource "github_repository" "test" {
+				name       = "tf-acc-test-%s"
+				visibility = "public"
+				auto_init  = true
+			}
+
+			resource "github_repository_pages" "test" {
+				repository = github_repository.test.name
+				build_type = "workflow"
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_repository_pages.test", "source.0.path",
+				"/",
+			),
+			resource.TestCheckResourceAttr(
+				"github_repository_pages.test", "https_enforced",
+				"true",
+			),
+			resource.TestCheckResourceAttrSet(
+				"github_repository_pages.test", "html_url",
+			),
+		)
+
+		updatedCheck := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_repository_pages.test", "build_type",
+				"workflow",
+			),
+			resource.TestCheckResourceAttr(
+				"github_repository_pages.test", "source.#",
+				"0",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+					{
+						Config: updatedConfig,
+						Check:  updatedCheck,
+					},
+					{
+						ResourceName:      "github_repository_pages.test",
+						ImportState:       true,
+						ImportStateVerify: true,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			testCase(t, individual)
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+}
+
+func TestGithubRepositoryPagesWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+	repo := newFakeRepository(t, meta, "site")
+
+	config := map[string]interface{}{
+		"repository": "site",
+		"source": []interface{}{
+			map[string]interface{}{"branch": "main"},
+		},
+	}
+
+	pages := newFakeResource(t, meta, "github_repository_pages")
+	pages.apply(config)
+	if pages.get("source.0.path") != "/" || pages.get("https_enforced") != "true" || pages.get("html_url") != "https://fake-org.github.io/site/" {
+		t.Fatalf("Unexpected pages: %v", pages.state.Attributes)
+	}
+	pages.expectNoChanges(config)
+
+	// The repository reads Pages back, so they show as drift unless its pages
+	// block is ignored.
+	repo.refresh()
+	if repo.get("pages.0.source.0.branch") != "main" {
+		t.Fatalf("Expected the repository to read Pages, got: %v", repo.state.Attributes)
+	}
+	repo.expectChanges(map[string]interface{}{
+		"name":      "site",
+		"auto_init": true,
+	})
+
+	config["cname"] = "docs.example.com"
+	pages.apply(config)
+	if pages.get("https_certificate_state") != "approved" || pages.get("https_enforced") != "true" || pages.get("html_url") != "https://docs.example.com/" {
+		t.Fatalf("Unexpected pages: %v", pages.state.Attributes)
+	}
+	pages.expectNoChanges(config)
+
+	config["https_enforced"] = false
+	pages.apply(config)
+	if pages.get("https_enforced") != "false" || pages.get("cname") != "docs.example.com" {
+		t.Fatalf("Unexpected pages: %v", pages.state.Attributes)
+	}
+
+	delete(config, "cname")
+	diff := pages.plan(config)
+	if _, diags := pages.resource.Apply(context.Background(), pages.state, diff, meta); !diags.HasError() {
+		t.Fatal("Expected disabling HTTPS without a custom domain to be refused")
+	}
+
+	delete(config, "https_enforced")
+	pages.apply(config)
+	if pages.get("cname") != "" || pages.get("https_enforced") != "true" {
+		t.Fatalf("Unexpected pages: %v", pages.state.Attributes)
+	}
+
+	config = map[string]interface{}{
+		"repository": "site",
+		"build_type": "workflow",
+	}
+	pages.apply(config)
+	if pages.get("source.#") != "0" {
+		t.Fatalf("Unexpected pages: %v", pages.state.Attributes)
+	}
+	pages.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_repository_pages")
+	imported.importState("site")
+	imported.expectNoChanges(config)
+
+	imported.destroy()
+	pages.refresh()
+	if pages.state != nil {
+		t.Fatal("Expected disabled Pages to be removed from state")
+	}
+}
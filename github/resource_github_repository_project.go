@@ -9,17 +9,18 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRepositoryProject() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryProjectCreate,
-		Read:   resourceGithubRepositoryProjectRead,
-		Update: resourceGithubRepositoryProjectUpdate,
-		Delete: resourceGithubRepositoryProjectDelete,
+		CreateContext: resourceGithubRepositoryProjectCreate,
+		ReadContext:   resourceGithubRepositoryProjectRead,
+		UpdateContext: resourceGithubRepositoryProjectUpdate,
+		DeleteContext: resourceGithubRepositoryProjectDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 2 {
 					return nil, fmt.Errorf("invalid ID specified: supplied ID must be written as <repository>/<project_id>")
@@ -62,7 +63,7 @@ func resourceGithubRepositoryProject() *schema.Resource {
 	}
 }
 
-func resourceGithubRepositoryProjectCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryProjectCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
@@ -74,27 +75,26 @@ func resourceGithubRepositoryProjectCreate(d *schema.ResourceData, meta interfac
 		Name: &name,
 		Body: &body,
 	}
-	ctx := context.Background()
 
 	project, _, err := client.Repositories.CreateProject(ctx,
 		owner, repoName, &options)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(project.GetID(), 10))
 
-	return resourceGithubRepositoryProjectRead(d, meta)
+	return resourceGithubRepositoryProjectRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryProjectRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryProjectRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 
 	projectID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -112,27 +112,27 @@ func resourceGithubRepositoryProjectRead(d *schema.ResourceData, meta interface{
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", project.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("body", project.GetBody()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err := d.Set("url", fmt.Sprintf("https://github.com/%s/%s/projects/%d",
 		owner, d.Get("repository"), project.GetNumber())); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubRepositoryProjectUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryProjectUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	name := d.Get("name").(string)
@@ -145,27 +145,27 @@ func resourceGithubRepositoryProjectUpdate(d *schema.ResourceData, meta interfac
 
 	projectID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, _, err = client.Projects.UpdateProject(ctx, projectID, &options)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubRepositoryProjectRead(d, meta)
+	return resourceGithubRepositoryProjectRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryProjectDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryProjectDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	projectID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Projects.DeleteProject(ctx, projectID)
-	return err
+	return diag.FromErr(err)
 }
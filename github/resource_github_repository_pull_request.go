@@ -8,17 +8,18 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRepositoryPullRequest() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryPullRequestCreate,
-		Read:   resourceGithubRepositoryPullRequestRead,
-		Update: resourceGithubRepositoryPullRequestUpdate,
-		Delete: resourceGithubRepositoryPullRequestDelete,
+		CreateContext: resourceGithubRepositoryPullRequestCreate,
+		ReadContext:   resourceGithubRepositoryPullRequestRead,
+		UpdateContext: resourceGithubRepositoryPullRequestUpdate,
+		DeleteContext: resourceGithubRepositoryPullRequestDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, m interface{}) ([]*schema.ResourceData, error) {
 				_, baseRepository, _, err := parsePullRequestID(d)
 				if err != nil {
 					return nil, err
@@ -135,8 +136,7 @@ func resourceGithubRepositoryPullRequest() *schema.Resource {
 	}
 }
 
-func resourceGithubRepositoryPullRequestCreate(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.TODO()
+func resourceGithubRepositoryPullRequestCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	// For convenience, by default we expect that the base repository and head
@@ -167,39 +167,38 @@ func resourceGithubRepositoryPullRequestCreate(d *schema.ResourceData, meta inte
 	})
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildThreePartID(baseOwner, baseRepository, strconv.Itoa(pullRequest.GetNumber())))
 
-	return resourceGithubRepositoryPullRequestRead(d, meta)
+	return resourceGithubRepositoryPullRequestRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryPullRequestRead(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.TODO()
+func resourceGithubRepositoryPullRequestRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner, repository, number, err := parsePullRequestID(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	pullRequest, _, err := client.PullRequests.Get(ctx, owner, repository, number)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("number", pullRequest.GetNumber()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if head := pullRequest.GetHead(); head != nil {
 		if err = d.Set("head_ref", head.GetRef()); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		if err = d.Set("head_sha", head.GetSHA()); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		// Totally unexpected condition. Better do that than segfault, I guess?
@@ -210,10 +209,10 @@ func resourceGithubRepositoryPullRequestRead(d *schema.ResourceData, meta interf
 
 	if base := pullRequest.GetBase(); base != nil {
 		if err = d.Set("base_ref", base.GetRef()); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err = d.Set("base_sha", base.GetSHA()); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		// Seme logic as with the missing head branch.
@@ -223,33 +222,33 @@ func resourceGithubRepositoryPullRequestRead(d *schema.ResourceData, meta interf
 	}
 
 	if err = d.Set("body", pullRequest.GetBody()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("title", pullRequest.GetTitle()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("draft", pullRequest.GetDraft()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("maintainer_can_modify", pullRequest.GetMaintainerCanModify()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("number", pullRequest.GetNumber()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("state", pullRequest.GetState()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("opened_at", pullRequest.GetCreatedAt().Unix()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("updated_at", pullRequest.GetUpdatedAt().Unix()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if user := pullRequest.GetUser(); user != nil {
 		if err = d.Set("opened_by", user.GetLogin()); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -258,19 +257,18 @@ func resourceGithubRepositoryPullRequestRead(d *schema.ResourceData, meta interf
 		labels = append(labels, label.GetName())
 	}
 	if err = d.Set("labels", labels); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubRepositoryPullRequestUpdate(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.TODO()
+func resourceGithubRepositoryPullRequestUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner, repository, number, err := parsePullRequestID(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	update := &github.PullRequest{
@@ -287,19 +285,19 @@ func resourceGithubRepositoryPullRequestUpdate(d *schema.ResourceData, meta inte
 
 	_, _, err = client.PullRequests.Edit(ctx, owner, repository, number, update)
 	if err == nil {
-		return resourceGithubRepositoryPullRequestRead(d, meta)
+		return resourceGithubRepositoryPullRequestRead(ctx, d, meta)
 	}
 
 	errors := []string{fmt.Sprintf("could not update the Pull Request: %v", err)}
 
-	if err := resourceGithubRepositoryPullRequestRead(d, meta); err != nil {
+	if err := errorFromDiagnostics(resourceGithubRepositoryPullRequestRead(ctx, d, meta)); err != nil {
 		errors = append(errors, fmt.Sprintf("could not read the Pull Request after the failed update: %v", err))
 	}
 
-	return fmt.Errorf(strings.Join(errors, ", "))
+	return diag.FromErr(fmt.Errorf(strings.Join(errors, ", ")))
 }
 
-func resourceGithubRepositoryPullRequestDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryPullRequestDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	// It's not entirely clear how to treat PR deletion according to Terraform's
 	// CRUD semantics. The approach we're taking here is to close the PR unless
 	// it's already closed or merged. Merging it feels intuitively wrong in what
@@ -309,17 +307,16 @@ func resourceGithubRepositoryPullRequestDelete(d *schema.ResourceData, meta inte
 		return nil
 	}
 
-	ctx := context.TODO()
 	client := meta.(*Owner).v3client
 
 	owner, repository, number, err := parsePullRequestID(d)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	update := &github.PullRequest{State: github.String("closed")}
 	if _, _, err = client.PullRequests.Edit(ctx, owner, repository, number, update); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId("")
@@ -8,18 +8,19 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubRepositoryRuleset() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryRulesetCreate,
-		Read:   resourceGithubRepositoryRulesetRead,
-		Update: resourceGithubRepositoryRulesetUpdate,
-		Delete: resourceGithubRepositoryRulesetDelete,
+		CreateContext: resourceGithubRepositoryRulesetCreate,
+		ReadContext:   resourceGithubRepositoryRulesetRead,
+		UpdateContext: resourceGithubRepositoryRulesetUpdate,
+		DeleteContext: resourceGithubRepositoryRulesetDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubRepositoryRulesetImport,
+			StateContext: resourceGithubRepositoryRulesetImport,
 		},
 
 		SchemaVersion: 1,
@@ -34,8 +35,8 @@ func resourceGithubRepositoryRuleset() *schema.Resource {
 			"target": {
 				Type:         schema.TypeString,
 				Required:     true,
-				ValidateFunc: validation.StringInSlice([]string{"branch", "tag"}, false),
-				Description:  "Possible values are `branch` and `tag`.",
+				ValidateFunc: validation.StringInSlice([]string{"branch", "tag", "push"}, false),
+				Description:  "Possible values are `branch`, `tag` and `push`.",
 			},
 			"repository": {
 				Type:        schema.TypeString,
@@ -213,6 +214,17 @@ func resourceGithubRepositoryRuleset() *schema.Resource {
 										Default:     false,
 										Description: "All conversations on code must be resolved before a pull request can be merged. Defaults to `false`.",
 									},
+									"allowed_merge_methods": {
+										Type:        schema.TypeSet,
+										Optional:    true,
+										Computed:    true,
+										MinItems:    1,
+										Description: "The merge methods allowed for pull requests. Can be any of `merge`, `squash` and `rebase`. Defaults to all of them.",
+										Elem: &schema.Schema{
+											Type:         schema.TypeString,
+											ValidateFunc: validation.StringInSlice([]string{"merge", "squash", "rebase"}, false),
+										},
+									},
 								},
 							},
 						},
@@ -450,6 +462,135 @@ func resourceGithubRepositoryRuleset() *schema.Resource {
 								},
 							},
 						},
+						"merge_queue": {
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "Merges must be performed via a merge queue.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"check_response_timeout_minutes": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      60,
+										ValidateFunc: validation.IntBetween(1, 360),
+										Description:  "Maximum time for a required status check to report a conclusion. After this much time has elapsed, checks that have not reported a conclusion will be assumed to have failed. Defaults to `60`.",
+									},
+									"grouping_strategy": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "ALLGREEN",
+										ValidateFunc: validation.StringInSlice([]string{"ALLGREEN", "HEADGREEN"}, false),
+										Description:  "When set to `ALLGREEN`, the merge commit created by merge queue for each PR in the group must pass all required checks to merge. When set to `HEADGREEN`, only the commit at the head of the merge group, i.e. the commit containing changes from all of the PRs in the group, must pass its required checks to merge. Defaults to `ALLGREEN`.",
+									},
+									"max_entries_to_build": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      5,
+										ValidateFunc: validation.IntBetween(0, 100),
+										Description:  "Limit the number of queued pull requests requesting checks and workflow runs at the same time. Defaults to `5`.",
+									},
+									"max_entries_to_merge": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      5,
+										ValidateFunc: validation.IntBetween(0, 100),
+										Description:  "The maximum number of PRs that will be merged together in a group. Defaults to `5`.",
+									},
+									"merge_method": {
+										Type:         schema.TypeString,
+										Optional:     true,
+										Default:      "MERGE",
+										ValidateFunc: validation.StringInSlice([]string{"MERGE", "SQUASH", "REBASE"}, false),
+										Description:  "Method to use when merging changes from queued pull requests. Can be one of: `MERGE`, `SQUASH`, `REBASE`. Defaults to `MERGE`.",
+									},
+									"min_entries_to_merge": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      1,
+										ValidateFunc: validation.IntBetween(0, 100),
+										Description:  "The minimum number of PRs that will be merged together in a group. Defaults to `1`.",
+									},
+									"min_entries_to_merge_wait_minutes": {
+										Type:         schema.TypeInt,
+										Optional:     true,
+										Default:      5,
+										ValidateFunc: validation.IntBetween(0, 360),
+										Description:  "The time merge queue should wait after the first PR is added to the queue for the minimum group size to be met. After this time has elapsed, the minimum group size will be ignored and a smaller group will be merged. Defaults to `5`.",
+									},
+								},
+							},
+						},
+						"file_path_restriction": {
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "Prevent commits that include changes in specified file paths from being pushed to the commit graph. Only available for the `push` target.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"restricted_file_paths": {
+										Type:        schema.TypeList,
+										MinItems:    1,
+										Required:    true,
+										Description: "The file paths that are restricted from being pushed to the commit graph.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+						"max_file_path_length": {
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "Prevent commits that include file paths that exceed a specified character limit from being pushed to the commit graph. Only available for the `push` target.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"max_file_path_length": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(1, 256),
+										Description:  "The maximum amount of characters allowed in file paths.",
+									},
+								},
+							},
+						},
+						"file_extension_restriction": {
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "Prevent commits that include files with specified file extensions from being pushed to the commit graph. Only available for the `push` target.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"restricted_file_extensions": {
+										Type:        schema.TypeSet,
+										MinItems:    1,
+										Required:    true,
+										Description: "The file extensions that are restricted from being pushed to the commit graph, e.g. `*.jar`.",
+										Elem: &schema.Schema{
+											Type: schema.TypeString,
+										},
+									},
+								},
+							},
+						},
+						"max_file_size": {
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Optional:    true,
+							Description: "Prevent commits that exceed a specified file size limit from being pushed to the commit graph. Only available for the `push` target.",
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"max_file_size": {
+										Type:         schema.TypeInt,
+										Required:     true,
+										ValidateFunc: validation.IntBetween(1, 100),
+										Description:  "The maximum file size allowed in megabytes. This limit does not apply to Git Large File Storage (Git LFS).",
+									},
+								},
+							},
+						},
 					},
 				},
 			},
@@ -461,7 +602,7 @@ func resourceGithubRepositoryRuleset() *schema.Resource {
 	}
 }
 
-func resourceGithubRepositoryRulesetCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryRulesetCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	rulesetReq := resourceGithubRulesetObject(d, "")
@@ -469,21 +610,20 @@ func resourceGithubRepositoryRulesetCreate(d *schema.ResourceData, meta interfac
 	owner := meta.(*Owner).name
 
 	repoName := d.Get("repository").(string)
-	ctx := context.Background()
 
 	var ruleset *github.Ruleset
 	var err error
 
 	ruleset, _, err = client.Repositories.CreateRuleset(ctx, owner, repoName, rulesetReq)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(*ruleset.ID, 10))
 
-	return resourceGithubRepositoryRulesetRead(d, meta)
+	return resourceGithubRepositoryRulesetRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryRulesetRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryRulesetRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
@@ -491,10 +631,10 @@ func resourceGithubRepositoryRulesetRead(d *schema.ResourceData, meta interface{
 	repoName := d.Get("repository").(string)
 	rulesetID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, rulesetID)
+	ctx = context.WithValue(ctx, ctxId, rulesetID)
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -502,7 +642,7 @@ func resourceGithubRepositoryRulesetRead(d *schema.ResourceData, meta interface{
 	var ruleset *github.Ruleset
 	var resp *github.Response
 
-	ruleset, resp, err = client.Repositories.GetRuleset(ctx, owner, repoName, rulesetID, false)
+	ruleset, resp, err = getRuleset(ctx, client, fmt.Sprintf("repos/%v/%v/rulesets/%v?includes_parents=false", owner, repoName, rulesetID))
 	if err != nil {
 		if ghErr, ok := err.(*github.ErrorResponse); ok {
 			if ghErr.Response.StatusCode == http.StatusNotModified {
@@ -530,7 +670,7 @@ func resourceGithubRepositoryRulesetRead(d *schema.ResourceData, meta interface{
 	return nil
 }
 
-func resourceGithubRepositoryRulesetUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryRulesetUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	rulesetReq := resourceGithubRulesetObject(d, "")
@@ -540,37 +680,37 @@ func resourceGithubRepositoryRulesetUpdate(d *schema.ResourceData, meta interfac
 	repoName := d.Get("repository").(string)
 	rulesetID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, rulesetID)
+	ctx = context.WithValue(ctx, ctxId, rulesetID)
 
 	ruleset, _, err := client.Repositories.UpdateRuleset(ctx, owner, repoName, rulesetID, rulesetReq)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(*ruleset.ID, 10))
 
-	return resourceGithubRepositoryRulesetRead(d, meta)
+	return resourceGithubRepositoryRulesetRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryRulesetDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryRulesetDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
 
 	repoName := d.Get("repository").(string)
 	rulesetID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, rulesetID)
+	ctx = context.WithValue(ctx, ctxId, rulesetID)
 
 	log.Printf("[DEBUG] Deleting repository ruleset: %s/%s: %d", owner, repoName, rulesetID)
 	_, err = client.Repositories.DeleteRuleset(ctx, owner, repoName, rulesetID)
-	return err
+	return diag.FromErr(err)
 }
 
-func resourceGithubRepositoryRulesetImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+func resourceGithubRepositoryRulesetImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 	repoName, rulesetIDStr, err := parseTwoPartID(d.Id(), "repository", "ruleset")
 	if err != nil {
 		return []*schema.ResourceData{d}, err
@@ -587,8 +727,7 @@ func resourceGithubRepositoryRulesetImport(d *schema.ResourceData, meta interfac
 
 	client := meta.(*Owner).v3client
 	owner := meta.(*Owner).name
-	ctx := context.Background()
-	repository, _, err := client.Repositories.Get(ctx, owner, repoName)
+	repository, err := getRepository(ctx, owner, repoName, meta)
 	if repository == nil || err != nil {
 		return []*schema.ResourceData{d}, err
 	}
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"strings"
@@ -190,6 +191,78 @@ func TestGithubRepositoryRulesets(t *testing.T) {
 
 	})
 
+	t.Run("Creates push rulesets without errors", func(t *testing.T) {
+
+		config := fmt.Sprintf(`
+			resource "github_repository" "test" {
+			  name         = "tf-acc-test-push-%s"
+			  description  = "Terraform acceptance tests %[1]s"
+			  visibility   = "private"
+			}
+
+			resource "github_repository_ruleset" "test" {
+				name        = "test-push"
+				repository  = github_repository.test.id
+				target      = "push"
+				enforcement = "active"
+
+				rules {
+					file_path_restriction {
+						restricted_file_paths = ["secrets/**"]
+					}
+
+					max_file_size {
+						max_file_size = 10
+					}
+
+					max_file_path_length {
+						max_file_path_length = 200
+					}
+
+					file_extension_restriction {
+						restricted_file_extensions = ["*.jar"]
+					}
+				}
+			}
+		`, randomID)
+
+		check := resource.ComposeTestCheckFunc(
+			resource.TestCheckResourceAttr(
+				"github_repository_ruleset.test", "rules.0.max_file_size.0.max_file_size",
+				"10",
+			),
+			resource.TestCheckResourceAttr(
+				"github_repository_ruleset.test", "rules.0.file_path_restriction.0.restricted_file_paths.0",
+				"secrets/**",
+			),
+		)
+
+		testCase := func(t *testing.T, mode string) {
+			resource.Test(t, resource.TestCase{
+				PreCheck:  func() { skipUnlessMode(t, mode) },
+				Providers: testAccProviders,
+				Steps: []resource.TestStep{
+					{
+						Config: config,
+						Check:  check,
+					},
+				},
+			})
+		}
+
+		t.Run("with an anonymous account", func(t *testing.T) {
+			t.Skip("anonymous account not supported for this operation")
+		})
+
+		t.Run("with an individual account", func(t *testing.T) {
+			t.Skip("individual account not supported for this operation")
+		})
+
+		t.Run("with an organization account", func(t *testing.T) {
+			testCase(t, organization)
+		})
+	})
+
 	t.Run("Updates a ruleset name without error", func(t *testing.T) {
 
 		repoName := fmt.Sprintf(`tf-acc-test-rename-%[1]s`, randomID)
@@ -393,3 +466,127 @@ func importRepositoryRulesetByResourcePaths(repoLogicalName, rulesetLogicalName
 		return fmt.Sprintf("%s:%s", repoID, rulesetID), nil
 	}
 }
+
+func TestGithubRepositoryRulesetWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+
+	config := map[string]interface{}{
+		"name":        "protect-main",
+		"repository":  "service",
+		"target":      "branch",
+		"enforcement": "active",
+		"conditions": []interface{}{map[string]interface{}{
+			"ref_name": []interface{}{map[string]interface{}{
+				"include": []interface{}{"~DEFAULT_BRANCH"},
+				"exclude": []interface{}{},
+			}},
+		}},
+		"rules": []interface{}{map[string]interface{}{
+			"deletion":                true,
+			"required_linear_history": true,
+			"pull_request": []interface{}{map[string]interface{}{
+				"required_approving_review_count": 1,
+			}},
+		}},
+	}
+
+	ruleset := newFakeResource(t, meta, "github_repository_ruleset")
+	ruleset.apply(config)
+	if ruleset.get("ruleset_id") == "" || ruleset.get("node_id") == "" {
+		t.Fatalf("Expected the ruleset IDs to be read, got: %v", ruleset.state.Attributes)
+	}
+	ruleset.expectNoChanges(config)
+
+	config["enforcement"] = "evaluate"
+	ruleset.apply(config)
+	remote, _, err := meta.(*Owner).v3client.Repositories.GetRuleset(context.Background(), fakeOrganization, "service", mustParseInt64(t, ruleset.state.ID), false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if remote.Enforcement != "evaluate" {
+		t.Fatalf("Expected the enforcement to be updated, got: %q", remote.Enforcement)
+	}
+
+	imported := newFakeResource(t, meta, "github_repository_ruleset")
+	imported.importState("service:" + ruleset.state.ID)
+	imported.expectNoChanges(config)
+
+	ruleset.destroy()
+	ruleset.state = imported.state
+	ruleset.refresh()
+	if ruleset.state != nil {
+		t.Fatal("Expected a deleted ruleset to be removed from state")
+	}
+}
+
+func TestGithubRepositoryRulesetMergeAndPushRulesWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+
+	branchConfig := map[string]interface{}{
+		"name":        "merge-queue",
+		"repository":  "service",
+		"target":      "branch",
+		"enforcement": "active",
+		"conditions": []interface{}{map[string]interface{}{
+			"ref_name": []interface{}{map[string]interface{}{
+				"include": []interface{}{"~DEFAULT_BRANCH"},
+				"exclude": []interface{}{},
+			}},
+		}},
+		"rules": []interface{}{map[string]interface{}{
+			"pull_request": []interface{}{map[string]interface{}{
+				"allowed_merge_methods": []interface{}{"squash", "merge"},
+			}},
+			"merge_queue": []interface{}{map[string]interface{}{
+				"merge_method":         "SQUASH",
+				"max_entries_to_merge": 10,
+			}},
+		}},
+	}
+
+	branchRuleset := newFakeResource(t, meta, "github_repository_ruleset")
+	branchRuleset.apply(branchConfig)
+	branchRuleset.expectNoChanges(branchConfig)
+	if methods := branchRuleset.get("rules.0.pull_request.0.allowed_merge_methods.#"); methods != "2" {
+		t.Fatalf("Expected the allowed merge methods to be read back, got: %v", branchRuleset.state.Attributes)
+	}
+	if timeout := branchRuleset.get("rules.0.merge_queue.0.check_response_timeout_minutes"); timeout != "60" {
+		t.Fatalf("Unexpected merge queue check response timeout: %q", timeout)
+	}
+
+	pushConfig := map[string]interface{}{
+		"name":        "restrict-pushes",
+		"repository":  "service",
+		"target":      "push",
+		"enforcement": "active",
+		"rules": []interface{}{map[string]interface{}{
+			"file_path_restriction": []interface{}{map[string]interface{}{
+				"restricted_file_paths": []interface{}{".github/workflows/*", "secrets/**"},
+			}},
+			"max_file_path_length": []interface{}{map[string]interface{}{
+				"max_file_path_length": 200,
+			}},
+			"file_extension_restriction": []interface{}{map[string]interface{}{
+				"restricted_file_extensions": []interface{}{"*.jar", "*.exe"},
+			}},
+			"max_file_size": []interface{}{map[string]interface{}{
+				"max_file_size": 10,
+			}},
+		}},
+	}
+
+	pushRuleset := newFakeResource(t, meta, "github_repository_ruleset")
+	pushRuleset.apply(pushConfig)
+	pushRuleset.expectNoChanges(pushConfig)
+
+	imported := newFakeResource(t, meta, "github_repository_ruleset")
+	imported.importState("service:" + pushRuleset.state.ID)
+	imported.expectNoChanges(pushConfig)
+	if paths := imported.get("rules.0.file_path_restriction.0.restricted_file_paths.1"); paths != "secrets/**" {
+		t.Fatalf("Unexpected imported restricted file paths: %v", imported.state.Attributes)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/google/go-github/v66/github"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
@@ -1700,3 +1701,48 @@ func TestGithubRepositoryNameFailsValidationWithSpace(t *testing.T) {
 		t.Error(fmt.Errorf("unexpected name validation failure; expected=%s; action=%s", expectedFailure, actualFailure))
 	}
 }
+
+func TestGithubRepositoryWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+
+	config := map[string]interface{}{
+		"name":        "service",
+		"description": "A service",
+		"visibility":  "private",
+		"auto_init":   true,
+		"topics":      []interface{}{"go", "terraform"},
+	}
+
+	repo := newFakeResource(t, meta, "github_repository")
+	repo.apply(config)
+	if fullName := repo.get("full_name"); fullName != fakeOrganization+"/service" {
+		t.Fatalf("Unexpected full name: %q", fullName)
+	}
+	if branch := repo.get("default_branch"); branch != "main" {
+		t.Fatalf("Unexpected default branch: %q", branch)
+	}
+	repo.expectNoChanges(config)
+
+	config["visibility"] = "public"
+	repo.apply(config)
+	if srv.Repository(fakeOrganization, "service").GetPrivate() {
+		t.Fatal("Expected the repository to be made public")
+	}
+
+	imported := newFakeResource(t, meta, "github_repository")
+	imported.importState("service")
+	if imported.get("description") != "A service" {
+		t.Fatalf("Unexpected imported description: %q", imported.get("description"))
+	}
+
+	srv.Repository(fakeOrganization, "service").Description = github.String("Changed elsewhere")
+	repo.refresh()
+	repo.expectChanges(config)
+
+	srv.DeleteRepository(fakeOrganization, "service")
+	repo.refresh()
+	if repo.state != nil {
+		t.Fatal("Expected a repository deleted outside of Terraform to be removed from state")
+	}
+}
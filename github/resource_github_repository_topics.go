@@ -7,18 +7,19 @@ import (
 	"regexp"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceGithubRepositoryTopics() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryTopicsCreateOrUpdate,
-		Read:   resourceGithubRepositoryTopicsRead,
-		Update: resourceGithubRepositoryTopicsCreateOrUpdate,
-		Delete: resourceGithubRepositoryTopicsDelete,
+		CreateContext: resourceGithubRepositoryTopicsCreateOrUpdate,
+		ReadContext:   resourceGithubRepositoryTopicsRead,
+		UpdateContext: resourceGithubRepositoryTopicsCreateOrUpdate,
+		DeleteContext: resourceGithubRepositoryTopicsDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				d.Set("repository", d.Id())
 				return []*schema.ResourceData{d}, nil
 			},
@@ -43,9 +44,8 @@ func resourceGithubRepositoryTopics() *schema.Resource {
 
 }
 
-func resourceGithubRepositoryTopicsCreateOrUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryTopicsCreateOrUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -54,17 +54,17 @@ func resourceGithubRepositoryTopicsCreateOrUpdate(d *schema.ResourceData, meta i
 	if len(topics) > 0 {
 		_, _, err := client.Repositories.ReplaceAllTopics(ctx, owner, repoName, topics)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	d.SetId(repoName)
-	return resourceGithubRepositoryTopicsRead(d, meta)
+	return resourceGithubRepositoryTopicsRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryTopicsRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryTopicsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
@@ -82,23 +82,23 @@ func resourceGithubRepositoryTopicsRead(d *schema.ResourceData, meta interface{}
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.Set("topics", flattenStringList(topics))
 	return nil
 }
 
-func resourceGithubRepositoryTopicsDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryTopicsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 
 	_, _, err := client.Repositories.ReplaceAllTopics(ctx, owner, repoName, []string{})
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
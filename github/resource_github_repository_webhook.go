@@ -9,17 +9,18 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubRepositoryWebhook() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubRepositoryWebhookCreate,
-		Read:   resourceGithubRepositoryWebhookRead,
-		Update: resourceGithubRepositoryWebhookUpdate,
-		Delete: resourceGithubRepositoryWebhookDelete,
+		CreateContext: resourceGithubRepositoryWebhookCreate,
+		ReadContext:   resourceGithubRepositoryWebhookRead,
+		UpdateContext: resourceGithubRepositoryWebhookUpdate,
+		DeleteContext: resourceGithubRepositoryWebhookDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				parts := strings.Split(d.Id(), "/")
 				if len(parts) != 2 {
 					return nil, fmt.Errorf("invalid ID specified: supplied ID must be written as <repository>/<webhook_id>")
@@ -35,6 +36,8 @@ func resourceGithubRepositoryWebhook() *schema.Resource {
 		SchemaVersion: 1,
 		MigrateState:  resourceGithubWebhookMigrateState,
 
+		ValidateRawResourceConfigFuncs: webhookPreferWriteOnlySecret(),
+
 		Schema: map[string]*schema.Schema{
 			"repository": {
 				Type:        schema.TypeString,
@@ -65,6 +68,8 @@ func resourceGithubRepositoryWebhook() *schema.Resource {
 				Type:     schema.TypeString,
 				Computed: true,
 			},
+			"secret_version":         webhookSecretVersionSchema(),
+			"redeliver_failed_since": redeliverFailedSinceSchema(),
 		},
 	}
 }
@@ -92,17 +97,19 @@ func resourceGithubRepositoryWebhookObject(d *schema.ResourceData) *github.Hook
 	return hook
 }
 
-func resourceGithubRepositoryWebhookCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryWebhookCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 	hk := resourceGithubRepositoryWebhookObject(d)
-	ctx := context.Background()
+	if diags := setWebhookWriteOnlySecret(d, hk.Config); diags.HasError() {
+		return diags
+	}
 
 	hook, _, err := client.Repositories.CreateHook(ctx, owner, repoName, hk)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(strconv.FormatInt(hook.GetID(), 10))
 
@@ -110,26 +117,26 @@ func resourceGithubRepositoryWebhookCreate(d *schema.ResourceData, meta interfac
 	// We would prefer to store the real secret in state, so we'll
 	// write the configuration secret in state from our request to GitHub
 	if hook.Config.Secret != nil {
-		hook.Config.Secret = hk.Config.Secret
+		hook.Config.Secret = github.String(d.Get("configuration.0.secret").(string))
 	}
 
 	if err = d.Set("configuration", interfaceFromWebhookConfig(hook.Config)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubRepositoryWebhookRead(d, meta)
+	return resourceGithubRepositoryWebhookRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryWebhookRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryWebhookRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 	hookID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -147,16 +154,16 @@ func resourceGithubRepositoryWebhookRead(d *schema.ResourceData, meta interface{
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("url", hook.GetURL()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("active", hook.GetActive()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("events", hook.Events); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// GitHub returns the secret as a string of 8 astrisks "********"
@@ -172,13 +179,13 @@ func resourceGithubRepositoryWebhookRead(d *schema.ResourceData, meta interface{
 	}
 
 	if err = d.Set("configuration", interfaceFromWebhookConfig(hook.Config)); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubRepositoryWebhookUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryWebhookUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
@@ -186,29 +193,57 @@ func resourceGithubRepositoryWebhookUpdate(d *schema.ResourceData, meta interfac
 	hk := resourceGithubRepositoryWebhookObject(d)
 	hookID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
+	}
+	ctx = context.WithValue(ctx, ctxId, d.Id())
+
+	// The configuration has its own endpoint, which does not require the
+	// events and the state of the webhook to be sent again.
+	config := hk.Config
+	hk.Config = nil
+	if d.HasChanges("events", "active") {
+		_, _, err = client.Repositories.EditHook(ctx, owner, repoName, hookID, hk)
+		if err != nil {
+			return diag.FromErr(err)
+		}
+	}
+	if d.HasChanges("configuration", "secret_version") && config != nil {
+		if diags := setWebhookWriteOnlySecret(d, config); diags.HasError() {
+			return diags
+		}
+		_, _, err = client.Repositories.EditHookConfiguration(ctx, owner, repoName, hookID, config)
+		if err != nil {
+			return attributeDiagnostics(err, "configuration")
+		}
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
 
-	_, _, err = client.Repositories.EditHook(ctx, owner, repoName, hookID, hk)
-	if err != nil {
-		return err
+	if d.HasChange("redeliver_failed_since") {
+		err = redeliverFailedHookDeliveries(ctx, d,
+			func(ctx context.Context, opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error) {
+				return client.Repositories.ListHookDeliveries(ctx, owner, repoName, hookID, opts)
+			},
+			func(ctx context.Context, deliveryID int64) (*github.HookDelivery, *github.Response, error) {
+				return client.Repositories.RedeliverHookDelivery(ctx, owner, repoName, hookID, deliveryID)
+			})
+		if err != nil {
+			return attributeDiagnostics(fmt.Errorf("error redelivering failed deliveries of webhook %s: %w", d.Id(), err), "redeliver_failed_since")
+		}
 	}
 
-	return resourceGithubRepositoryWebhookRead(d, meta)
+	return resourceGithubRepositoryWebhookRead(ctx, d, meta)
 }
 
-func resourceGithubRepositoryWebhookDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubRepositoryWebhookDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	owner := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 	hookID, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Repositories.DeleteHook(ctx, owner, repoName, hookID)
-	return err
+	return diag.FromErr(err)
 }
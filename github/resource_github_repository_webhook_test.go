@@ -1,11 +1,16 @@
 package github
 
 import (
+	"context"
 	"fmt"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/google/go-github/v66/github"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccGithubRepositoryWebhook(t *testing.T) {
@@ -208,3 +213,205 @@ func TestAccGithubRepositoryWebhook(t *testing.T) {
 		})
 	})
 }
+
+func TestGithubRepositoryWebhookWithFakeAPI(t *testing.T) {
+
+	_, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+
+	config := map[string]interface{}{
+		"repository": "service",
+		"events":     []interface{}{"pull_request", "push"},
+		"configuration": []interface{}{map[string]interface{}{
+			"url":          "https://example.com/hook",
+			"content_type": "json",
+			"secret":       "very-secret",
+		}},
+	}
+
+	webhook := newFakeResource(t, meta, "github_repository_webhook")
+	webhook.apply(config)
+	if url := webhook.get("url"); !strings.HasSuffix(url, "/repos/fake-org/service/hooks/"+webhook.state.ID) {
+		t.Fatalf("Unexpected URL: %q", url)
+	}
+	if secret := webhook.get("configuration.0.secret"); secret != "very-secret" {
+		t.Fatalf("Expected the masked secret to be kept from the configuration, got: %q", secret)
+	}
+	webhook.expectNoChanges(config)
+
+	config["active"] = false
+	webhook.apply(config)
+	hook, _, err := meta.(*Owner).v3client.Repositories.GetHook(context.Background(), fakeOrganization, "service", mustParseInt64(t, webhook.state.ID))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hook.GetActive() {
+		t.Fatal("Expected the webhook to be deactivated")
+	}
+
+	imported := newFakeResource(t, meta, "github_repository_webhook")
+	imported.importState("service/" + webhook.state.ID)
+	if events := imported.get("events.#"); events != "2" {
+		t.Fatalf("Unexpected imported events: %s", events)
+	}
+
+	webhook.destroy()
+	webhook.state = imported.state
+	webhook.refresh()
+	if webhook.state != nil {
+		t.Fatal("Expected a deleted webhook to be removed from state")
+	}
+}
+
+func TestGithubRepositoryWebhookRedeliverFailedWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	client := meta.(*Owner).v3client
+	newFakeRepository(t, meta, "service")
+
+	config := map[string]interface{}{
+		"repository": "service",
+		"events":     []interface{}{"issues", "push"},
+		"configuration": []interface{}{map[string]interface{}{
+			"url":          "https://example.com/hook",
+			"content_type": "json",
+		}},
+	}
+	webhook := newFakeResource(t, meta, "github_repository_webhook")
+	webhook.apply(config)
+	hookID := mustParseInt64(t, webhook.state.ID)
+
+	old := srv.AddHookDelivery(fakeOrganization, "service", hookID, "push", 500)
+	failed := srv.AddHookDelivery(fakeOrganization, "service", hookID, "push", 502)
+	unreachable := srv.AddHookDelivery(fakeOrganization, "service", hookID, "issues", 0)
+	srv.AddHookDelivery(fakeOrganization, "service", hookID, "push", 200)
+
+	// redelivered counts the redeliveries of each delivery.
+	redelivered := func() map[string]int {
+		deliveries, _, err := client.Repositories.ListHookDeliveries(context.Background(), fakeOrganization, "service", hookID, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		guids := map[string]int{}
+		for _, delivery := range deliveries {
+			if delivery.GetRedelivery() {
+				guids[delivery.GetGUID()]++
+			}
+		}
+		return guids
+	}
+
+	config["redeliver_failed_since"] = failed.GetDeliveredAt().Format(time.RFC3339)
+	webhook.apply(config)
+	webhook.expectNoChanges(config)
+	guids := redelivered()
+	if len(guids) != 2 || guids[failed.GetGUID()] != 1 || guids[unreachable.GetGUID()] != 1 {
+		t.Fatalf("Expected the deliveries which failed since %s to be redelivered, got %v", config["redeliver_failed_since"], guids)
+	}
+
+	// Deliveries which were redelivered successfully are not redelivered
+	// again.
+	config["redeliver_failed_since"] = old.GetDeliveredAt().Format(time.RFC3339)
+	webhook.apply(config)
+	guids = redelivered()
+	if len(guids) != 3 || guids[old.GetGUID()] != 1 || guids[failed.GetGUID()] != 1 || guids[unreachable.GetGUID()] != 1 {
+		t.Fatalf("Expected only the older failed delivery to be redelivered, got %v", guids)
+	}
+}
+
+func TestGithubRepositoryWebhookSecretVersionWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+
+	config := map[string]interface{}{
+		"repository": "service",
+		"events":     []interface{}{"push"},
+		"configuration": []interface{}{map[string]interface{}{
+			"url":          "https://example.com/hook",
+			"content_type": "json",
+			"secret":       "very-secret",
+		}},
+	}
+	webhook := newFakeResource(t, meta, "github_repository_webhook")
+	webhook.apply(config)
+	hook := srv.Hook(fakeOrganization, "service", mustParseInt64(t, webhook.state.ID))
+
+	// GitHub masks secrets, so a secret changed outside of Terraform cannot
+	// be detected.
+	hook.Config.Secret = github.String("changed-elsewhere")
+	webhook.refresh()
+	webhook.expectNoChanges(config)
+
+	config["secret_version"] = "2"
+	webhook.apply(config)
+	webhook.expectNoChanges(config)
+	if hook.GetConfig().GetSecret() != "very-secret" {
+		t.Fatalf("Expected the secret to be sent again, got %q", hook.GetConfig().GetSecret())
+	}
+
+	config["configuration"] = []interface{}{map[string]interface{}{
+		"url":          "https://example.com/moved",
+		"content_type": "json",
+		"secret":       "very-secret",
+	}}
+	webhook.apply(config)
+	webhook.expectNoChanges(config)
+	if hook.GetConfig().GetURL() != "https://example.com/moved" || len(hook.Events) != 1 {
+		t.Fatalf("Unexpected webhook: %v", hook)
+	}
+
+	config["events"] = []interface{}{"push", "release"}
+	webhook.apply(config)
+	webhook.expectNoChanges(config)
+	if hook.GetConfig().GetSecret() != "very-secret" || len(hook.Events) != 2 {
+		t.Fatalf("Expected the configuration to be kept when the events change, got %v", hook)
+	}
+}
+
+func TestGithubRepositoryWebhookWriteOnlySecretWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	newFakeRepository(t, meta, "service")
+
+	config := map[string]interface{}{
+		"repository": "service",
+		"events":     []interface{}{"push"},
+		"configuration": []interface{}{map[string]interface{}{
+			"url":          "https://example.com/hook",
+			"content_type": "json",
+			"secret_wo":    "very-secret",
+		}},
+		"secret_version": "1",
+	}
+	webhook := newFakeResource(t, meta, "github_repository_webhook")
+	webhook.apply(config)
+	hook := srv.Hook(fakeOrganization, "service", mustParseInt64(t, webhook.state.ID))
+	if hook.GetConfig().GetSecret() != "very-secret" {
+		t.Fatalf("Expected the write-only secret to be sent, got %q", hook.GetConfig().GetSecret())
+	}
+	if secret := webhook.get("configuration.0.secret"); secret != "" {
+		t.Fatalf("Expected the write-only secret not to be stored as the secret, got %q", secret)
+	}
+
+	config["configuration"] = []interface{}{map[string]interface{}{
+		"url":          "https://example.com/hook",
+		"content_type": "json",
+		"secret_wo":    "rotated-secret",
+	}}
+	config["secret_version"] = "2"
+	webhook.apply(config)
+	if hook.GetConfig().GetSecret() != "rotated-secret" {
+		t.Fatalf("Expected the write-only secret to be rotated, got %q", hook.GetConfig().GetSecret())
+	}
+
+	config["configuration"] = []interface{}{map[string]interface{}{
+		"url":          "https://example.com/hook",
+		"content_type": "json",
+		"secret":       "very-secret",
+		"secret_wo":    "rotated-secret",
+	}}
+	if diags := webhook.resource.Validate(terraform.NewResourceConfigRaw(config)); !diags.HasError() {
+		t.Fatal("Expected secret and secret_wo to conflict")
+	}
+}
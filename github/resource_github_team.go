@@ -7,6 +7,7 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/customdiff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/shurcooL/githubv4"
@@ -14,12 +15,12 @@ import (
 
 func resourceGithubTeam() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubTeamCreate,
-		Read:   resourceGithubTeamRead,
-		Update: resourceGithubTeamUpdate,
-		Delete: resourceGithubTeamDelete,
+		CreateContext: resourceGithubTeamCreate,
+		ReadContext:   resourceGithubTeamRead,
+		UpdateContext: resourceGithubTeamUpdate,
+		DeleteContext: resourceGithubTeamDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubTeamImport,
+			StateContext: resourceGithubTeamImport,
 		},
 
 		CustomizeDiff: customdiff.Sequence(
@@ -103,10 +104,10 @@ func resourceGithubTeam() *schema.Resource {
 	}
 }
 
-func resourceGithubTeamCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -125,18 +126,17 @@ func resourceGithubTeamCreate(d *schema.ResourceData, meta interface{}) error {
 	}
 
 	if parentTeamID, ok := d.GetOk("parent_team_id"); ok {
-		teamId, err := getTeamID(parentTeamID.(string), meta)
+		teamId, err := getTeamID(ctx, parentTeamID.(string), meta)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		newTeam.ParentTeamID = &teamId
 	}
-	ctx := context.Background()
 
 	githubTeam, _, err := client.Teams.CreateTeam(ctx,
 		ownerName, newTeam)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	/*
@@ -160,26 +160,26 @@ func resourceGithubTeamCreate(d *schema.ResourceData, meta interface{}) error {
 			false)
 
 		if err != nil {
-			return err
+			return attributeDiagnostics(err, "parent_team_id")
 		}
 	}
 
 	create_default_maintainer := d.Get("create_default_maintainer").(bool)
 	if !create_default_maintainer {
 		log.Printf("[DEBUG] Removing default maintainer from team: %s (%s)", name, ownerName)
-		if err := removeDefaultMaintainer(*githubTeam.Slug, meta); err != nil {
-			return err
+		if err := removeDefaultMaintainer(ctx, *githubTeam.Slug, meta); err != nil {
+			return attributeDiagnostics(err, "create_default_maintainer")
 		}
 	}
 
 	d.SetId(strconv.FormatInt(githubTeam.GetID(), 10))
-	return resourceGithubTeamRead(d, meta)
+	return resourceGithubTeamRead(ctx, d, meta)
 }
 
-func resourceGithubTeamRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -187,9 +187,9 @@ func resourceGithubTeamRead(d *schema.ResourceData, meta interface{}) error {
 
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -207,62 +207,62 @@ func resourceGithubTeamRead(d *schema.ResourceData, meta interface{}) error {
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("description", team.GetDescription()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("name", team.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("privacy", team.GetPrivacy()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if parent := team.Parent; parent != nil {
 		if err = d.Set("parent_team_id", strconv.FormatInt(team.Parent.GetID(), 10)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err = d.Set("parent_team_read_id", strconv.FormatInt(team.Parent.GetID(), 10)); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err = d.Set("parent_team_read_slug", parent.Slug); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		if err = d.Set("parent_team_id", ""); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err = d.Set("parent_team_read_id", ""); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		if err = d.Set("parent_team_read_slug", ""); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if err = d.Set("ldap_dn", team.GetLDAPDN()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("slug", team.GetSlug()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("node_id", team.GetNodeID()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("members_count", team.GetMembersCount()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubTeamUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -275,9 +275,9 @@ func resourceGithubTeamUpdate(d *schema.ResourceData, meta interface{}) error {
 		Privacy:     github.String(d.Get("privacy").(string)),
 	}
 	if parentTeamID, ok := d.GetOk("parent_team_id"); ok {
-		teamId, err := getTeamID(parentTeamID.(string), meta)
+		teamId, err := getTeamID(ctx, parentTeamID.(string), meta)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		editedTeam.ParentTeamID = &teamId
 		removeParentTeam = false
@@ -287,15 +287,23 @@ func resourceGithubTeamUpdate(d *schema.ResourceData, meta interface{}) error {
 
 	teamId, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	team, _, err := client.Teams.EditTeamByID(ctx, orgId, teamId, editedTeam, removeParentTeam)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
+	// Renaming a team also changes its slug
+	orgName := meta.(*Owner).name
+	meta.(*Owner).cache.invalidate(
+		teamCacheObject(orgName, d.Id()),
+		teamCacheObject(orgName, d.Get("slug").(string)),
+		teamCacheObject(orgName, team.GetSlug()),
+	)
+
 	if d.HasChange("ldap_dn") {
 		ldapDN := d.Get("ldap_dn").(string)
 		mapping := &github.TeamLDAPMapping{
@@ -303,18 +311,18 @@ func resourceGithubTeamUpdate(d *schema.ResourceData, meta interface{}) error {
 		}
 		_, _, err = client.Admin.UpdateTeamLDAPMapping(ctx, team.GetID(), mapping)
 		if err != nil {
-			return err
+			return attributeDiagnostics(err, "ldap_dn")
 		}
 	}
 
 	d.SetId(strconv.FormatInt(team.GetID(), 10))
-	return resourceGithubTeamRead(d, meta)
+	return resourceGithubTeamRead(ctx, d, meta)
 }
 
-func resourceGithubTeamDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -322,11 +330,15 @@ func resourceGithubTeamDelete(d *schema.ResourceData, meta interface{}) error {
 
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Teams.DeleteTeamByID(ctx, orgId, id)
+	meta.(*Owner).cache.invalidate(
+		teamCacheObject(meta.(*Owner).name, d.Id()),
+		teamCacheObject(meta.(*Owner).name, d.Get("slug").(string)),
+	)
 	/*
 		When deleting a team and it failed, we need to check if it has already been deleted meanwhile.
 		This could be the case when deleting nested teams via Terraform by looping through a module
@@ -350,11 +362,11 @@ func resourceGithubTeamDelete(d *schema.ResourceData, meta interface{}) error {
 			}
 		}
 	}
-	return err
+	return diag.FromErr(err)
 }
 
-func resourceGithubTeamImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	teamId, err := getTeamID(d.Id(), meta)
+func resourceGithubTeamImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	teamId, err := getTeamID(ctx, d.Id(), meta)
 	if err != nil {
 		return nil, err
 	}
@@ -367,7 +379,7 @@ func resourceGithubTeamImport(d *schema.ResourceData, meta interface{}) ([]*sche
 	return []*schema.ResourceData{d}, nil
 }
 
-func removeDefaultMaintainer(teamSlug string, meta interface{}) error {
+func removeDefaultMaintainer(ctx context.Context, teamSlug string, meta interface{}) error {
 
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
@@ -391,13 +403,13 @@ func removeDefaultMaintainer(teamSlug string, meta interface{}) error {
 		"login": githubv4.String(orgName),
 	}
 
-	err := v4client.Query(meta.(*Owner).StopContext, &query, variables)
+	err := v4client.Query(ctx, &query, variables)
 	if err != nil {
 		return err
 	}
 
 	for _, user := range query.Organization.Team.Members.Nodes {
-		_, err := client.Teams.RemoveTeamMembershipBySlug(meta.(*Owner).StopContext, orgName, teamSlug, string(user.Login))
+		_, err := client.Teams.RemoveTeamMembershipBySlug(ctx, orgName, teamSlug, string(user.Login))
 		if err != nil {
 			return err
 		}
@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/shurcooL/githubv4"
 )
@@ -19,12 +20,12 @@ type MemberChange struct {
 func resourceGithubTeamMembers() *schema.Resource {
 
 	return &schema.Resource{
-		Create: resourceGithubTeamMembersCreate,
-		Read:   resourceGithubTeamMembersRead,
-		Update: resourceGithubTeamMembersUpdate,
-		Delete: resourceGithubTeamMembersDelete,
+		CreateContext: resourceGithubTeamMembersCreate,
+		ReadContext:   resourceGithubTeamMembersRead,
+		UpdateContext: resourceGithubTeamMembersUpdate,
+		DeleteContext: resourceGithubTeamMembersDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubTeamMembersImport,
+			StateContext: resourceGithubTeamMembersImport,
 		},
 
 		Schema: map[string]*schema.Schema{
@@ -60,16 +61,15 @@ func resourceGithubTeamMembers() *schema.Resource {
 	}
 }
 
-func resourceGithubTeamMembersCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamMembersCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgId := meta.(*Owner).id
 
 	teamIdString := d.Get("team_id").(string)
-	teamId, err := getTeamID(teamIdString, meta)
+	teamId, err := getTeamID(ctx, teamIdString, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.Background()
 
 	members := d.Get("members").(*schema.Set)
 	for _, mMap := range members.List() {
@@ -87,25 +87,24 @@ func resourceGithubTeamMembersCreate(d *schema.ResourceData, meta interface{}) e
 			},
 		)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	d.SetId(teamIdString)
 
-	return resourceGithubTeamMembersRead(d, meta)
+	return resourceGithubTeamMembersRead(ctx, d, meta)
 }
 
-func resourceGithubTeamMembersUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamMembersUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgId := meta.(*Owner).id
 
 	teamIdString := d.Get("team_id").(string)
-	teamId, err := getTeamID(teamIdString, meta)
+	teamId, err := getTeamID(ctx, teamIdString, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	ctx := context.Background()
 
 	o, n := d.GetChange("members")
 	vals := make(map[string]*MemberChange)
@@ -147,7 +146,7 @@ func resourceGithubTeamMembersUpdate(d *schema.ResourceData, meta interface{}) e
 
 			_, err = client.Teams.RemoveTeamMembershipByID(ctx, orgId, teamId, username)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 
@@ -164,17 +163,17 @@ func resourceGithubTeamMembersUpdate(d *schema.ResourceData, meta interface{}) e
 				},
 			)
 			if err != nil {
-				return err
+				return diag.FromErr(err)
 			}
 		}
 	}
 
 	d.SetId(teamIdString)
 
-	return resourceGithubTeamMembersRead(d, meta)
+	return resourceGithubTeamMembersRead(ctx, d, meta)
 }
 
-func resourceGithubTeamMembersRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamMembersRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v4client
 	orgName := meta.(*Owner).name
 	teamIdString := d.Get("team_id").(string)
@@ -183,19 +182,19 @@ func resourceGithubTeamMembersRead(d *schema.ResourceData, meta interface{}) err
 		teamIdString = d.Id()
 	}
 
-	teamSlug, err := getTeamSlug(teamIdString, meta)
+	teamSlug, err := getTeamSlug(ctx, teamIdString, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// We intentionally set these early to allow reconciliation
 	// from an upstream bug which emptied team_id in state
 	// See https://github.com/integrations/terraform-provider-github/issues/323
 	if err := d.Set("team_id", teamIdString); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	log.Printf("[DEBUG] Reading team members: %s", teamIdString)
 	var q struct {
@@ -226,7 +225,7 @@ func resourceGithubTeamMembersRead(d *schema.ResourceData, meta interface{}) err
 	var teamMembersAndMaintainers []interface{}
 	for {
 		if err := client.Query(ctx, &q, variables); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 
 		// Add all members to the list
@@ -243,23 +242,23 @@ func resourceGithubTeamMembersRead(d *schema.ResourceData, meta interface{}) err
 	}
 
 	if err := d.Set("members", teamMembersAndMaintainers); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubTeamMembersDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamMembersDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgId := meta.(*Owner).id
 	teamIdString := d.Get("team_id").(string)
-	teamId, err := getTeamID(teamIdString, meta)
+	teamId, err := getTeamID(ctx, teamIdString, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	members := d.Get("members").(*schema.Set)
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	for _, member := range members.List() {
 		mem := member.(map[string]interface{})
@@ -269,15 +268,15 @@ func resourceGithubTeamMembersDelete(d *schema.ResourceData, meta interface{}) e
 
 		_, err = client.Teams.RemoveTeamMembershipByID(ctx, orgId, teamId, username)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
 	return nil
 }
 
-func resourceGithubTeamMembersImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	teamId, err := getTeamID(d.Id(), meta)
+func resourceGithubTeamMembersImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	teamId, err := getTeamID(ctx, d.Id(), meta)
 	if err != nil {
 		return nil, err
 	}
@@ -260,3 +260,37 @@ func testAccGithubTeamMembershipTheSame(orig, other *github.Membership) resource
 		return nil
 	}
 }
+
+func TestGithubTeamMembershipWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+	srv.AddUser("engineer")
+	newFakeResource(t, meta, "github_team").apply(map[string]interface{}{"name": "engineering"})
+
+	config := map[string]interface{}{
+		"team_id":  "engineering",
+		"username": "engineer",
+		"role":     "maintainer",
+	}
+
+	membership := newFakeResource(t, meta, "github_team_membership")
+	membership.apply(config)
+	membership.expectNoChanges(config)
+
+	imported := newFakeResource(t, meta, "github_team_membership")
+	imported.importState("engineering:engineer")
+	teamID := strconv.FormatInt(srv.Team(fakeOrganization, "engineering").GetID(), 10)
+	if id := imported.state.ID; id != teamID+":engineer" {
+		t.Fatalf("Expected the team slug to be resolved on import, got ID %q", id)
+	}
+	if role := imported.get("role"); role != "maintainer" {
+		t.Fatalf("Unexpected imported role: %q", role)
+	}
+
+	membership.destroy()
+	membership.state = imported.state
+	membership.refresh()
+	if membership.state != nil {
+		t.Fatal("Expected a deleted team membership to be removed from state")
+	}
+}
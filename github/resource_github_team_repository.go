@@ -7,23 +7,24 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubTeamRepository() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubTeamRepositoryCreate,
-		Read:   resourceGithubTeamRepositoryRead,
-		Update: resourceGithubTeamRepositoryUpdate,
-		Delete: resourceGithubTeamRepositoryDelete,
+		CreateContext: resourceGithubTeamRepositoryCreate,
+		ReadContext:   resourceGithubTeamRepositoryRead,
+		UpdateContext: resourceGithubTeamRepositoryUpdate,
+		DeleteContext: resourceGithubTeamRepositoryDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				teamIdString, username, err := parseTwoPartID(d.Id(), "team_id", "username")
 				if err != nil {
 					return nil, err
 				}
 
-				teamId, err := getTeamID(teamIdString, meta)
+				teamId, err := getTeamID(ctx, teamIdString, meta)
 				if err != nil {
 					return nil, err
 				}
@@ -60,10 +61,10 @@ func resourceGithubTeamRepository() *schema.Resource {
 	}
 }
 
-func resourceGithubTeamRepositoryCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamRepositoryCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -71,15 +72,14 @@ func resourceGithubTeamRepositoryCreate(d *schema.ResourceData, meta interface{}
 
 	// The given team id could be an id or a slug
 	givenTeamId := d.Get("team_id").(string)
-	teamId, err := getTeamID(givenTeamId, meta)
+	teamId, err := getTeamID(ctx, givenTeamId, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	orgName := meta.(*Owner).name
 	repoName := d.Get("repository").(string)
 	permission := d.Get("permission").(string)
-	ctx := context.Background()
 
 	_, err = client.Teams.AddTeamRepoByID(ctx,
 		orgId,
@@ -92,18 +92,18 @@ func resourceGithubTeamRepositoryCreate(d *schema.ResourceData, meta interface{}
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(buildTwoPartID(strconv.FormatInt(teamId, 10), repoName))
 
-	return resourceGithubTeamRepositoryRead(d, meta)
+	return resourceGithubTeamRepositoryRead(ctx, d, meta)
 }
 
-func resourceGithubTeamRepositoryRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamRepositoryRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -111,14 +111,14 @@ func resourceGithubTeamRepositoryRead(d *schema.ResourceData, meta interface{})
 
 	teamIdString, repoName, err := parseTwoPartID(d.Id(), "team_id", "repository")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	teamId, err := getTeamID(teamIdString, meta)
+	teamId, err := getTeamID(ctx, teamIdString, meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -136,33 +136,33 @@ func resourceGithubTeamRepositoryRead(d *schema.ResourceData, meta interface{})
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if d.Get("team_id") == "" {
 		// If team_id is empty, that means we are importing the resource.
 		// Set the team_id to be the id of the team.
 		if err = d.Set("team_id", teamIdString); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 	if err = d.Set("repository", repo.GetName()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("permission", getPermission(repo.GetRoleName())); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubTeamRepositoryUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamRepositoryUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -170,15 +170,15 @@ func resourceGithubTeamRepositoryUpdate(d *schema.ResourceData, meta interface{}
 
 	teamIdString, repoName, err := parseTwoPartID(d.Id(), "team_id", "repository")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	teamId, err := strconv.ParseInt(teamIdString, 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(teamIdString, err)
+		return diag.FromErr(unconvertibleIdErr(teamIdString, err))
 	}
 	orgName := meta.(*Owner).name
 	permission := d.Get("permission").(string)
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	// the go-github library's AddTeamRepo method uses the add/update endpoint from GitHub API
 	_, err = client.Teams.AddTeamRepoByID(ctx,
@@ -192,17 +192,17 @@ func resourceGithubTeamRepositoryUpdate(d *schema.ResourceData, meta interface{}
 	)
 
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(buildTwoPartID(teamIdString, repoName))
 
-	return resourceGithubTeamRepositoryRead(d, meta)
+	return resourceGithubTeamRepositoryRead(ctx, d, meta)
 }
 
-func resourceGithubTeamRepositoryDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamRepositoryDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
@@ -210,14 +210,14 @@ func resourceGithubTeamRepositoryDelete(d *schema.ResourceData, meta interface{}
 
 	teamIdString, repoName, err := parseTwoPartID(d.Id(), "team_id", "repository")
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	teamId, err := strconv.ParseInt(teamIdString, 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(teamIdString, err)
+		return diag.FromErr(unconvertibleIdErr(teamIdString, err))
 	}
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	resp, err := client.Teams.RemoveTeamRepoByID(ctx, orgId, teamId, orgName, repoName)
 
@@ -225,7 +225,7 @@ func resourceGithubTeamRepositoryDelete(d *schema.ResourceData, meta interface{}
 		log.Printf("[DEBUG] Failed to find team %s to delete for repo: %s.", teamIdString, repoName)
 		repo, _, err := client.Repositories.Get(ctx, orgName, repoName)
 		if err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 		newRepoName := repo.GetName()
 		if newRepoName != repoName {
@@ -233,9 +233,9 @@ func resourceGithubTeamRepositoryDelete(d *schema.ResourceData, meta interface{}
 				"Try deleting team repository again.",
 				repoName, newRepoName)
 			_, err := client.Teams.RemoveTeamRepoByID(ctx, orgId, teamId, orgName, newRepoName)
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
-	return err
+	return diag.FromErr(err)
 }
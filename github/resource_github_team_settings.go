@@ -6,18 +6,19 @@ import (
 	"fmt"
 	"strconv"
 
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/shurcooL/githubv4"
 )
 
 func resourceGithubTeamSettings() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubTeamSettingsCreate,
-		Read:   resourceGithubTeamSettingsRead,
-		Update: resourceGithubTeamSettingsUpdate,
-		Delete: resourceGithubTeamSettingsDelete,
+		CreateContext: resourceGithubTeamSettingsCreate,
+		ReadContext:   resourceGithubTeamSettingsRead,
+		UpdateContext: resourceGithubTeamSettingsUpdate,
+		DeleteContext: resourceGithubTeamSettingsDelete,
 		Importer: &schema.ResourceImporter{
-			State: resourceGithubTeamSettingsImport,
+			StateContext: resourceGithubTeamSettingsImport,
 		},
 		Schema: map[string]*schema.Schema{
 			"team_id": {
@@ -90,37 +91,36 @@ func resourceGithubTeamSettings() *schema.Resource {
 	}
 }
 
-func resourceGithubTeamSettingsCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamSettingsCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	// Given a string that is either a team id or team slug, return the
 	// get the basic details of the team including node_id and slug
-	ctx := context.Background()
 
 	teamIDString, _ := d.Get("team_id").(string)
 
 	nodeId, slug, err := resolveTeamIDs(teamIDString, meta.(*Owner), ctx)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(nodeId)
 	if err = d.Set("team_slug", slug); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("team_uid", nodeId); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
-	return resourceGithubTeamSettingsUpdate(d, meta)
+	return resourceGithubTeamSettingsUpdate(ctx, d, meta)
 
 }
 
-func resourceGithubTeamSettingsRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamSettingsRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	graphql := meta.(*Owner).v4client
@@ -134,9 +134,9 @@ func resourceGithubTeamSettingsRead(d *schema.ResourceData, meta interface{}) er
 		"login": githubv4.String(orgName),
 	}
 
-	e := graphql.Query(meta.(*Owner).StopContext, &query, variables)
+	e := graphql.Query(ctx, &query, variables)
 	if e != nil {
-		return e
+		return diag.FromErr(e)
 	}
 
 	if query.Organization.Team.ReviewRequestDelegation {
@@ -145,11 +145,11 @@ func resourceGithubTeamSettingsRead(d *schema.ResourceData, meta interface{}) er
 		reviewRequestDelegation["member_count"] = query.Organization.Team.ReviewRequestDelegationCount
 		reviewRequestDelegation["notify"] = query.Organization.Team.ReviewRequestDelegationNotifyAll
 		if err = d.Set("review_request_delegation", []interface{}{reviewRequestDelegation}); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	} else {
 		if err = d.Set("review_request_delegation", []interface{}{}); err != nil {
-			return err
+			return diag.FromErr(err)
 		}
 	}
 
@@ -157,10 +157,10 @@ func resourceGithubTeamSettingsRead(d *schema.ResourceData, meta interface{}) er
 
 }
 
-func resourceGithubTeamSettingsUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamSettingsUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	if d.HasChange("review_request_delegation") || d.IsNewResource() {
 
-		ctx := context.WithValue(context.Background(), ctxId, d.Id())
+		ctx := context.WithValue(ctx, ctxId, d.Id())
 		graphql := meta.(*Owner).v4client
 		if setting := d.Get("review_request_delegation").([]interface{}); len(setting) == 0 {
 			var mutation struct {
@@ -169,7 +169,7 @@ func resourceGithubTeamSettingsUpdate(d *schema.ResourceData, meta interface{})
 				} `graphql:"updateTeamReviewAssignment(input:$input)"`
 			}
 
-			return graphql.Mutate(ctx, &mutation, defaultTeamReviewAssignmentSettings(d.Id()), nil)
+			return attributeDiagnostics(graphql.Mutate(ctx, &mutation, defaultTeamReviewAssignmentSettings(d.Id()), nil), "review_request_delegation")
 		} else {
 			settings := d.Get("review_request_delegation").([]interface{})[0].(map[string]interface{})
 
@@ -179,22 +179,22 @@ func resourceGithubTeamSettingsUpdate(d *schema.ResourceData, meta interface{})
 				} `graphql:"updateTeamReviewAssignment(input:$input)"`
 			}
 
-			return graphql.Mutate(ctx, &mutation, UpdateTeamReviewAssignmentInput{
+			return attributeDiagnostics(graphql.Mutate(ctx, &mutation, UpdateTeamReviewAssignmentInput{
 				TeamID:                           d.Id(),
 				ReviewRequestDelegation:          true,
 				ReviewRequestDelegationAlgorithm: settings["algorithm"].(string),
 				ReviewRequestDelegationCount:     settings["member_count"].(int),
 				ReviewRequestDelegationNotifyAll: settings["notify"].(bool),
-			}, nil)
+			}, nil), "review_request_delegation")
 		}
 	}
 
-	return resourceGithubTeamSettingsRead(d, meta)
+	return resourceGithubTeamSettingsRead(ctx, d, meta)
 
 }
 
-func resourceGithubTeamSettingsDelete(d *schema.ResourceData, meta interface{}) error {
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+func resourceGithubTeamSettingsDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	graphql := meta.(*Owner).v4client
 
 	var mutation struct {
@@ -203,12 +203,12 @@ func resourceGithubTeamSettingsDelete(d *schema.ResourceData, meta interface{})
 		} `graphql:"updateTeamReviewAssignment(input:$input)"`
 	}
 
-	return graphql.Mutate(ctx, &mutation, defaultTeamReviewAssignmentSettings(d.Id()), nil)
+	return diag.FromErr(graphql.Mutate(ctx, &mutation, defaultTeamReviewAssignmentSettings(d.Id()), nil))
 
 }
 
-func resourceGithubTeamSettingsImport(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
-	nodeId, slug, err := resolveTeamIDs(d.Id(), meta.(*Owner), context.Background())
+func resourceGithubTeamSettingsImport(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+	nodeId, slug, err := resolveTeamIDs(d.Id(), meta.(*Owner), ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -222,7 +222,7 @@ func resourceGithubTeamSettingsImport(d *schema.ResourceData, meta interface{})
 	if err = d.Set("team_uid", nodeId); err != nil {
 		return nil, err
 	}
-	return []*schema.ResourceData{d}, resourceGithubTeamSettingsRead(d, meta)
+	return []*schema.ResourceData{d}, errorFromDiagnostics(resourceGithubTeamSettingsRead(ctx, d, meta))
 }
 
 func resolveTeamIDs(idOrSlug string, meta *Owner, ctx context.Context) (nodeId string, slug string, err error) {
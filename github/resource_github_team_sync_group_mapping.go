@@ -7,17 +7,18 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubTeamSyncGroupMapping() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubTeamSyncGroupMappingCreate,
-		Read:   resourceGithubTeamSyncGroupMappingRead,
-		Update: resourceGithubTeamSyncGroupMappingUpdate,
-		Delete: resourceGithubTeamSyncGroupMappingDelete,
+		CreateContext: resourceGithubTeamSyncGroupMappingCreate,
+		ReadContext:   resourceGithubTeamSyncGroupMappingRead,
+		UpdateContext: resourceGithubTeamSyncGroupMappingUpdate,
+		DeleteContext: resourceGithubTeamSyncGroupMappingDelete,
 		Importer: &schema.ResourceImporter{
-			State: func(d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
 				if err := d.Set("team_slug", d.Id()); err != nil {
 					return nil, err
 				}
@@ -65,39 +66,38 @@ func resourceGithubTeamSyncGroupMapping() *schema.Resource {
 	}
 }
 
-func resourceGithubTeamSyncGroupMappingCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamSyncGroupMappingCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
-	ctx := context.Background()
 	orgName := meta.(*Owner).name
 	slug := d.Get("team_slug").(string)
 
 	idpGroupList := expandTeamSyncGroups(d)
 	_, _, err = client.Teams.CreateOrUpdateIDPGroupConnectionsBySlug(ctx, orgName, slug, *idpGroupList)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(fmt.Sprintf("teams/%s/team-sync/group-mappings", slug))
 
-	return resourceGithubTeamSyncGroupMappingRead(d, meta)
+	return resourceGithubTeamSyncGroupMappingRead(ctx, d, meta)
 }
 
-func resourceGithubTeamSyncGroupMappingRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamSyncGroupMappingRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	slug := d.Get("team_slug").(string)
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -115,60 +115,60 @@ func resourceGithubTeamSyncGroupMappingRead(d *schema.ResourceData, meta interfa
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	groups, err := flattenGithubIDPGroupList(idpGroupList)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("group", groups); err != nil {
-		return fmt.Errorf("error setting groups: %s", err)
+		return diag.FromErr(fmt.Errorf("error setting groups: %s", err))
 	}
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return fmt.Errorf("error setting etag: %s", err)
+		return diag.FromErr(fmt.Errorf("error setting etag: %s", err))
 	}
 
 	return nil
 }
 
-func resourceGithubTeamSyncGroupMappingUpdate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamSyncGroupMappingUpdate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	slug := d.Get("team_slug").(string)
 
 	idpGroupList := expandTeamSyncGroups(d)
 	_, _, err = client.Teams.CreateOrUpdateIDPGroupConnectionsBySlug(ctx, orgName, slug, *idpGroupList)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
-	return resourceGithubTeamSyncGroupMappingRead(d, meta)
+	return resourceGithubTeamSyncGroupMappingRead(ctx, d, meta)
 }
 
-func resourceGithubTeamSyncGroupMappingDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubTeamSyncGroupMappingDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	slug := d.Get("team_slug").(string)
 
 	groups := make([]*github.IDPGroup, 0)
 	emptyGroupList := github.IDPGroupList{Groups: groups}
 
 	_, _, err = client.Teams.CreateOrUpdateIDPGroupConnectionsBySlug(ctx, orgName, slug, emptyGroupList)
-	return err
+	return diag.FromErr(err)
 }
 
 func flattenGithubIDPGroupList(idpGroupList *github.IDPGroupList) ([]interface{}, error) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/google/go-github/v66/github"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
@@ -250,3 +251,48 @@ func TestAccGithubTeamUpdateName(t *testing.T) {
 
 	})
 }
+
+func TestGithubTeamWithFakeAPI(t *testing.T) {
+
+	srv, meta := newFakeAPI(t)
+
+	config := map[string]interface{}{
+		"name":        "Platform Team",
+		"description": "Runs the platform",
+		"privacy":     "closed",
+	}
+
+	team := newFakeResource(t, meta, "github_team")
+	team.apply(config)
+	if slug := team.get("slug"); slug != "platform-team" {
+		t.Fatalf("Unexpected slug: %q", slug)
+	}
+	if count := team.get("members_count"); count != "0" {
+		t.Fatalf("Expected the default maintainer to be removed, got %s members", count)
+	}
+	team.expectNoChanges(config)
+
+	config["description"] = "Runs the new platform"
+	team.apply(config)
+	if description := srv.Team(fakeOrganization, "platform-team").GetDescription(); description != "Runs the new platform" {
+		t.Fatalf("Expected the description to be updated, got: %q", description)
+	}
+
+	imported := newFakeResource(t, meta, "github_team")
+	imported.importState("platform-team")
+	if imported.state.ID != team.state.ID {
+		t.Fatalf("Expected the team to be imported by slug, got ID %q", imported.state.ID)
+	}
+	imported.expectNoChanges(config)
+
+	srv.Team(fakeOrganization, "platform-team").Privacy = github.String("secret")
+	team.refresh()
+	team.expectChanges(config)
+	team.apply(config)
+	team.expectNoChanges(config)
+
+	team.destroy()
+	if srv.Team(fakeOrganization, "platform-team") != nil {
+		t.Fatal("Expected the team to be deleted")
+	}
+}
@@ -7,14 +7,15 @@ import (
 	"strconv"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubUserGpgKey() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubUserGpgKeyCreate,
-		Read:   resourceGithubUserGpgKeyRead,
-		Delete: resourceGithubUserGpgKeyDelete,
+		CreateContext: resourceGithubUserGpgKeyCreate,
+		ReadContext:   resourceGithubUserGpgKeyRead,
+		DeleteContext: resourceGithubUserGpgKeyDelete,
 
 		Schema: map[string]*schema.Schema{
 			"armored_public_key": {
@@ -36,30 +37,29 @@ func resourceGithubUserGpgKey() *schema.Resource {
 	}
 }
 
-func resourceGithubUserGpgKeyCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubUserGpgKeyCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	pubKey := d.Get("armored_public_key").(string)
-	ctx := context.Background()
 
 	key, _, err := client.Users.CreateGPGKey(ctx, pubKey)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(strconv.FormatInt(key.GetID(), 10))
 
-	return resourceGithubUserGpgKeyRead(d, meta)
+	return resourceGithubUserGpgKeyRead(ctx, d, meta)
 }
 
-func resourceGithubUserGpgKeyRead(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubUserGpgKeyRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -77,26 +77,26 @@ func resourceGithubUserGpgKeyRead(d *schema.ResourceData, meta interface{}) erro
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if err = d.Set("key_id", key.GetKeyID()); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceGithubUserGpgKeyDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubUserGpgKeyDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	id, err := strconv.ParseInt(d.Id(), 10, 64)
 	if err != nil {
-		return unconvertibleIdErr(d.Id(), err)
+		return diag.FromErr(unconvertibleIdErr(d.Id(), err))
 	}
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err = client.Users.DeleteGPGKey(ctx, id)
 
-	return err
+	return diag.FromErr(err)
 }
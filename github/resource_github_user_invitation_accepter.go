@@ -6,14 +6,15 @@ import (
 	"strconv"
 
 	"github.com/google/uuid"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceGithubUserInvitationAccepter() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceGithubUserInvitationAccepterCreate,
-		Read:   schema.Noop, // Nothing to read as invitation is removed after it's accepted
-		Delete: schema.Noop, // Nothing to remove as invitation is removed after it's accepted
+		CreateContext: resourceGithubUserInvitationAccepterCreate,
+		ReadContext:   schema.NoopContext, // Nothing to read as invitation is removed after it's accepted
+		DeleteContext: schema.NoopContext, // Nothing to remove as invitation is removed after it's accepted
 
 		Schema: map[string]*schema.Schema{
 			"invitation_id": {
@@ -33,7 +34,7 @@ func resourceGithubUserInvitationAccepter() *schema.Resource {
 	}
 }
 
-func resourceGithubUserInvitationAccepterCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceGithubUserInvitationAccepterCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	invitationIdString := d.Get("invitation_id").(string)
@@ -46,19 +47,18 @@ func resourceGithubUserInvitationAccepterCreate(d *schema.ResourceData, meta int
 			d.SetId(uuid.NewString())
 			return nil
 		} else {
-			return fmt.Errorf("invitation_id is not set and allow_empty_id is false")
+			return diag.FromErr(fmt.Errorf("invitation_id is not set and allow_empty_id is false"))
 		}
 	}
 
 	invitationId, err := strconv.Atoi(invitationIdString)
 	if err != nil {
-		return fmt.Errorf("failed to parse invitation ID: %s", err)
+		return diag.FromErr(fmt.Errorf("failed to parse invitation ID: %s", err))
 	}
-	ctx := context.Background()
 
 	_, err = client.Users.AcceptInvitation(ctx, int64(invitationId))
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	d.SetId(invitationIdString)
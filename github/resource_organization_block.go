@@ -6,14 +6,15 @@ import (
 	"net/http"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 func resourceOrganizationBlock() *schema.Resource {
 	return &schema.Resource{
-		Create: resourceOrganizationBlockCreate,
-		Read:   resourceOrganizationBlockRead,
-		Delete: resourceOrganizationBlockDelete,
+		CreateContext: resourceOrganizationBlockCreate,
+		ReadContext:   resourceOrganizationBlockRead,
+		DeleteContext: resourceOrganizationBlockDelete,
 		Importer: &schema.ResourceImporter{
 			StateContext: schema.ImportStatePassthroughContext,
 		},
@@ -34,33 +35,32 @@ func resourceOrganizationBlock() *schema.Resource {
 	}
 }
 
-func resourceOrganizationBlockCreate(d *schema.ResourceData, meta interface{}) error {
+func resourceOrganizationBlockCreate(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	err := checkOrganization(meta)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
-	ctx := context.Background()
 	username := d.Get("username").(string)
 
 	_, err = client.Organizations.BlockUser(ctx, orgName, username)
 	if err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	d.SetId(username)
 
-	return resourceOrganizationBlockRead(d, meta)
+	return resourceOrganizationBlockRead(ctx, d, meta)
 }
 
-func resourceOrganizationBlockRead(d *schema.ResourceData, meta interface{}) error {
+func resourceOrganizationBlockRead(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 
 	username := d.Id()
 
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 	if !d.IsNewResource() {
 		ctx = context.WithValue(ctx, ctxEtag, d.Get("etag").(string))
 	}
@@ -79,7 +79,7 @@ func resourceOrganizationBlockRead(d *schema.ResourceData, meta interface{}) err
 				return nil
 			}
 		}
-		return err
+		return diag.FromErr(err)
 	}
 
 	if !blocked {
@@ -88,22 +88,22 @@ func resourceOrganizationBlockRead(d *schema.ResourceData, meta interface{}) err
 	}
 
 	if err = d.Set("username", username); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 	if err = d.Set("etag", resp.Header.Get("ETag")); err != nil {
-		return err
+		return diag.FromErr(err)
 	}
 
 	return nil
 }
 
-func resourceOrganizationBlockDelete(d *schema.ResourceData, meta interface{}) error {
+func resourceOrganizationBlockDelete(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
 	client := meta.(*Owner).v3client
 
 	orgName := meta.(*Owner).name
 	username := d.Id()
-	ctx := context.WithValue(context.Background(), ctxId, d.Id())
+	ctx = context.WithValue(ctx, ctxId, d.Id())
 
 	_, err := client.Organizations.UnblockUser(ctx, orgName, username)
-	return err
+	return diag.FromErr(err)
 }
@@ -1,6 +1,7 @@
 package github
 
 import (
+	"context"
 	"encoding/json"
 	"log"
 	"reflect"
@@ -10,6 +11,51 @@ import (
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
+// pullRequestRuleParameters extends the parameters of pull request rules with
+// the merge methods they allow, which go-github does not know about.
+type pullRequestRuleParameters struct {
+	github.PullRequestRuleParameters
+	AllowedMergeMethods []string `json:"allowed_merge_methods,omitempty"`
+}
+
+// getRuleset fetches the ruleset at the given URL. Unlike go-github, it keeps
+// the parameters of the rules as returned by GitHub, including the ones
+// go-github drops when decoding them.
+func getRuleset(ctx context.Context, client *github.Client, u string) (*github.Ruleset, *github.Response, error) {
+	req, err := client.NewRequest("GET", u, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var raw json.RawMessage
+	resp, err := client.Do(ctx, req, &raw)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	ruleset := new(github.Ruleset)
+	if err := json.Unmarshal(raw, ruleset); err != nil {
+		return nil, resp, err
+	}
+
+	var rawRules struct {
+		Rules []struct {
+			Type       string           `json:"type"`
+			Parameters *json.RawMessage `json:"parameters,omitempty"`
+		} `json:"rules"`
+	}
+	if err := json.Unmarshal(raw, &rawRules); err != nil {
+		return nil, resp, err
+	}
+	for i, rule := range rawRules.Rules {
+		if i < len(ruleset.Rules) && ruleset.Rules[i].Type == rule.Type && rule.Parameters != nil {
+			ruleset.Rules[i].Parameters = rule.Parameters
+		}
+	}
+
+	return ruleset, resp, nil
+}
+
 func resourceGithubRulesetObject(d *schema.ResourceData, org string) *github.Ruleset {
 	isOrgLevel := len(org) > 0
 
@@ -146,6 +192,16 @@ func expandConditions(input []interface{}, org bool) *github.RulesetConditions {
 			}
 
 			rulesetConditions.RepositoryID = &github.RulesetRepositoryIDsConditionParameters{RepositoryIDs: repositoryIDs}
+		} else if v, ok := inputConditions["repository_property"].([]interface{}); ok && v != nil && len(v) != 0 {
+			inputRepositoryProperty := make(map[string]interface{})
+			if v[0] != nil {
+				inputRepositoryProperty = v[0].(map[string]interface{})
+			}
+
+			rulesetConditions.RepositoryProperty = &github.RulesetRepositoryPropertyConditionParameters{
+				Include: expandRepositoryPropertyTargets(inputRepositoryProperty["include"]),
+				Exclude: expandRepositoryPropertyTargets(inputRepositoryProperty["exclude"]),
+			}
 		}
 	}
 
@@ -153,19 +209,25 @@ func expandConditions(input []interface{}, org bool) *github.RulesetConditions {
 }
 
 func flattenConditions(conditions *github.RulesetConditions, org bool) []interface{} {
-	if conditions == nil || conditions.RefName == nil {
+	if conditions == nil {
 		return []interface{}{}
 	}
 
 	conditionsMap := make(map[string]interface{})
-	refNameSlice := make([]map[string]interface{}, 0)
 
-	refNameSlice = append(refNameSlice, map[string]interface{}{
-		"include": conditions.RefName.Include,
-		"exclude": conditions.RefName.Exclude,
-	})
+	// push rulesets have no ref_name
+	if conditions.RefName != nil {
+		refNameSlice := make([]map[string]interface{}, 0)
+
+		refNameSlice = append(refNameSlice, map[string]interface{}{
+			"include": conditions.RefName.Include,
+			"exclude": conditions.RefName.Exclude,
+		})
 
-	conditionsMap["ref_name"] = refNameSlice
+		conditionsMap["ref_name"] = refNameSlice
+	} else if !org {
+		return []interface{}{}
+	}
 
 	// org-only fields
 	if org {
@@ -189,11 +251,69 @@ func flattenConditions(conditions *github.RulesetConditions, org bool) []interfa
 		if conditions.RepositoryID != nil {
 			conditionsMap["repository_id"] = conditions.RepositoryID.RepositoryIDs
 		}
+
+		if conditions.RepositoryProperty != nil {
+			conditionsMap["repository_property"] = []map[string]interface{}{
+				{
+					"include": flattenRepositoryPropertyTargets(conditions.RepositoryProperty.Include),
+					"exclude": flattenRepositoryPropertyTargets(conditions.RepositoryProperty.Exclude),
+				},
+			}
+		}
 	}
 
 	return []interface{}{conditionsMap}
 }
 
+func expandRepositoryPropertyTargets(input interface{}) []github.RulesetRepositoryPropertyTargetParameters {
+	targets := make([]github.RulesetRepositoryPropertyTargetParameters, 0)
+
+	inputTargets, _ := input.([]interface{})
+	for _, v := range inputTargets {
+		if v == nil {
+			continue
+		}
+		inputTarget := v.(map[string]interface{})
+
+		values := make([]string, 0)
+		for _, value := range inputTarget["property_values"].([]interface{}) {
+			if value != nil {
+				values = append(values, value.(string))
+			}
+		}
+
+		target := github.RulesetRepositoryPropertyTargetParameters{
+			Name:   inputTarget["name"].(string),
+			Values: values,
+		}
+		if source, ok := inputTarget["source"].(string); ok && source != "" {
+			target.Source = github.String(source)
+		}
+		targets = append(targets, target)
+	}
+
+	return targets
+}
+
+func flattenRepositoryPropertyTargets(targets []github.RulesetRepositoryPropertyTargetParameters) []map[string]interface{} {
+	targetsSlice := make([]map[string]interface{}, 0, len(targets))
+	for _, target := range targets {
+		// The source is omitted for custom properties
+		source := "custom"
+		if target.Source != nil && *target.Source != "" {
+			source = *target.Source
+		}
+
+		targetsSlice = append(targetsSlice, map[string]interface{}{
+			"name":            target.Name,
+			"property_values": target.Values,
+			"source":          source,
+		})
+	}
+
+	return targetsSlice
+}
+
 func expandRules(input []interface{}, org bool) []*github.RepositoryRule {
 	if len(input) == 0 || input[0] == nil {
 		return nil
@@ -289,15 +409,26 @@ func expandRules(input []interface{}, org bool) []*github.RepositoryRule {
 	// Pull request rule
 	if v, ok := rulesMap["pull_request"].([]interface{}); ok && len(v) != 0 {
 		pullRequestMap := v[0].(map[string]interface{})
-		params := &github.PullRequestRuleParameters{
-			DismissStaleReviewsOnPush:      pullRequestMap["dismiss_stale_reviews_on_push"].(bool),
-			RequireCodeOwnerReview:         pullRequestMap["require_code_owner_review"].(bool),
-			RequireLastPushApproval:        pullRequestMap["require_last_push_approval"].(bool),
-			RequiredApprovingReviewCount:   pullRequestMap["required_approving_review_count"].(int),
-			RequiredReviewThreadResolution: pullRequestMap["required_review_thread_resolution"].(bool),
+		params := &pullRequestRuleParameters{
+			PullRequestRuleParameters: github.PullRequestRuleParameters{
+				DismissStaleReviewsOnPush:      pullRequestMap["dismiss_stale_reviews_on_push"].(bool),
+				RequireCodeOwnerReview:         pullRequestMap["require_code_owner_review"].(bool),
+				RequireLastPushApproval:        pullRequestMap["require_last_push_approval"].(bool),
+				RequiredApprovingReviewCount:   pullRequestMap["required_approving_review_count"].(int),
+				RequiredReviewThreadResolution: pullRequestMap["required_review_thread_resolution"].(bool),
+			},
+		}
+		if allowedMergeMethods, ok := pullRequestMap["allowed_merge_methods"].(*schema.Set); ok {
+			params.AllowedMergeMethods = expandStringList(allowedMergeMethods.List())
+			sort.Strings(params.AllowedMergeMethods)
 		}
 
-		rulesSlice = append(rulesSlice, github.NewPullRequestRule(params))
+		bytes, _ := json.Marshal(params)
+		rawParams := json.RawMessage(bytes)
+		rulesSlice = append(rulesSlice, &github.RepositoryRule{
+			Type:       "pull_request",
+			Parameters: &rawParams,
+		})
 	}
 
 	// Required status checks rule
@@ -396,6 +527,55 @@ func expandRules(input []interface{}, org bool) []*github.RepositoryRule {
 		rulesSlice = append(rulesSlice, github.NewRequiredCodeScanningRule(params))
 	}
 
+	// Merge queue rule
+	if v, ok := rulesMap["merge_queue"].([]interface{}); ok && len(v) != 0 {
+		mergeQueueMap := v[0].(map[string]interface{})
+		params := &github.MergeQueueRuleParameters{
+			CheckResponseTimeoutMinutes:  mergeQueueMap["check_response_timeout_minutes"].(int),
+			GroupingStrategy:             mergeQueueMap["grouping_strategy"].(string),
+			MaxEntriesToBuild:            mergeQueueMap["max_entries_to_build"].(int),
+			MaxEntriesToMerge:            mergeQueueMap["max_entries_to_merge"].(int),
+			MergeMethod:                  mergeQueueMap["merge_method"].(string),
+			MinEntriesToMerge:            mergeQueueMap["min_entries_to_merge"].(int),
+			MinEntriesToMergeWaitMinutes: mergeQueueMap["min_entries_to_merge_wait_minutes"].(int),
+		}
+		rulesSlice = append(rulesSlice, github.NewMergeQueueRule(params))
+	}
+
+	// Push rules
+	if v, ok := rulesMap["file_path_restriction"].([]interface{}); ok && len(v) != 0 {
+		filePathRestrictionMap := v[0].(map[string]interface{})
+		restrictedFilePaths := expandStringList(filePathRestrictionMap["restricted_file_paths"].([]interface{}))
+		params := &github.RuleFileParameters{
+			RestrictedFilePaths: &restrictedFilePaths,
+		}
+		rulesSlice = append(rulesSlice, github.NewFilePathRestrictionRule(params))
+	}
+
+	if v, ok := rulesMap["max_file_path_length"].([]interface{}); ok && len(v) != 0 {
+		maxFilePathLengthMap := v[0].(map[string]interface{})
+		params := &github.RuleMaxFilePathLengthParameters{
+			MaxFilePathLength: maxFilePathLengthMap["max_file_path_length"].(int),
+		}
+		rulesSlice = append(rulesSlice, github.NewMaxFilePathLengthRule(params))
+	}
+
+	if v, ok := rulesMap["file_extension_restriction"].([]interface{}); ok && len(v) != 0 {
+		fileExtensionRestrictionMap := v[0].(map[string]interface{})
+		params := &github.RuleFileExtensionRestrictionParameters{
+			RestrictedFileExtensions: expandStringList(fileExtensionRestrictionMap["restricted_file_extensions"].(*schema.Set).List()),
+		}
+		rulesSlice = append(rulesSlice, github.NewFileExtensionRestrictionRule(params))
+	}
+
+	if v, ok := rulesMap["max_file_size"].([]interface{}); ok && len(v) != 0 {
+		maxFileSizeMap := v[0].(map[string]interface{})
+		params := &github.RuleMaxFileSizeParameters{
+			MaxFileSize: int64(maxFileSizeMap["max_file_size"].(int)),
+		}
+		rulesSlice = append(rulesSlice, github.NewMaxFileSizeRule(params))
+	}
+
 	return rulesSlice
 }
 
@@ -465,7 +645,7 @@ func flattenRules(rules []*github.RepositoryRule, org bool) []interface{} {
 			}
 
 		case "pull_request":
-			var params github.PullRequestRuleParameters
+			var params pullRequestRuleParameters
 
 			err := json.Unmarshal(*v.Parameters, &params)
 			if err != nil {
@@ -479,6 +659,7 @@ func flattenRules(rules []*github.RepositoryRule, org bool) []interface{} {
 			rule["require_last_push_approval"] = params.RequireLastPushApproval
 			rule["required_approving_review_count"] = params.RequiredApprovingReviewCount
 			rule["required_review_thread_resolution"] = params.RequiredReviewThreadResolution
+			rule["allowed_merge_methods"] = params.AllowedMergeMethods
 			rulesMap[v.Type] = []map[string]interface{}{rule}
 
 		case "required_status_checks":
@@ -507,6 +688,79 @@ func flattenRules(rules []*github.RepositoryRule, org bool) []interface{} {
 			rule["strict_required_status_checks_policy"] = params.StrictRequiredStatusChecksPolicy
 			rule["do_not_enforce_on_create"] = params.DoNotEnforceOnCreate
 			rulesMap[v.Type] = []map[string]interface{}{rule}
+
+		case "merge_queue":
+			var params github.MergeQueueRuleParameters
+
+			err := json.Unmarshal(*v.Parameters, &params)
+			if err != nil {
+				log.Printf("[INFO] Unexpected error unmarshalling rule %s with parameters: %v",
+					v.Type, v.Parameters)
+			}
+
+			rule := make(map[string]interface{})
+			rule["check_response_timeout_minutes"] = params.CheckResponseTimeoutMinutes
+			rule["grouping_strategy"] = params.GroupingStrategy
+			rule["max_entries_to_build"] = params.MaxEntriesToBuild
+			rule["max_entries_to_merge"] = params.MaxEntriesToMerge
+			rule["merge_method"] = params.MergeMethod
+			rule["min_entries_to_merge"] = params.MinEntriesToMerge
+			rule["min_entries_to_merge_wait_minutes"] = params.MinEntriesToMergeWaitMinutes
+			rulesMap[v.Type] = []map[string]interface{}{rule}
+
+		case "file_path_restriction":
+			var params github.RuleFileParameters
+
+			err := json.Unmarshal(*v.Parameters, &params)
+			if err != nil {
+				log.Printf("[INFO] Unexpected error unmarshalling rule %s with parameters: %v",
+					v.Type, v.Parameters)
+			}
+
+			rule := make(map[string]interface{})
+			if params.RestrictedFilePaths != nil {
+				rule["restricted_file_paths"] = *params.RestrictedFilePaths
+			}
+			rulesMap[v.Type] = []map[string]interface{}{rule}
+
+		case "max_file_path_length":
+			var params github.RuleMaxFilePathLengthParameters
+
+			err := json.Unmarshal(*v.Parameters, &params)
+			if err != nil {
+				log.Printf("[INFO] Unexpected error unmarshalling rule %s with parameters: %v",
+					v.Type, v.Parameters)
+			}
+
+			rule := make(map[string]interface{})
+			rule["max_file_path_length"] = params.MaxFilePathLength
+			rulesMap[v.Type] = []map[string]interface{}{rule}
+
+		case "file_extension_restriction":
+			var params github.RuleFileExtensionRestrictionParameters
+
+			err := json.Unmarshal(*v.Parameters, &params)
+			if err != nil {
+				log.Printf("[INFO] Unexpected error unmarshalling rule %s with parameters: %v",
+					v.Type, v.Parameters)
+			}
+
+			rule := make(map[string]interface{})
+			rule["restricted_file_extensions"] = params.RestrictedFileExtensions
+			rulesMap[v.Type] = []map[string]interface{}{rule}
+
+		case "max_file_size":
+			var params github.RuleMaxFileSizeParameters
+
+			err := json.Unmarshal(*v.Parameters, &params)
+			if err != nil {
+				log.Printf("[INFO] Unexpected error unmarshalling rule %s with parameters: %v",
+					v.Type, v.Parameters)
+			}
+
+			rule := make(map[string]interface{})
+			rule["max_file_size"] = params.MaxFileSize
+			rulesMap[v.Type] = []map[string]interface{}{rule}
 		}
 	}
 
@@ -1,7 +1,11 @@
 package github
 
 import (
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func webhookConfigurationSchema() *schema.Schema {
@@ -24,10 +28,18 @@ func webhookConfigurationSchema() *schema.Schema {
 					Description: "The content type for the payload. Valid values are either 'form' or 'json'.",
 				},
 				"secret": {
-					Type:        schema.TypeString,
-					Optional:    true,
-					Sensitive:   true,
-					Description: "The shared secret for the webhook",
+					Type:          schema.TypeString,
+					Optional:      true,
+					Sensitive:     true,
+					ConflictsWith: []string{"configuration.0.secret_wo"},
+					Description:   "The shared secret for the webhook",
+				},
+				"secret_wo": {
+					Type:          schema.TypeString,
+					Optional:      true,
+					WriteOnly:     true,
+					ConflictsWith: []string{"configuration.0.secret"},
+					Description:   "The shared secret for the webhook, which is never stored in state. It is sent along with the configuration: on creation, when the configuration changes and when 'secret_version' changes.",
 				},
 				"insecure_ssl": {
 					Type:        schema.TypeBool,
@@ -38,3 +50,37 @@ func webhookConfigurationSchema() *schema.Schema {
 		},
 	}
 }
+
+func webhookSecretVersionSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeString,
+		Optional:    true,
+		Description: "An arbitrary value, changing it sends the secret of the configuration to GitHub again, e.g. to rotate the write-only 'secret_wo' or a secret changed outside of Terraform.",
+	}
+}
+
+// webhookPreferWriteOnlySecret warns to use secret_wo instead of secret when
+// Terraform supports write-only attributes.
+func webhookPreferWriteOnlySecret() []schema.ValidateRawResourceConfigFunc {
+	configuration := cty.GetAttrPath("configuration").Index(cty.UnknownVal(cty.Number))
+	return []schema.ValidateRawResourceConfigFunc{
+		validation.PreferWriteOnlyAttribute(configuration.GetAttr("secret"), configuration.GetAttr("secret_wo")),
+	}
+}
+
+// setWebhookWriteOnlySecret sets the secret of config to the write-only
+// secret_wo of the configuration, which is not available from the state.
+func setWebhookWriteOnlySecret(d *schema.ResourceData, config *github.HookConfig) diag.Diagnostics {
+	if config == nil || len(d.Get("configuration").([]interface{})) == 0 {
+		return nil
+	}
+
+	secret, diags := d.GetRawConfigAt(cty.GetAttrPath("configuration").IndexInt(0).GetAttr("secret_wo"))
+	if diags.HasError() {
+		return diags
+	}
+	if secret.Type().Equals(cty.String) && secret.IsKnown() && !secret.IsNull() {
+		config.Secret = github.String(secret.AsString())
+	}
+	return nil
+}
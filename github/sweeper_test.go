@@ -2,6 +2,7 @@ package github
 
 import (
 	"fmt"
+	"math/rand"
 	"os"
 	"testing"
 
@@ -9,6 +10,13 @@ import (
 )
 
 func TestMain(m *testing.M) {
+	// Tests name the resources they create randomly. Seed the names so that
+	// replaying tests makes the same requests as recording them did, as
+	// long as the same tests are run.
+	if os.Getenv("GITHUB_CASSETTE_MODE") != "" {
+		rand.Seed(1) //nolint:staticcheck
+	}
+
 	resource.TestMain(m)
 }
 
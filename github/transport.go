@@ -2,18 +2,32 @@ package github
 
 import (
 	"bytes"
+	"context"
+	"fmt"
 	"io"
 	"log"
 	"net/http"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
 const (
-	ctxEtag = ctxEtagType("etag")
-	ctxId   = ctxIdType("id")
+	ctxEtag         = ctxEtagType("etag")
+	ctxId           = ctxIdType("id")
+	ctxResourceType = ctxResourceTypeType("resourceType")
+)
+
+const (
+	headerRateLimit     = "X-RateLimit-Limit"
+	headerRateRemaining = "X-RateLimit-Remaining"
+	headerRateReset     = "X-RateLimit-Reset"
+	headerRateResource  = "X-RateLimit-Resource"
 )
 
 // ctxIdType is used to avoid collisions between packages using context
@@ -22,10 +36,15 @@ type ctxIdType string
 // ctxEtagType is used to avoid collisions between packages using context
 type ctxEtagType string
 
+// ctxResourceTypeType is used to avoid collisions between packages using context
+type ctxResourceTypeType string
+
 // etagTransport allows saving API quota by passing previously stored Etag
-// available via context to request headers
+// available via context to request headers. When given a cache, it also makes
+// other reads conditional on the responses cached by previous runs.
 type etagTransport struct {
 	transport http.RoundTripper
+	cache     *etagCache
 }
 
 func (ett *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -33,14 +52,35 @@ func (ett *etagTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 
 	etag := ctx.Value(ctxEtag)
 	if v, ok := etag.(string); ok && v != "" {
+		// Resources passing their own Etag handle 304 responses themselves
 		req.Header.Set("If-None-Match", v)
+		return ett.transport.RoundTrip(req)
+	}
+
+	if ett.cache != nil && req.Method == http.MethodGet {
+		return ett.cache.roundTrip(ett.transport, req)
 	}
 
 	return ett.transport.RoundTrip(req)
 }
 
-func NewEtagTransport(rt http.RoundTripper) *etagTransport {
-	return &etagTransport{transport: rt}
+type EtagTransportOption func(*etagTransport)
+
+func NewEtagTransport(rt http.RoundTripper, options ...EtagTransportOption) *etagTransport {
+	ett := &etagTransport{transport: rt}
+
+	for _, opt := range options {
+		opt(ett)
+	}
+
+	return ett
+}
+
+// WithEtagCache is used to persist responses for conditional requests
+func WithEtagCache(c *etagCache) EtagTransportOption {
+	return func(ett *etagTransport) {
+		ett.cache = c
+	}
 }
 
 // RateLimitTransport implements GitHub's best practices
@@ -52,8 +92,26 @@ type RateLimitTransport struct {
 	writeDelay       time.Duration
 	readDelay        time.Duration
 	parallelRequests bool
+	pacing           bool
+	pacingThreshold  int
 
 	m sync.Mutex
+
+	budgets  map[string]*rateLimitBudget
+	budgetsM sync.Mutex
+}
+
+// rateLimitBudget is the last known state of one of GitHub's rate limit
+// resources, e.g. "core" for the REST API or "graphql" for the GraphQL API
+// where the budget is consumed by the point cost of each query.
+type rateLimitBudget struct {
+	limit     int
+	remaining int
+	reset     time.Time
+	consumed  int
+	// consumedBy is the part of consumed attributed to each Terraform
+	// resource type, see withResourceType.
+	consumedBy map[string]int
 }
 
 func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
@@ -63,9 +121,16 @@ func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, err
 
 	// Sleep for the delay that the last request defined. This delay might be different
 	// for read and write requests. See isWriteMethod for the distinction between them.
-	if rlt.nextRequestDelay > 0 {
-		log.Printf("[DEBUG] Sleeping %s between operations", rlt.nextRequestDelay)
-		time.Sleep(rlt.nextRequestDelay)
+	delay := rlt.nextRequestDelay
+	if pacingDelay := rlt.calculatePacingDelay(rateLimitResource(req)); pacingDelay > delay {
+		delay = pacingDelay
+	}
+	if delay > 0 {
+		log.Printf("[DEBUG] Sleeping %s between operations", delay)
+		if err := sleepContext(req.Context(), delay); err != nil {
+			rlt.smartLock(false)
+			return nil, err
+		}
 	}
 
 	rlt.nextRequestDelay = rlt.calculateNextDelay(req.Method)
@@ -81,19 +146,25 @@ func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, err
 	// See https://github.com/google/go-github/pull/986
 	r1, r2, err := drainBody(resp.Body)
 	if err != nil {
+		rlt.smartLock(false)
 		return nil, err
 	}
 	resp.Body = r1
 	ghErr := github.CheckResponse(resp)
 	resp.Body = r2
 
+	rlt.updateBudget(req, resp)
+
 	// When you have been limited, use the Retry-After response header to slow down.
 	if arlErr, ok := ghErr.(*github.AbuseRateLimitError); ok {
 		rlt.nextRequestDelay = 0
 		retryAfter := arlErr.GetRetryAfter()
 		log.Printf("[DEBUG] Abuse detection mechanism triggered, sleeping for %s before retrying",
 			retryAfter)
-		time.Sleep(retryAfter)
+		if err := sleepContext(req.Context(), retryAfter); err != nil {
+			rlt.smartLock(false)
+			return nil, err
+		}
 		rlt.smartLock(false)
 		return rlt.RoundTrip(req)
 	}
@@ -103,7 +174,10 @@ func (rlt *RateLimitTransport) RoundTrip(req *http.Request) (*http.Response, err
 		retryAfter := time.Until(rlErr.Rate.Reset.Time)
 		log.Printf("[DEBUG] Rate limit %d reached, sleeping for %s (until %s) before retrying",
 			rlErr.Rate.Limit, retryAfter, time.Now().Add(retryAfter))
-		time.Sleep(retryAfter)
+		if err := sleepContext(req.Context(), retryAfter); err != nil {
+			rlt.smartLock(false)
+			return nil, err
+		}
 		rlt.smartLock(false)
 		return rlt.RoundTrip(req)
 	}
@@ -135,6 +209,145 @@ func (rlt *RateLimitTransport) calculateNextDelay(method string) time.Duration {
 	return rlt.readDelay
 }
 
+// calculatePacingDelay returns the delay needed to spread the remaining budget
+// of the given rate limit resource evenly until it resets. No delay is needed
+// while pacing is disabled or more than pacingThreshold percent of the budget
+// is left.
+func (rlt *RateLimitTransport) calculatePacingDelay(resource string) time.Duration {
+	if !rlt.pacing {
+		return 0
+	}
+
+	rlt.budgetsM.Lock()
+	defer rlt.budgetsM.Unlock()
+
+	budget, ok := rlt.budgets[resource]
+	if !ok || budget.limit <= 0 {
+		return 0
+	}
+
+	untilReset := time.Until(budget.reset)
+	if untilReset <= 0 {
+		return 0
+	}
+
+	if budget.remaining*100 >= budget.limit*rlt.pacingThreshold {
+		return 0
+	}
+
+	return untilReset / time.Duration(budget.remaining+1)
+}
+
+// updateBudget records the rate limit reported in the X-RateLimit-* headers of
+// resp and logs how much of it has been consumed.
+func (rlt *RateLimitTransport) updateBudget(req *http.Request, resp *http.Response) {
+	limit, err := strconv.Atoi(resp.Header.Get(headerRateLimit))
+	if err != nil {
+		return
+	}
+	remaining, err := strconv.Atoi(resp.Header.Get(headerRateRemaining))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get(headerRateReset), 10, 64)
+	if err != nil {
+		return
+	}
+
+	resource := resp.Header.Get(headerRateResource)
+	if resource == "" {
+		resource = rateLimitResource(req)
+	}
+
+	rlt.budgetsM.Lock()
+	defer rlt.budgetsM.Unlock()
+
+	if rlt.budgets == nil {
+		rlt.budgets = make(map[string]*rateLimitBudget)
+	}
+
+	budget, ok := rlt.budgets[resource]
+	if !ok {
+		budget = &rateLimitBudget{}
+		rlt.budgets[resource] = budget
+	}
+
+	resetTime := time.Unix(reset, 0)
+	consumed := 0
+	switch {
+	case !ok:
+		// Nothing is known about what was consumed before the first response.
+	case resetTime.Equal(budget.reset):
+		if budget.remaining > remaining {
+			consumed = budget.remaining - remaining
+		}
+	default:
+		// A new window started, everything used in it so far is attributed.
+		consumed = limit - remaining
+	}
+
+	// Requests made outside of a resource, e.g. while configuring the
+	// provider, are attributed to the provider.
+	resourceType := "provider"
+	if v, ok := req.Context().Value(ctxResourceType).(string); ok && v != "" {
+		resourceType = v
+	}
+	if budget.consumedBy == nil {
+		budget.consumedBy = make(map[string]int)
+	}
+	budget.consumed += consumed
+	budget.consumedBy[resourceType] += consumed
+
+	budget.limit = limit
+	budget.remaining = remaining
+	budget.reset = resetTime
+
+	id := ""
+	if v, ok := req.Context().Value(ctxId).(string); ok && v != "" {
+		id = fmt.Sprintf(" by %s", v)
+	}
+	log.Printf("[DEBUG] GitHub %s rate limit: %d of %d remaining until %s, %d consumed since the provider was configured, %d of which by %s (%s %s%s)",
+		resource, remaining, limit, resetTime.Format(time.RFC3339), budget.consumed, budget.consumedBy[resourceType], resourceType, req.Method, req.URL.Path, id)
+}
+
+// withResourceType wraps the CRUD functions and importer of a resource or data
+// source, so that the rate limit budget consumed by their requests is
+// attributed to its type.
+func withResourceType(name string, r *schema.Resource) {
+	r.CreateContext = contextWithResourceType(name, r.CreateContext)
+	r.ReadContext = contextWithResourceType(name, r.ReadContext)
+	r.UpdateContext = contextWithResourceType(name, r.UpdateContext)
+	r.DeleteContext = contextWithResourceType(name, r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		stateContext := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			return stateContext(context.WithValue(ctx, ctxResourceType, name), d, meta)
+		}
+	}
+}
+
+func contextWithResourceType(name string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		return f(context.WithValue(ctx, ctxResourceType, name), d, meta)
+	}
+}
+
+// rateLimitResource returns the GitHub rate limit resource a request is
+// expected to count against, used until GitHub reports it in a response.
+func rateLimitResource(req *http.Request) string {
+	switch {
+	case strings.HasSuffix(req.URL.Path, "/graphql"):
+		return "graphql"
+	case strings.Contains(req.URL.Path, "/search/"):
+		return "search"
+	}
+	return "core"
+}
+
 type RateLimitTransportOption func(*RateLimitTransport)
 
 // NewRateLimitTransport takes in an http.RoundTripper and a variadic list of
@@ -143,7 +356,8 @@ type RateLimitTransportOption func(*RateLimitTransport)
 func NewRateLimitTransport(rt http.RoundTripper, options ...RateLimitTransportOption) *RateLimitTransport {
 	// Default to 1 second of write delay if none is provided
 	// Default to no read delay if none is provided
-	rlt := &RateLimitTransport{transport: rt, writeDelay: 1 * time.Second, readDelay: 0 * time.Second, parallelRequests: false}
+	// Default to no proactive pacing, starting at half of the budget once enabled
+	rlt := &RateLimitTransport{transport: rt, writeDelay: 1 * time.Second, readDelay: 0 * time.Second, parallelRequests: false, pacingThreshold: 50}
 
 	for _, opt := range options {
 		opt(rlt)
@@ -173,6 +387,15 @@ func WithParallelRequests(p bool) RateLimitTransportOption {
 	}
 }
 
+// WithRateLimitPacing is used to spread requests evenly over the remaining
+// rate limit window once less than threshold percent of the budget is left
+func WithRateLimitPacing(p bool, threshold int) RateLimitTransportOption {
+	return func(rlt *RateLimitTransport) {
+		rlt.pacing = p
+		rlt.pacingThreshold = threshold
+	}
+}
+
 // drainBody reads all of b to memory and then returns two equivalent
 // ReadClosers yielding the same bytes.
 func drainBody(b io.ReadCloser) (r1, r2 io.ReadCloser, err error) {
@@ -190,6 +413,20 @@ func drainBody(b io.ReadCloser) (r1, r2 io.ReadCloser, err error) {
 	return io.NopCloser(&buf), io.NopCloser(bytes.NewReader(buf.Bytes())), nil
 }
 
+// sleepContext pauses for d, returning early with the context's error when it
+// is cancelled first, e.g. when Terraform is interrupted.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func isWriteMethod(method string) bool {
 	switch method {
 	case "POST", "PATCH", "PUT", "DELETE":
@@ -267,7 +504,9 @@ func (t *RetryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
 			return resp, err
 		}
 
-		time.Sleep(t.retryDelay)
+		if err := sleepContext(req.Context(), t.retryDelay); err != nil {
+			return nil, err
+		}
 	}
 
 	return resp, err
@@ -293,3 +532,51 @@ func WithRetryDelay(d time.Duration) RetryTransportOption {
 		rt.retryDelay = d
 	}
 }
+
+// tokenRefreshTransport retries a request once with a fresh token when GitHub
+// rejects the token it was sent with, which happens when a token expires
+// between being issued and being used, or when a token file is rotated.
+type tokenRefreshTransport struct {
+	transport   http.RoundTripper
+	tokenSource refreshingTokenSource
+}
+
+// NewTokenRefreshTransport takes in an http.RoundTripper authenticating
+// requests from the given token source, usually an *oauth2.Transport.
+func NewTokenRefreshTransport(rt http.RoundTripper, ts refreshingTokenSource) *tokenRefreshTransport {
+	return &tokenRefreshTransport{transport: rt, tokenSource: ts}
+}
+
+func (t *tokenRefreshTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
+	}
+
+	// The request body has already been consumed, it can only be replayed
+	// when the request knows how to produce a fresh copy of it.
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return resp, err
+	}
+
+	rejected := ""
+	if resp.Request != nil {
+		rejected = strings.TrimPrefix(resp.Request.Header.Get("Authorization"), "Bearer ")
+	}
+	t.tokenSource.invalidate(rejected)
+
+	retry := req.Clone(req.Context())
+	if req.GetBody != nil {
+		body, bodyErr := req.GetBody()
+		if bodyErr != nil {
+			return resp, err
+		}
+		retry.Body = body
+	}
+
+	_, _ = io.Copy(io.Discard, resp.Body)
+	resp.Body.Close()
+
+	log.Printf("[DEBUG] Token was rejected, retrying %s %s with a new token", req.Method, req.URL)
+	return t.transport.RoundTrip(retry)
+}
@@ -0,0 +1,183 @@
+package github
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// etagCache persists the bodies and ETags of GitHub API responses on disk, so
+// that later runs can make conditional requests for them. GitHub answers those
+// with 304 Not Modified when nothing changed, which does not count against the
+// REST API rate limit, and the cached body is replayed in place of the empty
+// 304 response.
+//
+// Entries are keyed by the identity the requests are authenticated as, so
+// that responses are never shared between credentials which may not be able
+// to see the same data. Each entry is a separate file that is replaced
+// atomically, which keeps the cache consistent with parallel requests and
+// concurrent Terraform runs sharing the same directory.
+type etagCache struct {
+	dir      string
+	identity string
+}
+
+type etagCacheEntry struct {
+	ETag       string      `json:"etag"`
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       []byte      `json:"body"`
+}
+
+// newEtagCache returns a cache storing its entries in dir, creating it if
+// needed. Identity must uniquely identify the credentials requests are
+// authenticated with, without containing them.
+func newEtagCache(dir, identity string) (*etagCache, error) {
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return nil, fmt.Errorf("error creating ETag cache directory %s: %w", dir, err)
+	}
+
+	return &etagCache{dir: dir, identity: identity}, nil
+}
+
+// cacheIdentity returns a stable identifier for the given credentials, used to
+// keep cached responses of different credentials apart.
+func cacheIdentity(credentials ...string) string {
+	h := sha256.Sum256([]byte(strings.Join(credentials, "\n")))
+	return hex.EncodeToString(h[:])
+}
+
+func (c *etagCache) path(req *http.Request) string {
+	h := sha256.New()
+	// Hash.Write never returns an error. See https://pkg.go.dev/hash#Hash
+	_, _ = fmt.Fprintf(h, "%s\n%s\n%s\n%s", c.identity, req.Method, req.URL.String(), req.Header.Get("Accept"))
+	return filepath.Join(c.dir, hex.EncodeToString(h.Sum(nil))+".json")
+}
+
+func (c *etagCache) load(req *http.Request) *etagCacheEntry {
+	data, err := os.ReadFile(c.path(req))
+	if err != nil {
+		if !os.IsNotExist(err) {
+			log.Printf("[WARN] Error reading cached response for %s: %s", req.URL, err)
+		}
+		return nil
+	}
+
+	var entry etagCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		log.Printf("[WARN] Ignoring unreadable cached response for %s: %s", req.URL, err)
+		return nil
+	}
+
+	return &entry
+}
+
+func (c *etagCache) store(req *http.Request, entry *etagCacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[WARN] Error encoding response for %s to cache: %s", req.URL, err)
+		return
+	}
+
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		log.Printf("[WARN] Error caching response for %s: %s", req.URL, err)
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), c.path(req))
+	}
+	if err != nil {
+		log.Printf("[WARN] Error caching response for %s: %s", req.URL, err)
+	}
+}
+
+// roundTrip performs a GET request conditionally on a previously cached
+// response and replays that response when GitHub reports it is unchanged.
+func (c *etagCache) roundTrip(rt http.RoundTripper, req *http.Request) (*http.Response, error) {
+	entry := c.load(req)
+	if entry != nil {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", entry.ETag)
+	}
+
+	resp, err := rt.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if resp.StatusCode == http.StatusNotModified && entry != nil {
+		log.Printf("[DEBUG] Replaying cached response for %s", req.URL)
+		return entry.response(req, resp), nil
+	}
+
+	etag := resp.Header.Get("ETag")
+	if resp.StatusCode != http.StatusOK || etag == "" {
+		return resp, nil
+	}
+
+	r1, r2, err := drainBody(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = r2
+
+	body, err := io.ReadAll(r1)
+	if err != nil {
+		return nil, err
+	}
+
+	c.store(req, &etagCacheEntry{
+		ETag:       etag,
+		StatusCode: resp.StatusCode,
+		Header:     resp.Header,
+		Body:       body,
+	})
+
+	return resp, nil
+}
+
+// response rebuilds the cached response, keeping the rate limit headers of the
+// live 304 response so that the remaining budget is still tracked accurately.
+func (e *etagCacheEntry) response(req *http.Request, notModified *http.Response) *http.Response {
+	_, _ = io.Copy(io.Discard, notModified.Body)
+	notModified.Body.Close()
+
+	header := e.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	for key, values := range notModified.Header {
+		if strings.HasPrefix(key, "X-Ratelimit-") {
+			header[key] = values
+		}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(e.Body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", e.StatusCode, http.StatusText(e.StatusCode)),
+		StatusCode:    e.StatusCode,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(e.Body)),
+		ContentLength: int64(len(e.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,113 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+const (
+	headerAcceptedPermissions = "X-Accepted-GitHub-Permissions"
+	headerAcceptedScopes      = "X-Accepted-OAuth-Scopes"
+	headerGrantedScopes       = "X-OAuth-Scopes"
+	headerSSO                 = "X-GitHub-SSO"
+)
+
+// apiErrorTransport records the failed responses of requests made while a
+// resource's CRUD functions run, so that the errors they return can be
+// translated into diagnostics explaining how to fix them. Requests made with a
+// context carrying no apiErrors, e.g. while configuring the provider, are
+// passed through untouched.
+type apiErrorTransport struct {
+	transport http.RoundTripper
+}
+
+func NewAPIErrorTransport(rt http.RoundTripper) *apiErrorTransport {
+	return &apiErrorTransport{transport: rt}
+}
+
+func (aet *apiErrorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := aet.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	errs, ok := req.Context().Value(ctxAPIErrors).(*apiErrors)
+	if !ok {
+		return resp, nil
+	}
+
+	// GraphQL errors are returned with 200 OK, other requests succeeded
+	isGraphQL := strings.HasSuffix(req.URL.Path, "/graphql")
+	if resp.StatusCode < 400 && (!isGraphQL || resp.StatusCode != http.StatusOK) {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	if e := newAPIError(req, resp, body, isGraphQL); e != nil {
+		errs.record(e)
+	}
+
+	return resp, nil
+}
+
+// newAPIError returns the details of a failed response, or nil when a GraphQL
+// response carries no errors.
+func newAPIError(req *http.Request, resp *http.Response, body []byte, isGraphQL bool) *apiError {
+	e := &apiError{
+		method:              req.Method,
+		url:                 req.URL.String(),
+		status:              resp.StatusCode,
+		isGraphQL:           isGraphQL,
+		acceptedPermissions: resp.Header.Get(headerAcceptedPermissions),
+		acceptedScopes:      resp.Header.Get(headerAcceptedScopes),
+		grantedScopes:       resp.Header.Get(headerGrantedScopes),
+		sso:                 resp.Header.Get(headerSSO),
+		rateRemaining:       resp.Header.Get(headerRateRemaining),
+		rateReset:           resp.Header.Get(headerRateReset),
+	}
+	// Classic tokens are told their scopes, even when they have none
+	_, e.classicToken = resp.Header[http.CanonicalHeaderKey(headerGrantedScopes)]
+
+	if isGraphQL && resp.StatusCode == http.StatusOK {
+		var response struct {
+			Errors []struct {
+				Type    string        `json:"type"`
+				Message string        `json:"message"`
+				Path    []interface{} `json:"path"`
+			} `json:"errors"`
+		}
+		if err := json.Unmarshal(body, &response); err != nil || len(response.Errors) == 0 {
+			return nil
+		}
+		for _, gqlErr := range response.Errors {
+			path := make([]string, len(gqlErr.Path))
+			for i, p := range gqlErr.Path {
+				path[i] = fmt.Sprint(p)
+			}
+			e.graphQLErrors = append(e.graphQLErrors, apiGraphQLError{
+				errorType: gqlErr.Type,
+				message:   gqlErr.Message,
+				path:      strings.Join(path, "."),
+			})
+		}
+		return e
+	}
+
+	var response struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(body, &response); err == nil {
+		e.message = response.Message
+	}
+	return e
+}
@@ -0,0 +1,257 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// cassetteModeRecord sends requests to GitHub and records them.
+	cassetteModeRecord = "record"
+	// cassetteModeReplay serves recorded responses without network access.
+	cassetteModeReplay = "replay"
+)
+
+// cassette holds the REST and GraphQL interactions of an acceptance test, so
+// that the test can be replayed without access to GitHub. Cassettes are shared
+// by every provider instance of the process using the same file, as Terraform
+// configures a new provider for each step of a test.
+type cassette struct {
+	path string
+	mode string
+
+	m            sync.Mutex
+	Interactions []*cassetteInteraction `json:"interactions"`
+	// served counts the replayed interactions of each request key
+	served map[string]int
+}
+
+type cassetteInteraction struct {
+	Request  cassetteRequest  `json:"request"`
+	Response cassetteResponse `json:"response"`
+}
+
+type cassetteRequest struct {
+	Method string `json:"method"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	// Operation is the GraphQL query document and its variables
+	Operation string `json:"operation,omitempty"`
+}
+
+type cassetteResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header"`
+	Body       string      `json:"body"`
+}
+
+var (
+	cassettesM sync.Mutex
+	cassettes  = map[string]*cassette{}
+)
+
+// openCassette returns the cassette stored at path. In record mode, a cassette
+// opened for the first time by the process starts empty, replacing an earlier
+// recording. In replay mode, the cassette must exist.
+func openCassette(path, mode string) (*cassette, error) {
+	if mode != cassetteModeRecord && mode != cassetteModeReplay {
+		return nil, fmt.Errorf("unknown cassette mode %q, must be %q or %q", mode, cassetteModeRecord, cassetteModeReplay)
+	}
+
+	cassettesM.Lock()
+	defer cassettesM.Unlock()
+
+	if c, ok := cassettes[path]; ok && c.mode == mode {
+		return c, nil
+	}
+
+	c := &cassette{path: path, mode: mode, served: map[string]int{}}
+	if mode == cassetteModeReplay {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("error reading cassette: %w", err)
+		}
+		if err := json.Unmarshal(data, c); err != nil {
+			return nil, fmt.Errorf("error decoding cassette %s: %w", path, err)
+		}
+	}
+
+	cassettes[path] = c
+	return c, nil
+}
+
+// newCassetteRequest describes a request the way it is matched on replay: by
+// method, path, query and, for GraphQL requests, operation.
+func newCassetteRequest(req *http.Request) (cassetteRequest, error) {
+	r := cassetteRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  req.URL.Query().Encode(),
+	}
+
+	if req.Method == http.MethodPost && strings.HasSuffix(req.URL.Path, "/graphql") && req.GetBody != nil {
+		body, err := req.GetBody()
+		if err != nil {
+			return r, err
+		}
+		defer body.Close()
+
+		var operation struct {
+			Query     string                 `json:"query"`
+			Variables map[string]interface{} `json:"variables"`
+		}
+		if err := json.NewDecoder(body).Decode(&operation); err != nil {
+			return r, fmt.Errorf("error decoding GraphQL request: %w", err)
+		}
+		// Re-encoding the variables sorts them, making them comparable
+		variables, err := json.Marshal(operation.Variables)
+		if err != nil {
+			return r, err
+		}
+		r.Operation = strings.Join(strings.Fields(operation.Query), " ") + " " + string(variables)
+	}
+
+	return r, nil
+}
+
+func (r cassetteRequest) key() string {
+	return fmt.Sprintf("%s %s?%s %s", r.Method, r.Path, r.Query, r.Operation)
+}
+
+func (c *cassette) record(req cassetteRequest, resp *http.Response) error {
+	r1, r2, err := drainBody(resp.Body)
+	if err != nil {
+		return err
+	}
+	resp.Body = r2
+
+	body, err := io.ReadAll(r1)
+	if err != nil {
+		return err
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	c.Interactions = append(c.Interactions, &cassetteInteraction{
+		Request: req,
+		Response: cassetteResponse{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header.Clone(),
+			Body:       string(body),
+		},
+	})
+
+	return c.save()
+}
+
+// save writes the cassette, it is called with the lock held after every
+// recorded interaction so that a failing test still leaves a usable cassette.
+func (c *cassette) save() error {
+	data, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+
+	tmp := c.path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// replay returns the next recorded response for the request. Requests made
+// more often than they were recorded, such as when polling, are answered with
+// the last recorded response.
+func (c *cassette) replay(req *http.Request, r cassetteRequest) (*http.Response, error) {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	key := r.key()
+
+	var matches []*cassetteInteraction
+	for _, i := range c.Interactions {
+		if i.Request.key() == key {
+			matches = append(matches, i)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("no interaction recorded in cassette %s for %s %s", c.path, r.Method, req.URL)
+	}
+
+	n := c.served[key]
+	if n >= len(matches) {
+		n = len(matches) - 1
+	}
+	c.served[key]++
+
+	recorded := matches[n].Response
+	header := recorded.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	header.Set("Content-Length", strconv.Itoa(len(recorded.Body)))
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", recorded.StatusCode, http.StatusText(recorded.StatusCode)),
+		StatusCode:    recorded.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader([]byte(recorded.Body))),
+		ContentLength: int64(len(recorded.Body)),
+		Request:       req,
+	}, nil
+}
+
+// recorderTransport records the requests made to GitHub into a cassette, or
+// serves them from a cassette without making any request. It is meant to run
+// acceptance tests hermetically. Requests are recorded before authentication
+// is added to them, so cassettes contain no credentials.
+type recorderTransport struct {
+	transport http.RoundTripper
+	cassette  *cassette
+}
+
+// NewRecorderTransport takes in an http.RoundTripper and records the requests
+// made through it into the given cassette, or replays them from it.
+func NewRecorderTransport(rt http.RoundTripper, c *cassette) *recorderTransport {
+	return &recorderTransport{transport: rt, cassette: c}
+}
+
+func (t *recorderTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	r, err := newCassetteRequest(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if t.cassette.mode == cassetteModeReplay {
+		log.Printf("[TRACE] Replaying %s %s from cassette", req.Method, req.URL)
+		return t.cassette.replay(req, r)
+	}
+
+	resp, err := t.transport.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	if err := t.cassette.record(r, resp); err != nil {
+		log.Printf("[WARN] Error recording %s %s into cassette %s: %s", req.Method, req.URL, t.cassette.path, err)
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,136 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/shurcooL/githubv4"
+)
+
+func TestRecorderTransport(t *testing.T) {
+
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:  "/repos/test/blah",
+			ResponseBody: `{"id": 1234, "name": "blah"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:  "/repos/test/blah",
+			ResponseBody: `{"id": 1234, "name": "renamed"}`,
+			StatusCode:   200,
+		},
+		{
+			ExpectedUri:    "/graphql",
+			ExpectedMethod: "POST",
+			ResponseBody:   `{"data": {"repository": {"id": "R_1"}}}`,
+			StatusCode:     200,
+		},
+		{
+			ExpectedUri:  "/repos/test/missing",
+			ResponseBody: `{"message": "Not Found"}`,
+			StatusCode:   404,
+		},
+	})
+	defer ts.Close()
+
+	var query struct {
+		Repository struct {
+			ID githubv4.ID
+		} `graphql:"repository(owner:$owner, name:$name)"`
+	}
+	variables := map[string]interface{}{
+		"owner": githubv4.String("test"),
+		"name":  githubv4.String("blah"),
+	}
+
+	t.Run("records interactions", func(t *testing.T) {
+		// The mock expects the repository to be read only twice, the third
+		// read is served by the recorder in replay mode only.
+		c, err := openCassette(path, cassetteModeRecord)
+		if err != nil {
+			t.Fatal(err)
+		}
+		httpClient := &http.Client{Transport: NewRecorderTransport(http.DefaultTransport, c)}
+		client := github.NewClient(httpClient)
+		u, _ := url.Parse(ts.URL + "/")
+		client.BaseURL = u
+		v4client := githubv4.NewEnterpriseClient(ts.URL+"/graphql", httpClient)
+
+		for i := 0; i < 2; i++ {
+			if _, _, err := client.Repositories.Get(context.Background(), "test", "blah"); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := v4client.Query(context.Background(), &query, variables); err != nil {
+			t.Fatal(err)
+		}
+		_, _, _ = client.Repositories.Get(context.Background(), "test", "missing")
+
+		if len(c.Interactions) != 4 {
+			t.Fatalf("Expected 4 recorded interactions, got %d", len(c.Interactions))
+		}
+	})
+
+	t.Run("replays interactions without network access", func(t *testing.T) {
+		ts.Close()
+
+		c, err := openCassette(path, cassetteModeReplay)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		httpClient := &http.Client{Transport: NewRecorderTransport(http.DefaultTransport, c)}
+		client := github.NewClient(httpClient)
+		u, _ := url.Parse(ts.URL + "/")
+		client.BaseURL = u
+		v4client := githubv4.NewEnterpriseClient(ts.URL+"/graphql", httpClient)
+
+		for _, name := range []string{"blah", "renamed", "renamed"} {
+			repo, _, err := client.Repositories.Get(context.Background(), "test", "blah")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if repo.GetName() != name {
+				t.Fatalf("Expected repository %q, got %q", name, repo.GetName())
+			}
+		}
+
+		if err := v4client.Query(context.Background(), &query, variables); err != nil {
+			t.Fatal(err)
+		}
+		if query.Repository.ID != "R_1" {
+			t.Fatalf("Unexpected GraphQL response: %v", query.Repository.ID)
+		}
+
+		_, resp, err := client.Repositories.Get(context.Background(), "test", "missing")
+		if err == nil || resp.StatusCode != 404 {
+			t.Fatalf("Expected a 404 response, got: %v", err)
+		}
+	})
+
+	t.Run("fails for requests which were not recorded", func(t *testing.T) {
+		c, err := openCassette(path, cassetteModeReplay)
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := github.NewClient(&http.Client{Transport: NewRecorderTransport(http.DefaultTransport, c)})
+		u, _ := url.Parse(ts.URL + "/")
+		client.BaseURL = u
+
+		if _, _, err := client.Repositories.Get(context.Background(), "test", "other"); err == nil {
+			t.Fatal("Expected an error for a request missing from the cassette")
+		}
+		variables["name"] = githubv4.String("other")
+		v4client := githubv4.NewEnterpriseClient(ts.URL+"/graphql", &http.Client{Transport: NewRecorderTransport(http.DefaultTransport, c)})
+		if err := v4client.Query(context.Background(), &query, variables); err == nil {
+			t.Fatal("Expected an error for a GraphQL operation missing from the cassette")
+		}
+	})
+}
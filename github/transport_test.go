@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -12,6 +13,7 @@ import (
 	"time"
 
 	"github.com/google/go-github/v66/github"
+	"golang.org/x/oauth2"
 )
 
 func TestEtagTransport(t *testing.T) {
@@ -46,6 +48,64 @@ func TestEtagTransport(t *testing.T) {
 	}
 }
 
+func TestEtagTransport_cache(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:  "/repos/test/blah",
+			ResponseBody: `{"id": 1234}`,
+			StatusCode:   200,
+			ResponseHeaders: map[string]string{
+				"ETag": `"abc"`,
+			},
+		},
+		{
+			ExpectedUri: "/repos/test/blah",
+			ExpectedHeaders: map[string]string{
+				"If-None-Match": `"abc"`,
+			},
+			StatusCode: 304,
+			ResponseHeaders: map[string]string{
+				"X-RateLimit-Remaining": "4999",
+			},
+		},
+		{
+			// Responses are not shared between identities
+			ExpectedUri: "/repos/test/blah",
+			ExpectedHeaders: map[string]string{
+				"If-None-Match": "",
+			},
+			ResponseBody: `{"id": 1234}`,
+			StatusCode:   200,
+		},
+	})
+	defer ts.Close()
+
+	dir := t.TempDir()
+	newClient := func(identity string) *github.Client {
+		cache, err := newEtagCache(dir, cacheIdentity(identity))
+		if err != nil {
+			t.Fatal(err)
+		}
+		client := github.NewClient(&http.Client{Transport: NewEtagTransport(http.DefaultTransport, WithEtagCache(cache))})
+		u, _ := url.Parse(ts.URL + "/")
+		client.BaseURL = u
+		return client
+	}
+
+	for i, identity := range []string{"first", "first", "second"} {
+		r, resp, err := newClient(identity).Repositories.Get(context.Background(), "test", "blah")
+		if err != nil {
+			t.Fatalf("request %d: %s", i, err)
+		}
+		if r.GetID() != 1234 {
+			t.Fatalf("request %d: expected ID to be 1234, got: %d", i, r.GetID())
+		}
+		if i == 1 && resp.Rate.Remaining != 4999 {
+			t.Fatalf("expected the rate limit of the 304 response, got: %d", resp.Rate.Remaining)
+		}
+	}
+}
+
 func githubApiMock(responseSequence []*mockResponse) *httptest.Server {
 	position := github.Int(0)
 	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -461,6 +521,181 @@ func TestRetryTransport_retry_post_success(t *testing.T) {
 	}
 }
 
+func TestRateLimitTransport_pacing(t *testing.T) {
+	reset := time.Now().Add(10 * time.Minute)
+
+	budgetResponse := func(remaining int) *mockResponse {
+		return &mockResponse{
+			ExpectedUri:  "/repos/test/blah",
+			ResponseBody: `{"id": 1234}`,
+			StatusCode:   200,
+			ResponseHeaders: map[string]string{
+				"X-RateLimit-Limit":     "5000",
+				"X-RateLimit-Remaining": fmt.Sprint(remaining),
+				"X-RateLimit-Reset":     fmt.Sprint(reset.Unix()),
+				"X-RateLimit-Resource":  "core",
+			},
+		}
+	}
+
+	t.Run("tracks the budget reported by GitHub", func(t *testing.T) {
+		ts := githubApiMock([]*mockResponse{budgetResponse(4000), budgetResponse(3990)})
+		defer ts.Close()
+
+		rlt := NewRateLimitTransport(http.DefaultTransport, WithWriteDelay(0), WithRateLimitPacing(true, 50))
+		client := github.NewClient(&http.Client{Transport: rlt})
+		u, _ := url.Parse(ts.URL + "/")
+		client.BaseURL = u
+
+		ctx := context.WithValue(context.Background(), ctxResourceType, "github_repository")
+		for i := 0; i < 2; i++ {
+			if _, _, err := client.Repositories.Get(ctx, "test", "blah"); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		budget := rlt.budgets["core"]
+		if budget == nil {
+			t.Fatal("Expected the core budget to be tracked")
+		}
+		if budget.remaining != 3990 || budget.limit != 5000 || budget.consumed != 10 {
+			t.Fatalf("Unexpected budget: %+v", budget)
+		}
+		if budget.consumedBy["github_repository"] != 10 {
+			t.Fatalf("Expected the consumption to be attributed to the resource type, got: %v", budget.consumedBy)
+		}
+		if delay := rlt.calculatePacingDelay("core"); delay != 0 {
+			t.Fatalf("Expected no pacing above the threshold, got: %s", delay)
+		}
+	})
+
+	t.Run("spreads the remaining budget until the reset", func(t *testing.T) {
+		rlt := NewRateLimitTransport(http.DefaultTransport, WithRateLimitPacing(true, 50))
+		rlt.budgets = map[string]*rateLimitBudget{
+			"graphql": {limit: 5000, remaining: 99, reset: reset},
+		}
+
+		delay := rlt.calculatePacingDelay("graphql")
+		if delay < 5*time.Second || delay > 6*time.Second {
+			t.Fatalf("Expected a delay of about 6s, got: %s", delay)
+		}
+		if delay := rlt.calculatePacingDelay("core"); delay != 0 {
+			t.Fatalf("Expected no pacing for an unknown budget, got: %s", delay)
+		}
+	})
+
+	t.Run("does not pace when disabled", func(t *testing.T) {
+		rlt := NewRateLimitTransport(http.DefaultTransport)
+		rlt.budgets = map[string]*rateLimitBudget{
+			"core": {limit: 5000, remaining: 1, reset: reset},
+		}
+
+		if delay := rlt.calculatePacingDelay("core"); delay != 0 {
+			t.Fatalf("Expected no pacing, got: %s", delay)
+		}
+	})
+}
+
+func TestRateLimitTransport_cancellation(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri: "/repos/test/blah",
+			ResponseBody: `{
+  "message": "You have triggered an abuse detection mechanism and have been temporarily blocked from content creation. Please retry your request again later.",
+  "documentation_url": "https://developer.github.com/v3/#abuse-rate-limits"
+}`,
+			StatusCode: 403,
+			ResponseHeaders: map[string]string{
+				"Retry-After": "3600",
+			},
+		},
+	})
+	defer ts.Close()
+
+	rlt := NewRateLimitTransport(http.DefaultTransport)
+	client := github.NewClient(&http.Client{Transport: rlt})
+	u, _ := url.Parse(ts.URL + "/")
+	client.BaseURL = u
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	_, _, err := client.Repositories.Get(ctx, "test", "blah")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the request to be cancelled, got: %v", err)
+	}
+
+	// The transport must remain usable after a cancelled request.
+	done := make(chan struct{})
+	go func() {
+		rlt.smartLock(true)
+		rlt.smartLock(false)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected the transport to be unlocked after cancellation")
+	}
+}
+
+func TestTokenRefreshTransport(t *testing.T) {
+	accessTokenURI := fmt.Sprintf("/api/v3/app/installations/%s/access_tokens", testGitHubAppInstallationID)
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:  accessTokenURI,
+			ResponseBody: `{"token": "expired"}`,
+			StatusCode:   201,
+		},
+		{
+			ExpectedUri:    "/orgs/tada/repos",
+			ExpectedMethod: "POST",
+			ExpectedHeaders: map[string]string{
+				"Authorization": "Bearer expired",
+			},
+			ResponseBody: `{"message": "Bad credentials"}`,
+			StatusCode:   401,
+		},
+		{
+			ExpectedUri:  accessTokenURI,
+			ResponseBody: `{"token": "fresh"}`,
+			StatusCode:   201,
+		},
+		{
+			ExpectedUri:    "/orgs/tada/repos",
+			ExpectedMethod: "POST",
+			ExpectedHeaders: map[string]string{
+				"Authorization": "Bearer fresh",
+			},
+			ExpectedBody: []byte(`{"name":"radek-example-48"}
+`),
+			ResponseBody: `{"id": 1234}`,
+			StatusCode:   200,
+		},
+	})
+	defer ts.Close()
+
+	source := NewAppInstallationTokenSource(nil, ts.URL+"/", testGitHubAppID, testGitHubAppInstallationID, string(testGitHubAppPrivateKeyPemData))
+	httpClient := &http.Client{
+		Transport: NewTokenRefreshTransport(&oauth2.Transport{Source: source}, source),
+	}
+
+	client := github.NewClient(httpClient)
+	u, _ := url.Parse(ts.URL + "/")
+	client.BaseURL = u
+
+	r, _, err := client.Repositories.Create(context.Background(), "tada", &github.Repository{
+		Name: github.String("radek-example-48"),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if r.GetID() != 1234 {
+		t.Fatalf("Expected ID to be 1234, got: %d", r.GetID())
+	}
+}
+
 type mockResponse struct {
 	ExpectedUri     string
 	ExpectedMethod  string
@@ -53,6 +53,27 @@ func wrapErrors(errs []error) diag.Diagnostics {
 	return diags
 }
 
+// errorFromDiagnostics is provided to turn the diagnostics returned by CRUD
+// functions back into an error, for callers like importers which return one
+func errorFromDiagnostics(diags diag.Diagnostics) error {
+	var errs []string
+	for _, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		if d.Detail != "" {
+			errs = append(errs, fmt.Sprintf("%s: %s", d.Summary, d.Detail))
+		} else {
+			errs = append(errs, d.Summary)
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(errs, "; "))
+}
+
 // toDiagFunc is a helper that operates on Hashicorp's helper/validation functions
 // and converts them to the diag.Diagnostic format
 // --> nolint: oldFunc needs to be schema.SchemaValidateFunc to keep compatibility with
@@ -151,6 +172,16 @@ func expandStringList(configured []interface{}) []string {
 	return vs
 }
 
+// expandInt64Set returns the values of a set of integers, such as the IDs of
+// repositories.
+func expandInt64Set(set *schema.Set) []int64 {
+	values := make([]int64, 0, set.Len())
+	for _, v := range set.List() {
+		values = append(values, int64(v.(int)))
+	}
+	return values
+}
+
 func flattenStringList(v []string) []interface{} {
 	c := make([]interface{}, 0, len(v))
 	for _, s := range v {
@@ -191,10 +222,9 @@ func splitRepoFilePath(path string) (string, string) {
 	return parts[0], strings.Join(parts[1:], "/")
 }
 
-func getTeamID(teamIDString string, meta interface{}) (int64, error) {
+func getTeamID(ctx context.Context, teamIDString string, meta interface{}) (int64, error) {
 	// Given a string that is either a team id or team slug, return the
 	// id of the team it is referring to.
-	ctx := context.Background()
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 
@@ -204,17 +234,28 @@ func getTeamID(teamIDString string, meta interface{}) (int64, error) {
 	}
 
 	// The given id not an integer, assume it is a team slug
-	team, _, slugErr := client.Teams.GetTeamBySlug(ctx, orgName, teamIDString)
-	if slugErr != nil {
-		return -1, errors.New(parseIntErr.Error() + slugErr.Error())
-	}
-	return team.GetID(), nil
+	id, err := meta.(*Owner).cache.get(teamCacheObject(orgName, teamIDString), "team-id", func() (interface{}, error) {
+		team, _, slugErr := client.Teams.GetTeamBySlug(ctx, orgName, teamIDString)
+		if slugErr != nil {
+			return int64(-1), errors.New(parseIntErr.Error() + slugErr.Error())
+		}
+		return team.GetID(), nil
+	})
+	return id.(int64), err
 }
 
-func getTeamSlug(teamIDString string, meta interface{}) (string, error) {
+func getTeamSlug(ctx context.Context, teamIDString string, meta interface{}) (string, error) {
 	// Given a string that is either a team id or team slug, return the
 	// team slug it is referring to.
-	ctx := context.Background()
+	orgName := meta.(*Owner).name
+
+	slug, err := meta.(*Owner).cache.get(teamCacheObject(orgName, teamIDString), "team-slug", func() (interface{}, error) {
+		return lookupTeamSlug(ctx, teamIDString, meta)
+	})
+	return slug.(string), err
+}
+
+func lookupTeamSlug(ctx context.Context, teamIDString string, meta interface{}) (string, error) {
 	client := meta.(*Owner).v3client
 	orgName := meta.(*Owner).name
 	orgId := meta.(*Owner).id
@@ -0,0 +1,112 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// actionsRunner is a self-hosted runner with the fields go-github does not
+// know about.
+type actionsRunner struct {
+	github.Runner
+	RunnerGroupID *int64 `json:"runner_group_id,omitempty"`
+	Ephemeral     *bool  `json:"ephemeral,omitempty"`
+}
+
+func (r *actionsRunner) GetRunnerGroupID() int64 {
+	if r == nil || r.RunnerGroupID == nil {
+		return 0
+	}
+	return *r.RunnerGroupID
+}
+
+func (r *actionsRunner) GetEphemeral() bool {
+	if r == nil || r.Ephemeral == nil {
+		return false
+	}
+	return *r.Ephemeral
+}
+
+// actionsRunnersPath returns the path of the runners of the repository named
+// by the repository argument of the resource, or of the organization of the
+// provider if it is not set.
+func actionsRunnersPath(d *schema.ResourceData, meta interface{}) (string, error) {
+	owner := meta.(*Owner).name
+	if repoName, ok := d.GetOk("repository"); ok {
+		return fmt.Sprintf("repos/%v/%v/actions/runners", owner, repoName), nil
+	}
+	if err := checkOrganization(meta); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("orgs/%v/actions/runners", owner), nil
+}
+
+func getActionsRunner(ctx context.Context, client *github.Client, path string, id int64) (*actionsRunner, error) {
+	req, err := client.NewRequest("GET", fmt.Sprintf("%v/%v", path, id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	runner := &actionsRunner{}
+	if _, err := client.Do(ctx, req, runner); err != nil {
+		return nil, err
+	}
+	return runner, nil
+}
+
+func listActionsRunners(ctx context.Context, client *github.Client, path string) ([]*actionsRunner, error) {
+	var runners []*actionsRunner
+	options := &github.ListOptions{PerPage: maxPerPage}
+	for {
+		req, err := client.NewRequest("GET", fmt.Sprintf("%v?per_page=%v&page=%v", path, options.PerPage, options.Page), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Runners []*actionsRunner `json:"runners"`
+		}
+		resp, err := client.Do(ctx, req, &page)
+		if err != nil {
+			return nil, err
+		}
+		runners = append(runners, page.Runners...)
+
+		if resp.NextPage == 0 {
+			break
+		}
+		options.Page = resp.NextPage
+	}
+	return runners, nil
+}
+
+// setActionsRunnerLabels replaces the custom labels of a runner, an empty
+// list of labels removes them all.
+func setActionsRunnerLabels(ctx context.Context, client *github.Client, path string, id int64, labels []string) error {
+	method, body := "PUT", interface{}(map[string]interface{}{"labels": labels})
+	if len(labels) == 0 {
+		method, body = "DELETE", nil
+	}
+	req, err := client.NewRequest(method, fmt.Sprintf("%v/%v/labels", path, id), body)
+	if err != nil {
+		return err
+	}
+
+	_, err = client.Do(ctx, req, nil)
+	return err
+}
+
+// customActionsRunnerLabels returns the names of the labels of a runner which
+// were added to it, leaving out the ones GitHub gives it from its platform.
+func customActionsRunnerLabels(labels []*github.RunnerLabels) []string {
+	names := []string{}
+	for _, label := range labels {
+		if label.GetType() == "custom" {
+			names = append(names, label.GetName())
+		}
+	}
+	return names
+}
@@ -0,0 +1,129 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// lookupCache memoizes lookups that many resources repeat for the same GitHub
+// objects during a single plan or apply, such as resolving a team slug to its
+// ID or fetching a repository's public key. It is shared by all resources of a
+// provider instance through Owner and is safe for concurrent use: concurrent
+// lookups of the same object wait for a single request instead of each making
+// their own. Failed lookups are not cached.
+//
+// Entries are grouped by the object they describe, so that a write to an
+// object can invalidate every cached lookup about it.
+type lookupCache struct {
+	m       sync.Mutex
+	objects map[string]map[string]*lookupCacheEntry
+}
+
+type lookupCacheEntry struct {
+	done  chan struct{}
+	value interface{}
+	err   error
+}
+
+func newLookupCache() *lookupCache {
+	return &lookupCache{objects: make(map[string]map[string]*lookupCacheEntry)}
+}
+
+// get returns the cached result of the lookup of the given kind for object,
+// calling fetch to populate it when it is not cached yet. A nil cache calls
+// fetch every time.
+func (c *lookupCache) get(object, kind string, fetch func() (interface{}, error)) (interface{}, error) {
+	if c == nil {
+		return fetch()
+	}
+
+	object = strings.ToLower(object)
+
+	c.m.Lock()
+	kinds, ok := c.objects[object]
+	if !ok {
+		kinds = make(map[string]*lookupCacheEntry)
+		c.objects[object] = kinds
+	}
+	if entry, ok := kinds[kind]; ok {
+		c.m.Unlock()
+		<-entry.done
+		if entry.err == nil {
+			log.Printf("[TRACE] Using cached %s lookup for %s", kind, object)
+		}
+		return entry.value, entry.err
+	}
+	entry := &lookupCacheEntry{done: make(chan struct{})}
+	kinds[kind] = entry
+	c.m.Unlock()
+
+	entry.value, entry.err = fetch()
+	close(entry.done)
+
+	if entry.err != nil {
+		c.m.Lock()
+		if c.objects[object][kind] == entry {
+			delete(c.objects[object], kind)
+		}
+		c.m.Unlock()
+	}
+
+	return entry.value, entry.err
+}
+
+// invalidate drops every cached lookup for the given objects, it is called
+// after writes that may change the result of those lookups.
+func (c *lookupCache) invalidate(objects ...string) {
+	if c == nil {
+		return
+	}
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	for _, object := range objects {
+		object = strings.ToLower(object)
+		if _, ok := c.objects[object]; ok {
+			log.Printf("[TRACE] Invalidating cached lookups for %s", object)
+			delete(c.objects, object)
+		}
+	}
+}
+
+// repositoryCacheObject identifies a repository in the lookup cache.
+func repositoryCacheObject(owner, name string) string {
+	return fmt.Sprintf("repository/%s/%s", owner, name)
+}
+
+// teamCacheObject identifies a team, by ID or by slug, in the lookup cache.
+func teamCacheObject(org, idOrSlug string) string {
+	return fmt.Sprintf("team/%s/%s", org, idOrSlug)
+}
+
+// userCacheObject identifies a user in the lookup cache.
+func userCacheObject(login string) string {
+	return fmt.Sprintf("user/%s", login)
+}
+
+// getRepository returns the repository, fetching it only once per provider
+// instance until a write to it invalidates the cache. Resources which read the
+// repository to detect drift in its own attributes should query the API
+// directly instead.
+func getRepository(ctx context.Context, owner, name string, meta interface{}) (*github.Repository, error) {
+	client := meta.(*Owner).v3client
+
+	repo, err := meta.(*Owner).cache.get(repositoryCacheObject(owner, name), "repository", func() (interface{}, error) {
+		repo, _, err := client.Repositories.Get(ctx, owner, name)
+		return repo, err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return repo.(*github.Repository), nil
+}
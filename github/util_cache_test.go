@@ -0,0 +1,140 @@
+package github
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestLookupCache(t *testing.T) {
+
+	t.Run("memoizes lookups of the same object", func(t *testing.T) {
+		cache := newLookupCache()
+		var calls int32
+		fetch := func() (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return "value", nil
+		}
+
+		for i := 0; i < 3; i++ {
+			v, err := cache.get(teamCacheObject("org", "Team"), "team-id", fetch)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if v.(string) != "value" {
+				t.Fatalf("unexpected value: %v", v)
+			}
+		}
+
+		// Object names are case insensitive
+		if _, err := cache.get(teamCacheObject("org", "team"), "team-id", fetch); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+
+		if calls != 1 {
+			t.Fatalf("expected a single lookup, got %d", calls)
+		}
+	})
+
+	t.Run("keeps lookups of different kinds apart", func(t *testing.T) {
+		cache := newLookupCache()
+
+		id, _ := cache.get(teamCacheObject("org", "team"), "team-id", func() (interface{}, error) { return int64(1), nil })
+		slug, _ := cache.get(teamCacheObject("org", "team"), "team-slug", func() (interface{}, error) { return "team", nil })
+
+		if id.(int64) != 1 || slug.(string) != "team" {
+			t.Fatalf("unexpected values: %v, %v", id, slug)
+		}
+	})
+
+	t.Run("shares a single lookup between concurrent callers", func(t *testing.T) {
+		cache := newLookupCache()
+		var calls int32
+		release := make(chan struct{})
+		fetch := func() (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			<-release
+			return "value", nil
+		}
+
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				if _, err := cache.get(repositoryCacheObject("owner", "repo"), "repository", fetch); err != nil {
+					t.Errorf("unexpected error: %s", err)
+				}
+			}()
+		}
+		close(release)
+		wg.Wait()
+
+		if calls != 1 {
+			t.Fatalf("expected a single lookup, got %d", calls)
+		}
+	})
+
+	t.Run("does not cache failed lookups", func(t *testing.T) {
+		cache := newLookupCache()
+		var calls int32
+		fetch := func() (interface{}, error) {
+			if atomic.AddInt32(&calls, 1) == 1 {
+				return "", errors.New("lookup failed")
+			}
+			return "value", nil
+		}
+
+		if _, err := cache.get(userCacheObject("user"), "node-id", fetch); err == nil {
+			t.Fatal("expected the first lookup to fail")
+		}
+		v, err := cache.get(userCacheObject("user"), "node-id", fetch)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if v.(string) != "value" || calls != 2 {
+			t.Fatalf("expected the lookup to be retried, got %v after %d calls", v, calls)
+		}
+	})
+
+	t.Run("invalidates every lookup of an object", func(t *testing.T) {
+		cache := newLookupCache()
+		var calls int32
+		fetch := func() (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return "value", nil
+		}
+
+		_, _ = cache.get(repositoryCacheObject("owner", "repo"), "repository", fetch)
+		_, _ = cache.get(repositoryCacheObject("owner", "repo"), "actions-public-key", fetch)
+		_, _ = cache.get(repositoryCacheObject("owner", "other"), "repository", fetch)
+
+		cache.invalidate(repositoryCacheObject("owner", "REPO"))
+
+		_, _ = cache.get(repositoryCacheObject("owner", "repo"), "repository", fetch)
+		_, _ = cache.get(repositoryCacheObject("owner", "repo"), "actions-public-key", fetch)
+		_, _ = cache.get(repositoryCacheObject("owner", "other"), "repository", fetch)
+
+		if calls != 5 {
+			t.Fatalf("expected 5 lookups, got %d", calls)
+		}
+	})
+
+	t.Run("always looks up without a cache", func(t *testing.T) {
+		var cache *lookupCache
+		var calls int32
+		fetch := func() (interface{}, error) {
+			atomic.AddInt32(&calls, 1)
+			return "value", nil
+		}
+
+		_, _ = cache.get(userCacheObject("user"), "node-id", fetch)
+		_, _ = cache.get(userCacheObject("user"), "node-id", fetch)
+		cache.invalidate(userCacheObject("user"))
+
+		if calls != 2 {
+			t.Fatalf("expected 2 lookups, got %d", calls)
+		}
+	})
+}
@@ -0,0 +1,375 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+const ctxAPIErrors = ctxAPIErrorsType("apiErrors")
+
+// ctxAPIErrorsType is used to avoid collisions between packages using context
+type ctxAPIErrorsType string
+
+// impliedScopes lists the OAuth scopes granted along with a broader scope, see
+// https://docs.github.com/en/apps/oauth-apps/building-oauth-apps/scopes-for-oauth-apps
+var impliedScopes = map[string][]string{
+	"repo":             {"repo:status", "repo_deployment", "public_repo", "repo:invite", "security_events"},
+	"admin:org":        {"write:org", "read:org", "manage_runners:org"},
+	"write:org":        {"read:org"},
+	"admin:public_key": {"write:public_key", "read:public_key"},
+	"write:public_key": {"read:public_key"},
+	"admin:repo_hook":  {"write:repo_hook", "read:repo_hook"},
+	"write:repo_hook":  {"read:repo_hook"},
+	"user":             {"read:user", "user:email", "user:follow"},
+	"write:packages":   {"read:packages"},
+	"admin:gpg_key":    {"write:gpg_key", "read:gpg_key"},
+	"write:gpg_key":    {"read:gpg_key"},
+	"project":          {"read:project"},
+	"admin:enterprise": {"manage_runners:enterprise", "manage_billing:enterprise", "read:enterprise"},
+}
+
+// apiError holds a failed response of the GitHub API along with the headers
+// explaining why it failed.
+type apiError struct {
+	method        string
+	url           string
+	status        int
+	message       string
+	isGraphQL     bool
+	graphQLErrors []apiGraphQLError
+
+	acceptedPermissions string
+	acceptedScopes      string
+	grantedScopes       string
+	classicToken        bool
+	sso                 string
+	rateRemaining       string
+	rateReset           string
+}
+
+type apiGraphQLError struct {
+	errorType string
+	message   string
+	path      string
+}
+
+// apiErrors collects the failed responses of the requests made by a CRUD
+// function, see apiErrorTransport.
+type apiErrors struct {
+	m      sync.Mutex
+	errors []*apiError
+}
+
+// withAPIErrors returns a context recording the failed responses of the
+// requests made with it.
+func withAPIErrors(ctx context.Context) (context.Context, *apiErrors) {
+	errs := &apiErrors{}
+	return context.WithValue(ctx, ctxAPIErrors, errs), errs
+}
+
+func (errs *apiErrors) record(e *apiError) {
+	errs.m.Lock()
+	defer errs.m.Unlock()
+
+	errs.errors = append(errs.errors, e)
+}
+
+// find returns the most recent failed response the error message stems from.
+func (errs *apiErrors) find(message string) *apiError {
+	errs.m.Lock()
+	defer errs.m.Unlock()
+
+	for i := len(errs.errors) - 1; i >= 0; i-- {
+		if errs.errors[i].matches(message) {
+			return errs.errors[i]
+		}
+	}
+	return nil
+}
+
+// translate replaces the error diagnostics caused by failed responses with
+// ones naming the resource and explaining how the failure can be fixed.
+func (errs *apiErrors) translate(diags diag.Diagnostics, resource, id string) diag.Diagnostics {
+	subject := resource
+	if id != "" {
+		subject = fmt.Sprintf("%s (%s)", resource, id)
+	}
+
+	for i, d := range diags {
+		if d.Severity != diag.Error {
+			continue
+		}
+		e := errs.find(d.Summary + "\n" + d.Detail)
+		if e == nil {
+			continue
+		}
+
+		detail := d.Summary
+		if d.Detail != "" {
+			detail += "\n\n" + d.Detail
+		}
+		for _, hint := range e.hints() {
+			detail += "\n\n" + hint
+		}
+
+		diags[i] = diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       fmt.Sprintf("GitHub API error on %s: %s", subject, e.describe()),
+			Detail:        detail,
+			AttributePath: d.AttributePath,
+		}
+	}
+
+	return diags
+}
+
+// translateError appends the hints for fixing a failed response to err, for
+// callers like importers which return errors rather than diagnostics.
+func (errs *apiErrors) translateError(err error) error {
+	if err == nil {
+		return nil
+	}
+	e := errs.find(err.Error())
+	if e == nil {
+		return err
+	}
+	hints := e.hints()
+	if len(hints) == 0 {
+		return err
+	}
+	return fmt.Errorf("%w\n\n%s", err, strings.Join(hints, "\n\n"))
+}
+
+// matches reports whether an error message stems from the response. go-github
+// errors start with the method, URL and status of the request, while GraphQL
+// errors carry the message of the first error of the response.
+func (e *apiError) matches(message string) bool {
+	if e.isGraphQL {
+		if len(e.graphQLErrors) > 0 {
+			return strings.Contains(message, e.graphQLErrors[0].message)
+		}
+		return strings.Contains(message, fmt.Sprintf("non-200 OK status code: %d", e.status))
+	}
+	return strings.Contains(message, fmt.Sprintf("%s %s: %d", e.method, e.url, e.status))
+}
+
+// describe summarizes the failure, e.g. "403 Resource not accessible by integration".
+func (e *apiError) describe() string {
+	if len(e.graphQLErrors) > 0 {
+		gqlErr := e.graphQLErrors[0]
+		description := gqlErr.message
+		if gqlErr.errorType != "" {
+			description = gqlErr.errorType + " " + description
+		}
+		if gqlErr.path != "" {
+			description += fmt.Sprintf(" (at %s)", gqlErr.path)
+		}
+		return description
+	}
+
+	description := fmt.Sprintf("%d %s", e.status, http.StatusText(e.status))
+	if e.message != "" {
+		description = fmt.Sprintf("%d %s", e.status, e.message)
+	}
+	return description
+}
+
+// hints returns remediation hints for the failure, derived from its status,
+// message, error types and headers.
+func (e *apiError) hints() []string {
+	var hints []string
+
+	if url := ssoAuthorizationURL(e.sso); url != "" {
+		hints = append(hints, fmt.Sprintf("The organization enforces SAML single sign-on and the token has not been authorized for it. Authorize the token at: %s", url))
+	}
+
+	if e.rateRemaining == "0" && (e.status == http.StatusForbidden || e.status == http.StatusTooManyRequests) {
+		hint := "The rate limit of the token is exhausted."
+		if reset, err := strconv.ParseInt(e.rateReset, 10, 64); err == nil {
+			hint = fmt.Sprintf("The rate limit of the token is exhausted, it resets at %s.", time.Unix(reset, 0).UTC().Format(time.RFC3339))
+		}
+		hints = append(hints, hint)
+	}
+
+	// Permissions and scopes are only to blame when access was denied, the
+	// headers are sent along with every response
+	if !e.accessDenied() {
+		return hints
+	}
+
+	if permissions := formatAcceptedPermissions(e.acceptedPermissions); permissions != "" {
+		hints = append(hints, fmt.Sprintf("The request requires the %s permissions of the GitHub App or fine-grained personal access token. Check they are granted, and for a GitHub App that the installation accepted them.", permissions))
+	} else if strings.Contains(e.message, "Resource not accessible by integration") {
+		hints = append(hints, "The GitHub App installation lacks a permission needed for the request. Grant it in the settings of the App and accept the new permissions on the installation.")
+	} else if strings.Contains(e.message, "Resource not accessible by personal access token") {
+		hints = append(hints, "The fine-grained personal access token lacks a permission needed for the request, or access to the repository or organization.")
+	}
+
+	if missing := missingScopes(e.acceptedScopes, e.grantedScopes); e.classicToken && len(missing) > 0 {
+		granted := e.grantedScopes
+		if granted == "" {
+			granted = "none"
+		}
+		hints = append(hints, fmt.Sprintf("The token needs one of the OAuth scopes %s, but has only been granted: %s.", strings.Join(missing, ", "), granted))
+	}
+
+	if len(e.graphQLErrors) > 0 {
+		switch e.graphQLErrors[0].errorType {
+		case "INSUFFICIENT_SCOPES":
+			hints = append(hints, "The token has not been granted the OAuth scopes required by the query, grant it the scopes named by the error.")
+		case "FORBIDDEN":
+			hints = append(hints, "The token is not allowed to access the object, e.g. as the GitHub App lacks a permission or the organization enforces SAML single sign-on.")
+		case "NOT_FOUND":
+			hints = append(hints, "The object does not exist, or the token can not access it.")
+		}
+	}
+
+	switch e.status {
+	case http.StatusUnauthorized:
+		hints = append(hints, "The credentials were rejected. Check that the token is valid and has not expired, or that the GitHub App ID, installation ID and private key are correct.")
+	case http.StatusNotFound:
+		hints = append(hints, "GitHub answers with 404 Not Found when the token can not access a resource. Check that it exists, and that the token has access to it.")
+	case http.StatusForbidden:
+		if len(hints) == 0 {
+			hints = append(hints, "The authenticated user or GitHub App is not allowed to perform the request, e.g. as it requires organization owner or repository admin rights.")
+		}
+	}
+
+	return hints
+}
+
+// accessDenied reports whether the failure may be caused by missing permissions.
+func (e *apiError) accessDenied() bool {
+	if len(e.graphQLErrors) > 0 {
+		switch e.graphQLErrors[0].errorType {
+		case "INSUFFICIENT_SCOPES", "FORBIDDEN", "NOT_FOUND":
+			return true
+		}
+		return false
+	}
+	return e.status == http.StatusUnauthorized || e.status == http.StatusForbidden || e.status == http.StatusNotFound
+}
+
+// ssoAuthorizationURL returns the URL to authorize a token for an organization
+// enforcing SAML single sign-on, from a header like "required; url=https://...".
+func ssoAuthorizationURL(header string) string {
+	parts := strings.Split(header, ";")
+	if strings.TrimSpace(parts[0]) != "required" {
+		return ""
+	}
+	for _, part := range parts[1:] {
+		if url, ok := strings.CutPrefix(strings.TrimSpace(part), "url="); ok {
+			return url
+		}
+	}
+	return ""
+}
+
+// formatAcceptedPermissions formats the sets of permissions accepted for a
+// request, which are separated by semicolons, their permissions by commas,
+// e.g. "contents=read,pull_requests=write; issues=write".
+func formatAcceptedPermissions(header string) string {
+	var sets []string
+	for _, set := range strings.Split(header, ";") {
+		var permissions []string
+		for _, permission := range strings.Split(set, ",") {
+			name, access, ok := strings.Cut(strings.TrimSpace(permission), "=")
+			if !ok || name == "" {
+				continue
+			}
+			permissions = append(permissions, fmt.Sprintf("%q (%s)", name, access))
+		}
+		if len(permissions) > 0 {
+			sets = append(sets, strings.Join(permissions, " and "))
+		}
+	}
+	return strings.Join(sets, " or ")
+}
+
+// missingScopes returns the accepted OAuth scopes of a request when none of
+// them has been granted to the token, taking broader scopes into account.
+func missingScopes(accepted, granted string) []string {
+	grantedScopes := map[string]bool{}
+	for _, scope := range strings.Split(granted, ",") {
+		scope = strings.TrimSpace(scope)
+		grantedScopes[scope] = true
+		for _, implied := range impliedScopes[scope] {
+			grantedScopes[implied] = true
+		}
+	}
+
+	var missing []string
+	for _, scope := range strings.Split(accepted, ",") {
+		scope = strings.TrimSpace(scope)
+		if scope == "" {
+			continue
+		}
+		if grantedScopes[scope] {
+			return nil
+		}
+		missing = append(missing, scope)
+	}
+	sort.Strings(missing)
+	return missing
+}
+
+// withAPIErrorDiagnostics wraps the CRUD functions and importer of a resource
+// or data source, so that the errors caused by failed API responses name the
+// resource and carry hints for fixing them.
+func withAPIErrorDiagnostics(name string, r *schema.Resource) {
+	r.CreateContext = translateAPIErrors(name, r.CreateContext)
+	r.ReadContext = translateAPIErrors(name, r.ReadContext)
+	r.UpdateContext = translateAPIErrors(name, r.UpdateContext)
+	r.DeleteContext = translateAPIErrors(name, r.DeleteContext)
+
+	if r.Importer != nil && r.Importer.StateContext != nil {
+		stateContext := r.Importer.StateContext
+		r.Importer.StateContext = func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+			ctx, errs := withAPIErrors(ctx)
+			imported, err := stateContext(ctx, d, meta)
+			return imported, errs.translateError(err)
+		}
+	}
+}
+
+func translateAPIErrors(name string, f func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics) func(context.Context, *schema.ResourceData, interface{}) diag.Diagnostics {
+	if f == nil {
+		return nil
+	}
+	return func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+		ctx, errs := withAPIErrors(ctx)
+		diags := f(ctx, d, meta)
+		if !diags.HasError() {
+			return diags
+		}
+		return errs.translate(diags, name, d.Id())
+	}
+}
+
+// attributeDiagnostics is like diag.FromErr, pointing the error at the
+// attribute of the configuration it was caused by. It is used for requests
+// which only write some attributes of a resource, e.g. the topics of a
+// repository, while errors of requests writing a resource as a whole are not
+// pointed at any attribute.
+func attributeDiagnostics(err error, attribute string) diag.Diagnostics {
+	if err == nil {
+		return nil
+	}
+	return diag.Diagnostics{
+		diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       err.Error(),
+			AttributePath: cty.GetAttrPath(attribute),
+		},
+	}
+}
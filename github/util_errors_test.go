@@ -0,0 +1,191 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/shurcooL/githubv4"
+)
+
+func TestAPIErrorDiagnostics_rest(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/repos/acme/widgets/topics",
+			ExpectedMethod: "PUT",
+			ResponseBody:   `{"message": "Resource not accessible by integration"}`,
+			ResponseHeaders: map[string]string{
+				headerAcceptedPermissions: "administration=write",
+				headerSSO:                 "required; url=https://github.com/orgs/acme/sso?authorization_request=abc",
+			},
+			StatusCode: 403,
+		},
+	})
+	defer ts.Close()
+
+	client := github.NewClient(&http.Client{Transport: NewAPIErrorTransport(http.DefaultTransport)})
+	client.BaseURL, _ = url.Parse(ts.URL + "/")
+
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{"topics": {Type: schema.TypeString, Optional: true}},
+		UpdateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			_, _, err := client.Repositories.ReplaceAllTopics(ctx, "acme", "widgets", []string{"terraform"})
+			return attributeDiagnostics(err, "topics")
+		},
+	}
+	withAPIErrorDiagnostics("github_repository", resource)
+
+	d := resource.TestResourceData()
+	d.SetId("widgets")
+	diags := resource.UpdateContext(context.Background(), d, nil)
+	if len(diags) != 1 {
+		t.Fatalf("Expected a single diagnostic, got: %v", diags)
+	}
+
+	expectedSummary := "GitHub API error on github_repository (widgets): 403 Resource not accessible by integration"
+	if diags[0].Summary != expectedSummary {
+		t.Fatalf("Expected summary %q, got: %q", expectedSummary, diags[0].Summary)
+	}
+	for _, expected := range []string{
+		"PUT " + ts.URL + "/repos/acme/widgets/topics: 403",
+		`"administration" (write) permissions`,
+		"Authorize the token at: https://github.com/orgs/acme/sso?authorization_request=abc",
+	} {
+		if !strings.Contains(diags[0].Detail, expected) {
+			t.Fatalf("Expected detail to contain %q, got: %q", expected, diags[0].Detail)
+		}
+	}
+	if !diags[0].AttributePath.Equals(cty.GetAttrPath("topics")) {
+		t.Fatalf("Expected the attribute path to be kept, got: %#v", diags[0].AttributePath)
+	}
+}
+
+func TestAPIErrorDiagnostics_graphQL(t *testing.T) {
+	ts := githubApiMock([]*mockResponse{
+		{
+			ExpectedUri:    "/graphql",
+			ExpectedMethod: "POST",
+			ResponseBody:   `{"data": {"organization": null}, "errors": [{"type": "FORBIDDEN", "path": ["organization"], "message": "Resource protected by organization SAML enforcement."}]}`,
+			ResponseHeaders: map[string]string{
+				headerSSO: "required; url=https://github.com/orgs/acme/sso?authorization_request=abc",
+			},
+			StatusCode: 200,
+		},
+	})
+	defer ts.Close()
+
+	client := githubv4.NewEnterpriseClient(ts.URL+"/graphql", &http.Client{Transport: NewAPIErrorTransport(http.DefaultTransport)})
+
+	resource := &schema.Resource{
+		ReadContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) diag.Diagnostics {
+			var query struct {
+				Organization struct {
+					ID githubv4.ID
+				} `graphql:"organization(login: \"acme\")"`
+			}
+			return diag.FromErr(client.Query(ctx, &query, nil))
+		},
+		Importer: &schema.ResourceImporter{
+			StateContext: func(ctx context.Context, d *schema.ResourceData, meta interface{}) ([]*schema.ResourceData, error) {
+				return nil, errorFromDiagnostics(diag.Errorf("unexpected error"))
+			},
+		},
+	}
+	withAPIErrorDiagnostics("github_team_members", resource)
+
+	diags := resource.ReadContext(context.Background(), resource.TestResourceData(), nil)
+	expectedSummary := "GitHub API error on github_team_members: FORBIDDEN Resource protected by organization SAML enforcement. (at organization)"
+	if len(diags) != 1 || diags[0].Summary != expectedSummary {
+		t.Fatalf("Expected a diagnostic with summary %q, got: %v", expectedSummary, diags)
+	}
+	if !strings.Contains(diags[0].Detail, "Authorize the token at: https://github.com/orgs/acme/sso?authorization_request=abc") {
+		t.Fatalf("Expected the detail to contain the SAML authorization URL, got: %q", diags[0].Detail)
+	}
+
+	// Errors which are not caused by API responses are kept as they are
+	_, err := resource.Importer.StateContext(context.Background(), resource.TestResourceData(), nil)
+	if err == nil || err.Error() != "unexpected error" {
+		t.Fatalf("Expected the error to be kept, got: %v", err)
+	}
+}
+
+func TestAPIErrorHints(t *testing.T) {
+	cases := []struct {
+		name     string
+		apiError *apiError
+		expected []string
+	}{
+		{
+			name: "missing OAuth scope",
+			apiError: &apiError{
+				status:         404,
+				acceptedScopes: "admin:org, read:org",
+				grantedScopes:  "repo, user",
+				classicToken:   true,
+			},
+			expected: []string{
+				"The token needs one of the OAuth scopes admin:org, read:org, but has only been granted: repo, user.",
+				"GitHub answers with 404 Not Found when the token can not access a resource. Check that it exists, and that the token has access to it.",
+			},
+		},
+		{
+			name: "scope implied by a broader scope",
+			apiError: &apiError{
+				status:         403,
+				message:        "Must have admin rights to Repository.",
+				acceptedScopes: "read:org",
+				grantedScopes:  "admin:org",
+				classicToken:   true,
+			},
+			expected: []string{
+				"The authenticated user or GitHub App is not allowed to perform the request, e.g. as it requires organization owner or repository admin rights.",
+			},
+		},
+		{
+			name: "alternative App permissions",
+			apiError: &apiError{
+				status:              403,
+				message:             "Resource not accessible by integration",
+				acceptedPermissions: "contents=read,pull_requests=write; issues=write",
+			},
+			expected: []string{
+				`The request requires the "contents" (read) and "pull_requests" (write) or "issues" (write) permissions of the GitHub App or fine-grained personal access token. Check they are granted, and for a GitHub App that the installation accepted them.`,
+			},
+		},
+		{
+			name: "rate limit",
+			apiError: &apiError{
+				status:        403,
+				rateRemaining: "0",
+				rateReset:     "1700000000",
+			},
+			expected: []string{
+				"The rate limit of the token is exhausted, it resets at 2023-11-14T22:13:20Z.",
+			},
+		},
+		{
+			name: "validation failure",
+			apiError: &apiError{
+				status:              422,
+				message:             "Validation Failed",
+				acceptedPermissions: "administration=write",
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if hints := tc.apiError.hints(); !reflect.DeepEqual(hints, tc.expected) {
+				t.Fatalf("Expected hints %q, got: %q", tc.expected, hints)
+			}
+		})
+	}
+}
@@ -121,11 +121,11 @@ type BranchProtectionResourceData struct {
 	LockBranch                     bool
 }
 
-func branchProtectionResourceData(d *schema.ResourceData, meta interface{}) (BranchProtectionResourceData, error) {
+func branchProtectionResourceData(ctx context.Context, d *schema.ResourceData, meta interface{}) (BranchProtectionResourceData, error) {
 	data := BranchProtectionResourceData{}
 
 	if v, ok := d.GetOk(REPOSITORY_ID); ok {
-		repoID, err := getRepositoryID(v.(string), meta)
+		repoID, err := getRepositoryID(ctx, v.(string), meta)
 		if err != nil {
 			return data, err
 		}
@@ -558,7 +558,7 @@ func setForcePushBypassers(protection BranchProtectionRule, data BranchProtectio
 	return bypassForcePushActors
 }
 
-func getBranchProtectionID(repoID githubv4.ID, pattern string, meta interface{}) (githubv4.ID, error) {
+func getBranchProtectionID(ctx context.Context, repoID githubv4.ID, pattern string, meta interface{}) (githubv4.ID, error) {
 	var query struct {
 		Node struct {
 			Repository struct {
@@ -579,7 +579,6 @@ func getBranchProtectionID(repoID githubv4.ID, pattern string, meta interface{})
 		"cursor": (*githubv4.String)(nil),
 	}
 
-	ctx := context.Background()
 	client := meta.(*Owner).v4client
 
 	var allRules []struct {
@@ -609,10 +608,10 @@ func getBranchProtectionID(repoID githubv4.ID, pattern string, meta interface{})
 	return nil, fmt.Errorf("could not find a branch protection rule with the pattern '%s'", pattern)
 }
 
-func getActorIds(data []string, meta interface{}) ([]string, error) {
+func getActorIds(ctx context.Context, data []string, meta interface{}) ([]string, error) {
 	var actors []string
 	for _, v := range data {
-		id, err := getNodeIDv4(v, meta)
+		id, err := getNodeIDv4(ctx, v, meta)
 		if err != nil {
 			return []string{}, err
 		}
@@ -628,9 +627,27 @@ func getActorIds(data []string, meta interface{}) ([]string, error) {
 // with the organization name as prefix (Ex.: exampleorg/exampleteam). Usernames
 // must be provided with the "/" prefix otherwise getNodeIDv4 assumes that
 // the provided string is a node ID.
-func getNodeIDv4(userOrSlug string, meta interface{}) (string, error) {
+func getNodeIDv4(ctx context.Context, userOrSlug string, meta interface{}) (string, error) {
+	orgName := meta.(*Owner).name
+
+	var object string
+	if strings.HasPrefix(userOrSlug, orgName+"/") {
+		object = teamCacheObject(orgName, strings.TrimPrefix(userOrSlug, orgName+"/"))
+	} else if strings.HasPrefix(userOrSlug, "/") {
+		object = userCacheObject(strings.TrimPrefix(userOrSlug, "/"))
+	} else {
+		// If userOrSlug does not contain the team or username prefix, assume it is a node ID
+		return userOrSlug, nil
+	}
+
+	id, err := meta.(*Owner).cache.get(object, "node-id", func() (interface{}, error) {
+		return lookupNodeIDv4(ctx, userOrSlug, meta)
+	})
+	return id.(string), err
+}
+
+func lookupNodeIDv4(ctx context.Context, userOrSlug string, meta interface{}) (string, error) {
 	orgName := meta.(*Owner).name
-	ctx := context.Background()
 	client := meta.(*Owner).v4client
 
 	if strings.HasPrefix(userOrSlug, orgName+"/") {
@@ -8,16 +8,27 @@ import (
 	"github.com/shurcooL/githubv4"
 )
 
-func getRepositoryID(name string, meta interface{}) (githubv4.ID, error) {
+func getRepositoryID(ctx context.Context, name string, meta interface{}) (githubv4.ID, error) {
+	id, err := meta.(*Owner).cache.get(repositoryCacheObject(meta.(*Owner).name, name), "repository-node-id", func() (interface{}, error) {
+		return lookupRepositoryID(ctx, name, meta)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return id.(githubv4.ID), nil
+}
+
+func lookupRepositoryID(ctx context.Context, name string, meta interface{}) (githubv4.ID, error) {
 
 	// Interpret `name` as a node ID
-	exists, nodeIDerr := repositoryNodeIDExists(name, meta)
+	exists, nodeIDerr := repositoryNodeIDExists(ctx, name, meta)
 	if exists {
 		return githubv4.ID(name), nil
 	}
 
 	// Interpret `name` as a legacy node ID
-	exists, _ = repositoryLegacyNodeIDExists(name, meta)
+	exists, _ = repositoryLegacyNodeIDExists(ctx, name, meta)
 	if exists {
 		return githubv4.ID(name), nil
 	}
@@ -32,7 +43,6 @@ func getRepositoryID(name string, meta interface{}) (githubv4.ID, error) {
 		"owner": githubv4.String(meta.(*Owner).name),
 		"name":  githubv4.String(name),
 	}
-	ctx := context.Background()
 	client := meta.(*Owner).v4client
 	nameErr := client.Query(ctx, &query, variables)
 	if nameErr != nil {
@@ -46,7 +56,7 @@ func getRepositoryID(name string, meta interface{}) (githubv4.ID, error) {
 	return query.Repository.ID, nil
 }
 
-func repositoryNodeIDExists(name string, meta interface{}) (bool, error) {
+func repositoryNodeIDExists(ctx context.Context, name string, meta interface{}) (bool, error) {
 
 	// API check if node ID exists
 	var query struct {
@@ -57,7 +67,6 @@ func repositoryNodeIDExists(name string, meta interface{}) (bool, error) {
 	variables := map[string]interface{}{
 		"id": githubv4.ID(name),
 	}
-	ctx := context.Background()
 	client := meta.(*Owner).v4client
 	err := client.Query(ctx, &query, variables)
 	if err != nil {
@@ -69,7 +78,7 @@ func repositoryNodeIDExists(name string, meta interface{}) (bool, error) {
 
 // Maintain compatibility with deprecated Global ID format
 // https://github.blog/2021-02-10-new-global-id-format-coming-to-graphql/
-func repositoryLegacyNodeIDExists(name string, meta interface{}) (bool, error) {
+func repositoryLegacyNodeIDExists(ctx context.Context, name string, meta interface{}) (bool, error) {
 	// Check if the name is a base 64 encoded node ID
 	_, err := base64.StdEncoding.DecodeString(name)
 	if err != nil {
@@ -85,7 +94,6 @@ func repositoryLegacyNodeIDExists(name string, meta interface{}) (bool, error) {
 	variables := map[string]interface{}{
 		"id": githubv4.ID(name),
 	}
-	ctx := context.Background()
 	client := meta.(*Owner).v4client
 	err = client.Query(ctx, &query, variables)
 	if err != nil {
@@ -0,0 +1,195 @@
+package github
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+// hookDeliveriesLister lists a page of the deliveries of a repository or
+// organization webhook.
+type hookDeliveriesLister func(ctx context.Context, opts *github.ListCursorOptions) ([]*github.HookDelivery, *github.Response, error)
+
+// hookDeliveryRedeliverer redelivers a delivery of a repository or
+// organization webhook.
+type hookDeliveryRedeliverer func(ctx context.Context, deliveryID int64) (*github.HookDelivery, *github.Response, error)
+
+func redeliverFailedSinceSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:             schema.TypeString,
+		Optional:         true,
+		ValidateDiagFunc: toDiagFunc(validation.IsRFC3339Time, "redeliver_failed_since"),
+		Description:      "When set or changed, the deliveries which failed since this RFC 3339 timestamp, and have not been redelivered successfully, are redelivered.",
+	}
+}
+
+// listHookDeliveries lists the deliveries of a webhook, newest first, down to
+// the first one delivered before since.
+func listHookDeliveries(ctx context.Context, list hookDeliveriesLister, since time.Time) ([]*github.HookDelivery, error) {
+	var deliveries []*github.HookDelivery
+	options := &github.ListCursorOptions{PerPage: maxPerPage}
+	for {
+		page, resp, err := list(ctx, options)
+		if err != nil {
+			return nil, err
+		}
+		for _, delivery := range page {
+			if delivery.GetDeliveredAt().Before(since) {
+				return deliveries, nil
+			}
+			deliveries = append(deliveries, delivery)
+		}
+
+		if resp.Cursor == "" {
+			break
+		}
+		options.Cursor = resp.Cursor
+	}
+	return deliveries, nil
+}
+
+// hookDeliveryFailed reports whether the receiver of a delivery did not
+// answer it with a 2xx status, a status code of 0 meaning it could not be
+// reached at all.
+func hookDeliveryFailed(delivery *github.HookDelivery) bool {
+	return delivery.GetStatusCode() < 200 || delivery.GetStatusCode() >= 300
+}
+
+// redeliverFailedHookDeliveries redelivers the deliveries which failed since
+// the redeliver_failed_since argument, when it is set. A delivery is only
+// redelivered if its latest attempt failed.
+func redeliverFailedHookDeliveries(ctx context.Context, d *schema.ResourceData, list hookDeliveriesLister, redeliver hookDeliveryRedeliverer) error {
+	v, ok := d.GetOk("redeliver_failed_since")
+	if !ok {
+		return nil
+	}
+	since, err := time.Parse(time.RFC3339, v.(string))
+	if err != nil {
+		return err
+	}
+
+	deliveries, err := listHookDeliveries(ctx, list, since)
+	if err != nil {
+		return err
+	}
+
+	// Deliveries are listed newest first, so the first attempt seen for a
+	// GUID is its latest one.
+	seen := map[string]bool{}
+	for _, delivery := range deliveries {
+		if seen[delivery.GetGUID()] {
+			continue
+		}
+		seen[delivery.GetGUID()] = true
+		if !hookDeliveryFailed(delivery) {
+			continue
+		}
+
+		log.Printf("[DEBUG] Redelivering %s delivery %d of webhook %s", delivery.GetEvent(), delivery.GetID(), d.Id())
+		_, _, err = redeliver(ctx, delivery.GetID())
+		// GitHub answers 202 Accepted, as redeliveries are asynchronous.
+		if _, ok := err.(*github.AcceptedError); err != nil && !ok {
+			return err
+		}
+	}
+	return nil
+}
+
+// flattenHookDeliveries flattens deliveries for the webhook deliveries data
+// sources.
+func flattenHookDeliveries(deliveries []*github.HookDelivery) []interface{} {
+	result := make([]interface{}, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		result = append(result, map[string]interface{}{
+			"id":           delivery.GetID(),
+			"guid":         delivery.GetGUID(),
+			"delivered_at": delivery.GetDeliveredAt().Format(time.RFC3339),
+			"redelivery":   delivery.GetRedelivery(),
+			"duration":     delivery.GetDuration(),
+			"status":       delivery.GetStatus(),
+			"status_code":  delivery.GetStatusCode(),
+			"event":        delivery.GetEvent(),
+			"action":       delivery.GetAction(),
+			"failed":       hookDeliveryFailed(delivery),
+		})
+	}
+	return result
+}
+
+// hookDeliveriesSchema is the schema of the deliveries listed by the webhook
+// deliveries data sources.
+func hookDeliveriesSchema() *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "The recent deliveries of the webhook, newest first.",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The ID of the delivery.",
+				},
+				"guid": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The ID of the event, shared by the redeliveries of the delivery.",
+				},
+				"delivered_at": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The time of the delivery.",
+				},
+				"redelivery": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the delivery is a redelivery.",
+				},
+				"duration": {
+					Type:        schema.TypeFloat,
+					Computed:    true,
+					Description: "The time the delivery took, in seconds.",
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The description of the status of the delivery.",
+				},
+				"status_code": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "The HTTP status code the receiver answered with, 0 if it could not be reached.",
+				},
+				"event": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The event the delivery is for.",
+				},
+				"action": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "The action of the event, if any.",
+				},
+				"failed": {
+					Type:        schema.TypeBool,
+					Computed:    true,
+					Description: "Whether the receiver did not answer with a 2xx status code.",
+				},
+			},
+		},
+	}
+}
+
+// hookDeliveriesSince returns the time the since argument of a webhook
+// deliveries data source is set to, or the zero time.
+func hookDeliveriesSince(d *schema.ResourceData) (time.Time, error) {
+	v, ok := d.GetOk("since")
+	if !ok {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339, v.(string))
+}
@@ -0,0 +1,170 @@
+package fakegithub
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+)
+
+func (s *Server) listBranches(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+
+	branches := []*github.Branch{}
+	for ref := range repo.branches {
+		if name, ok := strings.CutPrefix(ref, "refs/heads/"); ok {
+			branches = append(branches, s.branch(repo, name))
+		}
+	}
+	sort.Slice(branches, func(i, j int) bool {
+		return branches[i].GetName() < branches[j].GetName()
+	})
+	writeJSON(w, http.StatusOK, branches)
+}
+
+func (s *Server) getBranch(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	if _, ok := repo.branches["refs/heads/"+p["branch"]]; !ok {
+		writeError(w, http.StatusNotFound, "Branch not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.branch(repo, p["branch"]))
+}
+
+func (s *Server) branch(repo *repository, name string) *github.Branch {
+	ref := repo.branches["refs/heads/"+name]
+	protected := false
+	for _, rule := range repo.protections {
+		if matchesPattern(rule.Pattern, name) {
+			protected = true
+		}
+	}
+	return &github.Branch{
+		Name: github.String(name),
+		Commit: &github.RepositoryCommit{
+			SHA: github.String(ref.GetObject().GetSHA()),
+		},
+		Protected: github.Bool(protected),
+	}
+}
+
+// matchesPattern reports whether a branch matches a branch protection rule
+// pattern, supporting the * wildcard only.
+func matchesPattern(pattern, name string) bool {
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == name
+	}
+	if !strings.HasPrefix(name, parts[0]) {
+		return false
+	}
+	name = name[len(parts[0]):]
+	for _, part := range parts[1 : len(parts)-1] {
+		i := strings.Index(name, part)
+		if i < 0 {
+			return false
+		}
+		name = name[i+len(part):]
+	}
+	return strings.HasSuffix(name, parts[len(parts)-1])
+}
+
+func (s *Server) getReference(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	ref, ok := repo.branches["refs/"+p["ref"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(ref))
+}
+
+func (s *Server) createReference(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Ref string `json:"ref"`
+		SHA string `json:"sha"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if !strings.HasPrefix(body.Ref, "refs/") || strings.Count(body.Ref, "/") < 2 {
+		writeError(w, http.StatusUnprocessableEntity, "Reference name must start with 'refs/' and have at least two slashes.")
+		return
+	}
+	if !s.commitExists(repo, body.SHA) {
+		writeError(w, http.StatusUnprocessableEntity, "Object does not exist")
+		return
+	}
+	if _, ok := repo.branches[body.Ref]; ok {
+		writeError(w, http.StatusUnprocessableEntity, "Reference already exists")
+		return
+	}
+
+	ref := s.newReference(body.Ref, body.SHA)
+	repo.branches[body.Ref] = ref
+	writeJSON(w, http.StatusCreated, copyJSON(ref))
+}
+
+func (s *Server) updateReference(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	ref, ok := repo.branches["refs/"+p["ref"]]
+	if !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Reference does not exist")
+		return
+	}
+
+	var body struct {
+		SHA string `json:"sha"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if !s.commitExists(repo, body.SHA) {
+		writeError(w, http.StatusUnprocessableEntity, "Object does not exist")
+		return
+	}
+	ref.Object.SHA = github.String(body.SHA)
+	writeJSON(w, http.StatusOK, copyJSON(ref))
+}
+
+func (s *Server) deleteReference(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	if _, ok := repo.branches["refs/"+p["ref"]]; !ok {
+		writeError(w, http.StatusUnprocessableEntity, "Reference does not exist")
+		return
+	}
+	delete(repo.branches, "refs/"+p["ref"])
+	writeNoContent(w)
+}
+
+// commitExists reports whether a commit is known to the repository. As the
+// fake does not store commits, these are the commits references point to.
+func (s *Server) commitExists(repo *repository, sha string) bool {
+	for _, ref := range repo.branches {
+		if ref.GetObject().GetSHA() == sha {
+			return true
+		}
+	}
+	return false
+}
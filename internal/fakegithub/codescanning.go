@@ -0,0 +1,169 @@
+package fakegithub
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// codeScanningDefaultSetup is the code scanning default setup configuration
+// of a repository, with the runner fields go-github does not know about.
+type codeScanningDefaultSetup struct {
+	github.DefaultSetupConfiguration
+	RunnerType  *string `json:"runner_type"`
+	RunnerLabel *string `json:"runner_label"`
+}
+
+// workflowRun is a GitHub Actions run. Like on GitHub, it cannot be read
+// right after it is started: it is not found the first time it is read,
+// reported in progress the second time and completed from then on.
+type workflowRun struct {
+	run   *github.WorkflowRun
+	reads int
+}
+
+// defaultCodeScanningLanguages are the languages default setup is
+// configured for when none are requested, as the fake does not analyze the
+// contents of repositories.
+var defaultCodeScanningLanguages = []string{"actions", "python"}
+
+func (s *Server) getCodeScanningDefaultSetup(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(s.codeScanningDefaultSetup(repo)))
+}
+
+func (s *Server) codeScanningDefaultSetup(repo *repository) *codeScanningDefaultSetup {
+	if repo.codeScanning == nil {
+		repo.codeScanning = &codeScanningDefaultSetup{
+			DefaultSetupConfiguration: github.DefaultSetupConfiguration{
+				State:      github.String("not-configured"),
+				Languages:  []string{},
+				QuerySuite: github.String("default"),
+			},
+			RunnerType: github.String("standard"),
+		}
+	}
+	return repo.codeScanning
+}
+
+// updateCodeScanningDefaultSetup updates the default setup configuration and
+// starts a run applying it, answering 202 Accepted with the run like GitHub
+// does. Disabling default setup does not start a run.
+func (s *Server) updateCodeScanningDefaultSetup(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		State       string   `json:"state"`
+		QuerySuite  *string  `json:"query_suite"`
+		Languages   []string `json:"languages"`
+		RunnerType  *string  `json:"runner_type"`
+		RunnerLabel *string  `json:"runner_label"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	setup := s.codeScanningDefaultSetup(repo)
+	now := s.now()
+	switch body.State {
+	case "not-configured":
+		setup.State = github.String(body.State)
+		setup.UpdatedAt = &now
+		writeJSON(w, http.StatusOK, map[string]interface{}{})
+		return
+	case "configured":
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "Invalid state")
+		return
+	}
+
+	if body.QuerySuite != nil && *body.QuerySuite != "default" && *body.QuerySuite != "extended" {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid query suite")
+		return
+	}
+	if body.RunnerType != nil {
+		switch *body.RunnerType {
+		case "standard":
+			body.RunnerLabel = nil
+		case "labeled":
+			if body.RunnerLabel == nil || *body.RunnerLabel == "" {
+				writeError(w, http.StatusUnprocessableEntity, "A runner label is required for labeled runners")
+				return
+			}
+		default:
+			writeError(w, http.StatusUnprocessableEntity, "Invalid runner type")
+			return
+		}
+		setup.RunnerType = body.RunnerType
+		setup.RunnerLabel = body.RunnerLabel
+	}
+	if body.QuerySuite != nil {
+		setup.QuerySuite = body.QuerySuite
+	}
+	if len(body.Languages) > 0 {
+		setup.Languages = body.Languages
+	} else if setup.GetState() != "configured" {
+		setup.Languages = append([]string{}, defaultCodeScanningLanguages...)
+	}
+	sort.Strings(setup.Languages)
+	setup.State = github.String("configured")
+	setup.UpdatedAt = &now
+
+	run := s.startWorkflowRun(repo, "CodeQL Setup")
+	writeJSON(w, http.StatusAccepted, &github.UpdateDefaultSetupConfigurationResponse{
+		RunID:  run.ID,
+		RunURL: run.URL,
+	})
+}
+
+func (s *Server) startWorkflowRun(repo *repository, name string) *github.WorkflowRun {
+	id := s.newID()
+	now := s.now()
+	run := &github.WorkflowRun{
+		ID:        github.Int64(id),
+		Name:      github.String(name),
+		Status:    github.String("queued"),
+		URL:       github.String(s.URL + "/api/v3/repos/" + repo.repo.GetFullName() + "/actions/runs/" + formatID(id)),
+		HTMLURL:   github.String(s.URL + "/" + repo.repo.GetFullName() + "/actions/runs/" + formatID(id)),
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	}
+	repo.runs[id] = &workflowRun{run: run}
+	return run
+}
+
+func (s *Server) getWorkflowRun(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	id, err := strconv.ParseInt(p["id"], 10, 64)
+	run, found := repo.runs[id]
+	if err != nil || !found {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	run.reads++
+	switch {
+	case run.reads == 1:
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	case run.reads == 2:
+		run.run.Status = github.String("in_progress")
+	default:
+		now := s.now()
+		run.run.Status = github.String("completed")
+		run.run.Conclusion = github.String("success")
+		run.run.UpdatedAt = &now
+	}
+	writeJSON(w, http.StatusOK, copyJSON(run.run))
+}
@@ -0,0 +1,318 @@
+package fakegithub
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+)
+
+type codeSecurityConfiguration struct {
+	configuration      *codeSecurityConfigurationSettings
+	defaultForNewRepos string
+	repositoryIDs      []int64
+}
+
+// codeSecurityConfigurationSettings are the settings of a code security
+// configuration, including the delegated bypass of push protection which
+// go-github does not know about.
+type codeSecurityConfigurationSettings struct {
+	github.CodeSecurityConfiguration
+	SecretScanningDelegatedBypass        *string                               `json:"secret_scanning_delegated_bypass,omitempty"`
+	SecretScanningDelegatedBypassOptions *secretScanningDelegatedBypassOptions `json:"secret_scanning_delegated_bypass_options,omitempty"`
+}
+
+type secretScanningDelegatedBypassOptions struct {
+	Reviewers []struct {
+		ReviewerID   int64  `json:"reviewer_id"`
+		ReviewerType string `json:"reviewer_type"`
+	} `json:"reviewers"`
+}
+
+func (s *Server) listCodeSecurityConfigurations(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	configurations := []*codeSecurityConfigurationSettings{}
+	for _, c := range o.codeSecurity {
+		configurations = append(configurations, copyJSON(c.configuration))
+	}
+	sort.Slice(configurations, func(i, j int) bool {
+		return configurations[i].GetID() < configurations[j].GetID()
+	})
+	writeJSON(w, http.StatusOK, configurations)
+}
+
+// createCodeSecurityConfiguration creates a configuration, with the features
+// which are not in the request set the way GitHub does.
+func (s *Server) createCodeSecurityConfiguration(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	configuration := &codeSecurityConfigurationSettings{
+		CodeSecurityConfiguration: github.CodeSecurityConfiguration{
+			AdvancedSecurity:                  github.String("disabled"),
+			DependencyGraph:                   github.String("enabled"),
+			DependencyGraphAutosubmitAction:   github.String("disabled"),
+			DependabotAlerts:                  github.String("disabled"),
+			DependabotSecurityUpdates:         github.String("disabled"),
+			CodeScanningDefaultSetup:          github.String("disabled"),
+			SecretScanning:                    github.String("disabled"),
+			SecretScanningPushProtection:      github.String("disabled"),
+			SecretScanningValidityChecks:      github.String("disabled"),
+			SecretScanningNonProviderPatterns: github.String("disabled"),
+			PrivateVulnerabilityReporting:     github.String("disabled"),
+			Enforcement:                       github.String("enforced"),
+			DependencyGraphAutosubmitActionOptions: &github.DependencyGraphAutosubmitActionOptions{
+				LabeledRunners: github.Bool(false),
+			},
+		},
+		SecretScanningDelegatedBypass: github.String("disabled"),
+	}
+	if _, ok := patch(w, r, configuration); !ok {
+		return
+	}
+	if configuration.GetName() == "" || configuration.GetDescription() == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: name and description are required")
+		return
+	}
+	if !s.validDelegatedBypassReviewers(w, o, configuration) {
+		return
+	}
+	for _, existing := range o.codeSecurity {
+		if existing.configuration.GetName() == configuration.GetName() {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: Name must be unique")
+			return
+		}
+	}
+
+	id := s.newID()
+	now := s.now()
+	configuration.ID = github.Int64(id)
+	configuration.TargetType = github.String("organization")
+	configuration.URL = github.String(s.URL + "/api/v3/orgs/" + o.org.GetLogin() + "/code-security/configurations/" + formatID(id))
+	configuration.HTMLURL = github.String(s.URL + "/organizations/" + o.org.GetLogin() + "/settings/security_products/configurations/edit/" + formatID(id))
+	configuration.CreatedAt = &now
+	configuration.UpdatedAt = &now
+	o.codeSecurity[id] = &codeSecurityConfiguration{configuration: configuration, defaultForNewRepos: "none"}
+
+	writeJSON(w, http.StatusCreated, copyJSON(configuration))
+}
+
+func (s *Server) codeSecurityConfiguration(w http.ResponseWriter, p params) (*organization, *codeSecurityConfiguration, bool) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return nil, nil, false
+	}
+	id, err := strconv.ParseInt(p["id"], 10, 64)
+	if err == nil {
+		if c, ok := o.codeSecurity[id]; ok {
+			return o, c, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, nil, false
+}
+
+func (s *Server) getCodeSecurityConfiguration(w http.ResponseWriter, r *http.Request, p params) {
+	_, c, ok := s.codeSecurityConfiguration(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(c.configuration))
+}
+
+func (s *Server) updateCodeSecurityConfiguration(w http.ResponseWriter, r *http.Request, p params) {
+	o, c, ok := s.codeSecurityConfiguration(w, p)
+	if !ok {
+		return
+	}
+
+	updated := copyJSON(c.configuration)
+	if _, ok := patch(w, r, updated); !ok {
+		return
+	}
+	if !s.validDelegatedBypassReviewers(w, o, updated) {
+		return
+	}
+	now := s.now()
+	updated.ID = c.configuration.ID
+	updated.TargetType = c.configuration.TargetType
+	updated.URL = c.configuration.URL
+	updated.HTMLURL = c.configuration.HTMLURL
+	updated.CreatedAt = c.configuration.CreatedAt
+	updated.UpdatedAt = &now
+	c.configuration = updated
+
+	writeJSON(w, http.StatusOK, copyJSON(updated))
+}
+
+func (s *Server) deleteCodeSecurityConfiguration(w http.ResponseWriter, r *http.Request, p params) {
+	o, c, ok := s.codeSecurityConfiguration(w, p)
+	if !ok {
+		return
+	}
+	delete(o.codeSecurity, c.configuration.GetID())
+	writeNoContent(w)
+}
+
+// validDelegatedBypassReviewers checks that the reviewers of the delegated
+// bypass of push protection are teams or roles of the organization.
+func (s *Server) validDelegatedBypassReviewers(w http.ResponseWriter, o *organization, configuration *codeSecurityConfigurationSettings) bool {
+	if configuration.SecretScanningDelegatedBypassOptions == nil {
+		return true
+	}
+	for _, reviewer := range configuration.SecretScanningDelegatedBypassOptions.Reviewers {
+		found := false
+		switch reviewer.ReviewerType {
+		case "TEAM":
+			for _, t := range o.teams {
+				found = found || t.team.GetID() == reviewer.ReviewerID
+			}
+		case "ROLE":
+			_, found = o.roles[reviewer.ReviewerID]
+		}
+		if !found {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: invalid bypass reviewer")
+			return false
+		}
+	}
+	return true
+}
+
+// attachCodeSecurityConfiguration attaches the configuration to the selected
+// repositories, detaching them from their previous configuration. GitHub
+// processes attachments asynchronously, the fake does it right away.
+func (s *Server) attachCodeSecurityConfiguration(w http.ResponseWriter, r *http.Request, p params) {
+	o, c, ok := s.codeSecurityConfiguration(w, p)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Scope                 string  `json:"scope"`
+		SelectedRepositoryIDs []int64 `json:"selected_repository_ids"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.Scope != "selected" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: only the selected scope is supported")
+		return
+	}
+
+	for _, id := range body.SelectedRepositoryIDs {
+		detachCodeSecurityConfiguration(o, id)
+		c.repositoryIDs = append(c.repositoryIDs, id)
+	}
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{})
+}
+
+func (s *Server) detachCodeSecurityConfigurations(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		SelectedRepositoryIDs []int64 `json:"selected_repository_ids"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	for _, id := range body.SelectedRepositoryIDs {
+		detachCodeSecurityConfiguration(o, id)
+	}
+	writeNoContent(w)
+}
+
+func detachCodeSecurityConfiguration(o *organization, repositoryID int64) {
+	for _, c := range o.codeSecurity {
+		c.repositoryIDs = removeID(c.repositoryIDs, repositoryID)
+	}
+}
+
+func (s *Server) listCodeSecurityConfigurationRepositories(w http.ResponseWriter, r *http.Request, p params) {
+	_, c, ok := s.codeSecurityConfiguration(w, p)
+	if !ok {
+		return
+	}
+
+	repositories := []map[string]interface{}{}
+	for _, id := range c.repositoryIDs {
+		for _, repo := range s.repositories {
+			if repo.repo.GetID() == id {
+				repositories = append(repositories, map[string]interface{}{
+					"status":     "attached",
+					"repository": copyJSON(repo.repo),
+				})
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, repositories)
+}
+
+func (s *Server) listDefaultCodeSecurityConfigurations(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	defaults := []*github.CodeSecurityConfigurationWithDefaultForNewRepos{}
+	for _, c := range o.codeSecurity {
+		if c.defaultForNewRepos != "none" {
+			defaults = append(defaults, &github.CodeSecurityConfigurationWithDefaultForNewRepos{
+				Configuration:      copyJSON(&c.configuration.CodeSecurityConfiguration),
+				DefaultForNewRepos: github.String(c.defaultForNewRepos),
+			})
+		}
+	}
+	sort.Slice(defaults, func(i, j int) bool {
+		return defaults[i].GetConfiguration().GetID() < defaults[j].GetConfiguration().GetID()
+	})
+	writeJSON(w, http.StatusOK, defaults)
+}
+
+// setDefaultCodeSecurityConfiguration sets the type of new repositories the
+// configuration applies to. Each type of repositories has a single default
+// configuration.
+func (s *Server) setDefaultCodeSecurityConfiguration(w http.ResponseWriter, r *http.Request, p params) {
+	o, c, ok := s.codeSecurityConfiguration(w, p)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		DefaultForNewRepos string `json:"default_for_new_repos"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	switch body.DefaultForNewRepos {
+	case "all":
+		for _, other := range o.codeSecurity {
+			other.defaultForNewRepos = "none"
+		}
+	case "private_and_internal", "public":
+		for _, other := range o.codeSecurity {
+			if other.defaultForNewRepos == body.DefaultForNewRepos || other.defaultForNewRepos == "all" {
+				other.defaultForNewRepos = "none"
+			}
+		}
+	case "none":
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: default_for_new_repos is not valid")
+		return
+	}
+	c.defaultForNewRepos = body.DefaultForNewRepos
+
+	writeJSON(w, http.StatusOK, &github.CodeSecurityConfigurationWithDefaultForNewRepos{
+		Configuration:      copyJSON(&c.configuration.CodeSecurityConfiguration),
+		DefaultForNewRepos: github.String(c.defaultForNewRepos),
+	})
+}
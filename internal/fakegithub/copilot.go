@@ -0,0 +1,173 @@
+package fakegithub
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// copilotSeats are the Copilot seat assignments of an organization, to users
+// directly and to teams, whose members each get a seat.
+type copilotSeats struct {
+	users map[string]github.Timestamp
+	teams map[int64]github.Timestamp
+}
+
+// listCopilotSeats lists a seat for every member assigned a seat directly or
+// through one of their teams, the way GitHub does, in a single page. Pending
+// members get a seat too, and members assigned a seat more than once keep the
+// seat of the first assignment.
+func (s *Server) listCopilotSeats(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	seats := map[string]map[string]interface{}{}
+	createdAts := map[string]github.Timestamp{}
+	for login, createdAt := range o.copilot.users {
+		if m, ok := o.members[login]; ok {
+			seats[login] = copilotSeat(m.User, nil, createdAt)
+			createdAts[login] = createdAt
+		}
+	}
+	for _, t := range o.teams {
+		createdAt, ok := o.copilot.teams[t.team.GetID()]
+		if !ok {
+			continue
+		}
+		for login, m := range t.members {
+			if existing, ok := createdAts[login]; !ok || createdAt.Before(existing.Time) {
+				seats[login] = copilotSeat(m.User, t.team, createdAt)
+				createdAts[login] = createdAt
+			}
+		}
+	}
+
+	logins := make([]string, 0, len(seats))
+	for login := range seats {
+		logins = append(logins, login)
+	}
+	sort.Strings(logins)
+	list := make([]map[string]interface{}, 0, len(seats))
+	for _, login := range logins {
+		list = append(list, seats[login])
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{"total_seats": len(list), "seats": list})
+}
+
+func copilotSeat(u *github.User, assigningTeam *github.Team, createdAt github.Timestamp) map[string]interface{} {
+	seat := map[string]interface{}{
+		"assignee":   copyJSON(u),
+		"created_at": createdAt,
+		"plan_type":  "business",
+	}
+	if assigningTeam != nil {
+		seat["assigning_team"] = copyJSON(assigningTeam)
+	}
+	return seat
+}
+
+// assignCopilotTeams adds or cancels the seats of teams, referred to by slug or
+// name.
+func (s *Server) assignCopilotTeams(assign bool) func(w http.ResponseWriter, r *http.Request, p params) {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		o, ok := s.organization(w, p)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			SelectedTeams []string `json:"selected_teams"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if len(body.SelectedTeams) == 0 {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: selected_teams is required")
+			return
+		}
+
+		teams := []*team{}
+		for _, name := range body.SelectedTeams {
+			t, ok := o.teams[key(name)]
+			if !ok {
+				for _, candidate := range o.teams {
+					if key(candidate.team.GetName()) == key(name) {
+						t, ok = candidate, true
+					}
+				}
+			}
+			if !ok {
+				writeError(w, http.StatusUnprocessableEntity, "Validation Failed: team "+name+" does not exist")
+				return
+			}
+			teams = append(teams, t)
+		}
+
+		changed := 0
+		for _, t := range teams {
+			_, assigned := o.copilot.teams[t.team.GetID()]
+			switch {
+			case assign && !assigned:
+				o.copilot.teams[t.team.GetID()] = s.now()
+				changed += len(t.members)
+			case !assign && assigned:
+				delete(o.copilot.teams, t.team.GetID())
+				changed += len(t.members)
+			}
+		}
+		writeCopilotSeatChanges(w, assign, changed)
+	}
+}
+
+// assignCopilotUsers adds or cancels the seats of users, who must be members
+// of the organization.
+func (s *Server) assignCopilotUsers(assign bool) func(w http.ResponseWriter, r *http.Request, p params) {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		o, ok := s.organization(w, p)
+		if !ok {
+			return
+		}
+
+		var body struct {
+			SelectedUsernames []string `json:"selected_usernames"`
+		}
+		if !readJSON(w, r, &body) {
+			return
+		}
+		if len(body.SelectedUsernames) == 0 {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: selected_usernames is required")
+			return
+		}
+		for _, login := range body.SelectedUsernames {
+			if _, ok := o.members[key(login)]; !ok {
+				writeError(w, http.StatusUnprocessableEntity, "Validation Failed: user "+login+" is not a member of the organization")
+				return
+			}
+		}
+
+		changed := 0
+		for _, login := range body.SelectedUsernames {
+			_, assigned := o.copilot.users[key(login)]
+			switch {
+			case assign && !assigned:
+				o.copilot.users[key(login)] = s.now()
+				changed++
+			case !assign && assigned:
+				delete(o.copilot.users, key(login))
+				changed++
+			}
+		}
+		writeCopilotSeatChanges(w, assign, changed)
+	}
+}
+
+func writeCopilotSeatChanges(w http.ResponseWriter, assign bool, changed int) {
+	if assign {
+		writeJSON(w, http.StatusCreated, &github.SeatAssignments{SeatsCreated: changed})
+		return
+	}
+	writeJSON(w, http.StatusOK, &github.SeatCancellations{SeatsCancelled: changed})
+}
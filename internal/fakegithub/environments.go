@@ -0,0 +1,284 @@
+package fakegithub
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+)
+
+func (s *Server) listEnvironments(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+
+	environments := []*github.Environment{}
+	for _, env := range repo.environments {
+		environments = append(environments, copyJSON(env.environment))
+	}
+	sort.Slice(environments, func(i, j int) bool {
+		return environments[i].GetName() < environments[j].GetName()
+	})
+	writeJSON(w, http.StatusOK, &github.EnvResponse{TotalCount: github.Int(len(environments)), Environments: environments})
+}
+
+func (s *Server) getEnvironment(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	env, ok := repo.environments[p["environment"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(env.environment))
+}
+
+// putEnvironment creates or replaces an environment, turning the wait timer
+// and reviewers of the request into protection rules like GitHub does.
+func (s *Server) putEnvironment(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+
+	var body github.CreateUpdateEnvironment
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	name := p["environment"]
+	env, ok := repo.environments[name]
+	if !ok {
+		id := s.newID()
+		now := s.now()
+		env = &environment{
+			environment: &github.Environment{
+				ID:        github.Int64(id),
+				NodeID:    github.String(s.newNodeID("EN", id)),
+				Name:      github.String(name),
+				URL:       github.String(s.URL + "/api/v3/repos/" + repo.repo.GetFullName() + "/environments/" + url.PathEscape(name)),
+				HTMLURL:   github.String(s.URL + "/" + repo.repo.GetFullName() + "/deployments/activity_log?environments_filter=" + url.QueryEscape(name)),
+				CreatedAt: &now,
+			},
+			secrets:         map[string]*secret{},
+			protectionRules: map[int64]*github.CustomDeploymentProtectionRule{},
+		}
+	}
+
+	var rules []*github.ProtectionRule
+	if body.GetWaitTimer() > 0 {
+		rules = append(rules, &github.ProtectionRule{
+			ID:        github.Int64(s.newID()),
+			Type:      github.String("wait_timer"),
+			WaitTimer: body.WaitTimer,
+		})
+	}
+	if len(body.Reviewers) > 0 {
+		rule := &github.ProtectionRule{
+			ID:                github.Int64(s.newID()),
+			Type:              github.String("required_reviewers"),
+			PreventSelfReview: github.Bool(body.GetPreventSelfReview()),
+		}
+		for _, reviewer := range body.Reviewers {
+			actor := s.reviewer(reviewer.GetType(), reviewer.GetID())
+			if actor == nil {
+				writeError(w, http.StatusUnprocessableEntity, "Invalid reviewer")
+				return
+			}
+			rule.Reviewers = append(rule.Reviewers, &github.RequiredReviewer{Type: reviewer.Type, Reviewer: actor})
+		}
+		rules = append(rules, rule)
+	}
+	if body.DeploymentBranchPolicy != nil {
+		rules = append(rules, &github.ProtectionRule{
+			ID:   github.Int64(s.newID()),
+			Type: github.String("branch_policy"),
+		})
+	}
+
+	now := s.now()
+	env.environment.UpdatedAt = &now
+	env.environment.ProtectionRules = rules
+	env.environment.DeploymentBranchPolicy = body.DeploymentBranchPolicy
+	env.environment.CanAdminsBypass = github.Bool(body.CanAdminsBypass == nil || body.GetCanAdminsBypass())
+	repo.environments[name] = env
+
+	writeJSON(w, http.StatusOK, copyJSON(env.environment))
+}
+
+// reviewer returns the user or team with the given ID, or nil when there is
+// none.
+func (s *Server) reviewer(typ string, id int64) interface{} {
+	switch typ {
+	case "User":
+		for _, u := range s.users {
+			if u.GetID() == id {
+				return u
+			}
+		}
+	case "Team":
+		for _, o := range s.organizations {
+			for _, t := range o.teams {
+				if t.team.GetID() == id {
+					return t.team
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (s *Server) deleteEnvironment(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	if _, ok := repo.environments[p["environment"]]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(repo.environments, p["environment"])
+	writeNoContent(w)
+}
+
+// environment returns the environment named by the placeholders of the
+// request, or answers 404 Not Found.
+func (s *Server) environment(w http.ResponseWriter, p params) (*environment, bool) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return nil, false
+	}
+	env, ok := repo.environments[p["environment"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil, false
+	}
+	return env, true
+}
+
+func (s *Server) deploymentProtectionRuleApp(a *github.App) *github.CustomDeploymentProtectionRuleApp {
+	return &github.CustomDeploymentProtectionRuleApp{
+		ID:             a.ID,
+		Slug:           a.Slug,
+		IntegrationURL: github.String(s.URL + "/api/v3/apps/" + a.GetSlug()),
+		NodeID:         a.NodeID,
+	}
+}
+
+func (s *Server) listDeploymentProtectionRules(w http.ResponseWriter, r *http.Request, p params) {
+	env, ok := s.environment(w, p)
+	if !ok {
+		return
+	}
+
+	rules := []*github.CustomDeploymentProtectionRule{}
+	for _, rule := range env.protectionRules {
+		rules = append(rules, copyJSON(rule))
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].GetID() < rules[j].GetID()
+	})
+	writeJSON(w, http.StatusOK, &github.ListDeploymentProtectionRuleResponse{TotalCount: github.Int(len(rules)), ProtectionRules: rules})
+}
+
+// createDeploymentProtectionRule enables an app as a custom deployment
+// protection rule, refusing apps which are unknown or already enabled.
+func (s *Server) createDeploymentProtectionRule(w http.ResponseWriter, r *http.Request, p params) {
+	env, ok := s.environment(w, p)
+	if !ok {
+		return
+	}
+
+	var body github.CustomDeploymentProtectionRuleRequest
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	var app *github.App
+	for _, a := range s.apps {
+		if a.GetID() == body.GetIntegrationID() {
+			app = a
+		}
+	}
+	if app == nil {
+		writeError(w, http.StatusUnprocessableEntity, "Integration not found")
+		return
+	}
+	for _, rule := range env.protectionRules {
+		if rule.GetApp().GetID() == app.GetID() {
+			writeError(w, http.StatusUnprocessableEntity, "Custom deployment protection rule already exists")
+			return
+		}
+	}
+
+	id := s.newID()
+	rule := &github.CustomDeploymentProtectionRule{
+		ID:      github.Int64(id),
+		NodeID:  github.String(s.newNodeID("DPR", id)),
+		Enabled: github.Bool(true),
+		App:     s.deploymentProtectionRuleApp(app),
+	}
+	env.protectionRules[id] = rule
+	writeJSON(w, http.StatusCreated, copyJSON(rule))
+}
+
+// listDeploymentProtectionRuleIntegrations lists the apps which are not yet
+// enabled as custom deployment protection rules of the environment.
+func (s *Server) listDeploymentProtectionRuleIntegrations(w http.ResponseWriter, r *http.Request, p params) {
+	env, ok := s.environment(w, p)
+	if !ok {
+		return
+	}
+
+	enabled := map[int64]bool{}
+	for _, rule := range env.protectionRules {
+		enabled[rule.GetApp().GetID()] = true
+	}
+	apps := []*github.CustomDeploymentProtectionRuleApp{}
+	for _, a := range s.apps {
+		if !enabled[a.GetID()] {
+			apps = append(apps, s.deploymentProtectionRuleApp(a))
+		}
+	}
+	sort.Slice(apps, func(i, j int) bool {
+		return apps[i].GetID() < apps[j].GetID()
+	})
+	writeJSON(w, http.StatusOK, &github.ListCustomDeploymentRuleIntegrationsResponse{TotalCount: github.Int(len(apps)), AvailableIntegrations: apps})
+}
+
+func (s *Server) deploymentProtectionRule(w http.ResponseWriter, p params) (*environment, *github.CustomDeploymentProtectionRule, bool) {
+	env, ok := s.environment(w, p)
+	if !ok {
+		return nil, nil, false
+	}
+	id, err := strconv.ParseInt(p["id"], 10, 64)
+	if err == nil {
+		if rule, ok := env.protectionRules[id]; ok {
+			return env, rule, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, nil, false
+}
+
+func (s *Server) getDeploymentProtectionRule(w http.ResponseWriter, r *http.Request, p params) {
+	_, rule, ok := s.deploymentProtectionRule(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(rule))
+}
+
+func (s *Server) deleteDeploymentProtectionRule(w http.ResponseWriter, r *http.Request, p params) {
+	env, rule, ok := s.deploymentProtectionRule(w, p)
+	if !ok {
+		return
+	}
+	delete(env.protectionRules, rule.GetID())
+	writeNoContent(w)
+}
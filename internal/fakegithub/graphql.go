@@ -0,0 +1,683 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/shurcooL/githubv4"
+)
+
+// object is a GraphQL object, mapping field names to values. Fields taking
+// arguments or referring to other objects are resolvers, evaluated only when
+// selected.
+type object map[string]interface{}
+
+type resolver func(args map[string]interface{}) (interface{}, error)
+
+// graphQLError is an error of a GraphQL response, with the type GitHub gives
+// it, such as NOT_FOUND.
+type graphQLError struct {
+	Type    string        `json:"type,omitempty"`
+	Path    []interface{} `json:"path,omitempty"`
+	Message string        `json:"message"`
+}
+
+func (e *graphQLError) Error() string {
+	return e.Message
+}
+
+func notFound(format string, a ...interface{}) error {
+	return &graphQLError{Type: "NOT_FOUND", Message: fmt.Sprintf(format, a...)}
+}
+
+func unprocessable(format string, a ...interface{}) error {
+	return &graphQLError{Type: "UNPROCESSABLE", Message: fmt.Sprintf(format, a...)}
+}
+
+// serveGraphQL serves the GraphQL API, the operation runs with the state
+// locked.
+func (s *Server) serveGraphQL(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var request struct {
+		Query     string                 `json:"query"`
+		Variables map[string]interface{} `json:"variables"`
+	}
+	if !readJSON(w, r, &request) {
+		return
+	}
+
+	op, err := parseOperation(request.Query)
+	if err != nil {
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"errors": []*graphQLError{{Message: "Parse error: " + err.Error()}},
+		})
+		return
+	}
+
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	root := s.queryRoot()
+	if op.typ == "mutation" {
+		root = s.mutationRoot()
+	}
+
+	data := map[string]interface{}{}
+	var errs []*graphQLError
+	for _, sel := range op.selections {
+		value, err := s.resolveField(root, "Query", sel, request.Variables)
+		if err != nil {
+			gqlErr, ok := err.(*graphQLError)
+			if !ok {
+				gqlErr = &graphQLError{Message: err.Error()}
+			}
+			gqlErr.Path = []interface{}{sel.key()}
+			errs = append(errs, gqlErr)
+			value = nil
+		}
+		data[sel.key()] = value
+	}
+
+	response := map[string]interface{}{"data": data}
+	if len(errs) > 0 {
+		response["errors"] = errs
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+func (sel selection) key() string {
+	if sel.alias != "" {
+		return sel.alias
+	}
+	return sel.name
+}
+
+func (s *Server) resolveField(o object, typ string, sel selection, variables map[string]interface{}) (interface{}, error) {
+	v, ok := o[sel.name]
+	if !ok {
+		return nil, fmt.Errorf("Field '%s' doesn't exist on type '%s'", sel.name, typ)
+	}
+	if resolve, ok := v.(resolver); ok {
+		args := map[string]interface{}{}
+		for name, arg := range sel.arguments {
+			args[name] = arg.resolve(variables)
+		}
+		var err error
+		if v, err = resolve(args); err != nil {
+			return nil, err
+		}
+	}
+	return s.complete(v, sel.selections, variables)
+}
+
+// complete projects objects and lists of objects onto the selections.
+func (s *Server) complete(v interface{}, selections []selection, variables map[string]interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case object:
+		if v == nil {
+			return nil, nil
+		}
+		return s.project(v, selections, variables)
+	case []object:
+		list := make([]interface{}, 0, len(v))
+		for _, o := range v {
+			projected, err := s.project(o, selections, variables)
+			if err != nil {
+				return nil, err
+			}
+			list = append(list, projected)
+		}
+		return list, nil
+	default:
+		return v, nil
+	}
+}
+
+func (s *Server) project(o object, selections []selection, variables map[string]interface{}) (map[string]interface{}, error) {
+	typ, _ := o["__typename"].(string)
+	projected := map[string]interface{}{}
+	for _, sel := range selections {
+		if sel.fragment != "" {
+			if sel.fragment != typ {
+				continue
+			}
+			fragment, err := s.project(o, sel.selections, variables)
+			if err != nil {
+				return nil, err
+			}
+			for k, v := range fragment {
+				projected[k] = v
+			}
+			continue
+		}
+
+		v, err := s.resolveField(o, typ, sel, variables)
+		if err != nil {
+			return nil, err
+		}
+		projected[sel.key()] = v
+	}
+	return projected, nil
+}
+
+func stringArg(args map[string]interface{}, name string) string {
+	s, _ := args[name].(string)
+	return s
+}
+
+// decodeInput decodes the input argument of a mutation into v.
+func decodeInput(args map[string]interface{}, v interface{}) error {
+	data, err := json.Marshal(args["input"])
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return &graphQLError{Type: "INVALID_INPUT", Message: err.Error()}
+	}
+	return nil
+}
+
+func (s *Server) queryRoot() object {
+	return object{
+		"__typename": "Query",
+		"repository": resolver(func(args map[string]interface{}) (interface{}, error) {
+			owner, name := stringArg(args, "owner"), stringArg(args, "name")
+			repo, ok := s.repositories[key(owner+"/"+name)]
+			if !ok {
+				return nil, notFound("Could not resolve to a Repository with the name '%s/%s'.", owner, name)
+			}
+			return s.repositoryObject(repo), nil
+		}),
+		"node": resolver(func(args map[string]interface{}) (interface{}, error) {
+			id := stringArg(args, "id")
+			node := s.nodeObject(id)
+			if node == nil {
+				return nil, notFound("Could not resolve to a node with the global id of '%s'", id)
+			}
+			return node, nil
+		}),
+		"organization": resolver(func(args map[string]interface{}) (interface{}, error) {
+			login := stringArg(args, "login")
+			o, ok := s.organizations[key(login)]
+			if !ok {
+				return nil, notFound("Could not resolve to an Organization with the login of '%s'.", login)
+			}
+			return s.organizationObject(o), nil
+		}),
+		"repositoryOwner": resolver(func(args map[string]interface{}) (interface{}, error) {
+			login := stringArg(args, "login")
+			if o, ok := s.organizations[key(login)]; ok {
+				return s.organizationObject(o), nil
+			}
+			if u, ok := s.users[key(login)]; ok {
+				return userObject(u), nil
+			}
+			return object(nil), nil
+		}),
+		"user": resolver(func(args map[string]interface{}) (interface{}, error) {
+			login := stringArg(args, "login")
+			u, ok := s.users[key(login)]
+			if !ok {
+				return nil, notFound("Could not resolve to a User with the login of '%s'.", login)
+			}
+			return userObject(u), nil
+		}),
+		"viewer": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return userObject(s.users[key(s.login)]), nil
+		}),
+	}
+}
+
+func (s *Server) mutationRoot() object {
+	return object{
+		"__typename":                 "Mutation",
+		"createBranchProtectionRule": resolver(s.createBranchProtectionRule),
+		"updateBranchProtectionRule": resolver(s.updateBranchProtectionRule),
+		"deleteBranchProtectionRule": resolver(s.deleteBranchProtectionRule),
+		"createProjectV2":            resolver(s.createProjectV2),
+		"updateProjectV2":            resolver(s.updateProjectV2),
+		"deleteProjectV2":            resolver(s.deleteProjectV2),
+		"createProjectV2Field":       resolver(s.createProjectV2Field),
+		"updateProjectV2Field":       resolver(s.updateProjectV2Field),
+		"deleteProjectV2Field":       resolver(s.deleteProjectV2Field),
+		"addProjectV2ItemById":       resolver(s.addProjectV2ItemByID),
+		"deleteProjectV2Item":        resolver(s.deleteProjectV2Item),
+	}
+}
+
+// nodeObject returns the object with the given node ID, or nil.
+func (s *Server) nodeObject(id string) object {
+	switch node := s.nodes[id].(type) {
+	case *github.User:
+		return userObject(node)
+	case *github.Organization:
+		return s.organizationObject(s.organizations[key(node.GetLogin())])
+	case *team:
+		return s.teamObject(node)
+	case *repository:
+		return s.repositoryObject(node)
+	case *BranchProtectionRule:
+		return s.branchProtectionRuleObject(node)
+	case *github.Issue:
+		return issueObject(node)
+	case *draftIssue:
+		return draftIssueObject(node)
+	case *projectV2:
+		return s.projectV2Object(node)
+	case *projectV2Field:
+		return s.projectV2FieldObject(node)
+	case *projectV2Item:
+		return s.projectV2ItemObject(node)
+	}
+	return nil
+}
+
+func userObject(u *github.User) object {
+	return object{
+		"__typename": "User",
+		"id":         u.GetNodeID(),
+		"databaseId": u.GetID(),
+		"login":      u.GetLogin(),
+		"name":       u.GetName(),
+		"email":      u.GetEmail(),
+	}
+}
+
+func (s *Server) organizationObject(o *organization) object {
+	return object{
+		"__typename": "Organization",
+		"id":         o.org.GetNodeID(),
+		"databaseId": o.org.GetID(),
+		"login":      o.org.GetLogin(),
+		"name":       o.org.GetName(),
+		"membersWithRole": resolver(func(args map[string]interface{}) (interface{}, error) {
+			members := make([]*github.User, 0, len(o.members))
+			for _, m := range o.members {
+				members = append(members, m.User)
+			}
+			sort.Slice(members, func(i, j int) bool {
+				return members[i].GetID() < members[j].GetID()
+			})
+			nodes := make([]object, 0, len(members))
+			for _, u := range members {
+				nodes = append(nodes, userObject(u))
+			}
+			return connection(nodes), nil
+		}),
+		"team": resolver(func(args map[string]interface{}) (interface{}, error) {
+			if t, ok := o.teams[key(stringArg(args, "slug"))]; ok {
+				return s.teamObject(t), nil
+			}
+			return object(nil), nil
+		}),
+	}
+}
+
+func (s *Server) teamObject(t *team) object {
+	return object{
+		"__typename":  "Team",
+		"id":          t.team.GetNodeID(),
+		"databaseId":  t.team.GetID(),
+		"name":        t.team.GetName(),
+		"slug":        t.team.GetSlug(),
+		"description": t.team.GetDescription(),
+		"privacy":     strings.ToUpper(t.team.GetPrivacy()),
+		"members": resolver(func(args map[string]interface{}) (interface{}, error) {
+			role := strings.ToLower(stringArg(args, "role"))
+			users := []*github.User{}
+			for _, m := range t.members {
+				if role == "" || role == m.GetRole() {
+					users = append(users, m.User)
+				}
+			}
+			sortUsers(users)
+			nodes := make([]object, 0, len(users))
+			for _, u := range users {
+				nodes = append(nodes, userObject(u))
+			}
+			return connection(nodes), nil
+		}),
+	}
+}
+
+func (s *Server) repositoryObject(repo *repository) object {
+	return object{
+		"__typename":    "Repository",
+		"id":            repo.repo.GetNodeID(),
+		"databaseId":    repo.repo.GetID(),
+		"name":          repo.repo.GetName(),
+		"nameWithOwner": repo.repo.GetFullName(),
+		"isArchived":    repo.repo.GetArchived(),
+		"isPrivate":     repo.repo.GetPrivate(),
+		"visibility":    strings.ToUpper(repo.repo.GetVisibility()),
+		"branchProtectionRules": resolver(func(args map[string]interface{}) (interface{}, error) {
+			rules := make([]*BranchProtectionRule, 0, len(repo.protections))
+			for _, rule := range repo.protections {
+				rules = append(rules, rule)
+			}
+			sort.Slice(rules, func(i, j int) bool {
+				return rules[i].Pattern < rules[j].Pattern
+			})
+			nodes := make([]object, 0, len(rules))
+			for _, rule := range rules {
+				nodes = append(nodes, s.branchProtectionRuleObject(rule))
+			}
+			return connection(nodes), nil
+		}),
+	}
+}
+
+// connection returns all nodes in a single page.
+func connection(nodes []object) object {
+	return object{
+		"__typename": "Connection",
+		"nodes":      nodes,
+		"totalCount": len(nodes),
+		"pageInfo": object{
+			"__typename":      "PageInfo",
+			"hasNextPage":     false,
+			"hasPreviousPage": false,
+			"startCursor":     nil,
+			"endCursor":       nil,
+		},
+	}
+}
+
+func (s *Server) branchProtectionRuleObject(rule *BranchProtectionRule) object {
+	allowances := func(typ string, ids []string) resolver {
+		return func(args map[string]interface{}) (interface{}, error) {
+			nodes := make([]object, 0, len(ids))
+			for _, id := range ids {
+				nodes = append(nodes, object{
+					"__typename": typ,
+					"actor":      s.nodeObject(id),
+				})
+			}
+			return connection(nodes), nil
+		}
+	}
+
+	return object{
+		"__typename": "BranchProtectionRule",
+		"id":         rule.ID,
+		"pattern":    rule.Pattern,
+		"repository": resolver(func(args map[string]interface{}) (interface{}, error) {
+			return s.nodeObject(rule.RepositoryID), nil
+		}),
+		"allowsDeletions":                rule.AllowsDeletions,
+		"allowsForcePushes":              rule.AllowsForcePushes,
+		"blocksCreations":                rule.BlocksCreations,
+		"dismissesStaleReviews":          rule.DismissesStaleReviews,
+		"isAdminEnforced":                rule.IsAdminEnforced,
+		"lockBranch":                     rule.LockBranch,
+		"requireLastPushApproval":        rule.RequireLastPushApproval,
+		"requiredApprovingReviewCount":   rule.RequiredApprovingReviewCount,
+		"requiredStatusCheckContexts":    nonNil(rule.RequiredStatusCheckContexts),
+		"requiresApprovingReviews":       rule.RequiresApprovingReviews,
+		"requiresCodeOwnerReviews":       rule.RequiresCodeOwnerReviews,
+		"requiresCommitSignatures":       rule.RequiresCommitSignatures,
+		"requiresConversationResolution": rule.RequiresConversationResolution,
+		"requiresLinearHistory":          rule.RequiresLinearHistory,
+		"requiresStatusChecks":           rule.RequiresStatusChecks,
+		"requiresStrictStatusChecks":     rule.RequiresStrictStatusChecks,
+		"restrictsPushes":                rule.RestrictsPushes,
+		"restrictsReviewDismissals":      rule.RestrictsReviewDismissals,
+		"pushAllowances":                 allowances("PushAllowance", rule.PushActorIDs),
+		"reviewDismissalAllowances":      allowances("ReviewDismissalAllowance", rule.ReviewDismissalActorIDs),
+		"bypassForcePushAllowances":      allowances("BypassForcePushAllowance", rule.BypassForcePushActorIDs),
+		"bypassPullRequestAllowances":    allowances("BypassPullRequestAllowance", rule.BypassPullRequestActorIDs),
+	}
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+func (s *Server) createBranchProtectionRule(args map[string]interface{}) (interface{}, error) {
+	var input githubv4.CreateBranchProtectionRuleInput
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	repositoryID := fmt.Sprint(input.RepositoryID)
+	repo, ok := s.nodes[repositoryID].(*repository)
+	if !ok {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", repositoryID)
+	}
+	for _, existing := range repo.protections {
+		if existing.Pattern == string(input.Pattern) {
+			return nil, unprocessable("Name already protected: %s", input.Pattern)
+		}
+	}
+
+	rule := &BranchProtectionRule{
+		ID:           s.newNodeID("BPR", s.newID()),
+		RepositoryID: repositoryID,
+		Pattern:      string(input.Pattern),
+	}
+	err := s.applyBranchProtectionRuleInput(rule, branchProtectionRuleInput{
+		AllowsDeletions:                input.AllowsDeletions,
+		AllowsForcePushes:              input.AllowsForcePushes,
+		BlocksCreations:                input.BlocksCreations,
+		DismissesStaleReviews:          input.DismissesStaleReviews,
+		IsAdminEnforced:                input.IsAdminEnforced,
+		LockBranch:                     input.LockBranch,
+		RequireLastPushApproval:        input.RequireLastPushApproval,
+		RequiredApprovingReviewCount:   input.RequiredApprovingReviewCount,
+		RequiredStatusCheckContexts:    input.RequiredStatusCheckContexts,
+		RequiresApprovingReviews:       input.RequiresApprovingReviews,
+		RequiresCodeOwnerReviews:       input.RequiresCodeOwnerReviews,
+		RequiresCommitSignatures:       input.RequiresCommitSignatures,
+		RequiresConversationResolution: input.RequiresConversationResolution,
+		RequiresLinearHistory:          input.RequiresLinearHistory,
+		RequiresStatusChecks:           input.RequiresStatusChecks,
+		RequiresStrictStatusChecks:     input.RequiresStrictStatusChecks,
+		RestrictsPushes:                input.RestrictsPushes,
+		RestrictsReviewDismissals:      input.RestrictsReviewDismissals,
+		PushActorIDs:                   input.PushActorIDs,
+		ReviewDismissalActorIDs:        input.ReviewDismissalActorIDs,
+		BypassForcePushActorIDs:        input.BypassForcePushActorIDs,
+		BypassPullRequestActorIDs:      input.BypassPullRequestActorIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	repo.protections[rule.ID] = rule
+	s.nodes[rule.ID] = rule
+
+	return object{
+		"__typename":           "CreateBranchProtectionRulePayload",
+		"branchProtectionRule": s.branchProtectionRuleObject(rule),
+		"clientMutationId":     nil,
+	}, nil
+}
+
+func (s *Server) updateBranchProtectionRule(args map[string]interface{}) (interface{}, error) {
+	var input githubv4.UpdateBranchProtectionRuleInput
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	id := fmt.Sprint(input.BranchProtectionRuleID)
+	rule, ok := s.nodes[id].(*BranchProtectionRule)
+	if !ok {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", id)
+	}
+	if input.Pattern != nil {
+		repo := s.nodes[rule.RepositoryID].(*repository)
+		for _, existing := range repo.protections {
+			if existing != rule && existing.Pattern == string(*input.Pattern) {
+				return nil, unprocessable("Name already protected: %s", *input.Pattern)
+			}
+		}
+	}
+
+	updated := *rule
+	if input.Pattern != nil {
+		updated.Pattern = string(*input.Pattern)
+	}
+	err := s.applyBranchProtectionRuleInput(&updated, branchProtectionRuleInput{
+		AllowsDeletions:                input.AllowsDeletions,
+		AllowsForcePushes:              input.AllowsForcePushes,
+		BlocksCreations:                input.BlocksCreations,
+		DismissesStaleReviews:          input.DismissesStaleReviews,
+		IsAdminEnforced:                input.IsAdminEnforced,
+		LockBranch:                     input.LockBranch,
+		RequireLastPushApproval:        input.RequireLastPushApproval,
+		RequiredApprovingReviewCount:   input.RequiredApprovingReviewCount,
+		RequiredStatusCheckContexts:    input.RequiredStatusCheckContexts,
+		RequiresApprovingReviews:       input.RequiresApprovingReviews,
+		RequiresCodeOwnerReviews:       input.RequiresCodeOwnerReviews,
+		RequiresCommitSignatures:       input.RequiresCommitSignatures,
+		RequiresConversationResolution: input.RequiresConversationResolution,
+		RequiresLinearHistory:          input.RequiresLinearHistory,
+		RequiresStatusChecks:           input.RequiresStatusChecks,
+		RequiresStrictStatusChecks:     input.RequiresStrictStatusChecks,
+		RestrictsPushes:                input.RestrictsPushes,
+		RestrictsReviewDismissals:      input.RestrictsReviewDismissals,
+		PushActorIDs:                   input.PushActorIDs,
+		ReviewDismissalActorIDs:        input.ReviewDismissalActorIDs,
+		BypassForcePushActorIDs:        input.BypassForcePushActorIDs,
+		BypassPullRequestActorIDs:      input.BypassPullRequestActorIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+	*rule = updated
+
+	return object{
+		"__typename":           "UpdateBranchProtectionRulePayload",
+		"branchProtectionRule": s.branchProtectionRuleObject(rule),
+		"clientMutationId":     nil,
+	}, nil
+}
+
+func (s *Server) deleteBranchProtectionRule(args map[string]interface{}) (interface{}, error) {
+	var input githubv4.DeleteBranchProtectionRuleInput
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	id := fmt.Sprint(input.BranchProtectionRuleID)
+	rule, ok := s.nodes[id].(*BranchProtectionRule)
+	if !ok {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", id)
+	}
+	if repo, ok := s.nodes[rule.RepositoryID].(*repository); ok {
+		delete(repo.protections, id)
+	}
+	delete(s.nodes, id)
+
+	return object{
+		"__typename":       "DeleteBranchProtectionRulePayload",
+		"clientMutationId": nil,
+	}, nil
+}
+
+// branchProtectionRuleInput holds the settings shared by the inputs of the
+// create and update mutations, nil fields are left unchanged.
+type branchProtectionRuleInput struct {
+	AllowsDeletions                *githubv4.Boolean
+	AllowsForcePushes              *githubv4.Boolean
+	BlocksCreations                *githubv4.Boolean
+	DismissesStaleReviews          *githubv4.Boolean
+	IsAdminEnforced                *githubv4.Boolean
+	LockBranch                     *githubv4.Boolean
+	RequireLastPushApproval        *githubv4.Boolean
+	RequiredApprovingReviewCount   *githubv4.Int
+	RequiredStatusCheckContexts    *[]githubv4.String
+	RequiresApprovingReviews       *githubv4.Boolean
+	RequiresCodeOwnerReviews       *githubv4.Boolean
+	RequiresCommitSignatures       *githubv4.Boolean
+	RequiresConversationResolution *githubv4.Boolean
+	RequiresLinearHistory          *githubv4.Boolean
+	RequiresStatusChecks           *githubv4.Boolean
+	RequiresStrictStatusChecks     *githubv4.Boolean
+	RestrictsPushes                *githubv4.Boolean
+	RestrictsReviewDismissals      *githubv4.Boolean
+	PushActorIDs                   *[]githubv4.ID
+	ReviewDismissalActorIDs        *[]githubv4.ID
+	BypassForcePushActorIDs        *[]githubv4.ID
+	BypassPullRequestActorIDs      *[]githubv4.ID
+}
+
+func (s *Server) applyBranchProtectionRuleInput(rule *BranchProtectionRule, input branchProtectionRuleInput) error {
+	for _, b := range []struct {
+		from *githubv4.Boolean
+		to   *bool
+	}{
+		{input.AllowsDeletions, &rule.AllowsDeletions},
+		{input.AllowsForcePushes, &rule.AllowsForcePushes},
+		{input.BlocksCreations, &rule.BlocksCreations},
+		{input.DismissesStaleReviews, &rule.DismissesStaleReviews},
+		{input.IsAdminEnforced, &rule.IsAdminEnforced},
+		{input.LockBranch, &rule.LockBranch},
+		{input.RequireLastPushApproval, &rule.RequireLastPushApproval},
+		{input.RequiresApprovingReviews, &rule.RequiresApprovingReviews},
+		{input.RequiresCodeOwnerReviews, &rule.RequiresCodeOwnerReviews},
+		{input.RequiresCommitSignatures, &rule.RequiresCommitSignatures},
+		{input.RequiresConversationResolution, &rule.RequiresConversationResolution},
+		{input.RequiresLinearHistory, &rule.RequiresLinearHistory},
+		{input.RequiresStatusChecks, &rule.RequiresStatusChecks},
+		{input.RequiresStrictStatusChecks, &rule.RequiresStrictStatusChecks},
+		{input.RestrictsPushes, &rule.RestrictsPushes},
+		{input.RestrictsReviewDismissals, &rule.RestrictsReviewDismissals},
+	} {
+		if b.from != nil {
+			*b.to = bool(*b.from)
+		}
+	}
+
+	if input.RequiredApprovingReviewCount != nil {
+		count := int(*input.RequiredApprovingReviewCount)
+		if count < 0 || count > 10 {
+			return unprocessable("Required approving review count must be between 0 and 10")
+		}
+		rule.RequiredApprovingReviewCount = count
+	}
+	if input.RequiredStatusCheckContexts != nil {
+		rule.RequiredStatusCheckContexts = []string{}
+		for _, context := range *input.RequiredStatusCheckContexts {
+			rule.RequiredStatusCheckContexts = append(rule.RequiredStatusCheckContexts, string(context))
+		}
+	}
+
+	for _, actors := range []struct {
+		from *[]githubv4.ID
+		to   *[]string
+	}{
+		{input.PushActorIDs, &rule.PushActorIDs},
+		{input.ReviewDismissalActorIDs, &rule.ReviewDismissalActorIDs},
+		{input.BypassForcePushActorIDs, &rule.BypassForcePushActorIDs},
+		{input.BypassPullRequestActorIDs, &rule.BypassPullRequestActorIDs},
+	} {
+		if actors.from == nil {
+			continue
+		}
+		ids := []string{}
+		for _, id := range *actors.from {
+			actorID := fmt.Sprint(id)
+			switch s.nodes[actorID].(type) {
+			case *github.User, *team:
+			default:
+				return notFound("Could not resolve to a node with the global id of '%s'", actorID)
+			}
+			ids = append(ids, actorID)
+		}
+		*actors.to = ids
+	}
+
+	return nil
+}
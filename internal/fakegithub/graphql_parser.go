@@ -0,0 +1,229 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// operation is a parsed GraphQL query or mutation. The parser supports the
+// subset of GraphQL generated by githubv4: variable definitions, fields with
+// aliases and arguments, and inline fragments.
+type operation struct {
+	typ        string
+	selections []selection
+}
+
+type selection struct {
+	// fragment is the type condition of an inline fragment, which only has
+	// selections.
+	fragment   string
+	alias      string
+	name       string
+	arguments  map[string]value
+	selections []selection
+}
+
+// value is an argument value, a variable reference or a literal.
+type value struct {
+	variable string
+	literal  interface{}
+}
+
+func (v value) resolve(variables map[string]interface{}) interface{} {
+	if v.variable != "" {
+		return variables[v.variable]
+	}
+	return v.literal
+}
+
+type parser struct {
+	tokens []string
+	pos    int
+}
+
+func parseOperation(query string) (op *operation, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	p := &parser{tokens: tokenize(query)}
+	op = &operation{typ: "query"}
+	if t := p.peek(); t == "query" || t == "mutation" {
+		op.typ = p.next()
+		if t := p.peek(); t != "{" && t != "(" {
+			p.next() // operation name
+		}
+		if p.peek() == "(" {
+			p.skipVariableDefinitions()
+		}
+	}
+	op.selections = p.selectionSet()
+	if p.pos != len(p.tokens) {
+		panic(fmt.Sprintf("unexpected %q", p.peek()))
+	}
+	return op, nil
+}
+
+func (p *parser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() string {
+	t := p.peek()
+	if t == "" {
+		panic("unexpected end of document")
+	}
+	p.pos++
+	return t
+}
+
+func (p *parser) expect(t string) {
+	if got := p.next(); got != t {
+		panic(fmt.Sprintf("expected %q, got %q", t, got))
+	}
+}
+
+func (p *parser) skipVariableDefinitions() {
+	p.expect("(")
+	for p.peek() != ")" {
+		p.next()
+	}
+	p.expect(")")
+}
+
+func (p *parser) selectionSet() []selection {
+	p.expect("{")
+	var selections []selection
+	for p.peek() != "}" {
+		selections = append(selections, p.selection())
+	}
+	p.expect("}")
+	return selections
+}
+
+func (p *parser) selection() selection {
+	if p.peek() == "..." {
+		p.next()
+		p.expect("on")
+		return selection{fragment: p.next(), selections: p.selectionSet()}
+	}
+
+	s := selection{name: p.next()}
+	if p.peek() == ":" {
+		p.next()
+		s.alias = s.name
+		s.name = p.next()
+	}
+	if p.peek() == "(" {
+		p.next()
+		s.arguments = map[string]value{}
+		for p.peek() != ")" {
+			name := p.next()
+			p.expect(":")
+			s.arguments[name] = p.value()
+		}
+		p.expect(")")
+	}
+	if p.peek() == "{" {
+		s.selections = p.selectionSet()
+	}
+	return s
+}
+
+func (p *parser) value() value {
+	t := p.next()
+	switch {
+	case t == "$":
+		return value{variable: p.next()}
+	case t == "[":
+		var list []interface{}
+		for p.peek() != "]" {
+			list = append(list, p.value().literal)
+		}
+		p.expect("]")
+		return value{literal: list}
+	case t == "{":
+		object := map[string]interface{}{}
+		for p.peek() != "}" {
+			name := p.next()
+			p.expect(":")
+			object[name] = p.value().literal
+		}
+		p.expect("}")
+		return value{literal: object}
+	case strings.HasPrefix(t, `"`):
+		var s string
+		if err := json.Unmarshal([]byte(t), &s); err != nil {
+			panic(err)
+		}
+		return value{literal: s}
+	case t == "true" || t == "false":
+		return value{literal: t == "true"}
+	case t == "null":
+		return value{}
+	default:
+		var n json.Number
+		if err := json.Unmarshal([]byte(t), &n); err != nil {
+			// enum values are passed as strings
+			return value{literal: t}
+		}
+		return value{literal: n}
+	}
+}
+
+// tokenize splits a GraphQL document into punctuators, names, numbers and
+// string literals, dropping commas, whitespace and comments.
+func tokenize(document string) []string {
+	var tokens []string
+	runes := []rune(document)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r) || r == ',':
+			i++
+		case r == '#':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '.' && i+2 < len(runes) && runes[i+1] == '.' && runes[i+2] == '.':
+			tokens = append(tokens, "...")
+			i += 3
+		case strings.ContainsRune("{}()[]:!$=@", r):
+			tokens = append(tokens, string(r))
+			i++
+		case r == '"':
+			j := i + 1
+			for j < len(runes) && runes[j] != '"' {
+				if runes[j] == '\\' {
+					j++
+				}
+				j++
+			}
+			tokens = append(tokens, string(runes[i:min(j+1, len(runes))]))
+			i = j + 1
+		default:
+			// names may not contain the characters of numbers like 1.5e-3
+			numberCharacters := ""
+			if unicode.IsDigit(r) || r == '-' {
+				numberCharacters = ".-+"
+			}
+			j := i
+			for j < len(runes) && (unicode.IsLetter(runes[j]) || unicode.IsDigit(runes[j]) || runes[j] == '_' || strings.ContainsRune(numberCharacters, runes[j])) {
+				j++
+			}
+			if j == i {
+				j++
+			}
+			tokens = append(tokens, string(runes[i:j]))
+			i = j
+		}
+	}
+	return tokens
+}
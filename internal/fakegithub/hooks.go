@@ -0,0 +1,299 @@
+package fakegithub
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// maskedSecret is what GitHub returns in place of the secret of a webhook.
+const maskedSecret = "********"
+
+// hookScope is the repository or organization webhooks belong to.
+type hookScope struct {
+	hooks      map[int64]*github.Hook
+	deliveries map[int64][]*github.HookDelivery
+	hookURL    string
+	typ        string
+}
+
+// hooksHandler resolves the webhooks of the repository or organization named
+// by the placeholders before calling h.
+func (s *Server) hooksHandler(h func(w http.ResponseWriter, r *http.Request, p params, scope hookScope)) func(w http.ResponseWriter, r *http.Request, p params) {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := p["org"]; ok {
+			o, ok := s.organization(w, p)
+			if !ok {
+				return
+			}
+			h(w, r, p, hookScope{hooks: o.hooks, deliveries: o.hookDeliveries, hookURL: s.URL + "/api/v3/orgs/" + o.org.GetLogin() + "/hooks/", typ: "Organization"})
+			return
+		}
+
+		repo, ok := s.repository(w, p)
+		if !ok {
+			return
+		}
+		h(w, r, p, hookScope{hooks: repo.hooks, deliveries: repo.hookDeliveries, hookURL: s.URL + "/api/v3/repos/" + repo.repo.GetFullName() + "/hooks/", typ: "Repository"})
+	}
+}
+
+func (s *Server) listHooks(w http.ResponseWriter, r *http.Request, p params, scope hookScope) {
+	hooks := []*github.Hook{}
+	for _, hook := range scope.hooks {
+		hooks = append(hooks, hookResponse(hook))
+	}
+	sort.Slice(hooks, func(i, j int) bool {
+		return hooks[i].GetID() < hooks[j].GetID()
+	})
+	writeJSON(w, http.StatusOK, hooks)
+}
+
+func (s *Server) createHook(w http.ResponseWriter, r *http.Request, p params, scope hookScope) {
+	hook := &github.Hook{}
+	if !readJSON(w, r, hook) {
+		return
+	}
+	if hook.GetConfig().GetURL() == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: url cannot be blank")
+		return
+	}
+
+	id := s.newID()
+	now := s.now()
+	hook.ID = github.Int64(id)
+	hook.Type = github.String(scope.typ)
+	hook.URL = github.String(scope.hookURL + formatID(id))
+	hook.PingURL = github.String(scope.hookURL + formatID(id) + "/pings")
+	hook.CreatedAt = &now
+	hook.UpdatedAt = &now
+	if hook.Name == nil {
+		hook.Name = github.String("web")
+	}
+	if hook.Active == nil {
+		hook.Active = github.Bool(true)
+	}
+	if len(hook.Events) == 0 {
+		hook.Events = []string{"push"}
+	}
+	scope.hooks[id] = hook
+	// GitHub pings new webhooks.
+	s.addHookDelivery(scope, id, "ping", http.StatusOK)
+
+	writeJSON(w, http.StatusCreated, hookResponse(hook))
+}
+
+func (s *Server) hook(w http.ResponseWriter, p params, scope hookScope) (*github.Hook, bool) {
+	id, err := strconv.ParseInt(p["id"], 10, 64)
+	if err == nil {
+		if hook, ok := scope.hooks[id]; ok {
+			return hook, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, false
+}
+
+func (s *Server) getHook(w http.ResponseWriter, r *http.Request, p params, scope hookScope) {
+	hook, ok := s.hook(w, p, scope)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, hookResponse(hook))
+}
+
+func (s *Server) editHook(w http.ResponseWriter, r *http.Request, p params, scope hookScope) {
+	hook, ok := s.hook(w, p, scope)
+	if !ok {
+		return
+	}
+
+	edited := copyJSON(hook)
+	if _, ok := patch(w, r, edited); !ok {
+		return
+	}
+	now := s.now()
+	edited.ID = hook.ID
+	edited.Type = hook.Type
+	edited.URL = hook.URL
+	edited.PingURL = hook.PingURL
+	edited.CreatedAt = hook.CreatedAt
+	edited.UpdatedAt = &now
+	*hook = *edited
+
+	writeJSON(w, http.StatusOK, hookResponse(hook))
+}
+
+func (s *Server) getHookConfig(w http.ResponseWriter, r *http.Request, p params, scope hookScope) {
+	hook, ok := s.hook(w, p, scope)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, hookResponse(hook).GetConfig())
+}
+
+// editHookConfig edits the configuration of a webhook, leaving the fields
+// which are not in the request alone.
+func (s *Server) editHookConfig(w http.ResponseWriter, r *http.Request, p params, scope hookScope) {
+	hook, ok := s.hook(w, p, scope)
+	if !ok {
+		return
+	}
+
+	config := &github.HookConfig{}
+	if hook.Config != nil {
+		config = copyJSON(hook.Config)
+	}
+	if _, ok := patch(w, r, config); !ok {
+		return
+	}
+	if config.GetURL() == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: url cannot be blank")
+		return
+	}
+	now := s.now()
+	hook.Config = config
+	hook.UpdatedAt = &now
+
+	writeJSON(w, http.StatusOK, hookResponse(hook).GetConfig())
+}
+
+func (s *Server) deleteHook(w http.ResponseWriter, r *http.Request, p params, scope hookScope) {
+	hook, ok := s.hook(w, p, scope)
+	if !ok {
+		return
+	}
+	delete(scope.hooks, hook.GetID())
+	delete(scope.deliveries, hook.GetID())
+	writeNoContent(w)
+}
+
+// hookResponse returns a webhook with its secret masked.
+func hookResponse(hook *github.Hook) *github.Hook {
+	c := copyJSON(hook)
+	if c.Config != nil && c.Config.Secret != nil {
+		c.Config.Secret = github.String(maskedSecret)
+	}
+	return c
+}
+
+// addHookDelivery records a delivery of a webhook, which the receiver
+// answered with the given status code, 0 meaning it could not be reached.
+func (s *Server) addHookDelivery(scope hookScope, hookID int64, event string, statusCode int) *github.HookDelivery {
+	id := s.newID()
+	now := s.now()
+	duration := 0.25
+	return s.recordHookDelivery(scope, hookID, &github.HookDelivery{
+		ID:          github.Int64(id),
+		GUID:        github.String(fmt.Sprintf("%08x-0000-0000-0000-%012x", id, id)),
+		DeliveredAt: &now,
+		Redelivery:  github.Bool(false),
+		Duration:    &duration,
+		Event:       github.String(event),
+	}, statusCode)
+}
+
+func (s *Server) recordHookDelivery(scope hookScope, hookID int64, delivery *github.HookDelivery, statusCode int) *github.HookDelivery {
+	delivery.StatusCode = github.Int(statusCode)
+	switch {
+	case statusCode == 0:
+		delivery.Status = github.String("failed to connect to host")
+	case statusCode >= 200 && statusCode < 300:
+		delivery.Status = github.String("OK")
+	default:
+		delivery.Status = github.String(fmt.Sprintf("Invalid HTTP Response: %d", statusCode))
+	}
+	scope.deliveries[hookID] = append(scope.deliveries[hookID], delivery)
+	return delivery
+}
+
+// Hook returns the repository webhook, or the organization webhook if repo is
+// empty, with its secret unmasked, or nil if it does not exist. Changes to the
+// returned webhook are visible to later requests.
+func (s *Server) Hook(owner, repo string, id int64) *github.Hook {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	scope, ok := s.hookScope(owner, repo)
+	if !ok {
+		return nil
+	}
+	return scope.hooks[id]
+}
+
+// hookScope returns the webhooks of a repository, or of an organization if
+// repo is empty.
+func (s *Server) hookScope(owner, repo string) (hookScope, bool) {
+	if repo == "" {
+		o, ok := s.organizations[key(owner)]
+		if !ok {
+			return hookScope{}, false
+		}
+		return hookScope{hooks: o.hooks, deliveries: o.hookDeliveries}, true
+	}
+	r, ok := s.repositories[key(owner+"/"+repo)]
+	if !ok {
+		return hookScope{}, false
+	}
+	return hookScope{hooks: r.hooks, deliveries: r.hookDeliveries}, true
+}
+
+// AddHookDelivery records a delivery of a repository webhook, or of an
+// organization webhook if repo is empty, e.g. to simulate an event the
+// receiver failed to handle.
+func (s *Server) AddHookDelivery(owner, repo string, hookID int64, event string, statusCode int) *github.HookDelivery {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	scope, ok := s.hookScope(owner, repo)
+	if !ok {
+		return nil
+	}
+	if _, ok := scope.hooks[hookID]; !ok {
+		return nil
+	}
+	return copyJSON(s.addHookDelivery(scope, hookID, event, statusCode))
+}
+
+// listHookDeliveries lists the deliveries of a webhook, newest first.
+func (s *Server) listHookDeliveries(w http.ResponseWriter, r *http.Request, p params, scope hookScope) {
+	hook, ok := s.hook(w, p, scope)
+	if !ok {
+		return
+	}
+	deliveries := []*github.HookDelivery{}
+	for _, delivery := range scope.deliveries[hook.GetID()] {
+		deliveries = append(deliveries, copyJSON(delivery))
+	}
+	sort.Slice(deliveries, func(i, j int) bool {
+		return deliveries[i].GetID() > deliveries[j].GetID()
+	})
+	writeJSON(w, http.StatusOK, deliveries)
+}
+
+// redeliverHookDelivery delivers the event of a delivery again, which the
+// receiver is assumed to now handle, answering 202 Accepted like GitHub.
+func (s *Server) redeliverHookDelivery(w http.ResponseWriter, r *http.Request, p params, scope hookScope) {
+	hook, ok := s.hook(w, p, scope)
+	if !ok {
+		return
+	}
+	id, _ := strconv.ParseInt(p["delivery_id"], 10, 64)
+	for _, delivery := range scope.deliveries[hook.GetID()] {
+		if delivery.GetID() != id {
+			continue
+		}
+		redelivery := copyJSON(delivery)
+		now := s.now()
+		redelivery.ID = github.Int64(s.newID())
+		redelivery.DeliveredAt = &now
+		redelivery.Redelivery = github.Bool(true)
+		s.recordHookDelivery(scope, hook.GetID(), redelivery, http.StatusOK)
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{})
+		return
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
@@ -0,0 +1,236 @@
+package fakegithub
+
+import (
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// orgInvitation is an invitation to join an organization, with the teams the
+// invitee is added to once it is accepted.
+type orgInvitation struct {
+	invitation *github.Invitation
+	teamIDs    []int64
+}
+
+// orgInvitationRoles are the roles invitees can be given.
+var orgInvitationRoles = []string{"admin", "direct_member", "billing_manager", "reinstate"}
+
+func (s *Server) listOrgInvitations(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, sortedInvitations(o.invitations))
+}
+
+func (s *Server) listFailedOrgInvitations(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, sortedInvitations(o.failedInvitations))
+}
+
+func sortedInvitations(invitations map[int64]*orgInvitation) []*github.Invitation {
+	list := []*github.Invitation{}
+	for _, i := range invitations {
+		list = append(list, copyJSON(i.invitation))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].GetID() < list[j].GetID()
+	})
+	return list
+}
+
+// createOrgInvitation invites a user by ID or anyone by email, refusing to
+// invite members and people who already have a pending invitation.
+func (s *Server) createOrgInvitation(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	var body github.CreateOrgInvitationOptions
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if (body.InviteeID == nil) == (body.Email == nil) {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: exactly one of invitee_id or email is required")
+		return
+	}
+	if body.Role == nil {
+		body.Role = github.String("direct_member")
+	}
+	if !slices.Contains(orgInvitationRoles, body.GetRole()) {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: invalid role")
+		return
+	}
+	for _, id := range body.TeamID {
+		if s.teamByID(o, id) == nil {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: invalid team_ids")
+			return
+		}
+	}
+
+	// Invitations reinstating former members report the role they are
+	// reinstated with, the fake reinstates them as members.
+	if body.GetRole() == "reinstate" {
+		body.Role = github.String("direct_member")
+	}
+
+	id := s.newID()
+	now := s.now()
+	invitation := &github.Invitation{
+		ID:        github.Int64(id),
+		NodeID:    github.String(s.newNodeID("OI", id)),
+		Role:      body.Role,
+		CreatedAt: &now,
+		Inviter:   copyJSON(s.users[key(s.login)]),
+		TeamCount: github.Int(len(body.TeamID)),
+	}
+	if body.Email != nil {
+		invitation.Email = body.Email
+	} else {
+		var invitee *github.User
+		for _, u := range s.users {
+			if u.GetID() == body.GetInviteeID() {
+				invitee = u
+			}
+		}
+		if invitee == nil {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: invalid invitee_id")
+			return
+		}
+		if m, ok := o.members[key(invitee.GetLogin())]; ok && m.GetState() == "active" {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: Invitee is already a part of this organization")
+			return
+		}
+		invitation.Login = invitee.Login
+	}
+	for _, i := range o.invitations {
+		if (invitation.Login != nil && key(i.invitation.GetLogin()) == key(invitation.GetLogin())) ||
+			(invitation.Email != nil && key(i.invitation.GetEmail()) == key(invitation.GetEmail())) {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: Invitee has already been invited")
+			return
+		}
+	}
+
+	o.invitations[id] = &orgInvitation{invitation: invitation, teamIDs: body.TeamID}
+	writeJSON(w, http.StatusCreated, copyJSON(invitation))
+}
+
+func (s *Server) orgInvitation(w http.ResponseWriter, p params) (*organization, *orgInvitation, bool) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return nil, nil, false
+	}
+	id, _ := strconv.ParseInt(p["id"], 10, 64)
+	i, ok := o.invitations[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil, nil, false
+	}
+	return o, i, true
+}
+
+func (s *Server) listOrgInvitationTeams(w http.ResponseWriter, r *http.Request, p params) {
+	o, i, ok := s.orgInvitation(w, p)
+	if !ok {
+		return
+	}
+	teams := []*github.Team{}
+	for _, id := range i.teamIDs {
+		if t := s.teamByID(o, id); t != nil {
+			teams = append(teams, s.teamResponse(t))
+		}
+	}
+	writeJSON(w, http.StatusOK, teams)
+}
+
+// cancelOrgInvitation cancels a pending invitation, which is then gone like
+// on GitHub.
+func (s *Server) cancelOrgInvitation(w http.ResponseWriter, r *http.Request, p params) {
+	o, i, ok := s.orgInvitation(w, p)
+	if !ok {
+		return
+	}
+	delete(o.invitations, i.invitation.GetID())
+	writeNoContent(w)
+}
+
+func (s *Server) teamByID(o *organization, id int64) *team {
+	for _, t := range o.teams {
+		if t.team.GetID() == id {
+			return t
+		}
+	}
+	return nil
+}
+
+// AcceptInvitation accepts a pending invitation to an organization on behalf
+// of the user with the given login, who becomes an active member of the
+// organization and of the teams of the invitation, and is no longer an outside
+// collaborator. It returns false if the invitation is not pending.
+func (s *Server) AcceptInvitation(org string, id int64, login string) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	o, ok := s.organizations[key(org)]
+	if !ok {
+		return false
+	}
+	i, ok := o.invitations[id]
+	if !ok {
+		return false
+	}
+	delete(o.invitations, id)
+
+	u := s.addUser(login)
+	delete(o.outsideCollaborators, key(login))
+	role := "member"
+	if i.invitation.GetRole() == "admin" {
+		role = "admin"
+	}
+	o.members[key(login)] = &github.Membership{
+		State: github.String("active"),
+		Role:  github.String(role),
+		User:  u,
+	}
+	for _, teamID := range i.teamIDs {
+		if t := s.teamByID(o, teamID); t != nil {
+			t.members[key(login)] = &github.Membership{
+				State: github.String("active"),
+				Role:  github.String("member"),
+				User:  u,
+			}
+		}
+	}
+	return true
+}
+
+// ExpireInvitation makes a pending invitation to an organization expire, like
+// GitHub does after 7 days. It returns false if the invitation is not pending.
+func (s *Server) ExpireInvitation(org string, id int64) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	o, ok := s.organizations[key(org)]
+	if !ok {
+		return false
+	}
+	i, ok := o.invitations[id]
+	if !ok {
+		return false
+	}
+	delete(o.invitations, id)
+
+	now := s.now()
+	i.invitation.FailedAt = &now
+	i.invitation.FailedReason = github.String("Invitation expired")
+	o.failedInvitations[id] = i
+	return true
+}
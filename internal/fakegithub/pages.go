@@ -0,0 +1,217 @@
+package fakegithub
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// pagesSite is the GitHub Pages site of a repository. The certificate of a custom
+// domain is only issued once its health has been checked, the first check
+// being answered with 202 Accepted like GitHub does while checking the domain.
+type pagesSite struct {
+	pages              *github.Pages
+	builds             []*github.PagesBuild
+	certificateChecked bool
+}
+
+func (s *Server) pages(w http.ResponseWriter, p params) (*repository, bool) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return nil, false
+	}
+	if repo.pages == nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil, false
+	}
+	return repo, true
+}
+
+func (s *Server) getPages(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.pages(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(repo.pages.pages))
+}
+
+// enablePages creates the site of a repository and builds it once.
+func (s *Server) enablePages(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	if repo.pages != nil {
+		writeError(w, http.StatusConflict, "GitHub Pages is already enabled.")
+		return
+	}
+
+	var body github.Pages
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.BuildType == nil {
+		body.BuildType = github.String("legacy")
+	}
+	switch body.GetBuildType() {
+	case "legacy":
+		if body.GetSource().GetBranch() == "" {
+			writeError(w, http.StatusUnprocessableEntity, "Invalid request.\n\n\"source\" is required for legacy builds.")
+			return
+		}
+		if body.Source.Path == nil {
+			body.Source.Path = github.String("/")
+		}
+	case "workflow":
+		body.Source = nil
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "Invalid build type")
+		return
+	}
+
+	owner := repo.repo.GetOwner().GetLogin()
+	repo.pages = &pagesSite{
+		pages: &github.Pages{
+			URL:           github.String(s.URL + "/api/v3/repos/" + repo.repo.GetFullName() + "/pages"),
+			Status:        github.String("built"),
+			HTMLURL:       github.String("https://" + strings.ToLower(owner) + ".github.io/" + repo.repo.GetName() + "/"),
+			BuildType:     body.BuildType,
+			Source:        body.Source,
+			Public:        github.Bool(true),
+			Custom404:     github.Bool(false),
+			HTTPSEnforced: github.Bool(true),
+		},
+	}
+	s.buildPages(repo)
+	repo.repo.HasPages = github.Bool(true)
+
+	writeJSON(w, http.StatusCreated, copyJSON(repo.pages.pages))
+}
+
+func (s *Server) buildPages(repo *repository) {
+	now := s.now()
+	repo.pages.builds = append(repo.pages.builds, &github.PagesBuild{
+		URL:       github.String(repo.pages.pages.GetURL() + "/builds/" + formatID(s.newID())),
+		Status:    github.String("built"),
+		Error:     &github.PagesError{},
+		Pusher:    copyJSON(s.users[key(s.login)]),
+		Commit:    github.String(s.newSHA()),
+		Duration:  github.Int(1500),
+		CreatedAt: &now,
+		UpdatedAt: &now,
+	})
+}
+
+// updatePages updates the site of a repository, a missing or null cname
+// removing its custom domain. HTTPS can only be enforced on a custom domain
+// once its certificate is issued.
+func (s *Server) updatePages(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.pages(w, p)
+	if !ok {
+		return
+	}
+
+	var body github.PagesUpdate
+	if !readJSON(w, r, &body) {
+		return
+	}
+
+	site := repo.pages.pages
+	if body.GetCNAME() != site.GetCNAME() {
+		site.CNAME = nil
+		site.HTTPSCertificate = nil
+		site.HTTPSEnforced = github.Bool(body.GetCNAME() == "")
+		repo.pages.certificateChecked = false
+		if body.GetCNAME() != "" {
+			site.CNAME = body.CNAME
+			site.HTMLURL = github.String("https://" + body.GetCNAME() + "/")
+			site.HTTPSCertificate = &github.PagesHTTPSCertificate{
+				State:       github.String("new"),
+				Description: github.String("Certificate has not been requested yet"),
+				Domains:     []string{body.GetCNAME()},
+			}
+		} else {
+			site.HTMLURL = github.String("https://" + strings.ToLower(repo.repo.GetOwner().GetLogin()) + ".github.io/" + repo.repo.GetName() + "/")
+		}
+	}
+	if body.BuildType != nil {
+		site.BuildType = body.BuildType
+	}
+	if body.Source != nil {
+		if body.Source.Path == nil {
+			body.Source.Path = github.String("/")
+		}
+		site.Source = body.Source
+	}
+	if body.Public != nil {
+		site.Public = body.Public
+	}
+	if body.HTTPSEnforced != nil {
+		if body.GetHTTPSEnforced() && site.GetHTTPSCertificate().GetState() != "" && site.GetHTTPSCertificate().GetState() != "approved" {
+			writeError(w, http.StatusUnprocessableEntity, "The certificate does not exist yet")
+			return
+		}
+		if !body.GetHTTPSEnforced() && site.GetCNAME() == "" {
+			writeError(w, http.StatusUnprocessableEntity, "HTTPS is always enforced on github.io domains")
+			return
+		}
+		site.HTTPSEnforced = body.HTTPSEnforced
+	}
+	s.buildPages(repo)
+
+	writeNoContent(w)
+}
+
+func (s *Server) disablePages(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.pages(w, p)
+	if !ok {
+		return
+	}
+	repo.pages = nil
+	repo.repo.HasPages = github.Bool(false)
+	writeNoContent(w)
+}
+
+func (s *Server) getLatestPagesBuild(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.pages(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(repo.pages.builds[len(repo.pages.builds)-1]))
+}
+
+// getPagesHealthCheck checks the custom domain of a site, issuing its
+// certificate from the second check on.
+func (s *Server) getPagesHealthCheck(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.pages(w, p)
+	if !ok {
+		return
+	}
+	site := repo.pages.pages
+	if site.GetCNAME() == "" {
+		writeError(w, http.StatusBadRequest, "There isn't a CNAME for this page")
+		return
+	}
+	if !repo.pages.certificateChecked {
+		repo.pages.certificateChecked = true
+		writeJSON(w, http.StatusAccepted, map[string]interface{}{})
+		return
+	}
+
+	site.HTTPSCertificate.State = github.String("approved")
+	site.HTTPSCertificate.Description = github.String("The certificate has been approved.")
+	writeJSON(w, http.StatusOK, &github.PagesHealthCheckResponse{
+		Domain: &github.PagesDomain{
+			Host:            site.CNAME,
+			URI:             github.String("http://" + site.GetCNAME() + "/"),
+			DNSResolves:     github.Bool(true),
+			IsValidDomain:   github.Bool(true),
+			IsServedByPages: github.Bool(true),
+			IsValid:         github.Bool(true),
+			IsHTTPSEligible: github.Bool(true),
+			RespondsToHTTPS: github.Bool(true),
+			EnforcesHTTPS:   github.Bool(site.GetHTTPSEnforced()),
+		},
+	})
+}
@@ -0,0 +1,576 @@
+package fakegithub
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+	"github.com/shurcooL/githubv4"
+)
+
+// projectV2 is a project of an organization or a user, managed through
+// GraphQL.
+type projectV2 struct {
+	id               string
+	ownerID          string
+	number           int
+	title            string
+	shortDescription string
+	readme           string
+	public           bool
+	closed           bool
+	fields           []*projectV2Field
+	items            []*projectV2Item
+}
+
+type projectV2Field struct {
+	id         string
+	project    *projectV2
+	name       string
+	dataType   string
+	options    []projectV2FieldOption
+	duration   int
+	iterations []projectV2FieldIteration
+}
+
+type projectV2FieldOption struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Color       string `json:"color"`
+	Description string `json:"description"`
+}
+
+type projectV2FieldIteration struct {
+	ID        string `json:"id"`
+	Title     string `json:"title"`
+	StartDate string `json:"startDate"`
+	Duration  int    `json:"duration"`
+}
+
+type projectV2Item struct {
+	id        string
+	project   *projectV2
+	contentID string
+}
+
+// draftIssue is the content of a draft item, which only exists in its
+// project.
+type draftIssue struct {
+	id    string
+	title string
+}
+
+// The inputs of the project mutations, which githubv4 does not all define.
+type projectV2FieldInput struct {
+	ProjectID           string  `json:"projectId"`
+	FieldID             string  `json:"fieldId"`
+	DataType            string  `json:"dataType"`
+	Name                *string `json:"name"`
+	SingleSelectOptions *[]struct {
+		Name        string `json:"name"`
+		Color       string `json:"color"`
+		Description string `json:"description"`
+	} `json:"singleSelectOptions"`
+	IterationConfiguration *struct {
+		StartDate string `json:"startDate"`
+		Duration  int    `json:"duration"`
+	} `json:"iterationConfiguration"`
+}
+
+// AddIssue adds an issue to a repository, which can only be referred to by
+// its node ID, e.g. to add it to a project. It returns nil if the repository
+// does not exist.
+func (s *Server) AddIssue(owner, repo, title string) *github.Issue {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	r, ok := s.repositories[key(owner+"/"+repo)]
+	if !ok {
+		return nil
+	}
+	id := s.newID()
+	issue := &github.Issue{
+		ID:            github.Int64(id),
+		NodeID:        github.String(s.newNodeID("I", id)),
+		Number:        github.Int(int(id)),
+		Title:         github.String(title),
+		State:         github.String("open"),
+		RepositoryURL: github.String(s.URL + "/api/v3/repos/" + r.repo.GetFullName()),
+	}
+	s.nodes[issue.GetNodeID()] = issue
+	return issue
+}
+
+// AddDraftIssue adds a draft issue to a project, and returns the node IDs of
+// the item and of the draft issue. It returns empty IDs if the project does
+// not exist.
+func (s *Server) AddDraftIssue(projectID, title string) (string, string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	p, ok := s.nodes[projectID].(*projectV2)
+	if !ok {
+		return "", ""
+	}
+	draft := &draftIssue{
+		id:    s.newNodeID("DI", s.newID()),
+		title: title,
+	}
+	s.nodes[draft.id] = draft
+	item := &projectV2Item{
+		id:        s.newNodeID("PVTI", s.newID()),
+		project:   p,
+		contentID: draft.id,
+	}
+	p.items = append(p.items, item)
+	s.nodes[item.id] = item
+	return item.id, draft.id
+}
+
+// RemoveIssue removes an issue, the project items it was added to are kept
+// but their content can no longer be resolved, the way GitHub redacts the
+// content of items the authenticated user cannot see.
+func (s *Server) RemoveIssue(nodeID string) bool {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if _, ok := s.nodes[nodeID].(*github.Issue); !ok {
+		return false
+	}
+	delete(s.nodes, nodeID)
+	return true
+}
+
+func issueObject(issue *github.Issue) object {
+	return object{
+		"__typename": "Issue",
+		"id":         issue.GetNodeID(),
+		"databaseId": issue.GetID(),
+		"number":     issue.GetNumber(),
+		"title":      issue.GetTitle(),
+	}
+}
+
+func (s *Server) ownerObject(id string) object {
+	switch owner := s.nodes[id].(type) {
+	case *github.User:
+		return userObject(owner)
+	case *github.Organization:
+		return s.organizationObject(s.organizations[key(owner.GetLogin())])
+	}
+	return nil
+}
+
+func (s *Server) projectV2Object(p *projectV2) object {
+	url := s.URL + "/users/"
+	owner := s.ownerObject(p.ownerID)
+	if owner["__typename"] == "Organization" {
+		url = s.URL + "/orgs/"
+	}
+	url += fmt.Sprintf("%s/projects/%d", owner["login"], p.number)
+
+	return object{
+		"__typename":       "ProjectV2",
+		"id":               p.id,
+		"number":           p.number,
+		"title":            p.title,
+		"shortDescription": nilIfEmpty(p.shortDescription),
+		"readme":           nilIfEmpty(p.readme),
+		"public":           p.public,
+		"closed":           p.closed,
+		"url":              url,
+		"owner":            owner,
+	}
+}
+
+func (s *Server) projectV2FieldObject(f *projectV2Field) object {
+	o := object{
+		"__typename": "ProjectV2Field",
+		"id":         f.id,
+		"name":       f.name,
+		"dataType":   f.dataType,
+		"project":    resolver(func(args map[string]interface{}) (interface{}, error) { return s.projectV2Object(f.project), nil }),
+	}
+	switch f.dataType {
+	case "SINGLE_SELECT":
+		o["__typename"] = "ProjectV2SingleSelectField"
+		options := make([]object, 0, len(f.options))
+		for _, option := range f.options {
+			options = append(options, object{
+				"__typename":  "ProjectV2SingleSelectFieldOption",
+				"id":          option.ID,
+				"name":        option.Name,
+				"color":       option.Color,
+				"description": option.Description,
+			})
+		}
+		o["options"] = options
+	case "ITERATION":
+		o["__typename"] = "ProjectV2IterationField"
+		iterations := make([]object, 0, len(f.iterations))
+		for _, iteration := range f.iterations {
+			iterations = append(iterations, object{
+				"__typename": "ProjectV2IterationFieldIteration",
+				"id":         iteration.ID,
+				"title":      iteration.Title,
+				"startDate":  iteration.StartDate,
+				"duration":   iteration.Duration,
+			})
+		}
+		o["configuration"] = object{
+			"__typename":          "ProjectV2IterationFieldConfiguration",
+			"duration":            f.duration,
+			"iterations":          iterations,
+			"completedIterations": []object{},
+		}
+	}
+	return o
+}
+
+func (s *Server) projectV2ItemObject(item *projectV2Item) object {
+	content := s.nodeObject(item.contentID)
+	itemType := "REDACTED"
+	switch s.nodes[item.contentID].(type) {
+	case *github.Issue:
+		itemType = "ISSUE"
+	case *draftIssue:
+		itemType = "DRAFT_ISSUE"
+	}
+	return object{
+		"__typename": "ProjectV2Item",
+		"id":         item.id,
+		"type":       itemType,
+		"isArchived": false,
+		"project":    resolver(func(args map[string]interface{}) (interface{}, error) { return s.projectV2Object(item.project), nil }),
+		"content":    content,
+	}
+}
+
+func draftIssueObject(draft *draftIssue) object {
+	return object{
+		"__typename": "DraftIssue",
+		"id":         draft.id,
+		"title":      draft.title,
+	}
+}
+
+func nilIfEmpty(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+func (s *Server) projectV2Node(id string) (*projectV2, error) {
+	p, ok := s.nodes[id].(*projectV2)
+	if !ok {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", id)
+	}
+	return p, nil
+}
+
+func (s *Server) createProjectV2(args map[string]interface{}) (interface{}, error) {
+	var input githubv4.CreateProjectV2Input
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	ownerID := fmt.Sprint(input.OwnerID)
+	if s.ownerObject(ownerID) == nil {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", ownerID)
+	}
+	if input.Title == "" {
+		return nil, unprocessable("Title can't be blank")
+	}
+
+	number := 1
+	for _, node := range s.nodes {
+		if p, ok := node.(*projectV2); ok && p.ownerID == ownerID && p.number >= number {
+			number = p.number + 1
+		}
+	}
+	p := &projectV2{
+		id:      s.newNodeID("PVT", s.newID()),
+		ownerID: ownerID,
+		number:  number,
+		title:   string(input.Title),
+	}
+	s.nodes[p.id] = p
+
+	return object{
+		"__typename":       "CreateProjectV2Payload",
+		"projectV2":        s.projectV2Object(p),
+		"clientMutationId": nil,
+	}, nil
+}
+
+func (s *Server) updateProjectV2(args map[string]interface{}) (interface{}, error) {
+	var input githubv4.UpdateProjectV2Input
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	p, err := s.projectV2Node(fmt.Sprint(input.ProjectID))
+	if err != nil {
+		return nil, err
+	}
+	if input.Title != nil {
+		if *input.Title == "" {
+			return nil, unprocessable("Title can't be blank")
+		}
+		p.title = string(*input.Title)
+	}
+	if input.ShortDescription != nil {
+		p.shortDescription = string(*input.ShortDescription)
+	}
+	if input.Readme != nil {
+		p.readme = string(*input.Readme)
+	}
+	if input.Public != nil {
+		p.public = bool(*input.Public)
+	}
+	if input.Closed != nil {
+		p.closed = bool(*input.Closed)
+	}
+
+	return object{
+		"__typename":       "UpdateProjectV2Payload",
+		"projectV2":        s.projectV2Object(p),
+		"clientMutationId": nil,
+	}, nil
+}
+
+func (s *Server) deleteProjectV2(args map[string]interface{}) (interface{}, error) {
+	var input struct {
+		ProjectID string `json:"projectId"`
+	}
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	p, err := s.projectV2Node(input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range p.fields {
+		delete(s.nodes, f.id)
+	}
+	for _, item := range p.items {
+		delete(s.nodes, item.id)
+	}
+	delete(s.nodes, p.id)
+
+	return object{
+		"__typename":       "DeleteProjectV2Payload",
+		"projectV2":        s.projectV2Object(p),
+		"clientMutationId": nil,
+	}, nil
+}
+
+func (s *Server) createProjectV2Field(args map[string]interface{}) (interface{}, error) {
+	var input projectV2FieldInput
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	p, err := s.projectV2Node(input.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	switch input.DataType {
+	case "TEXT", "NUMBER", "DATE":
+	case "SINGLE_SELECT":
+		if input.SingleSelectOptions == nil || len(*input.SingleSelectOptions) == 0 {
+			return nil, unprocessable("Single select fields require at least one option")
+		}
+	case "ITERATION":
+		if input.IterationConfiguration == nil {
+			return nil, unprocessable("Iteration fields require an iteration configuration")
+		}
+	default:
+		return nil, &graphQLError{Type: "INVALID_INPUT", Message: fmt.Sprintf("Invalid data type %q", input.DataType)}
+	}
+
+	f := &projectV2Field{
+		id:       s.newNodeID("PVTF", s.newID()),
+		project:  p,
+		dataType: input.DataType,
+	}
+	if err := s.applyProjectV2FieldInput(f, input); err != nil {
+		return nil, err
+	}
+	p.fields = append(p.fields, f)
+	s.nodes[f.id] = f
+
+	return object{
+		"__typename":       "CreateProjectV2FieldPayload",
+		"projectV2Field":   s.projectV2FieldObject(f),
+		"clientMutationId": nil,
+	}, nil
+}
+
+func (s *Server) updateProjectV2Field(args map[string]interface{}) (interface{}, error) {
+	var input projectV2FieldInput
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	f, ok := s.nodes[input.FieldID].(*projectV2Field)
+	if !ok {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", input.FieldID)
+	}
+	if err := s.applyProjectV2FieldInput(f, input); err != nil {
+		return nil, err
+	}
+
+	return object{
+		"__typename":       "UpdateProjectV2FieldPayload",
+		"projectV2Field":   s.projectV2FieldObject(f),
+		"clientMutationId": nil,
+	}, nil
+}
+
+// applyProjectV2FieldInput applies the name, options and iteration
+// configuration of the input to a field. Options are replaced, so they get
+// new IDs, and iterations are generated from the configuration.
+func (s *Server) applyProjectV2FieldInput(f *projectV2Field, input projectV2FieldInput) error {
+	if input.Name != nil {
+		if *input.Name == "" {
+			return unprocessable("Name can't be blank")
+		}
+		for _, other := range f.project.fields {
+			if other != f && strings.EqualFold(other.name, *input.Name) {
+				return unprocessable("Name has already been taken")
+			}
+		}
+		f.name = *input.Name
+	}
+
+	if input.SingleSelectOptions != nil && f.dataType == "SINGLE_SELECT" {
+		f.options = nil
+		for _, option := range *input.SingleSelectOptions {
+			f.options = append(f.options, projectV2FieldOption{
+				ID:          fmt.Sprintf("%08x", s.newID()),
+				Name:        option.Name,
+				Color:       option.Color,
+				Description: option.Description,
+			})
+		}
+	}
+
+	if c := input.IterationConfiguration; c != nil && f.dataType == "ITERATION" {
+		start, err := time.Parse("2006-01-02", c.StartDate)
+		if err != nil || c.Duration < 1 {
+			return &graphQLError{Type: "INVALID_INPUT", Message: "Invalid iteration configuration"}
+		}
+		f.duration = c.Duration
+		f.iterations = nil
+		for i := 0; i < 3; i++ {
+			f.iterations = append(f.iterations, projectV2FieldIteration{
+				ID:        fmt.Sprintf("%08x", s.newID()),
+				Title:     fmt.Sprintf("Iteration %d", i+1),
+				StartDate: start.AddDate(0, 0, i*c.Duration).Format("2006-01-02"),
+				Duration:  c.Duration,
+			})
+		}
+	}
+	return nil
+}
+
+func (s *Server) deleteProjectV2Field(args map[string]interface{}) (interface{}, error) {
+	var input projectV2FieldInput
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	f, ok := s.nodes[input.FieldID].(*projectV2Field)
+	if !ok {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", input.FieldID)
+	}
+	fields := f.project.fields[:0]
+	for _, other := range f.project.fields {
+		if other != f {
+			fields = append(fields, other)
+		}
+	}
+	f.project.fields = fields
+	delete(s.nodes, f.id)
+
+	return object{
+		"__typename":       "DeleteProjectV2FieldPayload",
+		"projectV2Field":   s.projectV2FieldObject(f),
+		"clientMutationId": nil,
+	}, nil
+}
+
+// addProjectV2ItemByID adds an issue to a project, adding it again returns
+// the existing item.
+func (s *Server) addProjectV2ItemByID(args map[string]interface{}) (interface{}, error) {
+	var input githubv4.AddProjectV2ItemByIdInput
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	p, err := s.projectV2Node(fmt.Sprint(input.ProjectID))
+	if err != nil {
+		return nil, err
+	}
+	contentID := fmt.Sprint(input.ContentID)
+	if _, ok := s.nodes[contentID].(*github.Issue); !ok {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", contentID)
+	}
+
+	var item *projectV2Item
+	for _, existing := range p.items {
+		if existing.contentID == contentID {
+			item = existing
+		}
+	}
+	if item == nil {
+		item = &projectV2Item{
+			id:        s.newNodeID("PVTI", s.newID()),
+			project:   p,
+			contentID: contentID,
+		}
+		p.items = append(p.items, item)
+		s.nodes[item.id] = item
+	}
+
+	return object{
+		"__typename":       "AddProjectV2ItemByIdPayload",
+		"item":             s.projectV2ItemObject(item),
+		"clientMutationId": nil,
+	}, nil
+}
+
+func (s *Server) deleteProjectV2Item(args map[string]interface{}) (interface{}, error) {
+	var input githubv4.DeleteProjectV2ItemInput
+	if err := decodeInput(args, &input); err != nil {
+		return nil, err
+	}
+
+	p, err := s.projectV2Node(fmt.Sprint(input.ProjectID))
+	if err != nil {
+		return nil, err
+	}
+	itemID := fmt.Sprint(input.ItemID)
+	item, ok := s.nodes[itemID].(*projectV2Item)
+	if !ok || item.project != p {
+		return nil, notFound("Could not resolve to a node with the global id of '%s'", itemID)
+	}
+	items := p.items[:0]
+	for _, other := range p.items {
+		if other != item {
+			items = append(items, other)
+		}
+	}
+	p.items = items
+	delete(s.nodes, item.id)
+
+	return object{
+		"__typename":       "DeleteProjectV2ItemPayload",
+		"deletedItemId":    item.id,
+		"clientMutationId": nil,
+	}, nil
+}
@@ -0,0 +1,176 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+
+	"github.com/google/go-github/v66/github"
+)
+
+func (s *Server) listCustomProperties(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	properties := []*github.CustomProperty{}
+	for _, property := range o.properties {
+		properties = append(properties, copyJSON(property))
+	}
+	sort.Slice(properties, func(i, j int) bool {
+		return properties[i].GetPropertyName() < properties[j].GetPropertyName()
+	})
+	writeJSON(w, http.StatusOK, properties)
+}
+
+func (s *Server) getCustomProperty(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	property, ok := o.properties[key(p["name"])]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(property))
+}
+
+// setCustomProperty creates or replaces a property of the organization's
+// schema, keeping its default value unless it is sent.
+func (s *Server) setCustomProperty(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	// The default value is kept when omitted, and removed when null.
+	var body struct {
+		github.CustomProperty
+		DefaultValue json.RawMessage `json:"default_value"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	property := &body.CustomProperty
+	switch {
+	case len(body.DefaultValue) == 0:
+		if existing, ok := o.properties[key(p["name"])]; ok {
+			property.DefaultValue = existing.DefaultValue
+		}
+	case string(body.DefaultValue) != "null":
+		if err := json.Unmarshal(body.DefaultValue, &property.DefaultValue); err != nil {
+			writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+			return
+		}
+	}
+	switch property.ValueType {
+	case "string", "single_select", "multi_select", "true_false":
+	default:
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: value_type is not valid")
+		return
+	}
+	if property.GetRequired() && property.GetDefaultValue() == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: required properties must have a default value")
+		return
+	}
+	property.PropertyName = github.String(p["name"])
+	if property.ValuesEditableBy == nil {
+		property.ValuesEditableBy = github.String("org_actors")
+	}
+	o.properties[key(p["name"])] = property
+
+	writeJSON(w, http.StatusOK, copyJSON(property))
+}
+
+// deleteCustomProperty removes a property from the organization's schema,
+// along with its values.
+func (s *Server) deleteCustomProperty(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	name := key(p["name"])
+	property, ok := o.properties[name]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(o.properties, name)
+	for _, repo := range s.repositories {
+		if key(repo.repo.GetOwner().GetLogin()) == key(o.org.GetLogin()) {
+			delete(repo.repo.CustomProperties, property.GetPropertyName())
+		}
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) getCustomPropertyValues(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+
+	names := make([]string, 0, len(repo.repo.CustomProperties))
+	for name := range repo.repo.CustomProperties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	values := []map[string]interface{}{}
+	for _, name := range names {
+		values = append(values, map[string]interface{}{
+			"property_name": name,
+			"value":         repo.repo.CustomProperties[name],
+		})
+	}
+	writeJSON(w, http.StatusOK, values)
+}
+
+// setCustomPropertyValues sets the values of the repository's properties
+// present in the request, a null value unsets the property.
+func (s *Server) setCustomPropertyValues(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	o, ok := s.organizations[key(repo.repo.GetOwner().GetLogin())]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var request struct {
+		Properties []*github.CustomPropertyValue `json:"properties"`
+	}
+	if !readJSON(w, r, &request) {
+		return
+	}
+	for _, value := range request.Properties {
+		property, ok := o.properties[key(value.PropertyName)]
+		if !ok {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: unknown property "+value.PropertyName)
+			return
+		}
+		if _, multiple := value.Value.([]string); multiple != (property.ValueType == "multi_select") && value.Value != nil {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: invalid value for property "+value.PropertyName)
+			return
+		}
+	}
+
+	if repo.repo.CustomProperties == nil {
+		repo.repo.CustomProperties = map[string]interface{}{}
+	}
+	for _, value := range request.Properties {
+		name := o.properties[key(value.PropertyName)].GetPropertyName()
+		if value.Value == nil {
+			delete(repo.repo.CustomProperties, name)
+			continue
+		}
+		repo.repo.CustomProperties[name] = value.Value
+	}
+	writeNoContent(w)
+}
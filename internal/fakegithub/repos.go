@@ -0,0 +1,306 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// repository returns the repository named by the owner and repo placeholders,
+// or by the id placeholder for routes addressing it by ID, answering with 404
+// Not Found when it does not exist.
+func (s *Server) repository(w http.ResponseWriter, p params) (*repository, bool) {
+	if id, ok := p["id"]; ok && p["repo"] == "" {
+		for _, repo := range s.repositories {
+			if formatID(repo.repo.GetID()) == id {
+				return repo, true
+			}
+		}
+	} else if repo, ok := s.repositories[key(p["owner"]+"/"+p["repo"])]; ok {
+		return repo, true
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, false
+}
+
+func (s *Server) createRepository(w http.ResponseWriter, r *http.Request, p params) {
+	var owner *github.User
+	if _, ok := p["org"]; ok {
+		o, ok := s.organization(w, p)
+		if !ok {
+			return
+		}
+		owner = &github.User{
+			ID:     o.org.ID,
+			NodeID: o.org.NodeID,
+			Login:  github.String(o.org.GetLogin()),
+			Type:   github.String("Organization"),
+		}
+	} else {
+		owner = s.users[key(s.login)]
+	}
+
+	repo := &github.Repository{}
+	if !readJSON(w, r, repo) {
+		return
+	}
+	if repo.GetName() == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Repository creation failed: name is missing")
+		return
+	}
+	fullName := owner.GetLogin() + "/" + repo.GetName()
+	if _, ok := s.repositories[key(fullName)]; ok {
+		writeError(w, http.StatusUnprocessableEntity, "Repository creation failed: name already exists on this account")
+		return
+	}
+
+	id := s.newID()
+	now := s.now()
+	repo.ID = github.Int64(id)
+	repo.NodeID = github.String(s.newNodeID("R", id))
+	repo.FullName = github.String(fullName)
+	repo.Owner = copyJSON(owner)
+	repo.HTMLURL = github.String(s.URL + "/" + fullName)
+	repo.URL = github.String(s.URL + "/api/v3/repos/" + fullName)
+	repo.CloneURL = github.String(s.URL + "/" + fullName + ".git")
+	repo.GitURL = github.String(strings.Replace(s.URL, "http", "git", 1) + "/" + fullName + ".git")
+	repo.SSHURL = github.String("git@" + strings.TrimPrefix(s.URL, "http://") + ":" + fullName + ".git")
+	repo.SVNURL = github.String(s.URL + "/" + fullName)
+	repo.DefaultBranch = github.String("main")
+	repo.CreatedAt = &now
+	repo.UpdatedAt = &now
+	repo.Permissions = map[string]bool{"admin": true, "maintain": true, "push": true, "triage": true, "pull": true}
+	for _, b := range []**bool{&repo.HasIssues, &repo.HasProjects, &repo.HasWiki, &repo.HasDownloads,
+		&repo.AllowMergeCommit, &repo.AllowSquashMerge, &repo.AllowRebaseMerge} {
+		if *b == nil {
+			*b = github.Bool(true)
+		}
+	}
+	for _, b := range []**bool{&repo.HasDiscussions, &repo.AllowAutoMerge, &repo.AllowUpdateBranch,
+		&repo.DeleteBranchOnMerge, &repo.Archived, &repo.IsTemplate, &repo.Fork} {
+		if *b == nil {
+			*b = github.Bool(false)
+		}
+	}
+	syncVisibility(repo, nil)
+
+	state := &repository{
+		repo:           repo,
+		branches:       map[string]*github.Reference{},
+		secrets:        map[string]*secret{},
+		rulesets:       map[int64]*storedRuleset{},
+		hooks:          map[int64]*github.Hook{},
+		hookDeliveries: map[int64][]*github.HookDelivery{},
+		environments:   map[string]*environment{},
+		protections:    map[string]*BranchProtectionRule{},
+		runs:           map[int64]*workflowRun{},
+		runners:        map[int64]*runner{},
+	}
+	if repo.GetAutoInit() {
+		state.branches["refs/heads/main"] = s.newReference("refs/heads/main", s.newSHA())
+	}
+	repo.AutoInit = nil
+	repo.LicenseTemplate = nil
+	repo.GitignoreTemplate = nil
+
+	s.repositories[key(fullName)] = state
+	s.nodes[repo.GetNodeID()] = state
+
+	writeJSON(w, http.StatusCreated, copyJSON(repo))
+}
+
+// syncVisibility keeps the private and visibility fields of a repository
+// consistent, after fields was applied to it.
+func syncVisibility(repo *github.Repository, fields map[string]json.RawMessage) {
+	_, visibilitySet := fields["visibility"]
+	_, privateSet := fields["private"]
+	switch {
+	case fields == nil && repo.Visibility != nil, visibilitySet:
+		repo.Private = github.Bool(repo.GetVisibility() != "public")
+	case fields == nil, privateSet:
+		if repo.GetPrivate() {
+			repo.Visibility = github.String("private")
+		} else {
+			repo.Visibility = github.String("public")
+		}
+	}
+}
+
+func (s *Server) listOrganizationRepositories(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, s.listRepositories(o.org.GetLogin()))
+}
+
+func (s *Server) listUserRepositories(w http.ResponseWriter, r *http.Request, p params) {
+	writeJSON(w, http.StatusOK, s.listRepositories(s.login))
+}
+
+func (s *Server) listRepositories(owner string) []*github.Repository {
+	repos := []*github.Repository{}
+	for _, repo := range s.repositories {
+		if key(repo.repo.GetOwner().GetLogin()) == key(owner) {
+			repos = append(repos, copyJSON(repo.repo))
+		}
+	}
+	sort.Slice(repos, func(i, j int) bool {
+		return key(repos[i].GetName()) < key(repos[j].GetName())
+	})
+	return repos
+}
+
+func (s *Server) getRepositoryByID(w http.ResponseWriter, r *http.Request, p params) {
+	s.getRepository(w, r, p)
+}
+
+func (s *Server) getRepository(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(repo.repo))
+}
+
+func (s *Server) editRepository(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+
+	edited := copyJSON(repo.repo)
+	fields, ok := patch(w, r, edited)
+	if !ok {
+		return
+	}
+	oldFullName := repo.repo.GetFullName()
+	newFullName := edited.GetOwner().GetLogin() + "/" + edited.GetName()
+	if key(newFullName) != key(oldFullName) {
+		if _, exists := s.repositories[key(newFullName)]; exists {
+			writeError(w, http.StatusUnprocessableEntity, "name already exists on this account")
+			return
+		}
+		delete(s.repositories, key(oldFullName))
+		s.repositories[key(newFullName)] = repo
+		edited.FullName = github.String(newFullName)
+		edited.HTMLURL = github.String(s.URL + "/" + newFullName)
+		edited.URL = github.String(s.URL + "/api/v3/repos/" + newFullName)
+	}
+	syncVisibility(edited, fields)
+	now := s.now()
+	edited.UpdatedAt = &now
+
+	*repo.repo = *edited
+	writeJSON(w, http.StatusOK, copyJSON(repo.repo))
+}
+
+func (s *Server) deleteRepository(w http.ResponseWriter, r *http.Request, p params) {
+	if _, ok := s.repository(w, p); !ok {
+		return
+	}
+	s.removeRepository(p["owner"], p["repo"])
+	writeNoContent(w)
+}
+
+func (s *Server) removeRepository(owner, name string) {
+	repo, ok := s.repositories[key(owner+"/"+name)]
+	if !ok {
+		return
+	}
+	for _, rule := range repo.protections {
+		delete(s.nodes, rule.ID)
+	}
+	for _, o := range s.organizations {
+		for name, ids := range o.secretRepos {
+			o.secretRepos[name] = removeID(ids, repo.repo.GetID())
+		}
+		detachCodeSecurityConfiguration(o, repo.repo.GetID())
+	}
+	delete(s.nodes, repo.repo.GetNodeID())
+	delete(s.repositories, key(owner+"/"+name))
+}
+
+func (s *Server) getTopics(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string][]string{"names": topics(repo.repo)})
+}
+
+func (s *Server) replaceTopics(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+
+	var body struct {
+		Names []string `json:"names"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	repo.repo.Topics = body.Names
+	writeJSON(w, http.StatusOK, map[string][]string{"names": topics(repo.repo)})
+}
+
+func topics(repo *github.Repository) []string {
+	if repo.Topics == nil {
+		return []string{}
+	}
+	return repo.Topics
+}
+
+func (s *Server) getVulnerabilityAlerts(w http.ResponseWriter, r *http.Request, p params) {
+	repo, ok := s.repository(w, p)
+	if !ok {
+		return
+	}
+	if !repo.vulnerabilityAlerts {
+		writeError(w, http.StatusNotFound, "Vulnerability alerts are disabled.")
+		return
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) setVulnerabilityAlerts(enabled bool) func(w http.ResponseWriter, r *http.Request, p params) {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		repo, ok := s.repository(w, p)
+		if !ok {
+			return
+		}
+		repo.vulnerabilityAlerts = enabled
+		writeNoContent(w)
+	}
+}
+
+func (s *Server) newSHA() string {
+	return fmt.Sprintf("%040x", s.newID())
+}
+
+func (s *Server) newReference(ref, sha string) *github.Reference {
+	return &github.Reference{
+		Ref:    github.String(ref),
+		NodeID: github.String(s.newNodeID("REF", s.newID())),
+		Object: &github.GitObject{
+			Type: github.String("commit"),
+			SHA:  github.String(sha),
+		},
+	}
+}
+
+func removeID(ids []int64, id int64) []int64 {
+	kept := []int64{}
+	for _, i := range ids {
+		if i != id {
+			kept = append(kept, i)
+		}
+	}
+	return kept
+}
@@ -0,0 +1,208 @@
+package fakegithub
+
+import (
+	"net/http"
+)
+
+// serveREST serves the REST API, all handlers run with the state locked.
+func (s *Server) serveREST(w http.ResponseWriter, r *http.Request) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.rest.serve(w, r)
+}
+
+func (s *Server) newRESTRouter() *router {
+	rt := &router{}
+
+	// Users and organizations
+	rt.handle("GET", "/user", s.getAuthenticatedUser)
+	rt.handle("GET", "/users/{user}", s.getUser)
+	rt.handle("GET", "/orgs/{org}", s.getOrganization)
+	rt.handle("GET", "/orgs/{org}/members", s.listOrganizationMembers)
+	rt.handle("DELETE", "/orgs/{org}/members/{user}", s.deleteOrganizationMembership)
+	rt.handle("GET", "/orgs/{org}/memberships/{user}", s.getOrganizationMembership)
+	rt.handle("PUT", "/orgs/{org}/memberships/{user}", s.setOrganizationMembership)
+	rt.handle("DELETE", "/orgs/{org}/memberships/{user}", s.deleteOrganizationMembership)
+	rt.handle("GET", "/orgs/{org}/invitations", s.listOrgInvitations)
+	rt.handle("POST", "/orgs/{org}/invitations", s.createOrgInvitation)
+	rt.handle("DELETE", "/orgs/{org}/invitations/{id}", s.cancelOrgInvitation)
+	rt.handle("GET", "/orgs/{org}/invitations/{id}/teams", s.listOrgInvitationTeams)
+	rt.handle("GET", "/orgs/{org}/failed_invitations", s.listFailedOrgInvitations)
+	rt.handle("GET", "/orgs/{org}/outside_collaborators", s.listOutsideCollaborators)
+	rt.handle("PUT", "/orgs/{org}/outside_collaborators/{user}", s.convertMemberToOutsideCollaborator)
+
+	// Organization roles
+	rt.handle("GET", "/orgs/{org}/organization-roles", s.listOrganizationRoles)
+	rt.handle("POST", "/orgs/{org}/organization-roles", s.createOrganizationRole)
+	rt.handle("GET", "/orgs/{org}/organization-roles/{id}", s.getOrganizationRole)
+	rt.handle("PATCH", "/orgs/{org}/organization-roles/{id}", s.updateOrganizationRole)
+	rt.handle("DELETE", "/orgs/{org}/organization-roles/{id}", s.deleteOrganizationRole)
+	rt.handle("GET", "/orgs/{org}/organization-roles/{id}/teams", s.listOrganizationRoleTeams)
+	rt.handle("GET", "/orgs/{org}/organization-roles/{id}/users", s.listOrganizationRoleUsers)
+	rt.handle("PUT", "/orgs/{org}/organization-roles/teams/{team}/{id}", s.assignOrganizationRole(true))
+	rt.handle("DELETE", "/orgs/{org}/organization-roles/teams/{team}/{id}", s.assignOrganizationRole(false))
+	rt.handle("PUT", "/orgs/{org}/organization-roles/users/{user}/{id}", s.assignOrganizationRole(true))
+	rt.handle("DELETE", "/orgs/{org}/organization-roles/users/{user}/{id}", s.assignOrganizationRole(false))
+
+	// Copilot seats
+	rt.handle("GET", "/orgs/{org}/copilot/billing/seats", s.listCopilotSeats)
+	rt.handle("POST", "/orgs/{org}/copilot/billing/selected_teams", s.assignCopilotTeams(true))
+	rt.handle("DELETE", "/orgs/{org}/copilot/billing/selected_teams", s.assignCopilotTeams(false))
+	rt.handle("POST", "/orgs/{org}/copilot/billing/selected_users", s.assignCopilotUsers(true))
+	rt.handle("DELETE", "/orgs/{org}/copilot/billing/selected_users", s.assignCopilotUsers(false))
+
+	// Repositories
+	rt.handle("POST", "/orgs/{org}/repos", s.createRepository)
+	rt.handle("GET", "/orgs/{org}/repos", s.listOrganizationRepositories)
+	rt.handle("POST", "/user/repos", s.createRepository)
+	rt.handle("GET", "/user/repos", s.listUserRepositories)
+	rt.handle("GET", "/repositories/{id}", s.getRepositoryByID)
+	rt.handle("GET", "/repos/{owner}/{repo}", s.getRepository)
+	rt.handle("PATCH", "/repos/{owner}/{repo}", s.editRepository)
+	rt.handle("DELETE", "/repos/{owner}/{repo}", s.deleteRepository)
+	rt.handle("GET", "/repos/{owner}/{repo}/topics", s.getTopics)
+	rt.handle("PUT", "/repos/{owner}/{repo}/topics", s.replaceTopics)
+	rt.handle("GET", "/repos/{owner}/{repo}/vulnerability-alerts", s.getVulnerabilityAlerts)
+	rt.handle("PUT", "/repos/{owner}/{repo}/vulnerability-alerts", s.setVulnerabilityAlerts(true))
+	rt.handle("DELETE", "/repos/{owner}/{repo}/vulnerability-alerts", s.setVulnerabilityAlerts(false))
+
+	// Branches
+	rt.handle("GET", "/repos/{owner}/{repo}/branches", s.listBranches)
+	rt.handle("GET", "/repos/{owner}/{repo}/branches/{branch...}", s.getBranch)
+	rt.handle("GET", "/repos/{owner}/{repo}/git/ref/{ref...}", s.getReference)
+	rt.handle("POST", "/repos/{owner}/{repo}/git/refs", s.createReference)
+	rt.handle("PATCH", "/repos/{owner}/{repo}/git/refs/{ref...}", s.updateReference)
+	rt.handle("DELETE", "/repos/{owner}/{repo}/git/refs/{ref...}", s.deleteReference)
+
+	// Teams
+	rt.handle("POST", "/orgs/{org}/teams", s.createTeam)
+	rt.handle("GET", "/orgs/{org}/teams", s.listTeams)
+	rt.handle("GET", "/orgs/{org}/teams/{team}", s.teamHandler(s.getTeam))
+	rt.handle("PATCH", "/orgs/{org}/teams/{team}", s.teamHandler(s.editTeam))
+	rt.handle("DELETE", "/orgs/{org}/teams/{team}", s.teamHandler(s.deleteTeam))
+	rt.handle("GET", "/orgs/{org}/teams/{team}/members", s.teamHandler(s.listTeamMembers))
+	rt.handle("GET", "/orgs/{org}/teams/{team}/memberships/{user}", s.teamHandler(s.getTeamMembership))
+	rt.handle("PUT", "/orgs/{org}/teams/{team}/memberships/{user}", s.teamHandler(s.setTeamMembership))
+	rt.handle("DELETE", "/orgs/{org}/teams/{team}/memberships/{user}", s.teamHandler(s.deleteTeamMembership))
+	rt.handle("GET", "/organizations/{orgID}/team/{teamID}", s.teamHandler(s.getTeam))
+	rt.handle("PATCH", "/organizations/{orgID}/team/{teamID}", s.teamHandler(s.editTeam))
+	rt.handle("DELETE", "/organizations/{orgID}/team/{teamID}", s.teamHandler(s.deleteTeam))
+	rt.handle("GET", "/organizations/{orgID}/team/{teamID}/members", s.teamHandler(s.listTeamMembers))
+	rt.handle("GET", "/organizations/{orgID}/team/{teamID}/memberships/{user}", s.teamHandler(s.getTeamMembership))
+	rt.handle("PUT", "/organizations/{orgID}/team/{teamID}/memberships/{user}", s.teamHandler(s.setTeamMembership))
+	rt.handle("DELETE", "/organizations/{orgID}/team/{teamID}/memberships/{user}", s.teamHandler(s.deleteTeamMembership))
+
+	// Actions secrets
+	rt.handle("GET", "/repos/{owner}/{repo}/actions/secrets/public-key", s.getPublicKey)
+	rt.handle("GET", "/repos/{owner}/{repo}/actions/secrets", s.secretsHandler(s.listSecrets))
+	rt.handle("GET", "/repos/{owner}/{repo}/actions/secrets/{name}", s.secretsHandler(s.getSecret))
+	rt.handle("PUT", "/repos/{owner}/{repo}/actions/secrets/{name}", s.secretsHandler(s.putSecret))
+	rt.handle("DELETE", "/repos/{owner}/{repo}/actions/secrets/{name}", s.secretsHandler(s.deleteSecret))
+	rt.handle("GET", "/orgs/{org}/actions/secrets/public-key", s.getPublicKey)
+	rt.handle("GET", "/orgs/{org}/actions/secrets", s.secretsHandler(s.listSecrets))
+	rt.handle("GET", "/orgs/{org}/actions/secrets/{name}", s.secretsHandler(s.getSecret))
+	rt.handle("PUT", "/orgs/{org}/actions/secrets/{name}", s.secretsHandler(s.putSecret))
+	rt.handle("DELETE", "/orgs/{org}/actions/secrets/{name}", s.secretsHandler(s.deleteSecret))
+	rt.handle("GET", "/orgs/{org}/actions/secrets/{name}/repositories", s.listSelectedRepositories)
+	rt.handle("PUT", "/orgs/{org}/actions/secrets/{name}/repositories", s.setSelectedRepositories)
+	rt.handle("GET", "/repositories/{id}/environments/{environment}/secrets/public-key", s.getPublicKey)
+	rt.handle("GET", "/repositories/{id}/environments/{environment}/secrets", s.secretsHandler(s.listSecrets))
+	rt.handle("GET", "/repositories/{id}/environments/{environment}/secrets/{name}", s.secretsHandler(s.getSecret))
+	rt.handle("PUT", "/repositories/{id}/environments/{environment}/secrets/{name}", s.secretsHandler(s.putSecret))
+	rt.handle("DELETE", "/repositories/{id}/environments/{environment}/secrets/{name}", s.secretsHandler(s.deleteSecret))
+
+	// Rulesets
+	rt.handle("GET", "/repos/{owner}/{repo}/rulesets", s.rulesetsHandler(s.listRulesets))
+	rt.handle("POST", "/repos/{owner}/{repo}/rulesets", s.rulesetsHandler(s.createRuleset))
+	rt.handle("GET", "/repos/{owner}/{repo}/rulesets/{id}", s.rulesetsHandler(s.getRuleset))
+	rt.handle("PUT", "/repos/{owner}/{repo}/rulesets/{id}", s.rulesetsHandler(s.updateRuleset))
+	rt.handle("DELETE", "/repos/{owner}/{repo}/rulesets/{id}", s.rulesetsHandler(s.deleteRuleset))
+	rt.handle("GET", "/orgs/{org}/rulesets", s.rulesetsHandler(s.listRulesets))
+	rt.handle("POST", "/orgs/{org}/rulesets", s.rulesetsHandler(s.createRuleset))
+	rt.handle("GET", "/orgs/{org}/rulesets/{id}", s.rulesetsHandler(s.getRuleset))
+	rt.handle("PUT", "/orgs/{org}/rulesets/{id}", s.rulesetsHandler(s.updateRuleset))
+	rt.handle("DELETE", "/orgs/{org}/rulesets/{id}", s.rulesetsHandler(s.deleteRuleset))
+
+	// Webhooks
+	rt.handle("GET", "/repos/{owner}/{repo}/hooks", s.hooksHandler(s.listHooks))
+	rt.handle("POST", "/repos/{owner}/{repo}/hooks", s.hooksHandler(s.createHook))
+	rt.handle("GET", "/repos/{owner}/{repo}/hooks/{id}", s.hooksHandler(s.getHook))
+	rt.handle("PATCH", "/repos/{owner}/{repo}/hooks/{id}", s.hooksHandler(s.editHook))
+	rt.handle("DELETE", "/repos/{owner}/{repo}/hooks/{id}", s.hooksHandler(s.deleteHook))
+	rt.handle("GET", "/repos/{owner}/{repo}/hooks/{id}/config", s.hooksHandler(s.getHookConfig))
+	rt.handle("PATCH", "/repos/{owner}/{repo}/hooks/{id}/config", s.hooksHandler(s.editHookConfig))
+	rt.handle("GET", "/repos/{owner}/{repo}/hooks/{id}/deliveries", s.hooksHandler(s.listHookDeliveries))
+	rt.handle("POST", "/repos/{owner}/{repo}/hooks/{id}/deliveries/{delivery_id}/attempts", s.hooksHandler(s.redeliverHookDelivery))
+	rt.handle("GET", "/orgs/{org}/hooks", s.hooksHandler(s.listHooks))
+	rt.handle("POST", "/orgs/{org}/hooks", s.hooksHandler(s.createHook))
+	rt.handle("GET", "/orgs/{org}/hooks/{id}", s.hooksHandler(s.getHook))
+	rt.handle("PATCH", "/orgs/{org}/hooks/{id}", s.hooksHandler(s.editHook))
+	rt.handle("DELETE", "/orgs/{org}/hooks/{id}", s.hooksHandler(s.deleteHook))
+	rt.handle("GET", "/orgs/{org}/hooks/{id}/config", s.hooksHandler(s.getHookConfig))
+	rt.handle("PATCH", "/orgs/{org}/hooks/{id}/config", s.hooksHandler(s.editHookConfig))
+	rt.handle("GET", "/orgs/{org}/hooks/{id}/deliveries", s.hooksHandler(s.listHookDeliveries))
+	rt.handle("POST", "/orgs/{org}/hooks/{id}/deliveries/{delivery_id}/attempts", s.hooksHandler(s.redeliverHookDelivery))
+
+	// Pages
+	rt.handle("GET", "/repos/{owner}/{repo}/pages", s.getPages)
+	rt.handle("POST", "/repos/{owner}/{repo}/pages", s.enablePages)
+	rt.handle("PUT", "/repos/{owner}/{repo}/pages", s.updatePages)
+	rt.handle("DELETE", "/repos/{owner}/{repo}/pages", s.disablePages)
+	rt.handle("GET", "/repos/{owner}/{repo}/pages/builds/latest", s.getLatestPagesBuild)
+	rt.handle("GET", "/repos/{owner}/{repo}/pages/health", s.getPagesHealthCheck)
+
+	// Environments
+	rt.handle("GET", "/repos/{owner}/{repo}/environments", s.listEnvironments)
+	rt.handle("GET", "/repos/{owner}/{repo}/environments/{environment}", s.getEnvironment)
+	rt.handle("PUT", "/repos/{owner}/{repo}/environments/{environment}", s.putEnvironment)
+	rt.handle("DELETE", "/repos/{owner}/{repo}/environments/{environment}", s.deleteEnvironment)
+	rt.handle("GET", "/repos/{owner}/{repo}/environments/{environment}/deployment_protection_rules", s.listDeploymentProtectionRules)
+	rt.handle("POST", "/repos/{owner}/{repo}/environments/{environment}/deployment_protection_rules", s.createDeploymentProtectionRule)
+	rt.handle("GET", "/repos/{owner}/{repo}/environments/{environment}/deployment_protection_rules/apps", s.listDeploymentProtectionRuleIntegrations)
+	rt.handle("GET", "/repos/{owner}/{repo}/environments/{environment}/deployment_protection_rules/{id}", s.getDeploymentProtectionRule)
+	rt.handle("DELETE", "/repos/{owner}/{repo}/environments/{environment}/deployment_protection_rules/{id}", s.deleteDeploymentProtectionRule)
+
+	// Custom properties
+	rt.handle("GET", "/orgs/{org}/properties/schema", s.listCustomProperties)
+	rt.handle("GET", "/orgs/{org}/properties/schema/{name}", s.getCustomProperty)
+	rt.handle("PUT", "/orgs/{org}/properties/schema/{name}", s.setCustomProperty)
+	rt.handle("DELETE", "/orgs/{org}/properties/schema/{name}", s.deleteCustomProperty)
+	rt.handle("GET", "/repos/{owner}/{repo}/properties/values", s.getCustomPropertyValues)
+	rt.handle("PATCH", "/repos/{owner}/{repo}/properties/values", s.setCustomPropertyValues)
+
+	// Code security configurations
+	rt.handle("GET", "/orgs/{org}/code-security/configurations", s.listCodeSecurityConfigurations)
+	rt.handle("POST", "/orgs/{org}/code-security/configurations", s.createCodeSecurityConfiguration)
+	rt.handle("GET", "/orgs/{org}/code-security/configurations/defaults", s.listDefaultCodeSecurityConfigurations)
+	rt.handle("DELETE", "/orgs/{org}/code-security/configurations/detach", s.detachCodeSecurityConfigurations)
+	rt.handle("GET", "/orgs/{org}/code-security/configurations/{id}", s.getCodeSecurityConfiguration)
+	rt.handle("PATCH", "/orgs/{org}/code-security/configurations/{id}", s.updateCodeSecurityConfiguration)
+	rt.handle("DELETE", "/orgs/{org}/code-security/configurations/{id}", s.deleteCodeSecurityConfiguration)
+	rt.handle("POST", "/orgs/{org}/code-security/configurations/{id}/attach", s.attachCodeSecurityConfiguration)
+	rt.handle("PUT", "/orgs/{org}/code-security/configurations/{id}/defaults", s.setDefaultCodeSecurityConfiguration)
+	rt.handle("GET", "/orgs/{org}/code-security/configurations/{id}/repositories", s.listCodeSecurityConfigurationRepositories)
+
+	// Code scanning default setup
+	rt.handle("GET", "/repos/{owner}/{repo}/code-scanning/default-setup", s.getCodeScanningDefaultSetup)
+	rt.handle("PATCH", "/repos/{owner}/{repo}/code-scanning/default-setup", s.updateCodeScanningDefaultSetup)
+	rt.handle("GET", "/repos/{owner}/{repo}/actions/runs/{id}", s.getWorkflowRun)
+
+	// Self-hosted runners
+	rt.handle("GET", "/repos/{owner}/{repo}/actions/runners", s.runnersHandler(s.listRunners))
+	rt.handle("POST", "/repos/{owner}/{repo}/actions/runners/generate-jitconfig", s.runnersHandler(s.generateJITConfig))
+	rt.handle("GET", "/repos/{owner}/{repo}/actions/runners/{id}", s.runnersHandler(s.getRunner))
+	rt.handle("DELETE", "/repos/{owner}/{repo}/actions/runners/{id}", s.runnersHandler(s.deleteRunner))
+	rt.handle("GET", "/repos/{owner}/{repo}/actions/runners/{id}/labels", s.runnersHandler(s.listRunnerLabels))
+	rt.handle("PUT", "/repos/{owner}/{repo}/actions/runners/{id}/labels", s.runnersHandler(s.setRunnerLabels))
+	rt.handle("DELETE", "/repos/{owner}/{repo}/actions/runners/{id}/labels", s.runnersHandler(s.deleteRunnerLabels))
+	rt.handle("GET", "/orgs/{org}/actions/runners", s.runnersHandler(s.listRunners))
+	rt.handle("POST", "/orgs/{org}/actions/runners/generate-jitconfig", s.runnersHandler(s.generateJITConfig))
+	rt.handle("GET", "/orgs/{org}/actions/runners/{id}", s.runnersHandler(s.getRunner))
+	rt.handle("DELETE", "/orgs/{org}/actions/runners/{id}", s.runnersHandler(s.deleteRunner))
+	rt.handle("GET", "/orgs/{org}/actions/runners/{id}/labels", s.runnersHandler(s.listRunnerLabels))
+	rt.handle("PUT", "/orgs/{org}/actions/runners/{id}/labels", s.runnersHandler(s.setRunnerLabels))
+	rt.handle("DELETE", "/orgs/{org}/actions/runners/{id}/labels", s.runnersHandler(s.deleteRunnerLabels))
+
+	return rt
+}
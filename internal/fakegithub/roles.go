@@ -0,0 +1,223 @@
+package fakegithub
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// predefinedRoles are the organization roles every organization has.
+var predefinedRoles = []string{"all_repo_read", "all_repo_triage", "all_repo_write", "all_repo_maintain", "all_repo_admin", "security_manager"}
+
+type organizationRole struct {
+	role    *github.CustomOrgRoles
+	teamIDs []int64
+	userIDs []int64
+}
+
+// addPredefinedRoles adds the roles GitHub predefines to a new organization.
+func (s *Server) addPredefinedRoles(o *organization) {
+	for _, name := range predefinedRoles {
+		id := s.newID()
+		o.roles[id] = &organizationRole{role: &github.CustomOrgRoles{
+			ID:          github.Int64(id),
+			Name:        github.String(name),
+			Description: github.String("Predefined role " + name),
+			Permissions: []string{},
+			Source:      github.String("Predefined"),
+		}}
+	}
+}
+
+func (s *Server) listOrganizationRoles(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	roles := []*github.CustomOrgRoles{}
+	for _, role := range o.roles {
+		roles = append(roles, copyJSON(role.role))
+	}
+	sort.Slice(roles, func(i, j int) bool {
+		return roles[i].GetID() < roles[j].GetID()
+	})
+	writeJSON(w, http.StatusOK, &github.OrganizationCustomRoles{TotalCount: github.Int(len(roles)), CustomRepoRoles: roles})
+}
+
+func (s *Server) createOrganizationRole(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	var options github.CreateOrUpdateOrgRoleOptions
+	if !readJSON(w, r, &options) {
+		return
+	}
+	if options.GetName() == "" || len(options.Permissions) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: name and permissions are required")
+		return
+	}
+	for _, existing := range o.roles {
+		if existing.role.GetName() == options.GetName() {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: Name has already been taken")
+			return
+		}
+	}
+
+	id := s.newID()
+	now := s.now()
+	role := &github.CustomOrgRoles{
+		ID:          github.Int64(id),
+		Name:        options.Name,
+		Description: options.Description,
+		Permissions: options.Permissions,
+		BaseRole:    options.BaseRole,
+		Org:         copyJSON(o.org),
+		Source:      github.String("Organization"),
+		CreatedAt:   &now,
+		UpdatedAt:   &now,
+	}
+	o.roles[id] = &organizationRole{role: role}
+
+	writeJSON(w, http.StatusCreated, copyJSON(role))
+}
+
+func (s *Server) organizationRole(w http.ResponseWriter, p params) (*organization, *organizationRole, bool) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return nil, nil, false
+	}
+	id, err := strconv.ParseInt(p["id"], 10, 64)
+	if err == nil {
+		if role, ok := o.roles[id]; ok {
+			return o, role, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, nil, false
+}
+
+func (s *Server) getOrganizationRole(w http.ResponseWriter, r *http.Request, p params) {
+	_, role, ok := s.organizationRole(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(role.role))
+}
+
+// updateOrganizationRole replaces the fields present in the request of a
+// custom role, predefined roles can not be changed.
+func (s *Server) updateOrganizationRole(w http.ResponseWriter, r *http.Request, p params) {
+	_, role, ok := s.organizationRole(w, p)
+	if !ok {
+		return
+	}
+	if role.role.GetSource() == "Predefined" {
+		writeError(w, http.StatusUnprocessableEntity, "Predefined roles can not be updated")
+		return
+	}
+
+	var options github.CreateOrUpdateOrgRoleOptions
+	if !readJSON(w, r, &options) {
+		return
+	}
+	if options.Name != nil {
+		role.role.Name = options.Name
+	}
+	if options.Description != nil {
+		role.role.Description = options.Description
+	}
+	if options.Permissions != nil {
+		role.role.Permissions = options.Permissions
+	}
+	role.role.BaseRole = options.BaseRole
+	now := s.now()
+	role.role.UpdatedAt = &now
+
+	writeJSON(w, http.StatusOK, copyJSON(role.role))
+}
+
+func (s *Server) deleteOrganizationRole(w http.ResponseWriter, r *http.Request, p params) {
+	o, role, ok := s.organizationRole(w, p)
+	if !ok {
+		return
+	}
+	if role.role.GetSource() == "Predefined" {
+		writeError(w, http.StatusUnprocessableEntity, "Predefined roles can not be deleted")
+		return
+	}
+	delete(o.roles, role.role.GetID())
+	writeNoContent(w)
+}
+
+func (s *Server) listOrganizationRoleTeams(w http.ResponseWriter, r *http.Request, p params) {
+	o, role, ok := s.organizationRole(w, p)
+	if !ok {
+		return
+	}
+
+	teams := []*github.Team{}
+	for _, id := range role.teamIDs {
+		for _, t := range o.teams {
+			if t.team.GetID() == id {
+				teams = append(teams, copyJSON(t.team))
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, teams)
+}
+
+func (s *Server) listOrganizationRoleUsers(w http.ResponseWriter, r *http.Request, p params) {
+	_, role, ok := s.organizationRole(w, p)
+	if !ok {
+		return
+	}
+
+	users := []*github.User{}
+	for _, id := range role.userIDs {
+		for _, u := range s.users {
+			if u.GetID() == id {
+				users = append(users, copyJSON(u))
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, users)
+}
+
+// assignOrganizationRole assigns a role to, or removes it from, a team or a
+// user of the organization.
+func (s *Server) assignOrganizationRole(assign bool) func(w http.ResponseWriter, r *http.Request, p params) {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		o, role, ok := s.organizationRole(w, p)
+		if !ok {
+			return
+		}
+
+		if slug, ok := p["team"]; ok {
+			t, ok := o.teams[key(slug)]
+			if !ok {
+				writeError(w, http.StatusNotFound, "Not Found")
+				return
+			}
+			role.teamIDs = removeID(role.teamIDs, t.team.GetID())
+			if assign {
+				role.teamIDs = append(role.teamIDs, t.team.GetID())
+			}
+		} else {
+			if _, member := o.members[key(p["user"])]; !member {
+				writeError(w, http.StatusNotFound, "Not Found")
+				return
+			}
+			u := s.users[key(p["user"])]
+			role.userIDs = removeID(role.userIDs, u.GetID())
+			if assign {
+				role.userIDs = append(role.userIDs, u.GetID())
+			}
+		}
+		writeNoContent(w)
+	}
+}
@@ -0,0 +1,159 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// params holds the values of the placeholders of a matched route.
+type params map[string]string
+
+type route struct {
+	method   string
+	segments []string
+	handler  func(w http.ResponseWriter, r *http.Request, p params)
+}
+
+// router dispatches requests on patterns like /repos/{owner}/{repo}, where a
+// trailing {name...} placeholder matches the rest of the path.
+type router struct {
+	routes []route
+}
+
+func (rt *router) handle(method, pattern string, handler func(w http.ResponseWriter, r *http.Request, p params)) {
+	rt.routes = append(rt.routes, route{
+		method:   method,
+		segments: strings.Split(strings.Trim(pattern, "/"), "/"),
+		handler:  handler,
+	})
+}
+
+func (rt *router) serve(w http.ResponseWriter, r *http.Request) {
+	path := strings.Split(strings.Trim(r.URL.EscapedPath(), "/"), "/")
+	for i, segment := range path {
+		if unescaped, err := url.PathUnescape(segment); err == nil {
+			path[i] = unescaped
+		}
+	}
+
+	methodNotAllowed := false
+	for _, route := range rt.routes {
+		p, ok := route.match(path)
+		if !ok {
+			continue
+		}
+		if route.method != r.Method {
+			methodNotAllowed = true
+			continue
+		}
+		route.handler(w, r, p)
+		return
+	}
+
+	if methodNotAllowed {
+		writeError(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+}
+
+func (r route) match(path []string) (params, bool) {
+	p := params{}
+	for i, segment := range r.segments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "...}") {
+			if i >= len(path) {
+				return nil, false
+			}
+			p[strings.TrimSuffix(strings.TrimPrefix(segment, "{"), "...}")] = strings.Join(path[i:], "/")
+			return p, true
+		}
+		if i >= len(path) {
+			return nil, false
+		}
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			p[strings.Trim(segment, "{}")] = path[i]
+			continue
+		}
+		if segment != path[i] {
+			return nil, false
+		}
+	}
+	return p, len(path) == len(r.segments)
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, map[string]string{
+		"message":           message,
+		"documentation_url": "https://docs.github.com/rest",
+	})
+}
+
+func writeNoContent(w http.ResponseWriter) {
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// readJSON decodes the request body into v, answering with 400 Bad Request
+// when it can not be decoded.
+func readJSON(w http.ResponseWriter, r *http.Request, v interface{}) bool {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		writeError(w, http.StatusBadRequest, "Problems parsing JSON")
+		return false
+	}
+	return true
+}
+
+// patch applies the fields of a JSON request body onto v, the way GitHub
+// applies PATCH requests. It returns the decoded fields of the body.
+func patch(w http.ResponseWriter, r *http.Request, v interface{}) (map[string]json.RawMessage, bool) {
+	var fields map[string]json.RawMessage
+	if !readJSON(w, r, &fields) {
+		return nil, false
+	}
+
+	current, err := json.Marshal(v)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	var merged map[string]json.RawMessage
+	if err := json.Unmarshal(current, &merged); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	for k, value := range fields {
+		merged[k] = value
+	}
+	data, err := json.Marshal(merged)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return nil, false
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "Invalid request")
+		return nil, false
+	}
+
+	return fields, true
+}
+
+// copyJSON returns a deep copy of v, so that responses are not affected by
+// later changes to the state.
+func copyJSON[T any](v T) T {
+	var c T
+	data, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		panic(err)
+	}
+	return c
+}
@@ -0,0 +1,140 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// storedRuleset is a ruleset as stored by the server. Its rules are kept as sent,
+// since go-github drops the rule parameters it does not know about.
+type storedRuleset struct {
+	github.Ruleset
+	Rules []json.RawMessage `json:"rules,omitempty"`
+}
+
+// rulesetScope is the repository or organization rulesets belong to.
+type rulesetScope struct {
+	rulesets   map[int64]*storedRuleset
+	source     string
+	sourceType string
+}
+
+// rulesetsHandler resolves the rulesets of the repository or organization
+// named by the placeholders before calling h.
+func (s *Server) rulesetsHandler(h func(w http.ResponseWriter, r *http.Request, p params, scope rulesetScope)) func(w http.ResponseWriter, r *http.Request, p params) {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := p["org"]; ok {
+			o, ok := s.organization(w, p)
+			if !ok {
+				return
+			}
+			h(w, r, p, rulesetScope{rulesets: o.rulesets, source: o.org.GetLogin(), sourceType: "Organization"})
+			return
+		}
+
+		repo, ok := s.repository(w, p)
+		if !ok {
+			return
+		}
+		h(w, r, p, rulesetScope{rulesets: repo.rulesets, source: repo.repo.GetFullName(), sourceType: "Repository"})
+	}
+}
+
+func (s *Server) listRulesets(w http.ResponseWriter, r *http.Request, p params, scope rulesetScope) {
+	rulesets := []*storedRuleset{}
+	for _, ruleset := range scope.rulesets {
+		rulesets = append(rulesets, copyJSON(ruleset))
+	}
+	sort.Slice(rulesets, func(i, j int) bool {
+		return rulesets[i].GetID() < rulesets[j].GetID()
+	})
+	writeJSON(w, http.StatusOK, rulesets)
+}
+
+func (s *Server) createRuleset(w http.ResponseWriter, r *http.Request, p params, scope rulesetScope) {
+	ruleset := &storedRuleset{}
+	if !readJSON(w, r, ruleset) {
+		return
+	}
+	if ruleset.Name == "" || ruleset.Enforcement == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+	for _, existing := range scope.rulesets {
+		if existing.Name == ruleset.Name {
+			writeError(w, http.StatusUnprocessableEntity, "Validation Failed: Name must be unique")
+			return
+		}
+	}
+
+	id := s.newID()
+	now := s.now()
+	ruleset.ID = github.Int64(id)
+	ruleset.NodeID = github.String(s.newNodeID("RRS", id))
+	ruleset.Source = scope.source
+	ruleset.SourceType = github.String(scope.sourceType)
+	ruleset.CreatedAt = &now
+	ruleset.UpdatedAt = &now
+	if ruleset.Target == nil {
+		ruleset.Target = github.String("branch")
+	}
+	scope.rulesets[id] = ruleset
+
+	writeJSON(w, http.StatusCreated, copyJSON(ruleset))
+}
+
+func (s *Server) ruleset(w http.ResponseWriter, p params, scope rulesetScope) (*storedRuleset, bool) {
+	id, err := strconv.ParseInt(p["id"], 10, 64)
+	if err == nil {
+		if ruleset, ok := scope.rulesets[id]; ok {
+			return ruleset, true
+		}
+	}
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, false
+}
+
+func (s *Server) getRuleset(w http.ResponseWriter, r *http.Request, p params, scope rulesetScope) {
+	ruleset, ok := s.ruleset(w, p, scope)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(ruleset))
+}
+
+// updateRuleset replaces the fields present in the request, leaving the others
+// unchanged.
+func (s *Server) updateRuleset(w http.ResponseWriter, r *http.Request, p params, scope rulesetScope) {
+	ruleset, ok := s.ruleset(w, p, scope)
+	if !ok {
+		return
+	}
+
+	updated := copyJSON(ruleset)
+	if _, ok := patch(w, r, updated); !ok {
+		return
+	}
+	now := s.now()
+	updated.ID = ruleset.ID
+	updated.NodeID = ruleset.NodeID
+	updated.Source = ruleset.Source
+	updated.SourceType = ruleset.SourceType
+	updated.CreatedAt = ruleset.CreatedAt
+	updated.UpdatedAt = &now
+	*ruleset = *updated
+
+	writeJSON(w, http.StatusOK, copyJSON(ruleset))
+}
+
+func (s *Server) deleteRuleset(w http.ResponseWriter, r *http.Request, p params, scope rulesetScope) {
+	ruleset, ok := s.ruleset(w, p, scope)
+	if !ok {
+		return
+	}
+	delete(scope.rulesets, ruleset.GetID())
+	writeNoContent(w)
+}
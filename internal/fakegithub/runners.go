@@ -0,0 +1,204 @@
+package fakegithub
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// runner is a self-hosted runner, with the group go-github does not know
+// about.
+type runner struct {
+	ID            int64                  `json:"id"`
+	Name          string                 `json:"name"`
+	OS            string                 `json:"os"`
+	Status        string                 `json:"status"`
+	Busy          bool                   `json:"busy"`
+	Ephemeral     bool                   `json:"ephemeral"`
+	RunnerGroupID int64                  `json:"runner_group_id"`
+	Labels        []*github.RunnerLabels `json:"labels"`
+}
+
+// defaultRunnerGroupID is the ID of the default runner group of every
+// organization, which is the only group of repository runners.
+const defaultRunnerGroupID = 1
+
+// readOnlyRunnerLabels are the labels GitHub gives every runner from its
+// platform, the fake pretends all runners are Linux x64 machines.
+var readOnlyRunnerLabels = []string{"self-hosted", "Linux", "X64"}
+
+// runnersHandler resolves the runners of the repository or organization named
+// by the placeholders before calling h.
+func (s *Server) runnersHandler(h func(w http.ResponseWriter, r *http.Request, p params, runners map[int64]*runner)) func(w http.ResponseWriter, r *http.Request, p params) {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := p["org"]; ok {
+			o, ok := s.organization(w, p)
+			if !ok {
+				return
+			}
+			h(w, r, p, o.runners)
+			return
+		}
+
+		repo, ok := s.repository(w, p)
+		if !ok {
+			return
+		}
+		h(w, r, p, repo.runners)
+	}
+}
+
+func (s *Server) listRunners(w http.ResponseWriter, r *http.Request, p params, runners map[int64]*runner) {
+	list := []*runner{}
+	for _, runner := range runners {
+		list = append(list, copyJSON(runner))
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].ID < list[j].ID
+	})
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total_count": len(list),
+		"runners":     list,
+	})
+}
+
+// generateJITConfig registers an ephemeral runner and answers with the
+// configuration it is started with, which is opaque to the provider.
+func (s *Server) generateJITConfig(w http.ResponseWriter, r *http.Request, p params, runners map[int64]*runner) {
+	var body github.GenerateJITConfigRequest
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.Name == "" || len(body.Labels) == 0 {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: name and labels are required")
+		return
+	}
+	if _, ok := p["org"]; !ok && body.RunnerGroupID != defaultRunnerGroupID {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed: repository runners can only be added to the default runner group")
+		return
+	}
+	for _, existing := range runners {
+		if existing.Name == body.Name {
+			writeError(w, http.StatusConflict, "Already exists - A runner with the same name already exists")
+			return
+		}
+	}
+
+	id := s.newID()
+	rn := &runner{
+		ID:            id,
+		Name:          body.Name,
+		OS:            "Linux",
+		Status:        "offline",
+		Ephemeral:     true,
+		RunnerGroupID: body.RunnerGroupID,
+	}
+	for _, name := range readOnlyRunnerLabels {
+		rn.Labels = append(rn.Labels, s.newRunnerLabel(name, "read-only"))
+	}
+	s.setCustomRunnerLabels(rn, body.Labels)
+	runners[id] = rn
+
+	config := fmt.Sprintf(`{"runner_id":%d,"name":%q}`, id, body.Name)
+	writeJSON(w, http.StatusCreated, map[string]interface{}{
+		"runner":             copyJSON(rn),
+		"encoded_jit_config": base64.StdEncoding.EncodeToString([]byte(config)),
+	})
+}
+
+func (s *Server) newRunnerLabel(name, labelType string) *github.RunnerLabels {
+	return &github.RunnerLabels{
+		ID:   github.Int64(s.newID()),
+		Name: github.String(name),
+		Type: github.String(labelType),
+	}
+}
+
+// setCustomRunnerLabels replaces the custom labels of a runner, keeping its
+// read-only labels.
+func (s *Server) setCustomRunnerLabels(rn *runner, names []string) {
+	labels := []*github.RunnerLabels{}
+	for _, label := range rn.Labels {
+		if label.GetType() == "read-only" {
+			labels = append(labels, label)
+		}
+	}
+	for _, name := range names {
+		labels = append(labels, s.newRunnerLabel(name, "custom"))
+	}
+	rn.Labels = labels
+}
+
+func (s *Server) runner(w http.ResponseWriter, p params, runners map[int64]*runner) (*runner, bool) {
+	id, err := strconv.ParseInt(p["id"], 10, 64)
+	if err != nil {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil, false
+	}
+	rn, ok := runners[id]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return nil, false
+	}
+	return rn, true
+}
+
+func (s *Server) getRunner(w http.ResponseWriter, r *http.Request, p params, runners map[int64]*runner) {
+	rn, ok := s.runner(w, p, runners)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(rn))
+}
+
+func (s *Server) deleteRunner(w http.ResponseWriter, r *http.Request, p params, runners map[int64]*runner) {
+	rn, ok := s.runner(w, p, runners)
+	if !ok {
+		return
+	}
+	delete(runners, rn.ID)
+	writeNoContent(w)
+}
+
+func (s *Server) writeRunnerLabels(w http.ResponseWriter, rn *runner) {
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"total_count": len(rn.Labels),
+		"labels":      copyJSON(rn.Labels),
+	})
+}
+
+func (s *Server) listRunnerLabels(w http.ResponseWriter, r *http.Request, p params, runners map[int64]*runner) {
+	rn, ok := s.runner(w, p, runners)
+	if !ok {
+		return
+	}
+	s.writeRunnerLabels(w, rn)
+}
+
+func (s *Server) setRunnerLabels(w http.ResponseWriter, r *http.Request, p params, runners map[int64]*runner) {
+	rn, ok := s.runner(w, p, runners)
+	if !ok {
+		return
+	}
+	var body struct {
+		Labels []string `json:"labels"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	s.setCustomRunnerLabels(rn, body.Labels)
+	s.writeRunnerLabels(w, rn)
+}
+
+func (s *Server) deleteRunnerLabels(w http.ResponseWriter, r *http.Request, p params, runners map[int64]*runner) {
+	rn, ok := s.runner(w, p, runners)
+	if !ok {
+		return
+	}
+	s.setCustomRunnerLabels(rn, nil)
+	s.writeRunnerLabels(w, rn)
+}
@@ -0,0 +1,205 @@
+package fakegithub
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/google/go-github/v66/github"
+)
+
+const (
+	// publicKeyID identifies publicKey, the key secrets are encrypted with.
+	publicKeyID = "fake-key-id"
+	publicKey   = "ZmFrZS1naXRodWItcHVibGljLWtleS0wMDAwMDAwMDA="
+)
+
+// secretScope is the repository, organization or environment secrets belong
+// to, only organization secrets have a visibility.
+type secretScope struct {
+	secrets map[string]*secret
+	org     *organization
+}
+
+// secretsHandler resolves the secrets of the repository, organization or
+// environment named by the placeholders before calling h.
+func (s *Server) secretsHandler(h func(w http.ResponseWriter, r *http.Request, p params, scope secretScope)) func(w http.ResponseWriter, r *http.Request, p params) {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		if _, ok := p["org"]; ok {
+			o, ok := s.organization(w, p)
+			if !ok {
+				return
+			}
+			h(w, r, p, secretScope{secrets: o.secrets, org: o})
+			return
+		}
+
+		repo, ok := s.repository(w, p)
+		if !ok {
+			return
+		}
+		if name, ok := p["environment"]; ok {
+			env, ok := repo.environments[name]
+			if !ok {
+				writeError(w, http.StatusNotFound, "Not Found")
+				return
+			}
+			h(w, r, p, secretScope{secrets: env.secrets})
+			return
+		}
+		h(w, r, p, secretScope{secrets: repo.secrets})
+	}
+}
+
+func (s *Server) getPublicKey(w http.ResponseWriter, r *http.Request, p params) {
+	writeJSON(w, http.StatusOK, &github.PublicKey{
+		KeyID: github.String(publicKeyID),
+		Key:   github.String(publicKey),
+	})
+}
+
+func (s *Server) listSecrets(w http.ResponseWriter, r *http.Request, p params, scope secretScope) {
+	secrets := []*github.Secret{}
+	for _, secret := range scope.secrets {
+		secrets = append(secrets, s.secretResponse(r, scope, secret))
+	}
+	sort.Slice(secrets, func(i, j int) bool {
+		return secrets[i].Name < secrets[j].Name
+	})
+	writeJSON(w, http.StatusOK, &github.Secrets{TotalCount: len(secrets), Secrets: secrets})
+}
+
+func (s *Server) getSecret(w http.ResponseWriter, r *http.Request, p params, scope secretScope) {
+	secret, ok := scope.secrets[p["name"]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.secretResponse(r, scope, secret))
+}
+
+func (s *Server) secretResponse(r *http.Request, scope secretScope, secret *secret) *github.Secret {
+	response := &github.Secret{
+		Name:       secret.Name,
+		CreatedAt:  secret.CreatedAt,
+		UpdatedAt:  secret.UpdatedAt,
+		Visibility: secret.Visibility,
+	}
+	if scope.org != nil && secret.Visibility == "selected" {
+		response.SelectedRepositoriesURL = s.URL + "/api/v3/orgs/" + scope.org.org.GetLogin() + "/actions/secrets/" + secret.Name + "/repositories"
+	}
+	return response
+}
+
+func (s *Server) putSecret(w http.ResponseWriter, r *http.Request, p params, scope secretScope) {
+	var body struct {
+		EncryptedValue        string  `json:"encrypted_value"`
+		KeyID                 string  `json:"key_id"`
+		Visibility            string  `json:"visibility"`
+		SelectedRepositoryIDs []int64 `json:"selected_repository_ids"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.KeyID != publicKeyID || body.EncryptedValue == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Bad request - the key_id or encrypted_value is invalid")
+		return
+	}
+	if scope.org != nil {
+		switch body.Visibility {
+		case "all", "private":
+		case "selected":
+			scope.org.secretRepos[p["name"]] = body.SelectedRepositoryIDs
+		default:
+			writeError(w, http.StatusUnprocessableEntity, "Invalid visibility")
+			return
+		}
+	}
+
+	now := s.now()
+	status := http.StatusNoContent
+	existing, ok := scope.secrets[p["name"]]
+	if !ok {
+		existing = &secret{Name: p["name"], CreatedAt: now}
+		scope.secrets[p["name"]] = existing
+		status = http.StatusCreated
+	}
+	existing.UpdatedAt = now
+	existing.EncryptedValue = body.EncryptedValue
+	existing.KeyID = body.KeyID
+	if scope.org != nil {
+		existing.Visibility = body.Visibility
+	}
+
+	if status == http.StatusCreated {
+		writeJSON(w, status, struct{}{})
+		return
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) deleteSecret(w http.ResponseWriter, r *http.Request, p params, scope secretScope) {
+	if _, ok := scope.secrets[p["name"]]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(scope.secrets, p["name"])
+	if scope.org != nil {
+		delete(scope.org.secretRepos, p["name"])
+	}
+	writeNoContent(w)
+}
+
+func (s *Server) listSelectedRepositories(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+	if secret, ok := o.secrets[p["name"]]; !ok || secret.Visibility != "selected" {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	repos := []*github.Repository{}
+	for _, id := range o.secretRepos[p["name"]] {
+		for _, repo := range s.repositories {
+			if repo.repo.GetID() == id {
+				repos = append(repos, copyJSON(repo.repo))
+			}
+		}
+	}
+	writeJSON(w, http.StatusOK, &github.SelectedReposList{TotalCount: github.Int(len(repos)), Repositories: repos})
+}
+
+func (s *Server) setSelectedRepositories(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+	if secret, ok := o.secrets[p["name"]]; !ok || secret.Visibility != "selected" {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var body struct {
+		SelectedRepositoryIDs []int64 `json:"selected_repository_ids"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	o.secretRepos[p["name"]] = body.SelectedRepositoryIDs
+	writeNoContent(w)
+}
+
+// Secret returns the encrypted value of a repository secret, or false if the
+// secret does not exist.
+func (s *Server) Secret(owner, repo, name string) (string, bool) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if r, ok := s.repositories[key(owner+"/"+repo)]; ok {
+		if secret, ok := r.secrets[name]; ok {
+			return secret.EncryptedValue, true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,356 @@
+// Package fakegithub provides an in-process, stateful fake of the parts of the
+// GitHub REST and GraphQL APIs used by the provider, for testing resources
+// deterministically without a real organization.
+//
+// The fake serves the API the way GitHub Enterprise Server does, so that the
+// provider can be pointed at it with its base_url argument:
+//
+//	srv := fakegithub.New()
+//	defer srv.Close()
+//	srv.AddOrganization("my-org")
+//	config := Config{BaseURL: srv.URL + "/", Token: "fake", Owner: "my-org"}
+//
+// It covers repositories, branches, teams and their memberships, organization
+// memberships, invitations, outside collaborators and roles, Copilot seats,
+// Actions secrets, self-hosted runners, rulesets, webhooks and their
+// deliveries, Pages, environments and their custom deployment protection rules,
+// custom properties, code security configurations and code scanning default
+// setup over REST, and repository, owner, organization member and node lookups,
+// branch protection rules and projects (v2) with their fields and items over
+// GraphQL. Issues and draft issues can only be seeded with AddIssue and
+// AddDraftIssue. Requests to anything else are answered with 404 Not Found.
+// Authentication is not checked, every request acts as the authenticated user.
+package fakegithub
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// DefaultLogin is the login of the user requests are authenticated as.
+const DefaultLogin = "octocat"
+
+// Server is a fake GitHub API. Its state can be inspected and changed directly
+// by tests, e.g. to simulate drift, using the methods of the Server.
+type Server struct {
+	*httptest.Server
+
+	rest *router
+
+	m             sync.Mutex
+	nextID        int64
+	lastTimestamp time.Time
+
+	login         string
+	users         map[string]*github.User
+	organizations map[string]*organization
+	repositories  map[string]*repository
+	apps          map[string]*github.App
+	nodes         map[string]interface{}
+}
+
+type organization struct {
+	org               *github.Organization
+	members           map[string]*github.Membership
+	invitations       map[int64]*orgInvitation
+	failedInvitations map[int64]*orgInvitation
+	// outsideCollaborators are the former members converted to outside
+	// collaborators, by login.
+	outsideCollaborators map[string]*github.User
+	teams                map[string]*team
+	secrets              map[string]*secret
+	rulesets             map[int64]*storedRuleset
+	hooks                map[int64]*github.Hook
+	hookDeliveries       map[int64][]*github.HookDelivery
+	secretRepos          map[string][]int64
+	properties           map[string]*github.CustomProperty
+	codeSecurity         map[int64]*codeSecurityConfiguration
+	roles                map[int64]*organizationRole
+	runners              map[int64]*runner
+	copilot              copilotSeats
+}
+
+type team struct {
+	team    *github.Team
+	members map[string]*github.Membership
+}
+
+type repository struct {
+	repo                *github.Repository
+	branches            map[string]*github.Reference
+	secrets             map[string]*secret
+	rulesets            map[int64]*storedRuleset
+	hooks               map[int64]*github.Hook
+	hookDeliveries      map[int64][]*github.HookDelivery
+	environments        map[string]*environment
+	pages               *pagesSite
+	codeScanning        *codeScanningDefaultSetup
+	runs                map[int64]*workflowRun
+	runners             map[int64]*runner
+	vulnerabilityAlerts bool
+	protections         map[string]*BranchProtectionRule
+}
+
+type environment struct {
+	environment     *github.Environment
+	secrets         map[string]*secret
+	protectionRules map[int64]*github.CustomDeploymentProtectionRule
+}
+
+type secret struct {
+	Name           string
+	CreatedAt      github.Timestamp
+	UpdatedAt      github.Timestamp
+	Visibility     string
+	EncryptedValue string
+	KeyID          string
+}
+
+// BranchProtectionRule is a branch protection rule managed through GraphQL.
+// Actor IDs are the node IDs of users, teams or apps.
+type BranchProtectionRule struct {
+	ID                             string
+	RepositoryID                   string
+	Pattern                        string
+	AllowsDeletions                bool
+	AllowsForcePushes              bool
+	BlocksCreations                bool
+	DismissesStaleReviews          bool
+	IsAdminEnforced                bool
+	LockBranch                     bool
+	RequireLastPushApproval        bool
+	RequiredApprovingReviewCount   int
+	RequiredStatusCheckContexts    []string
+	RequiresApprovingReviews       bool
+	RequiresCodeOwnerReviews       bool
+	RequiresCommitSignatures       bool
+	RequiresConversationResolution bool
+	RequiresLinearHistory          bool
+	RequiresStatusChecks           bool
+	RequiresStrictStatusChecks     bool
+	RestrictsPushes                bool
+	RestrictsReviewDismissals      bool
+	PushActorIDs                   []string
+	ReviewDismissalActorIDs        []string
+	BypassForcePushActorIDs        []string
+	BypassPullRequestActorIDs      []string
+}
+
+// New starts a fake GitHub API, authenticated as DefaultLogin. It must be
+// closed once it is no longer used.
+func New() *Server {
+	s := &Server{
+		nextID:        1000,
+		users:         map[string]*github.User{},
+		organizations: map[string]*organization{},
+		repositories:  map[string]*repository{},
+		apps:          map[string]*github.App{},
+		nodes:         map[string]interface{}{},
+	}
+	s.login = DefaultLogin
+	s.AddUser(DefaultLogin)
+	s.rest = s.newRESTRouter()
+
+	mux := http.NewServeMux()
+	mux.Handle("/api/v3/", http.StripPrefix("/api/v3", http.HandlerFunc(s.serveREST)))
+	mux.HandleFunc("/api/graphql", s.serveGraphQL)
+	s.Server = httptest.NewServer(mux)
+
+	return s
+}
+
+// BaseURL returns the URL to configure as the provider's base_url.
+func (s *Server) BaseURL() string {
+	return s.URL + "/"
+}
+
+// AddUser adds a user, which can then be made a member of organizations and
+// teams or be referred to by login.
+func (s *Server) AddUser(login string) *github.User {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	return s.addUser(login)
+}
+
+func (s *Server) addUser(login string) *github.User {
+	if u, ok := s.users[key(login)]; ok {
+		return u
+	}
+
+	id := s.newID()
+	u := &github.User{
+		ID:     github.Int64(id),
+		NodeID: github.String(s.newNodeID("U", id)),
+		Login:  github.String(login),
+		Type:   github.String("User"),
+	}
+	s.users[key(login)] = u
+	s.nodes[u.GetNodeID()] = u
+	return u
+}
+
+// SetUserEmail sets the public email of a user, e.g. to the address an
+// invitation by email was sent to.
+func (s *Server) SetUserEmail(login, email string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.addUser(login).Email = github.String(email)
+}
+
+// AddOrganization adds an organization, of which the authenticated user is an
+// admin.
+func (s *Server) AddOrganization(login string) *github.Organization {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if o, ok := s.organizations[key(login)]; ok {
+		return o.org
+	}
+
+	id := s.newID()
+	o := &organization{
+		org: &github.Organization{
+			ID:     github.Int64(id),
+			NodeID: github.String(s.newNodeID("O", id)),
+			Login:  github.String(login),
+			Type:   github.String("Organization"),
+		},
+		members:              map[string]*github.Membership{},
+		invitations:          map[int64]*orgInvitation{},
+		failedInvitations:    map[int64]*orgInvitation{},
+		outsideCollaborators: map[string]*github.User{},
+		teams:                map[string]*team{},
+		secrets:              map[string]*secret{},
+		rulesets:             map[int64]*storedRuleset{},
+		hooks:                map[int64]*github.Hook{},
+		hookDeliveries:       map[int64][]*github.HookDelivery{},
+		secretRepos:          map[string][]int64{},
+		properties:           map[string]*github.CustomProperty{},
+		codeSecurity:         map[int64]*codeSecurityConfiguration{},
+		roles:                map[int64]*organizationRole{},
+		runners:              map[int64]*runner{},
+		copilot: copilotSeats{
+			users: map[string]github.Timestamp{},
+			teams: map[int64]github.Timestamp{},
+		},
+	}
+	s.addPredefinedRoles(o)
+	o.members[key(s.login)] = &github.Membership{
+		State: github.String("active"),
+		Role:  github.String("admin"),
+		User:  s.users[key(s.login)],
+	}
+	s.organizations[key(login)] = o
+	s.nodes[o.org.GetNodeID()] = o.org
+	return o.org
+}
+
+// AddApp adds a GitHub App installed on every repository, which can be used
+// as a custom deployment protection rule of their environments.
+func (s *Server) AddApp(slug string) *github.App {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if a, ok := s.apps[key(slug)]; ok {
+		return a
+	}
+
+	id := s.newID()
+	a := &github.App{
+		ID:          github.Int64(id),
+		NodeID:      github.String(s.newNodeID("A", id)),
+		Slug:        github.String(slug),
+		Name:        github.String(slug),
+		ExternalURL: github.String("https://" + slug + ".example.com"),
+	}
+	s.apps[key(slug)] = a
+	return a
+}
+
+// Repository returns the repository, or nil if it does not exist. Changes to
+// the returned repository are visible to later requests.
+func (s *Server) Repository(owner, name string) *github.Repository {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if r, ok := s.repositories[key(owner+"/"+name)]; ok {
+		return r.repo
+	}
+	return nil
+}
+
+// DeleteRepository deletes a repository, e.g. to simulate it being deleted
+// outside of Terraform.
+func (s *Server) DeleteRepository(owner, name string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.removeRepository(owner, name)
+}
+
+// Team returns the team, or nil if it does not exist. Changes to the returned
+// team are visible to later requests.
+func (s *Server) Team(org, slug string) *github.Team {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if o, ok := s.organizations[key(org)]; ok {
+		if t, ok := o.teams[key(slug)]; ok {
+			return t.team
+		}
+	}
+	return nil
+}
+
+// BranchProtectionRule returns the branch protection rule with the given node
+// ID, or nil if it does not exist. Changes to the returned rule are visible to
+// later requests.
+func (s *Server) BranchProtectionRule(id string) *BranchProtectionRule {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	if rule, ok := s.nodes[id].(*BranchProtectionRule); ok {
+		return rule
+	}
+	return nil
+}
+
+func (s *Server) newID() int64 {
+	s.nextID++
+	return s.nextID
+}
+
+// newNodeID returns a node ID in the format used by GitHub, a prefix
+// identifying the type of the object followed by an opaque identifier.
+func (s *Server) newNodeID(prefix string, id int64) string {
+	return prefix + "_" + base64.RawURLEncoding.EncodeToString([]byte(fmt.Sprintf("fake:%d", id)))
+}
+
+// now returns the current time with the precision of the API. Timestamps are
+// strictly increasing, so that every change is observable through them.
+func (s *Server) now() github.Timestamp {
+	now := time.Now().UTC().Truncate(time.Second)
+	if !now.After(s.lastTimestamp) {
+		now = s.lastTimestamp.Add(time.Second)
+	}
+	s.lastTimestamp = now
+	return github.Timestamp{Time: now}
+}
+
+func key(name string) string {
+	return strings.ToLower(name)
+}
+
+func formatID(id int64) string {
+	return strconv.FormatInt(id, 10)
+}
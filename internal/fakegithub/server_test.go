@@ -0,0 +1,137 @@
+package fakegithub
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func do(t *testing.T, srv *Server, method, path string, body interface{}) (int, map[string]interface{}) {
+	t.Helper()
+
+	var reader *bytes.Reader
+	if body == nil {
+		reader = bytes.NewReader(nil)
+	} else {
+		data, err := json.Marshal(body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, srv.URL+path, reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	var decoded map[string]interface{}
+	_ = json.NewDecoder(resp.Body).Decode(&decoded)
+	return resp.StatusCode, decoded
+}
+
+func TestServerREST(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+	srv.AddOrganization("acme")
+
+	status, repo := do(t, srv, "POST", "/api/v3/orgs/acme/repos", map[string]interface{}{"name": "widgets", "private": true, "auto_init": true})
+	if status != http.StatusCreated || repo["full_name"] != "acme/widgets" || repo["visibility"] != "private" {
+		t.Fatalf("Unexpected response creating a repository: %d %v", status, repo)
+	}
+	if status, _ := do(t, srv, "POST", "/api/v3/orgs/acme/repos", map[string]interface{}{"name": "Widgets"}); status != http.StatusUnprocessableEntity {
+		t.Fatalf("Expected repository names to be unique regardless of case, got: %d", status)
+	}
+
+	status, repo = do(t, srv, "PATCH", "/api/v3/repos/acme/widgets", map[string]interface{}{"name": "gadgets", "visibility": "public"})
+	if status != http.StatusOK || repo["full_name"] != "acme/gadgets" || repo["private"] != false {
+		t.Fatalf("Unexpected response editing a repository: %d %v", status, repo)
+	}
+	if status, _ := do(t, srv, "GET", "/api/v3/repos/acme/widgets", nil); status != http.StatusNotFound {
+		t.Fatalf("Expected the repository to be renamed, got: %d", status)
+	}
+
+	status, ref := do(t, srv, "GET", "/api/v3/repos/acme/gadgets/git/ref/heads/main", nil)
+	if status != http.StatusOK {
+		t.Fatalf("Expected auto_init to create the main branch, got: %d", status)
+	}
+	sha := ref["object"].(map[string]interface{})["sha"]
+	if status, _ := do(t, srv, "POST", "/api/v3/repos/acme/gadgets/git/refs", map[string]interface{}{"ref": "refs/heads/release/v1", "sha": sha}); status != http.StatusCreated {
+		t.Fatalf("Unexpected status creating a branch: %d", status)
+	}
+	if status, branch := do(t, srv, "GET", "/api/v3/repos/acme/gadgets/branches/release/v1", nil); status != http.StatusOK || branch["name"] != "release/v1" {
+		t.Fatalf("Unexpected response getting a branch with a slash: %d %v", status, branch)
+	}
+
+	if status, _ := do(t, srv, "DELETE", "/api/v3/repos/acme/gadgets/topics", nil); status != http.StatusMethodNotAllowed {
+		t.Fatalf("Expected unsupported methods to be refused, got: %d", status)
+	}
+	if status, _ := do(t, srv, "GET", "/api/v3/repos/acme/gadgets/releases", nil); status != http.StatusNotFound {
+		t.Fatalf("Expected unsupported endpoints to be answered with 404, got: %d", status)
+	}
+
+	status, hook := do(t, srv, "POST", "/api/v3/repos/acme/gadgets/hooks", map[string]interface{}{"config": map[string]interface{}{"url": "https://example.com", "secret": "s3cret"}})
+	if status != http.StatusCreated || hook["config"].(map[string]interface{})["secret"] != maskedSecret || hook["active"] != true {
+		t.Fatalf("Unexpected response creating a webhook: %d %v", status, hook)
+	}
+}
+
+func TestServerGraphQL(t *testing.T) {
+
+	srv := New()
+	defer srv.Close()
+	srv.AddOrganization("acme")
+	do(t, srv, "POST", "/api/v3/orgs/acme/repos", map[string]interface{}{"name": "widgets"})
+	repoID := srv.Repository("acme", "widgets").GetNodeID()
+
+	query := func(query string, variables map[string]interface{}) map[string]interface{} {
+		t.Helper()
+		_, response := do(t, srv, "POST", "/api/graphql", map[string]interface{}{"query": query, "variables": variables})
+		return response
+	}
+
+	response := query(`mutation($input:CreateBranchProtectionRuleInput!){createBranchProtectionRule(input: $input){branchProtectionRule{id}}}`,
+		map[string]interface{}{"input": map[string]interface{}{"repositoryId": repoID, "pattern": "main", "requiresLinearHistory": true}})
+	rule := response["data"].(map[string]interface{})["createBranchProtectionRule"].(map[string]interface{})["branchProtectionRule"].(map[string]interface{})
+	if !srv.BranchProtectionRule(rule["id"].(string)).RequiresLinearHistory {
+		t.Fatalf("Unexpected response creating a branch protection rule: %v", response)
+	}
+
+	response = query(`query($id:ID!$first:Int!){node(id: $id){...on Repository{id,branchProtectionRules(first: $first){nodes{pattern,requiresLinearHistory},pageInfo{hasNextPage}}}}}`,
+		map[string]interface{}{"id": repoID, "first": 100})
+	expected := map[string]interface{}{
+		"node": map[string]interface{}{
+			"id": repoID,
+			"branchProtectionRules": map[string]interface{}{
+				"nodes":    []interface{}{map[string]interface{}{"pattern": "main", "requiresLinearHistory": true}},
+				"pageInfo": map[string]interface{}{"hasNextPage": false},
+			},
+		},
+	}
+	if !reflect.DeepEqual(response["data"], expected) {
+		t.Fatalf("Expected the response to be projected onto the selections, got: %v", response["data"])
+	}
+
+	response = query(`query{node(id: "missing"){id},user(login:"octocat"){login}}`, nil)
+	errors := response["errors"].([]interface{})
+	if len(errors) != 1 || !strings.Contains(errors[0].(map[string]interface{})["message"].(string), "Could not resolve to a node with the global id of 'missing'") {
+		t.Fatalf("Expected a NOT_FOUND error, got: %v", response)
+	}
+	if data := response["data"].(map[string]interface{}); data["node"] != nil || data["user"].(map[string]interface{})["login"] != DefaultLogin {
+		t.Fatalf("Expected partial data alongside the error, got: %v", data)
+	}
+
+	response = query(`query{viewer{unknown}}`, nil)
+	if _, ok := response["errors"]; !ok {
+		t.Fatalf("Expected an error selecting an unknown field, got: %v", response)
+	}
+}
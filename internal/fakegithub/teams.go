@@ -0,0 +1,288 @@
+package fakegithub
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/go-github/v66/github"
+)
+
+// teamHandler resolves the team named by the org and team placeholders, or by
+// the orgID and teamID placeholders, before calling h.
+func (s *Server) teamHandler(h func(w http.ResponseWriter, r *http.Request, p params, o *organization, t *team)) func(w http.ResponseWriter, r *http.Request, p params) {
+	return func(w http.ResponseWriter, r *http.Request, p params) {
+		o, ok := s.organization(w, p)
+		if !ok {
+			return
+		}
+
+		var t *team
+		if id, ok := p["teamID"]; ok {
+			for _, candidate := range o.teams {
+				if formatID(candidate.team.GetID()) == id {
+					t = candidate
+				}
+			}
+		} else {
+			t = o.teams[key(p["team"])]
+		}
+		if t == nil {
+			writeError(w, http.StatusNotFound, "Not Found")
+			return
+		}
+
+		h(w, r, p, o, t)
+	}
+}
+
+var nonSlugCharacters = regexp.MustCompile(`[^a-z0-9_]+`)
+
+func slugify(name string) string {
+	return strings.Trim(nonSlugCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// createTeam makes the authenticated user a maintainer of the team, the way
+// GitHub does for teams created by users.
+func (s *Server) createTeam(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	var body github.NewTeam
+	if !readJSON(w, r, &body) {
+		return
+	}
+	slug := slugify(body.Name)
+	if slug == "" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+	if _, ok := o.teams[slug]; ok {
+		writeError(w, http.StatusUnprocessableEntity, "Name must be unique for this org")
+		return
+	}
+
+	id := s.newID()
+	t := &team{
+		team: &github.Team{
+			ID:           github.Int64(id),
+			NodeID:       github.String(s.newNodeID("T", id)),
+			Name:         github.String(body.Name),
+			Slug:         github.String(slug),
+			Description:  github.String(body.GetDescription()),
+			Privacy:      github.String(body.GetPrivacy()),
+			Permission:   github.String("pull"),
+			LDAPDN:       body.LDAPDN,
+			Organization: o.org,
+		},
+		members: map[string]*github.Membership{},
+	}
+	if t.team.GetPrivacy() == "" {
+		t.team.Privacy = github.String("secret")
+	}
+	if body.ParentTeamID != nil {
+		if !s.setParentTeam(w, o, t, body.GetParentTeamID()) {
+			return
+		}
+	}
+	t.members[key(s.login)] = &github.Membership{
+		State: github.String("active"),
+		Role:  github.String("maintainer"),
+		User:  s.users[key(s.login)],
+	}
+	for _, login := range body.Maintainers {
+		if u, ok := s.users[key(login)]; ok {
+			t.members[key(login)] = &github.Membership{
+				State: github.String("active"),
+				Role:  github.String("maintainer"),
+				User:  u,
+			}
+		}
+	}
+
+	o.teams[slug] = t
+	s.nodes[t.team.GetNodeID()] = t
+
+	writeJSON(w, http.StatusCreated, s.teamResponse(t))
+}
+
+func (s *Server) setParentTeam(w http.ResponseWriter, o *organization, t *team, parentID int64) bool {
+	for _, parent := range o.teams {
+		if parent.team.GetID() == parentID && parent != t {
+			t.team.Parent = &github.Team{
+				ID:     parent.team.ID,
+				NodeID: parent.team.NodeID,
+				Name:   parent.team.Name,
+				Slug:   parent.team.Slug,
+			}
+			return true
+		}
+	}
+	writeError(w, http.StatusUnprocessableEntity, "Parent team not found")
+	return false
+}
+
+func (s *Server) teamResponse(t *team) *github.Team {
+	c := copyJSON(t.team)
+	c.MembersCount = github.Int(len(t.members))
+	return c
+}
+
+func (s *Server) listTeams(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	teams := []*github.Team{}
+	for _, t := range o.teams {
+		teams = append(teams, s.teamResponse(t))
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].GetSlug() < teams[j].GetSlug()
+	})
+	writeJSON(w, http.StatusOK, teams)
+}
+
+func (s *Server) getTeam(w http.ResponseWriter, r *http.Request, p params, o *organization, t *team) {
+	writeJSON(w, http.StatusOK, s.teamResponse(t))
+}
+
+func (s *Server) editTeam(w http.ResponseWriter, r *http.Request, p params, o *organization, t *team) {
+	var fields map[string]json.RawMessage
+	if !readJSON(w, r, &fields) {
+		return
+	}
+
+	var body github.NewTeam
+	data, _ := json.Marshal(fields)
+	if err := json.Unmarshal(data, &body); err != nil {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+
+	if _, ok := fields["name"]; ok && body.Name != t.team.GetName() {
+		slug := slugify(body.Name)
+		if other, ok := o.teams[slug]; ok && other != t {
+			writeError(w, http.StatusUnprocessableEntity, "Name must be unique for this org")
+			return
+		}
+		delete(o.teams, key(t.team.GetSlug()))
+		t.team.Name = github.String(body.Name)
+		t.team.Slug = github.String(slug)
+		o.teams[slug] = t
+	}
+	if _, ok := fields["description"]; ok {
+		t.team.Description = github.String(body.GetDescription())
+	}
+	if _, ok := fields["privacy"]; ok && body.GetPrivacy() != "" {
+		t.team.Privacy = body.Privacy
+	}
+	if _, ok := fields["ldap_dn"]; ok {
+		t.team.LDAPDN = body.LDAPDN
+	}
+	if _, ok := fields["parent_team_id"]; ok {
+		if body.ParentTeamID == nil {
+			t.team.Parent = nil
+		} else if !s.setParentTeam(w, o, t, body.GetParentTeamID()) {
+			return
+		}
+	}
+
+	writeJSON(w, http.StatusOK, s.teamResponse(t))
+}
+
+func (s *Server) deleteTeam(w http.ResponseWriter, r *http.Request, p params, o *organization, t *team) {
+	s.removeTeam(o, t)
+	writeNoContent(w)
+}
+
+// removeTeam deletes a team along with its child teams.
+func (s *Server) removeTeam(o *organization, t *team) {
+	for _, child := range o.teams {
+		if child.team.GetParent().GetID() == t.team.GetID() {
+			s.removeTeam(o, child)
+		}
+	}
+	delete(o.teams, key(t.team.GetSlug()))
+	delete(s.nodes, t.team.GetNodeID())
+}
+
+func (s *Server) listTeamMembers(w http.ResponseWriter, r *http.Request, p params, o *organization, t *team) {
+	role := r.URL.Query().Get("role")
+	users := []*github.User{}
+	for _, m := range t.members {
+		if role != "" && role != "all" && role != m.GetRole() {
+			continue
+		}
+		users = append(users, m.User)
+	}
+	sortUsers(users)
+	writeJSON(w, http.StatusOK, copyJSON(users))
+}
+
+func (s *Server) getTeamMembership(w http.ResponseWriter, r *http.Request, p params, o *organization, t *team) {
+	m, ok := t.members[key(p["user"])]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, teamMembership(m))
+}
+
+// setTeamMembership adds users who are not members of the organization as
+// pending members, as GitHub invites them to the organization.
+func (s *Server) setTeamMembership(w http.ResponseWriter, r *http.Request, p params, o *organization, t *team) {
+	u, ok := s.users[key(p["user"])]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var body struct {
+		Role string `json:"role"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.Role == "" {
+		body.Role = "member"
+	}
+	if body.Role != "member" && body.Role != "maintainer" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+
+	m, ok := t.members[key(u.GetLogin())]
+	if !ok {
+		state := "pending"
+		if om, ok := o.members[key(u.GetLogin())]; ok && om.GetState() == "active" {
+			state = "active"
+		}
+		m = &github.Membership{State: github.String(state), User: u}
+		t.members[key(u.GetLogin())] = m
+	}
+	m.Role = github.String(body.Role)
+
+	writeJSON(w, http.StatusOK, teamMembership(m))
+}
+
+func (s *Server) deleteTeamMembership(w http.ResponseWriter, r *http.Request, p params, o *organization, t *team) {
+	if _, ok := t.members[key(p["user"])]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(t.members, key(p["user"]))
+	writeNoContent(w)
+}
+
+func teamMembership(m *github.Membership) *github.Membership {
+	return &github.Membership{
+		State: m.State,
+		Role:  m.Role,
+	}
+}
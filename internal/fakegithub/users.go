@@ -0,0 +1,200 @@
+package fakegithub
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/google/go-github/v66/github"
+)
+
+func (s *Server) getAuthenticatedUser(w http.ResponseWriter, r *http.Request, p params) {
+	writeJSON(w, http.StatusOK, copyJSON(s.users[key(s.login)]))
+}
+
+func (s *Server) getUser(w http.ResponseWriter, r *http.Request, p params) {
+	u, ok := s.users[key(p["user"])]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(u))
+}
+
+// organization returns the organization named by the org placeholder, or by
+// the orgID placeholder for routes addressing it by ID, answering with 404 Not
+// Found when it does not exist.
+func (s *Server) organization(w http.ResponseWriter, p params) (*organization, bool) {
+	if id, ok := p["orgID"]; ok {
+		for _, o := range s.organizations {
+			if formatID(o.org.GetID()) == id {
+				return o, true
+			}
+		}
+	} else if o, ok := s.organizations[key(p["org"])]; ok {
+		return o, true
+	}
+
+	writeError(w, http.StatusNotFound, "Not Found")
+	return nil, false
+}
+
+func (s *Server) getOrganization(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+	writeJSON(w, http.StatusOK, copyJSON(o.org))
+}
+
+func (s *Server) listOrganizationMembers(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	role := r.URL.Query().Get("role")
+	users := []*github.User{}
+	for _, m := range o.members {
+		if m.GetState() != "active" || (role != "" && role != "all" && role != m.GetRole()) {
+			continue
+		}
+		users = append(users, m.User)
+	}
+	sortUsers(users)
+	writeJSON(w, http.StatusOK, copyJSON(users))
+}
+
+func (s *Server) getOrganizationMembership(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	m, ok := o.members[key(p["user"])]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	writeJSON(w, http.StatusOK, s.organizationMembership(o, m))
+}
+
+// setOrganizationMembership invites users who are not yet members, their
+// membership stays pending until changed by the test.
+func (s *Server) setOrganizationMembership(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+	u, ok := s.users[key(p["user"])]
+	if !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+
+	var body struct {
+		Role string `json:"role"`
+	}
+	if !readJSON(w, r, &body) {
+		return
+	}
+	if body.Role == "" {
+		body.Role = "member"
+	}
+	if body.Role != "member" && body.Role != "admin" {
+		writeError(w, http.StatusUnprocessableEntity, "Validation Failed")
+		return
+	}
+
+	m, ok := o.members[key(u.GetLogin())]
+	if !ok {
+		m = &github.Membership{State: github.String("pending"), User: u}
+		o.members[key(u.GetLogin())] = m
+	}
+	m.Role = github.String(body.Role)
+
+	writeJSON(w, http.StatusOK, s.organizationMembership(o, m))
+}
+
+func (s *Server) deleteOrganizationMembership(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	login := key(p["user"])
+	if _, ok := o.members[login]; !ok {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	delete(o.members, login)
+	for _, t := range o.teams {
+		delete(t.members, login)
+	}
+	writeNoContent(w)
+}
+
+// listOutsideCollaborators lists the members converted to outside
+// collaborators. Users of the fake all have two-factor authentication
+// enabled, so none are listed with the 2fa_disabled filter.
+func (s *Server) listOutsideCollaborators(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	users := []*github.User{}
+	if r.URL.Query().Get("filter") != "2fa_disabled" {
+		for _, u := range o.outsideCollaborators {
+			users = append(users, u)
+		}
+	}
+	sortUsers(users)
+	writeJSON(w, http.StatusOK, copyJSON(users))
+}
+
+// convertMemberToOutsideCollaborator removes an active member from the
+// organization and its teams, refusing to convert the last admin.
+func (s *Server) convertMemberToOutsideCollaborator(w http.ResponseWriter, r *http.Request, p params) {
+	o, ok := s.organization(w, p)
+	if !ok {
+		return
+	}
+
+	login := key(p["user"])
+	m, ok := o.members[login]
+	if !ok || m.GetState() != "active" {
+		writeError(w, http.StatusNotFound, "Not Found")
+		return
+	}
+	if m.GetRole() == "admin" {
+		admins := 0
+		for _, other := range o.members {
+			if other.GetState() == "active" && other.GetRole() == "admin" {
+				admins++
+			}
+		}
+		if admins == 1 {
+			writeError(w, http.StatusForbidden, "Cannot convert the last owner to an outside collaborator")
+			return
+		}
+	}
+
+	delete(o.members, login)
+	for _, t := range o.teams {
+		delete(t.members, login)
+	}
+	o.outsideCollaborators[login] = m.User
+	writeNoContent(w)
+}
+
+func (s *Server) organizationMembership(o *organization, m *github.Membership) *github.Membership {
+	c := copyJSON(m)
+	c.Organization = copyJSON(o.org)
+	return c
+}
+
+func sortUsers(users []*github.User) {
+	sort.Slice(users, func(i, j int) bool {
+		return key(users[i].GetLogin()) < key(users[j].GetLogin())
+	})
+}